@@ -0,0 +1,33 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+)
+
+// DecodedVinDto contains the information about a motorcycle that is encoded in its VIN.
+// The manufacturer, model year and plant are omitted when they could not be decoded.
+type DecodedVinDto struct {
+	Vin          string `json:"vin"`
+	Wmi          string `json:"wmi"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	ModelYear    int    `json:"modelYear,omitempty"`
+	PlantCode    string `json:"plantCode"`
+	Plant        string `json:"plant,omitempty"`
+}
+
+// NewDecodedVinDto creates a new instance of a DecodedVinDto from the decoded VIN.
+// Returns (instance of DecodedVinDto, nil).
+func NewDecodedVinDto(decoded entity.DecodedVin) (*DecodedVinDto, error) {
+	decodedDto := &DecodedVinDto{
+		Vin:          decoded.Vin,
+		Wmi:          decoded.Wmi,
+		Manufacturer: decoded.Manufacturer,
+		ModelYear:    decoded.ModelYear,
+		PlantCode:    decoded.PlantCode,
+		Plant:        decoded.Plant,
+	}
+
+	// All okay
+	return decodedDto, nil
+}
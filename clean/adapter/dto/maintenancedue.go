@@ -0,0 +1,52 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/maintenancestatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// MaintenanceDueDto contains information about when a maintenance task is due.
+// The fields that do not apply to the task, such as the distance of a task that is only due by time, are omitted.
+type MaintenanceDueDto struct {
+	Type              servicetype.ServiceType             `json:"type"`
+	Status            maintenancestatus.MaintenanceStatus `json:"status"`
+	LastServiceID     typedef.ID                          `json:"lastServiceId,omitempty"`
+	LastPerformedUtc  *time.Time                          `json:"lastPerformedUtc,omitempty"`
+	DueOdometer       *float64                            `json:"dueOdometer,omitempty"`
+	RemainingDistance *float64                            `json:"remainingDistance,omitempty"`
+	Unit              distanceunit.DistanceUnit           `json:"unit,omitempty"`
+	DueUtc            *time.Time                          `json:"dueUtc,omitempty"`
+}
+
+// NewMaintenanceDueDto creates a new instance of a MaintenanceDueDto from the maintenance that is due.
+// Returns (instance of MaintenanceDueDto, nil).
+func NewMaintenanceDueDto(due entity.MaintenanceDue) (*MaintenanceDueDto, error) {
+	dueDto := &MaintenanceDueDto{
+		Type:          due.Type,
+		Status:        due.Status,
+		LastServiceID: due.LastServiceID,
+	}
+
+	if !due.LastPerformedUtc.IsZero() {
+		dueDto.LastPerformedUtc = &due.LastPerformedUtc
+	}
+
+	if due.DueOdometer > 0 {
+		dueDto.DueOdometer = &due.DueOdometer
+		dueDto.RemainingDistance = &due.RemainingDistance
+		dueDto.Unit = due.Unit
+	}
+
+	if !due.DueUtc.IsZero() {
+		dueDto.DueUtc = &due.DueUtc
+	}
+
+	// All okay
+	return dueDto, nil
+}
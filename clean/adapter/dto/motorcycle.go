@@ -2,7 +2,6 @@
 package dto
 
 import (
-	"github.com/abitofhelp/motominderapi/clean/domain/constant"
 	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
 	"github.com/go-ozzo/ozzo-validation"
@@ -11,13 +10,15 @@ import (
 
 // MotorcycleDto contains motorcycle information.
 type MotorcycleDto struct {
-	ID          typedef.ID `json:"id"`
-	Make        string     `json:"make"`
-	Model       string     `json:"model"`
-	Year        int        `json:"year"`
-	Vin         string     `json:"vin"`
-	CreatedUtc  time.Time  `json:"createdUtc"`
-	ModifiedUtc time.Time  `json:"modifiedUtc"`
+	ID          typedef.ID         `json:"id"`
+	Make        string             `json:"make"`
+	Model       string             `json:"model"`
+	Year        int                `json:"year"`
+	Vin         string             `json:"vin"`
+	CreatedUtc  time.Time          `json:"createdUtc"`
+	ModifiedUtc time.Time          `json:"modifiedUtc"`
+	RowVersion  typedef.RowVersion `json:"rowVersion"`
+	OwnerID     string             `json:"ownerId"`
 }
 
 func NewMotorcycleDto(motorcycle entity.Motorcycle) (*MotorcycleDto, error) {
@@ -29,6 +30,8 @@ func NewMotorcycleDto(motorcycle entity.Motorcycle) (*MotorcycleDto, error) {
 		Vin:         motorcycle.Vin,
 		CreatedUtc:  motorcycle.CreatedUtc,
 		ModifiedUtc: motorcycle.ModifiedUtc,
+		RowVersion:  motorcycle.RowVersion,
+		OwnerID:     motorcycle.OwnerID,
 	}
 	err := motorcycle.Validate()
 	if err != nil {
@@ -42,13 +45,16 @@ func NewMotorcycleDto(motorcycle entity.Motorcycle) (*MotorcycleDto, error) {
 // Validate implemented Entity.Validate().  It verifies that a motorcycle's fields contain valid data that satisfies enterprise's common business rules.
 // Returns nil if the motorcycle contains valid data, otherwise an error.
 func (m MotorcycleDto) Validate() error {
+	policy := entity.CurrentValidationPolicy()
+	minYear, maxYear := policy.YearRange(time.Now())
+
 	return validation.ValidateStruct(&m,
-		// Make cannot be nil, cannot be empty, max length of 20, and not Ford (case insensitive)
-		validation.Field(&m.Make, validation.Required, validation.Length(constant.MinMakeLength, constant.MaxMakeLength), validation.By(entity.IsInvalidManufacturer)),
-		// Model cannot be nil, cannot be empty, and max length of 20
-		validation.Field(&m.Model, validation.Required, validation.Length(constant.MinModelLength, constant.MaxModelLength)),
-		// Year must be between 1999 and 2020, inclusive.
-		validation.Field(&m.Year, validation.Required, validation.Min(constant.MinYear), validation.Max(constant.MaxYear)),
+		// Make cannot be nil, cannot be empty, is limited in length, and must be permitted by the validation policy (case insensitive)
+		validation.Field(&m.Make, validation.Required, validation.Length(policy.MinMakeLength, policy.MaxMakeLength), validation.By(policy.IsValidMake)),
+		// Model cannot be nil, cannot be empty, and is limited in length
+		validation.Field(&m.Model, validation.Required, validation.Length(policy.MinModelLength, policy.MaxModelLength)),
+		// Year must be within the range of the validation policy, inclusive.
+		validation.Field(&m.Year, validation.Required, validation.Min(minYear), validation.Max(maxYear)),
 		// Vin cannot be nil, cannot be empty, and has a length of 17
 		validation.Field(&m.Vin, validation.Required, validation.By(entity.Is17Characters)),
 	)
@@ -0,0 +1,23 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// OdometerReadingDto contains odometer reading information.
+type OdometerReadingDto struct {
+	ID           typedef.ID                  `json:"id"`
+	MotorcycleID typedef.ID                  `json:"motorcycleId"`
+	Value        float64                     `json:"value"`
+	Unit         distanceunit.DistanceUnit   `json:"unit"`
+	ReadingUtc   time.Time                   `json:"readingUtc"`
+	Source       readingsource.ReadingSource `json:"source"`
+	Rollover     bool                        `json:"rollover"`
+	Distance     float64                     `json:"distance"`
+	CreatedUtc   time.Time                   `json:"createdUtc"`
+}
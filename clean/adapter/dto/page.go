@@ -0,0 +1,15 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+// PageDto contains information about a page of a list, so a client can ask for the page that follows it.
+// The links are URLs of the pages, which are set by the web service, since it knows how the list was requested.
+type PageDto struct {
+	// Count is the number of items in the page.
+	Count int `json:"count"`
+	// NextCursor is the opaque position of the last item in the page.  It is omitted on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+	// Self is the URL of the page.
+	Self string `json:"self,omitempty"`
+	// Next is the URL of the page that follows this one.  It is omitted on the last page.
+	Next string `json:"next,omitempty"`
+}
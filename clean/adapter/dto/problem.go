@@ -0,0 +1,85 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// ProblemMediaType is the media type of the body of a failed request.
+const ProblemMediaType = "application/problem+json"
+
+// BlankProblemType is the type of a problem that is fully described by its HTTP status code.
+const BlankProblemType = "about:blank"
+
+// ProblemDto contains the details of a failed request, as defined by RFC 7807.
+type ProblemDto struct {
+	// Type is a URI identifying the kind of problem.
+	Type string `json:"type"`
+	// Title is a short summary of the kind of problem, which is the text of its HTTP status code.
+	Title string `json:"title"`
+	// Status is the HTTP status code of the response.
+	Status int `json:"status"`
+	// Detail explains this occurrence of the problem.  It is omitted for an unexpected error, which is only logged.
+	Detail string `json:"detail,omitempty"`
+	// Instance is the URI of the request that failed.
+	Instance string `json:"instance,omitempty"`
+	// Errors are the fields of the request that are not valid, when the problem is a validation failure.
+	Errors []FieldErrorDto `json:"errors,omitempty"`
+}
+
+// FieldErrorDto explains why a field of a request is not valid.
+type FieldErrorDto struct {
+	// Field is the name of the field, where the name of a nested field follows its parent's and a period.
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// NewProblemDto creates a new instance of a ProblemDto for a request that failed with the HTTP status code.
+// The fields that are not valid are found in the error, when it is caused by the validation of a struct.
+// Returns the instance of ProblemDto.
+func NewProblemDto(status int, err error, instance string) *ProblemDto {
+	problem := &ProblemDto{
+		Type:     BlankProblemType,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: instance,
+	}
+
+	// The detail of an unexpected error might disclose how the service works, so it is not sent to the client.
+	if err != nil && status < http.StatusInternalServerError {
+		problem.Detail = err.Error()
+		problem.Errors = fieldErrors("", err)
+	}
+
+	return problem
+}
+
+// fieldErrors flattens the validation errors of a struct, and of the structs that it contains, in order by field name.
+// Returns the list of field errors, or nil when the error is not caused by validation.
+func fieldErrors(prefix string, err error) []FieldErrorDto {
+	errs, ok := errors.Cause(err).(validation.Errors)
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]FieldErrorDto, 0, len(errs))
+	for _, name := range names {
+		if nested := fieldErrors(prefix+name+".", errs[name]); nested != nil {
+			result = append(result, nested...)
+			continue
+		}
+		result = append(result, FieldErrorDto{Field: prefix + name, Message: errs[name].Error()})
+	}
+
+	return result
+}
@@ -0,0 +1,58 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// ReminderDto contains reminder information.  The times that have not happened yet are omitted.
+type ReminderDto struct {
+	ID              typedef.ID                    `json:"id"`
+	MotorcycleID    typedef.ID                    `json:"motorcycleId"`
+	Name            string                        `json:"name"`
+	DueUtc          time.Time                     `json:"dueUtc"`
+	Status          reminderstatus.ReminderStatus `json:"status"`
+	SnoozedUntilUtc *time.Time                    `json:"snoozedUntilUtc,omitempty"`
+	NotifiedUtc     *time.Time                    `json:"notifiedUtc,omitempty"`
+	AcknowledgedUtc *time.Time                    `json:"acknowledgedUtc,omitempty"`
+	CreatedUtc      time.Time                     `json:"createdUtc"`
+	RowVersion      typedef.RowVersion            `json:"rowVersion"`
+}
+
+// NewReminderDto creates a new instance of a ReminderDto from a reminder.
+// Returns (instance of ReminderDto, nil) on success, otherwise (nil, error).
+func NewReminderDto(reminder entity.Reminder) (*ReminderDto, error) {
+	err := reminder.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	reminderDto := &ReminderDto{
+		ID:           reminder.ID,
+		MotorcycleID: reminder.MotorcycleID,
+		Name:         reminder.Name,
+		DueUtc:       reminder.DueUtc,
+		Status:       reminder.Status,
+		CreatedUtc:   reminder.CreatedUtc,
+		RowVersion:   reminder.RowVersion,
+	}
+
+	if !reminder.SnoozedUntilUtc.IsZero() {
+		reminderDto.SnoozedUntilUtc = &reminder.SnoozedUntilUtc
+	}
+
+	if !reminder.NotifiedUtc.IsZero() {
+		reminderDto.NotifiedUtc = &reminder.NotifiedUtc
+	}
+
+	if !reminder.AcknowledgedUtc.IsZero() {
+		reminderDto.AcknowledgedUtc = &reminder.AcknowledgedUtc
+	}
+
+	// All okay
+	return reminderDto, nil
+}
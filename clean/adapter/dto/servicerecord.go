@@ -0,0 +1,62 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// ServiceRecordDto contains service record information.
+type ServiceRecordDto struct {
+	ID           typedef.ID                `json:"id"`
+	MotorcycleID typedef.ID                `json:"motorcycleId"`
+	Type         servicetype.ServiceType   `json:"type"`
+	PerformedUtc time.Time                 `json:"performedUtc"`
+	Odometer     float64                   `json:"odometer"`
+	Unit         distanceunit.DistanceUnit `json:"unit"`
+	Notes        string                    `json:"notes"`
+	Parts        []string                  `json:"parts"`
+	LaborHours   float64                   `json:"laborHours"`
+	Cost         float64                   `json:"cost"`
+	PerformedBy  string                    `json:"performedBy"`
+	CreatedUtc   time.Time                 `json:"createdUtc"`
+	ModifiedUtc  time.Time                 `json:"modifiedUtc"`
+	RowVersion   typedef.RowVersion        `json:"rowVersion"`
+}
+
+// NewServiceRecordDto creates a new instance of a ServiceRecordDto from a service record.
+// Returns (instance of ServiceRecordDto, nil) on success, otherwise (nil, error).
+func NewServiceRecordDto(serviceRecord entity.ServiceRecord) (*ServiceRecordDto, error) {
+	err := serviceRecord.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure that the parts are an empty list rather than null.
+	parts := make([]string, 0, len(serviceRecord.Parts))
+	parts = append(parts, serviceRecord.Parts...)
+
+	record := &ServiceRecordDto{
+		ID:           serviceRecord.ID,
+		MotorcycleID: serviceRecord.MotorcycleID,
+		Type:         serviceRecord.Type,
+		PerformedUtc: serviceRecord.PerformedUtc,
+		Odometer:     serviceRecord.Odometer,
+		Unit:         serviceRecord.Unit,
+		Notes:        serviceRecord.Notes,
+		Parts:        parts,
+		LaborHours:   serviceRecord.LaborHours,
+		Cost:         serviceRecord.Cost,
+		PerformedBy:  serviceRecord.PerformedBy,
+		CreatedUtc:   serviceRecord.CreatedUtc,
+		ModifiedUtc:  serviceRecord.ModifiedUtc,
+		RowVersion:   serviceRecord.RowVersion,
+	}
+
+	// All okay
+	return record, nil
+}
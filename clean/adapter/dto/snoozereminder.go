@@ -0,0 +1,12 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"time"
+)
+
+// SnoozeReminderDto contains the data that a client provides to snooze a reminder.
+type SnoozeReminderDto struct {
+	// UntilUtc is when the owner is notified of the reminder again.
+	UntilUtc time.Time `json:"untilUtc"`
+}
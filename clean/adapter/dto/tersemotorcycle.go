@@ -1,10 +1,14 @@
 // Package dto contains data transfer objects sent to/from client applications.
 package dto
 
+import "github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+
 // TerseMotorcycleDto contains the data that can be modified for a motorcycle.
 type TerseMotorcycleDto struct {
 	Make  string `json:"make"`
 	Model string `json:"model"`
 	Year  int    `json:"year"`
 	Vin   string `json:"vin"`
+	// VinCheck asks for the make and year to be filled in or verified from the VIN when a motorcycle is inserted.
+	VinCheck vincheck.VinCheck `json:"vinCheck,omitempty"`
 }
@@ -0,0 +1,19 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+)
+
+// TerseOdometerReadingDto contains the data that a client provides to record an odometer reading.
+// The reading time and source are optional.
+type TerseOdometerReadingDto struct {
+	Value      float64                     `json:"value"`
+	Unit       distanceunit.DistanceUnit   `json:"unit"`
+	ReadingUtc time.Time                   `json:"readingUtc"`
+	Source     readingsource.ReadingSource `json:"source"`
+	Rollover   bool                        `json:"rollover"`
+}
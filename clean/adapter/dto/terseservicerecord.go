@@ -0,0 +1,26 @@
+// Package dto contains data transfer objects sent to/from client applications.
+package dto
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// TerseServiceRecordDto contains the data that a client provides to record or correct a service record.
+// The notes, parts, labor hours, cost and who performed the service are optional.  The row version is
+// only used by an update without an If-Match header.
+type TerseServiceRecordDto struct {
+	Type         servicetype.ServiceType   `json:"type"`
+	PerformedUtc time.Time                 `json:"performedUtc"`
+	Odometer     float64                   `json:"odometer"`
+	Unit         distanceunit.DistanceUnit `json:"unit"`
+	Notes        string                    `json:"notes"`
+	Parts        []string                  `json:"parts"`
+	LaborHours   float64                   `json:"laborHours"`
+	Cost         float64                   `json:"cost"`
+	PerformedBy  string                    `json:"performedBy"`
+	RowVersion   typedef.RowVersion        `json:"rowVersion"`
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	// Third party packages
 	"github.com/julienschmidt/httprouter"
@@ -15,50 +17,129 @@ import (
 
 	// Motominder's entity packages
 	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/metrics"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
-	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
 	"github.com/abitofhelp/motominderapi/clean/adapter/presenter"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
 	"github.com/abitofhelp/motominderapi/clean/domain/entity"
-	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
 	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
 	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/go-ozzo/ozzo-validation"
 )
 
+// Authenticator establishes who made an HTTP request, and what they are authorized to do.
+type Authenticator interface {
+	// Authenticate creates the auth service for the user who made the request.
+	// Returns (auth service, nil) on success, otherwise (nil, error) when the request's credentials are not valid.
+	Authenticate(r *http.Request) (contract.AuthService, error)
+}
+
 // Api is a web service.
 type Api struct {
-	Roles                map[authorizationrole.AuthorizationRole]bool
-	AuthService          *security.AuthService
-	MotorcycleRepository *repository.MotorcycleRepository
-	Router               *httprouter.Router
+	Authenticator                 Authenticator
+	MotorcycleRepository          contract.MotorcycleRepository
+	OdometerReadingRepository     contract.OdometerReadingRepository
+	ServiceRecordRepository       contract.ServiceRecordRepository
+	MaintenanceScheduleRepository contract.MaintenanceScheduleRepository
+	ReminderRepository            contract.ReminderRepository
+	ManufacturerRepository        contract.ManufacturerRepository
+	Router                        *httprouter.Router
+	Config                        ServerConfig
+	server                        *http.Server
+	redirectServer                *http.Server
+	metricsServer                 *http.Server
+	Metrics                       *metrics.Registry
+	requestMetrics                *requestMetrics
+	// onStop are the functions that stop the background work that uses the repositories.
+	onStop []func()
 }
 
 // Validate verifies that a api's fields contain valid data.
 // Returns nil on success, otherwise error.
 func (api Api) Validate() error {
 	return validation.ValidateStruct(&api,
-		validation.Field(&api.Roles, validation.Required),
-		validation.Field(&api.AuthService, validation.Required),
+		validation.Field(&api.Authenticator, validation.Required),
 		validation.Field(&api.MotorcycleRepository, validation.Required),
+		validation.Field(&api.OdometerReadingRepository, validation.Required),
+		validation.Field(&api.ServiceRecordRepository, validation.Required),
+		validation.Field(&api.MaintenanceScheduleRepository, validation.Required),
+		validation.Field(&api.ReminderRepository, validation.Required),
+		validation.Field(&api.ManufacturerRepository, validation.Required),
 		validation.Field(&api.Router, validation.Required))
 }
 
+// ApiOption sets one of the optional parts of an Api, which has a default otherwise.
+type ApiOption func(api *Api)
+
+// WithOdometerReadingRepository sets the repository of odometer readings, which is empty and kept in memory by default.
+func WithOdometerReadingRepository(odometerReadingRepository contract.OdometerReadingRepository) ApiOption {
+	return func(api *Api) {
+		api.OdometerReadingRepository = odometerReadingRepository
+	}
+}
+
+// WithServiceRecordRepository sets the repository of service records, which is empty and kept in memory by default.
+func WithServiceRecordRepository(serviceRecordRepository contract.ServiceRecordRepository) ApiOption {
+	return func(api *Api) {
+		api.ServiceRecordRepository = serviceRecordRepository
+	}
+}
+
+// WithMaintenanceScheduleRepository sets the maintenance schedule, which is the default schedule by default.
+func WithMaintenanceScheduleRepository(maintenanceScheduleRepository contract.MaintenanceScheduleRepository) ApiOption {
+	return func(api *Api) {
+		api.MaintenanceScheduleRepository = maintenanceScheduleRepository
+	}
+}
+
+// WithReminderRepository sets the repository of reminders, which is empty and kept in memory by default.
+func WithReminderRepository(reminderRepository contract.ReminderRepository) ApiOption {
+	return func(api *Api) {
+		api.ReminderRepository = reminderRepository
+	}
+}
+
+// WithManufacturerRepository sets the table of manufacturers, which is the default table by default.
+func WithManufacturerRepository(manufacturerRepository contract.ManufacturerRepository) ApiOption {
+	return func(api *Api) {
+		api.ManufacturerRepository = manufacturerRepository
+	}
+}
+
 // NewApi creates a new instance of an Api.
+// The repositories that are not set by the options have their defaults.
 // Returns (an instance of APi, nil), otherwise (nil, error)
-func NewApi(roles map[authorizationrole.AuthorizationRole]bool, authService *security.AuthService, motorcycleRepository *repository.MotorcycleRepository, router *httprouter.Router) (*Api, error) {
+func NewApi(authenticator Authenticator, motorcycleRepository contract.MotorcycleRepository, router *httprouter.Router,
+	options ...ApiOption) (*Api, error) {
 
 	api := &Api{
-		Roles:                roles,
-		AuthService:          authService,
+		Authenticator:        authenticator,
 		MotorcycleRepository: motorcycleRepository,
 		Router:               router,
 	}
 
+	for _, option := range options {
+		option(api)
+	}
+
+	err := api.setDefaults()
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize logging
 	api.init()
 
-	err := api.Validate()
+	err = api.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Measure the requests and the repositories.
+	err = api.configureMetrics()
 	if err != nil {
 		return nil, err
 	}
@@ -66,92 +147,194 @@ func NewApi(roles map[authorizationrole.AuthorizationRole]bool, authService *sec
 	// Configure the router.
 	api.configureRouter()
 
+	// Configure the HTTP server with the default settings, which can be replaced before it is started.
+	err = api.Configure(DefaultServerConfig())
+	if err != nil {
+		return nil, err
+	}
+
 	// All okay
 	return api, nil
 }
 
+// setDefaults creates the default of each optional repository that has not been set.
+// Returns nil on success, otherwise error.
+func (api *Api) setDefaults() error {
+	if api.OdometerReadingRepository == nil {
+		odometerReadingRepository, err := repository.NewOdometerReadingRepository()
+		if err != nil {
+			return err
+		}
+		api.OdometerReadingRepository = odometerReadingRepository
+	}
+
+	if api.ServiceRecordRepository == nil {
+		serviceRecordRepository, err := repository.NewServiceRecordRepository()
+		if err != nil {
+			return err
+		}
+		api.ServiceRecordRepository = serviceRecordRepository
+	}
+
+	if api.MaintenanceScheduleRepository == nil {
+		maintenanceScheduleRepository, err := repository.DefaultMaintenanceScheduleRepository()
+		if err != nil {
+			return err
+		}
+		api.MaintenanceScheduleRepository = maintenanceScheduleRepository
+	}
+
+	if api.ReminderRepository == nil {
+		reminderRepository, err := repository.NewReminderRepository()
+		if err != nil {
+			return err
+		}
+		api.ReminderRepository = reminderRepository
+	}
+
+	if api.ManufacturerRepository == nil {
+		manufacturerRepository, err := repository.DefaultManufacturerRepository()
+		if err != nil {
+			return err
+		}
+		api.ManufacturerRepository = manufacturerRepository
+	}
+
+	// All okay
+	return nil
+}
+
 // configureRouter sets up the router's handlers and endpoints.
 // Returns nil on success, otherwise error.
 func (api *Api) configureRouter() error {
 
 	// Set up the handler to get a list of motorcycles from the repository.
-	api.Router.GET("/api/motorcycles", api.ListMotorcyclesHandler)
+	api.handle(http.MethodGet, "/api/motorcycles", api.ListMotorcyclesHandler)
 
 	// Set up the handler to get a particular motorcycle from the repository.
-	api.Router.GET("/api/motorcycles/:id", api.GetMotorcycleHandler)
+	api.handle(http.MethodGet, "/api/motorcycles/:id", api.GetMotorcycleHandler)
 
 	// Set up the handler to insert a new motorcycle into the repository.
-	api.Router.POST("/api/motorcycles", api.PostMotorcycleHandler)
+	api.handle(http.MethodPost, "/api/motorcycles", api.PostMotorcycleHandler)
 
 	// Set up the handler to update a motorcycle in the repository.
-	api.Router.PUT("/api/motorcycles/:id", api.PutMotorcycleHandler)
+	api.handle(http.MethodPut, "/api/motorcycles/:id", api.PutMotorcycleHandler)
+
+	// Set up the handler to change some of the fields of a motorcycle in the repository.
+	api.handle(http.MethodPatch, "/api/motorcycles/:id", api.PatchMotorcycleHandler)
 
 	// Set up the handler to delete a motorcycle from the repository.
-	api.Router.DELETE("/api/motorcycles/:id", api.DelMotorcycleHandler)
+	api.handle(http.MethodDelete, "/api/motorcycles/:id", api.DelMotorcycleHandler)
 
-	return nil
-}
+	// Set up the handler to get a list of a motorcycle's odometer readings from the repository.
+	api.handle(http.MethodGet, "/api/motorcycles/:id/odometer", api.ListOdometerReadingsHandler)
 
-// Start launches the web service.
-// Returns nil on success, otherwise error.
-func (api *Api) Start() error {
-	println("Starting the API server...")
-	log.Fatal(http.ListenAndServe(":8080", api.Router))
-	return nil
-}
+	// Set up the handler to record an odometer reading for a motorcycle.
+	api.handle(http.MethodPost, "/api/motorcycles/:id/odometer", api.PostOdometerReadingHandler)
+
+	// Set up the handler to delete a motorcycle's latest odometer reading from the repository.
+	api.handle(http.MethodDelete, "/api/motorcycles/:id/odometer/:readingId", api.DelOdometerReadingHandler)
+
+	// Set up the handler to get a motorcycle's service history from the repository.
+	api.handle(http.MethodGet, "/api/motorcycles/:id/services", api.ListServiceRecordsHandler)
+
+	// Set up the handler to get a particular service record of a motorcycle from the repository.
+	api.handle(http.MethodGet, "/api/motorcycles/:id/services/:serviceId", api.GetServiceRecordHandler)
+
+	// Set up the handler to record service that was performed on a motorcycle.
+	api.handle(http.MethodPost, "/api/motorcycles/:id/services", api.PostServiceRecordHandler)
+
+	// Set up the handler to correct a service record of a motorcycle in the repository.
+	api.handle(http.MethodPut, "/api/motorcycles/:id/services/:serviceId", api.PutServiceRecordHandler)
+
+	// Set up the handler to delete a service record of a motorcycle from the repository.
+	api.handle(http.MethodDelete, "/api/motorcycles/:id/services/:serviceId", api.DelServiceRecordHandler)
+
+	// Set up the handler to get the maintenance that is due on a motorcycle.
+	api.handle(http.MethodGet, "/api/motorcycles/:id/due", api.GetMaintenanceDueHandler)
+
+	// Set up the handler to get a list of a motorcycle's reminders from the repository.
+	api.handle(http.MethodGet, "/api/motorcycles/:id/reminders", api.ListRemindersHandler)
+
+	// Set up the handler to postpone a reminder of a motorcycle.
+	api.handle(http.MethodPost, "/api/motorcycles/:id/reminders/:reminderId/snooze", api.SnoozeReminderHandler)
+
+	// Set up the handler to acknowledge a reminder of a motorcycle.
+	api.handle(http.MethodPost, "/api/motorcycles/:id/reminders/:reminderId/acknowledge", api.AcknowledgeReminderHandler)
+
+	// Set up the handler to decode the manufacturer, model year and plant from a VIN.
+	api.handle(http.MethodGet, "/api/vin/:vin/decode", api.DecodeVinHandler)
 
-// Stop terminates the web service.
-// Returns nil on success, otherwise error.
-func (api *Api) Stop() error {
-	println("Stopping the API server...")
 	return nil
 }
 
-// ListMotorcyclesHandler processes requests to get a list of motorcycles from the repository.
+// ListMotorcyclesHandler processes requests to get a page of the list of motorcycles from the repository.
+// The optional query parameters are make, model, minYear, maxYear and vinPrefix, which filter the list, sort, which is
+// a comma separated list of fields such as "make,-year", limit, which is the size of the page, and cursor, which is
+// the nextCursor of the previous page.
 func (api *Api) ListMotorcyclesHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	query, err := parseMotorcycleQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
 	// Create the listRequest, process it, and get the resulting view model or error.
-	listRequest, err := request.NewListMotorcyclesRequest()
+	listRequest, err := request.NewListMotorcyclesRequest(query)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
-	listInteractor, err := interactor.NewListMotorcyclesInteractor(api.MotorcycleRepository, api.AuthService)
+	listInteractor, err := interactor.NewListMotorcyclesInteractor(api.MotorcycleRepository, authService)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	listInteractor.Logger = requestLogger(r)
 
 	listResponse, err := listInteractor.Handle(listRequest)
 	if err != nil {
-		w.WriteHeader(int(listResponse.Status))
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if listResponse.Error != nil {
+		writeProblem(w, r, httpStatus(listResponse.Status, false), listResponse.Error)
 		return
 	}
 
 	listPresenter, err := presenter.NewListMotorcyclesPresenter()
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	viewModel, err := listPresenter.Handle(listResponse)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
+	// The links to the pages are the request's own URL, with the cursor of the next page.
+	viewModel.Page.Self = r.URL.RequestURI()
+	if viewModel.Page.NextCursor != "" {
+		viewModel.Page.Next = nextPageURI(r.URL, viewModel.Page.NextCursor)
+		w.Header().Set("Link", "<"+viewModel.Page.Next+">; rel=\"next\"")
+	}
+
 	// Marshal provided viewModel into JSON structure
 	uj, err := json.Marshal(viewModel)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -164,114 +347,146 @@ func (api *Api) ListMotorcyclesHandler(w http.ResponseWriter, r *http.Request, _
 // GetMotorcycleHandler gets a particular motorcycle from the repository.
 func (api *Api) GetMotorcycleHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
 	id, err := strconv.Atoi(p.ByName("id"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
 	// Create the motorcycleRequest, process it, and get the resulting view model or error.
 	getRequest, err := request.NewGetMotorcycleRequest(typedef.ID(id))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
-	getInteractor, err := interactor.NewGetMotorcycleInteractor(api.MotorcycleRepository, api.AuthService)
+	getInteractor, err := interactor.NewGetMotorcycleInteractor(api.MotorcycleRepository, authService)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	getInteractor.Logger = requestLogger(r)
 
 	getResponse, err := getInteractor.Handle(getRequest)
 	if err != nil {
-		w.WriteHeader(int(getResponse.Status))
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if getResponse.Error != nil {
+		writeProblem(w, r, httpStatus(getResponse.Status, false), getResponse.Error)
+		return
+	}
+
+	// A motorcycle that was not found is not an error for the interactor, but it is for the client.
+	if getResponse.Motorcycle == nil {
+		writeProblem(w, r, http.StatusNotFound, fmt.Errorf("the motorcycle with ID %d does not exist", id))
 		return
 	}
 
 	getPresenter, err := presenter.NewGetMotorcyclePresenter()
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	viewModel, err := getPresenter.Handle(getResponse)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	// Marshal provided viewModel into JSON structure
 	uj, err := json.Marshal(viewModel)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
-	// Write content-type, status code, payload
+	// Write content-type, entity tag, status code, payload
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", formatETag(getResponse.Motorcycle.RowVersion))
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintf(w, "%s", uj)
 }
 
 // DelMotorcycleHandler removes a motorcycle from the repository.
+// When an If-Match header is present, the motorcycle is only removed if its entity tag matches.
 func (api *Api) DelMotorcycleHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
 	id, err := strconv.Atoi(p.ByName("id"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	rowVersion, hasIfMatch, err := parseIfMatch(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusPreconditionFailed, err)
 		return
 	}
 
 	// Create the motorcycleRequest, process it, and get the resulting view model or error.
-	deleteRequest, err := request.NewDeleteMotorcycleRequest(typedef.ID(id))
+	deleteRequest, err := request.NewDeleteMotorcycleRequest(typedef.ID(id), rowVersion)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
-	deleteInteractor, err := interactor.NewDeleteMotorcycleInteractor(api.MotorcycleRepository, api.AuthService)
+	session := api.NewSession()
+	defer session.Close()
+
+	deleteInteractor, err := interactor.NewDeleteMotorcycleInteractor(session.MotorcycleRepository, authService)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	deleteInteractor.OdometerReadingRepository = session.OdometerReadingRepository
+	deleteInteractor.ServiceRecordRepository = session.ServiceRecordRepository
+	deleteInteractor.ReminderRepository = session.ReminderRepository
+	deleteInteractor.Logger = requestLogger(r)
 
 	deleteResponse, err := deleteInteractor.Handle(deleteRequest)
 	if err != nil {
-		w.WriteHeader(int(deleteResponse.Status))
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if deleteResponse.Error != nil {
+		writeProblem(w, r, httpStatus(deleteResponse.Status, hasIfMatch), deleteResponse.Error)
 		return
 	}
 
 	deletePresenter, err := presenter.NewDeleteMotorcyclePresenter()
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	viewModel, err := deletePresenter.Handle(deleteResponse)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	// Marshal provided viewModel into JSON structure
 	_, err = json.Marshal(viewModel)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -280,12 +495,19 @@ func (api *Api) DelMotorcycleHandler(w http.ResponseWriter, r *http.Request, p h
 }
 
 // PutMotorcycleHandler updates an existing motorcycle in the repository.
+// The motorcycle is only updated if the entity tag in an If-Match header, or else the row version in the body, is current.
 func (api *Api) PutMotorcycleHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
 	id, err := strconv.Atoi(p.ByName("id"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
@@ -293,49 +515,67 @@ func (api *Api) PutMotorcycleHandler(w http.ResponseWriter, r *http.Request, p h
 	motorcycle := &entity.Motorcycle{}
 
 	// Populate the motorcycle from the motorcycleRequest body.
-	json.NewDecoder(r.Body).Decode(&motorcycle)
+	status, err := api.decodeJsonBody(w, r, motorcycle)
+	if err != nil {
+		writeProblem(w, r, status, err)
+		return
+	}
+
+	// An If-Match header takes precedence over the row version in the body.
+	rowVersion, hasIfMatch, err := parseIfMatch(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	if !hasIfMatch {
+		rowVersion = motorcycle.RowVersion
+	}
 
 	// Create the motorcycleRequest, process it, and get the resulting view model or error.
-	motorcycleRequest, err := request.NewUpdateMotorcycleRequest(typedef.ID(id), motorcycle) //typedef.ID(id), motorcycle)
+	motorcycleRequest, err := request.NewUpdateMotorcycleRequest(typedef.ID(id), rowVersion, motorcycle)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
-	motorcycleInteractor, err := interactor.NewUpdateMotorcycleInteractor(api.MotorcycleRepository, api.AuthService)
+	session := api.NewSession()
+	defer session.Close()
+
+	motorcycleInteractor, err := interactor.NewUpdateMotorcycleInteractor(session.MotorcycleRepository, authService)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	motorcycleInteractor.Logger = requestLogger(r)
 
 	updateResponse, err := motorcycleInteractor.Handle(motorcycleRequest)
 	if err != nil {
-		w.WriteHeader(int(updateResponse.Status))
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if updateResponse.Error != nil {
+		writeProblem(w, r, httpStatus(updateResponse.Status, hasIfMatch), updateResponse.Error)
 		return
 	}
 
 	motorcyclePresenter, err := presenter.NewUpdateMotorcyclePresenter()
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	viewModel, err := motorcyclePresenter.Handle(updateResponse)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	// Marshal provided viewModel into JSON structure
 	_, err = json.Marshal(viewModel)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -346,53 +586,67 @@ func (api *Api) PutMotorcycleHandler(w http.ResponseWriter, r *http.Request, p h
 // PostMotorcycleHandler adds a new motorcycle to the repository.
 func (api *Api) PostMotorcycleHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
 	// Stub a motorcycle to be populated from the body of the motorcycleRequest.
 	motorcycleDto := dto.TerseMotorcycleDto{}
 
 	// Populate the motorcycle from the motorcycleRequest body.
-	json.NewDecoder(r.Body).Decode(&motorcycleDto)
+	status, err := api.decodeJsonBody(w, r, &motorcycleDto)
+	if err != nil {
+		writeProblem(w, r, status, err)
+		return
+	}
 
 	// Create the motorcycleRequest, process it, and get the resulting view model or error.
-	motorcycleRequest, err := request.NewInsertMotorcycleRequest(motorcycleDto.Make, motorcycleDto.Model, motorcycleDto.Year, motorcycleDto.Vin)
+	motorcycleRequest, err := request.NewInsertMotorcycleRequest(motorcycleDto.Make, motorcycleDto.Model, motorcycleDto.Year, motorcycleDto.Vin, motorcycleDto.VinCheck)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
-	motorcycleInteractor, err := interactor.NewInsertMotorcycleInteractor(api.MotorcycleRepository, api.AuthService)
+	session := api.NewSession()
+	defer session.Close()
+
+	motorcycleInteractor, err := interactor.NewInsertMotorcycleInteractor(session.MotorcycleRepository, api.ManufacturerRepository, authService)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	motorcycleInteractor.Logger = requestLogger(r)
 
 	insertResponse, err := motorcycleInteractor.Handle(motorcycleRequest)
 	if err != nil {
-		w.WriteHeader(int(insertResponse.Status))
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if insertResponse.Error != nil {
+		writeProblem(w, r, httpStatus(insertResponse.Status, false), insertResponse.Error)
 		return
 	}
 
 	motorcyclePresenter, err := presenter.NewInsertMotorcyclePresenter()
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	viewModel, err := motorcyclePresenter.Handle(insertResponse)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
 	// Marshal provided viewModel into JSON structure
 	uj, err := json.Marshal(viewModel)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.WithError(err)
+		writeProblem(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -403,6 +657,105 @@ func (api *Api) PostMotorcycleHandler(w http.ResponseWriter, r *http.Request, _
 	fmt.Fprintf(w, "%s", uj)
 }
 
+// parseMotorcycleQuery gets the filters, sort order and page of a list of motorcycles from a request's query parameters.
+// Returns (query, nil) on success, otherwise (empty query, error) when a parameter cannot be parsed.
+func parseMotorcycleQuery(values url.Values) (entity.MotorcycleQuery, error) {
+	query := entity.MotorcycleQuery{
+		Make:      values.Get("make"),
+		Model:     values.Get("model"),
+		VinPrefix: values.Get("vinPrefix"),
+	}
+
+	numbers := map[string]*int{
+		"minYear": &query.MinYear,
+		"maxYear": &query.MaxYear,
+		"limit":   &query.Limit,
+	}
+	for name, number := range numbers {
+		if text := values.Get(name); text != "" {
+			value, err := strconv.Atoi(text)
+			if err != nil {
+				return entity.MotorcycleQuery{}, fmt.Errorf("the %s parameter is not a number", name)
+			}
+			*number = value
+		}
+	}
+
+	var err error
+	query.Sort, err = entity.ParseMotorcycleSort(values.Get("sort"))
+	if err != nil {
+		return entity.MotorcycleQuery{}, err
+	}
+
+	if cursor := values.Get("cursor"); cursor != "" {
+		query.After, err = entity.ParseMotorcycleCursor(cursor)
+		if err != nil {
+			return entity.MotorcycleQuery{}, err
+		}
+	}
+
+	return query, nil
+}
+
+// nextPageURI creates the URI of the page that follows the one at the URL, by replacing its cursor.
+func nextPageURI(pageURL *url.URL, nextCursor string) string {
+	values := pageURL.Query()
+	values.Set("cursor", nextCursor)
+
+	next := *pageURL
+	next.RawQuery = values.Encode()
+	return next.RequestURI()
+}
+
+// formatETag creates the entity tag for a version of a motorcycle.
+func formatETag(rowVersion typedef.RowVersion) string {
+	return strconv.Quote(strconv.FormatInt(int64(rowVersion), 10))
+}
+
+// parseIfMatch gets the row version from the entity tag in a request's If-Match header.
+// A missing header, or "*", matches any row version.  Weak entity tags never match.
+// Returns (row version, whether the header is present, nil) on success, otherwise (AnyRowVersion, true, error).
+func parseIfMatch(r *http.Request) (typedef.RowVersion, bool, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" {
+		return constant.AnyRowVersion, false, nil
+	}
+
+	if ifMatch == "*" {
+		return constant.AnyRowVersion, true, nil
+	}
+
+	tag, err := strconv.Unquote(ifMatch)
+	if err != nil {
+		return constant.AnyRowVersion, true, fmt.Errorf("the If-Match header %q is not a strong entity tag", ifMatch)
+	}
+
+	rowVersion, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || rowVersion < constant.InitialRowVersion {
+		return constant.AnyRowVersion, true, fmt.Errorf("the If-Match header %q does not match any motorcycle", ifMatch)
+	}
+
+	return typedef.RowVersion(rowVersion), true, nil
+}
+
+// httpStatus translates the operation status of a failed request into an HTTP status code.
+// A conflict is reported as a failed precondition when the client supplied an If-Match header, but a duplicate key never is.
+// A status that does not describe a failure is a mistake by the use case or the repository, so it is logged, and reported
+// as an internal error.
+func httpStatus(status operationstatus.OperationStatus, hasIfMatch bool) int {
+	switch {
+	case status == operationstatus.Conflict && hasIfMatch:
+		return http.StatusPreconditionFailed
+	case status == operationstatus.DuplicateKey:
+		return http.StatusConflict
+	case status < operationstatus.BadRequest:
+		log.WithField("operationStatus", status.ToString()).Error("A failed operation reported a status that is not an error.")
+		return http.StatusInternalServerError
+	default:
+		return int(status)
+	}
+}
+
 // init configures the API for use.
 func (api *Api) init() {
 	// Log as JSON instead of the default ASCII formatter.
@@ -411,7 +764,4 @@ func (api *Api) init() {
 	// Output to stdout instead of the default stderr
 	// Can be any io.Writer
 	log.SetOutput(os.Stdout)
-
-	// Only log the warning severity or above.
-	log.SetLevel(log.WarnLevel)
 }
@@ -0,0 +1,93 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	// Third party packages
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// MaxRequestBodySize is the default maximum size of the body of a request, in bytes.  The largest entity that a client
+// can write is a service record, which is much smaller.
+const MaxRequestBodySize = 64 << 10
+
+// jsonMediaType is the media type of a request body that is decoded as JSON.
+const jsonMediaType = "application/json"
+
+// decodeJsonBody decodes the JSON body of a request into the value.  The body must be declared to be JSON by its
+// Content-Type header, cannot be larger than the configured maximum size, must be a single JSON value, and cannot
+// contain a field that the value does not have.
+// Returns (Ok, nil) on success, otherwise (HTTP status code, error) explaining why the body cannot be decoded.
+func (api *Api) decodeJsonBody(w http.ResponseWriter, r *http.Request, value interface{}) (int, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != jsonMediaType {
+		return http.StatusUnsupportedMediaType, fmt.Errorf("the request body must be %s", jsonMediaType)
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, api.Config.MaxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	err = decoder.Decode(value)
+	if err != nil {
+		return bodyError(err)
+	}
+
+	// Anything after the value, other than white space, is a mistake rather than something to ignore.
+	err = decoder.Decode(&struct{}{})
+	switch {
+	case err == io.EOF:
+		return http.StatusOK, nil
+	case err != nil && errors.As(err, new(*http.MaxBytesError)):
+		return bodyError(err)
+	default:
+		return http.StatusBadRequest, errors.New("the request body must only contain a single JSON value")
+	}
+}
+
+// readBody reads the body of a request, which cannot be larger than the configured maximum size.
+// Returns (body, Ok, nil) on success, otherwise (nil, HTTP status code, error).
+func (api *Api) readBody(w http.ResponseWriter, r *http.Request) ([]byte, int, error) {
+	contents, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, api.Config.MaxBodyBytes))
+	if err != nil {
+		status, err := bodyError(err)
+		return nil, status, err
+	}
+
+	return contents, http.StatusOK, nil
+}
+
+// bodyError explains why a request body could not be read or decoded, which is never nil.  A field that is unknown or has the wrong type
+// is reported as a validation error of that field.
+// Returns (HTTP status code, error).
+func bodyError(err error) (int, error) {
+	var maxBytesError *http.MaxBytesError
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &maxBytesError):
+		return http.StatusRequestEntityTooLarge, fmt.Errorf("the request body cannot be larger than %d bytes", maxBytesError.Limit)
+	case err == io.EOF:
+		return http.StatusBadRequest, errors.New("the request body cannot be empty")
+	case err == io.ErrUnexpectedEOF:
+		return http.StatusBadRequest, errors.New("the request body is not valid JSON, because it ends unexpectedly")
+	case errors.As(err, &syntaxError):
+		return http.StatusBadRequest, fmt.Errorf("the request body is not valid JSON at offset %d: %s", syntaxError.Offset, syntaxError.Error())
+	case errors.As(err, &typeError) && typeError.Field != "":
+		return http.StatusBadRequest, validation.Errors{typeError.Field: fmt.Errorf("cannot be a JSON %s", typeError.Value)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return http.StatusBadRequest, validation.Errors{field: errors.New("is not a known field")}
+	default:
+		return http.StatusBadRequest, fmt.Errorf("the request body cannot be decoded: %s", err.Error())
+	}
+}
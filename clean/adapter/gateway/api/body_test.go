@@ -0,0 +1,87 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBodyApi creates an instance of the API web service for an administrator.
+// Returns the (API, motorcycle repository).
+func newBodyApi() (*Api, *repository.MotorcycleRepository) {
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, motorcycleRepository, httprouter.New())
+
+	return ourApi, motorcycleRepository
+}
+
+// TestApi_Body_Valid verifies that a JSON body is accepted when its media type has parameters.
+func TestApi_Body_Valid(t *testing.T) {
+
+	// ARRANGE
+	ourApi, motorcycleRepository := newBodyApi()
+	body := `{"make": "Honda", "model": "Shadow", "year": 2006, "vin": "01234567890123456"}` + "\n"
+
+	// ACT
+	resp, _ := PostMotorcycleBody(ourApi, "Application/JSON; charset=utf-8", []byte(body))
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusCreated)
+	assert.True(t, len(motorcycleRepository.Motorcycles) == 1)
+}
+
+// TestApi_Body_Invalid verifies that a body that cannot be decoded strictly is rejected with a problem explaining why,
+// and that nothing is inserted.
+func TestApi_Body_Invalid(t *testing.T) {
+
+	// ARRANGE
+	valid := `{"make": "Honda", "model": "Shadow", "year": 2006, "vin": "01234567890123456"}`
+	bodies := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		field       string
+	}{
+		{"no content type", "", valid, http.StatusUnsupportedMediaType, ""},
+		{"other content type", "text/plain", valid, http.StatusUnsupportedMediaType, ""},
+		{"empty", "application/json", "", http.StatusBadRequest, ""},
+		{"malformed", "application/json", `{"make": "Honda",}`, http.StatusBadRequest, ""},
+		{"truncated", "application/json", `{"make": "Honda"`, http.StatusBadRequest, ""},
+		{"unknown field", "application/json", `{"make": "Honda", "color": "red"}`, http.StatusBadRequest, "color"},
+		{"wrong type", "application/json", `{"make": "Honda", "year": "2006"}`, http.StatusBadRequest, "year"},
+		{"trailing data", "application/json", valid + `{}`, http.StatusBadRequest, ""},
+		{"too large", "application/json", `{"make": "` + strings.Repeat("a", MaxRequestBodySize) + `"}`, http.StatusRequestEntityTooLarge, ""},
+	}
+
+	for _, b := range bodies {
+		ourApi, motorcycleRepository := newBodyApi()
+
+		// ACT
+		resp, _ := PostMotorcycleBody(ourApi, b.contentType, []byte(b.body))
+		problem := dto.ProblemDto{}
+		json.NewDecoder(resp.Body).Decode(&problem)
+
+		// ASSERT
+		assert.Equal(t, b.status, resp.StatusCode, b.name)
+		assert.Equal(t, b.status, problem.Status, b.name)
+		assert.NotEmpty(t, problem.Detail, b.name)
+		if b.field != "" {
+			assert.True(t, len(problem.Errors) == 1 && problem.Errors[0].Field == b.field, b.name)
+		}
+		assert.True(t, len(motorcycleRepository.Motorcycles) == 0, b.name)
+	}
+}
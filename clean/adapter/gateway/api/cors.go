@@ -0,0 +1,123 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	// Third party packages
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// corsExposedHeaders are the response headers that a script from another origin may read.
+var corsExposedHeaders = []string{"ETag", "Link", "Location", RequestIDHeader}
+
+// CorsConfig contains the settings of cross-origin resource sharing, which allows scripts in web pages from other
+// origins to call the API web service.
+type CorsConfig struct {
+	// AllowedOrigins are the origins, such as "https://app.example.com", whose scripts may call the service, or "*"
+	// for any origin.  Cross-origin requests are not allowed when it is empty.
+	AllowedOrigins []string
+	// AllowedMethods are the HTTP methods that a script from another origin may use.
+	AllowedMethods []string
+	// AllowedHeaders are the request headers that a script from another origin may send.
+	AllowedHeaders []string
+	// MaxAge is how long a browser may cache the response to a preflight request.
+	MaxAge time.Duration
+}
+
+// DefaultCorsConfig creates the settings used when none are configured, which do not allow cross-origin requests.
+func DefaultCorsConfig() CorsConfig {
+	return CorsConfig{
+		AllowedOrigins: []string{},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		AllowedHeaders: []string{"Authorization", "Content-Type", "If-Match", "X-API-Key"},
+		MaxAge:         10 * time.Minute,
+	}
+}
+
+// Validate verifies that a CorsConfig's fields contain valid data.
+// Returns nil if the CorsConfig contains valid data, otherwise an error.
+func (config CorsConfig) Validate() error {
+	err := validation.ValidateStruct(&config,
+		// MaxAge cannot be negative.
+		validation.Field(&config.MaxAge, validation.Min(time.Duration(0))))
+	if err != nil {
+		return err
+	}
+
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" || strings.TrimSuffix(parsed.Path, "/") != "" {
+			return fmt.Errorf("the origin %q is not a scheme and host, such as https://app.example.com", origin)
+		}
+	}
+
+	if len(config.AllowedOrigins) > 0 && len(config.AllowedMethods) == 0 {
+		return errors.New("the methods allowed from other origins cannot be empty")
+	}
+
+	for _, method := range config.AllowedMethods {
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return fmt.Errorf("the method %q is not one that the API web service supports", method)
+		}
+	}
+
+	return nil
+}
+
+// IsEnabled determines whether cross-origin requests are allowed from any origin.
+func (config CorsConfig) IsEnabled() bool {
+	return len(config.AllowedOrigins) > 0
+}
+
+// isAllowedOrigin determines whether a script from the origin may call the service.
+func (config CorsConfig) isAllowedOrigin(origin string) bool {
+	for _, allowed := range config.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newCorsHandler creates a handler that adds the cross-origin resource sharing headers to the response to a request
+// from an allowed origin, and answers its preflight requests, before passing the request to the next handler.
+// A request from an origin that is not allowed is passed on without the headers, so the browser will reject it.
+func newCorsHandler(config CorsConfig, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		w.Header().Add("Vary", "Origin")
+		if origin == "" || !config.isAllowedOrigin(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(config.MaxAge/time.Second)))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(corsExposedHeaders, ", "))
+		next.ServeHTTP(w, r)
+	})
+}
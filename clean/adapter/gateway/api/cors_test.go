@@ -0,0 +1,71 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCorsHandler verifies that a preflight request from an allowed origin is answered, that a request from it may be
+// read by its script, and that a request from any other origin does not have the headers that allow it.
+func TestCorsHandler(t *testing.T) {
+
+	// ARRANGE
+	ourApi, _ := newBodyApi()
+	config := DefaultServerConfig()
+	config.Cors.AllowedOrigins = []string{"https://app.example.com"}
+	configErr := ourApi.Configure(config)
+
+	preflight, _ := http.NewRequest(http.MethodOptions, "/api/motorcycles", nil)
+	preflight.Header.Set("Origin", "https://app.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	allowed, _ := http.NewRequest(http.MethodGet, "/api/motorcycles", nil)
+	allowed.Header.Set("Origin", "https://app.example.com")
+	other, _ := http.NewRequest(http.MethodGet, "/api/motorcycles", nil)
+	other.Header.Set("Origin", "https://evil.example.com")
+	preflightResp := httptest.NewRecorder()
+	allowedResp := httptest.NewRecorder()
+	otherResp := httptest.NewRecorder()
+
+	// ACT
+	ourApi.server.Handler.ServeHTTP(preflightResp, preflight)
+	ourApi.server.Handler.ServeHTTP(allowedResp, allowed)
+	ourApi.server.Handler.ServeHTTP(otherResp, other)
+
+	// ASSERT
+	assert.Nil(t, configErr)
+	assert.True(t, preflightResp.Code == http.StatusNoContent)
+	assert.Equal(t, "https://app.example.com", preflightResp.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST, PUT, PATCH, DELETE", preflightResp.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "600", preflightResp.Header().Get("Access-Control-Max-Age"))
+	assert.True(t, allowedResp.Code == http.StatusOK)
+	assert.Equal(t, "https://app.example.com", allowedResp.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, allowedResp.Header().Get("Access-Control-Expose-Headers"), "ETag")
+	assert.True(t, otherResp.Code == http.StatusOK)
+	assert.Empty(t, otherResp.Header().Get("Access-Control-Allow-Origin"))
+}
+
+// TestCorsConfig_Validate verifies that an origin must be a scheme and host, and that the methods must be supported.
+func TestCorsConfig_Validate(t *testing.T) {
+
+	// ARRANGE
+	valid := DefaultCorsConfig()
+	valid.AllowedOrigins = []string{"*", "https://app.example.com", "http://localhost:3000/"}
+	path := DefaultCorsConfig()
+	path.AllowedOrigins = []string{"https://app.example.com/motorcycles"}
+	method := DefaultCorsConfig()
+	method.AllowedMethods = []string{"TRACE"}
+
+	// ACT
+	validErr := valid.Validate()
+	pathErr := path.Validate()
+	methodErr := method.Validate()
+
+	// ASSERT
+	assert.Nil(t, validErr)
+	assert.NotNil(t, pathErr)
+	assert.NotNil(t, methodErr)
+}
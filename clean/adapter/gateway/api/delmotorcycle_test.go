@@ -33,7 +33,7 @@ func TestApi_DelMotorcycle(t *testing.T) {
 	router := httprouter.New()
 
 	// Create an instance of the API web service.
-	ourApi, err := NewApi(roles, authService, repos, router)
+	ourApi, err := NewApi(authService, repos, router)
 	if err != nil {
 		println("Failed to create an instance of the API web service: &s", err.Error())
 		return
@@ -54,9 +54,86 @@ func TestApi_DelMotorcycle(t *testing.T) {
 	assert.True(t, resp.StatusCode == 204)
 }
 
+// TestApi_DelMotorcycle_StaleIfMatch verifies that a delete with a stale entity tag fails its precondition.
+func TestApi_DelMotorcycle_StaleIfMatch(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repos.Insert(motorcycle)
+	repos.Update(moto.ID, moto)
+
+	// ACT
+	resp, _ := DelMotorcycleIfMatch(ourApi, moto.ID, `"1"`)
+	exists, _, _ := repos.ExistsByID(moto.ID)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 412)
+	assert.True(t, exists)
+}
+
+// TestApi_DelMotorcycle_IfMatch verifies that a delete with a current entity tag is successful.
+func TestApi_DelMotorcycle_IfMatch(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repos.Insert(motorcycle)
+
+	// ACT
+	resp, _ := DelMotorcycleIfMatch(ourApi, moto.ID, `"1"`)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 204)
+}
+
+// TestApi_DelMotorcycle_NotAuthorized verifies that a user whose roles do not permit deletion is forbidden.
+func TestApi_DelMotorcycle_NotAuthorized(t *testing.T) {
+
+	// ARRANGE
+	ourApi := newJwtApi()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := ourApi.MotorcycleRepository.Insert(motorcycle)
+	token := signTestToken(t, testSecret, "mechanic", "General")
+
+	// ACT
+	resp, _ := DelMotorcycleWithHeader(ourApi, moto.ID, "Authorization", "Bearer "+token)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 403)
+}
+
 // DelMotorcycle deletes a motorcycle from the repository using the RESTful API.
 // Returns (*response, nil) on success, otherwise (nil, error).
 func DelMotorcycle(ourApi *Api, id typedef.ID) (*http.Response, error) {
+	return DelMotorcycleIfMatch(ourApi, id, "")
+}
+
+// DelMotorcycleIfMatch deletes a motorcycle from the repository using the RESTful API.
+// The If-Match header is set to ifMatch, unless it is empty.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func DelMotorcycleIfMatch(ourApi *Api, id typedef.ID, ifMatch string) (*http.Response, error) {
+	return DelMotorcycleWithHeader(ourApi, id, "If-Match", ifMatch)
+}
+
+// DelMotorcycleWithHeader deletes a motorcycle from the repository using the RESTful API.
+// The header is set to value, unless it is empty.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func DelMotorcycleWithHeader(ourApi *Api, id typedef.ID, header string, value string) (*http.Response, error) {
 
 	idText := strconv.Itoa(int(id))
 
@@ -79,5 +156,9 @@ func DelMotorcycle(ourApi *Api, id typedef.ID) (*http.Response, error) {
 		return nil, err
 	}
 
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+
 	return client.Do(req)
 }
@@ -33,7 +33,7 @@ func TestApi_GetMotorcycle(t *testing.T) {
 	router := httprouter.New()
 
 	// Create an instance of the API web service.
-	ourApi, err := NewApi(roles, authService, repos, router)
+	ourApi, err := NewApi(authService, repos, router)
 	if err != nil {
 		println("Failed to create an instance of the API web service: &s", err.Error())
 		return
@@ -52,6 +52,26 @@ func TestApi_GetMotorcycle(t *testing.T) {
 
 	// ASSERT
 	assert.True(t, resp.StatusCode == 200)
+	assert.True(t, resp.Header.Get("ETag") == `"1"`)
+}
+
+// TestApi_GetMotorcycle_NotExist verifies a not found response when the motorcycle does not exist.
+func TestApi_GetMotorcycle_NotExist(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	// ACT
+	resp, _ := GetMotorcycle(ourApi, 123)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 404)
 }
 
 // GetMotorcycle retrieves a motorcycle from the repository using the RESTful API.
@@ -2,14 +2,18 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
 	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/golang-jwt/jwt"
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,7 +33,7 @@ func TestApi_ListMotorcycles_Empty(t *testing.T) {
 	router := httprouter.New()
 
 	// Create an instance of the API web service.
-	ourApi, err := NewApi(roles, authService, motorcycleRepository, router)
+	ourApi, err := NewApi(authService, motorcycleRepository, router)
 	if err != nil {
 		println("Failed to create an instance of the API web service: &s", err.Error())
 		return
@@ -66,7 +70,7 @@ func TestApi_ListMotorcycles_NotEmpty(t *testing.T) {
 	router := httprouter.New()
 
 	// Create an instance of the API web service.
-	ourApi, err := NewApi(roles, authService, motorcycleRepository, router)
+	ourApi, err := NewApi(authService, motorcycleRepository, router)
 	if err != nil {
 		println("Failed to create an instance of the API web service: &s", err.Error())
 		return
@@ -85,9 +89,199 @@ func TestApi_ListMotorcycles_NotEmpty(t *testing.T) {
 	assert.True(t, resp.StatusCode == http.StatusOK)
 }
 
+// TestApi_ListMotorcycles_NotAuthenticated verifies that a request without a bearer token is challenged for one.
+func TestApi_ListMotorcycles_NotAuthenticated(t *testing.T) {
+
+	// ARRANGE
+	ourApi := newJwtApi()
+
+	// ACT
+	resp, _ := GetMotorcyclesWithToken(ourApi, "")
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusUnauthorized)
+	assert.True(t, resp.Header.Get("WWW-Authenticate") == "Bearer")
+}
+
+// TestApi_ListMotorcycles_InvalidToken verifies that a request with an invalid bearer token is not authenticated.
+func TestApi_ListMotorcycles_InvalidToken(t *testing.T) {
+
+	// ARRANGE
+	ourApi := newJwtApi()
+	token := signTestToken(t, []byte("another secret"), "mechanic", "General")
+
+	// ACT
+	resp, _ := GetMotorcyclesWithToken(ourApi, token)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusUnauthorized)
+}
+
+// TestApi_ListMotorcycles_Token verifies that a request with a valid bearer token is successful.
+func TestApi_ListMotorcycles_Token(t *testing.T) {
+
+	// ARRANGE
+	ourApi := newJwtApi()
+	token := signTestToken(t, testSecret, "accountant", "Accounting")
+
+	// ACT
+	resp, _ := GetMotorcyclesWithToken(ourApi, token)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusOK)
+}
+
+// TestApi_ListMotorcycles_ApiKey verifies that a request with an API key is successful, when the API accepts either credential.
+func TestApi_ListMotorcycles_ApiKey(t *testing.T) {
+
+	// ARRANGE
+	ourApi, apiKeys := newChainApi()
+	key, _, _ := security.IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.AccountingAuthorizationRole}, 0)
+
+	// ACT
+	resp, _ := GetMotorcyclesWithHeader(ourApi, security.ApiKeyHeader, key)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusOK)
+}
+
+// TestApi_ListMotorcycles_RevokedApiKey verifies that a request with a revoked API key is not authenticated.
+func TestApi_ListMotorcycles_RevokedApiKey(t *testing.T) {
+
+	// ARRANGE
+	ourApi, apiKeys := newChainApi()
+	key, apiKey, _ := security.IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.AccountingAuthorizationRole}, 0)
+	security.RevokeApiKey(apiKeys, apiKey.ID)
+
+	// ACT
+	resp, _ := GetMotorcyclesWithHeader(ourApi, security.ApiKeyHeader, key)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusUnauthorized)
+}
+
+// TestApi_ListMotorcycles_Query verifies that the list is filtered, sorted and divided into pages, which are linked together.
+func TestApi_ListMotorcycles_Query(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, motorcycleRepository, httprouter.New())
+	for year, vin := range map[int]string{2006: "01234567890123456", 2009: "01234567499923456", 2012: "11234567590123456"} {
+		motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", year, vin)
+		motorcycleRepository.Insert(motorcycle)
+	}
+	motorcycle, _ := entity.NewMotorcycle("Yamaha", "Bolt", 2015, "21234567290123456")
+	motorcycleRepository.Insert(motorcycle)
+
+	// ACT
+	first, _ := GetMotorcyclesWithQuery(ourApi, "/?make=honda&sort=-year&limit=2")
+	firstViewModel := viewmodel.ListMotorcyclesViewModel{}
+	json.NewDecoder(first.Body).Decode(&firstViewModel)
+	second, _ := GetMotorcyclesWithQuery(ourApi, firstViewModel.Page.Next)
+	secondViewModel := viewmodel.ListMotorcyclesViewModel{}
+	json.NewDecoder(second.Body).Decode(&secondViewModel)
+
+	// ASSERT
+	assert.True(t, first.StatusCode == http.StatusOK)
+	assert.True(t, firstViewModel.Page.Count == 2)
+	assert.True(t, firstViewModel.Motorcycles[0].Year == 2012)
+	assert.True(t, firstViewModel.Motorcycles[1].Year == 2009)
+	assert.True(t, firstViewModel.Page.Self == "/?make=honda&sort=-year&limit=2")
+	assert.True(t, first.Header.Get("Link") == "<"+firstViewModel.Page.Next+">; rel=\"next\"")
+	assert.True(t, second.StatusCode == http.StatusOK)
+	assert.True(t, secondViewModel.Page.Count == 1)
+	assert.True(t, secondViewModel.Motorcycles[0].Year == 2006)
+	assert.True(t, secondViewModel.Page.NextCursor == "")
+	assert.True(t, second.Header.Get("Link") == "")
+}
+
+// TestApi_ListMotorcycles_InvalidQuery verifies that a query parameter that cannot be parsed, or is not valid, is a bad request.
+func TestApi_ListMotorcycles_InvalidQuery(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, motorcycleRepository, httprouter.New())
+
+	for _, uri := range []string{"/?limit=many", "/?limit=1000", "/?sort=color", "/?cursor=invalid", "/?minYear=2012&maxYear=2009", "/?vinPrefix=JH_"} {
+
+		// ACT
+		resp, _ := GetMotorcyclesWithQuery(ourApi, uri)
+
+		// ASSERT
+		assert.True(t, resp.StatusCode == http.StatusBadRequest, uri)
+	}
+}
+
+// testSecret is the HMAC secret that signs the bearer tokens in the tests.
+var testSecret = []byte("a secret that is only used by tests")
+
+// newJwtApi creates an instance of the API web service, which authenticates each request from its bearer token.
+func newJwtApi() *Api {
+	authenticator, _ := security.NewJwtAuthenticator("HS256", testSecret, security.DefaultRolePolicy())
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authenticator, motorcycleRepository, httprouter.New())
+
+	return ourApi
+}
+
+// newChainApi creates an instance of the API web service, which authenticates each request from its bearer token or API key.
+// Returns the (API, key store).
+func newChainApi() (*Api, *repository.ApiKeyRepository) {
+	jwtAuthenticator, _ := security.NewJwtAuthenticator("HS256", testSecret, security.DefaultRolePolicy())
+	apiKeys, _ := repository.NewApiKeyRepository()
+	apiKeyAuthenticator, _ := security.NewApiKeyAuthenticator(apiKeys, security.DefaultRolePolicy())
+	authenticator, _ := security.NewChainAuthenticator(jwtAuthenticator, apiKeyAuthenticator)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authenticator, motorcycleRepository, httprouter.New())
+
+	return ourApi, apiKeys
+}
+
+// signTestToken creates a bearer token for the subject with the roles, which is signed with the secret.
+func signTestToken(t *testing.T, secret []byte, subject string, roles ...string) string {
+	claims := jwt.MapClaims{
+		"sub":   subject,
+		"roles": roles,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
 // GetMotorcycles retrieves the list of motorcycles from the repository using the RESTful API.
 // Returns (*response, nil) on success, otherwise (nil, error).
 func GetMotorcycles(ourApi *Api) (*http.Response, error) {
+	return GetMotorcyclesWithToken(ourApi, "")
+}
+
+// GetMotorcyclesWithToken retrieves the list of motorcycles from the repository using the RESTful API.
+// The bearer token is sent in the Authorization header, unless it is empty.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func GetMotorcyclesWithToken(ourApi *Api, token string) (*http.Response, error) {
+	if token == "" {
+		return GetMotorcyclesWithHeader(ourApi, "", "")
+	}
+
+	return GetMotorcyclesWithHeader(ourApi, "Authorization", "Bearer "+token)
+}
+
+// GetMotorcyclesWithHeader retrieves the list of motorcycles from the repository using the RESTful API.
+// The header is sent with the request, unless it is empty.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func GetMotorcyclesWithHeader(ourApi *Api, header string, value string) (*http.Response, error) {
 	// An http handler wrapper around httprouter's handler.  It permits us to use
 	// the test server.
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -104,5 +298,23 @@ func GetMotorcycles(ourApi *Api) (*http.Response, error) {
 		return nil, err
 	}
 
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+
 	return client.Do(req)
 }
+
+// GetMotorcyclesWithQuery retrieves a page of the list of motorcycles from the repository using the RESTful API.
+// The URI is the path and query of the request, such as the link to the next page.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func GetMotorcyclesWithQuery(ourApi *Api, uri string) (*http.Response, error) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ourApi.ListMotorcyclesHandler(w, r, httprouter.Params{})
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	return http.Get(server.URL + uri)
+}
@@ -0,0 +1,96 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	// Third party packages
+	"github.com/julienschmidt/httprouter"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/adapter/presenter"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+)
+
+// GetMaintenanceDueHandler processes requests to get the maintenance that is due, overdue, or upcoming for a motorcycle.
+// The optional asOf query parameter is an RFC 3339 time at which to evaluate the maintenance, rather than now.
+func (api *Api) GetMaintenanceDueHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	asOfUtc := time.Time{}
+	if asOf := r.URL.Query().Get("asOf"); asOf != "" {
+		asOfUtc, err = time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	// Create the dueRequest, process it, and get the resulting view model or error.
+	dueRequest, err := request.NewGetMaintenanceDueRequest(typedef.ID(motorcycleID), asOfUtc.UTC())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	dueInteractor, err := interactor.NewGetMaintenanceDueInteractor(api.MotorcycleRepository, api.OdometerReadingRepository,
+		api.ServiceRecordRepository, api.MaintenanceScheduleRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	dueResponse, err := dueInteractor.Handle(dueRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if dueResponse.Error != nil {
+		writeProblem(w, r, httpStatus(dueResponse.Status, false), dueResponse.Error)
+		return
+	}
+
+	duePresenter, err := presenter.NewGetMaintenanceDuePresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewModel, err := duePresenter.Handle(dueResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Marshal provided viewModel into JSON structure
+	uj, err := json.Marshal(viewModel)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write content-type, status code, payload
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "%s", uj)
+}
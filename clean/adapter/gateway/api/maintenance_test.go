@@ -0,0 +1,108 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/maintenancestatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApi_GetMaintenanceDue verifies that the oil change is due once the motorcycle has travelled far enough since it was performed.
+func TestApi_GetMaintenanceDue(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	insertResponse, _ := InsertMotorcycle(ourApi, motorcycle)
+	insertionViewModel := viewmodel.InsertMotorcycleViewModel{}
+	json.NewDecoder(insertResponse.Body).Decode(&insertionViewModel)
+	motorcycleID := insertionViewModel.ID
+	record := newTestServiceRecordDto()
+	record.Unit = distanceunit.KilometersDistanceUnit
+	PostServiceRecord(ourApi, motorcycleID, record)
+	PostOdometerReading(ourApi, motorcycleID, dto.TerseOdometerReadingDto{Value: 9800, Unit: distanceunit.KilometersDistanceUnit})
+
+	// ACT
+	resp, _ := GetMaintenanceDue(ourApi, motorcycleID, "2018-06-01T00:00:00Z")
+	viewModel := viewmodel.GetMaintenanceDueViewModel{}
+	json.NewDecoder(resp.Body).Decode(&viewModel)
+	badResp, _ := GetMaintenanceDue(ourApi, motorcycleID, "yesterday")
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 200)
+	assert.True(t, viewModel.Odometer == 9800)
+	assert.True(t, len(viewModel.Items) == 6)
+	oilChange := viewModel.Items[0]
+	for _, item := range viewModel.Items {
+		if item.Type == servicetype.OilChangeServiceType {
+			oilChange = item
+		}
+	}
+	assert.True(t, oilChange.Status == maintenancestatus.UpcomingMaintenanceStatus)
+	assert.True(t, *oilChange.DueOdometer == 10000)
+	assert.True(t, oilChange.LastServiceID == 1)
+	assert.True(t, badResp.StatusCode == 400)
+}
+
+// TestApi_GetMaintenanceDue_MotorcycleNotExist verifies that the maintenance of a missing motorcycle is not found.
+func TestApi_GetMaintenanceDue_MotorcycleNotExist(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	// ACT
+	resp, _ := GetMaintenanceDue(ourApi, 123, "")
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 404)
+}
+
+// GetMaintenanceDue gets the maintenance that is due on a motorcycle at the time using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func GetMaintenanceDue(ourApi *Api, motorcycleID typedef.ID, asOf string) (*http.Response, error) {
+
+	// An http handler wrapper around httprouter's handler.  It permits us to use
+	// the test server.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ourApi.GetMaintenanceDueHandler(w, r, httprouter.Params{
+			httprouter.Param{Key: "id", Value: strconv.Itoa(int(motorcycleID))},
+		})
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	url := server.URL
+	if asOf != "" {
+		url += "?asOf=" + asOf
+	}
+
+	return http.Get(url)
+}
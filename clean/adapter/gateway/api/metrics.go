@@ -0,0 +1,170 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"bytes"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	// Third party packages
+	"github.com/julienschmidt/httprouter"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/metrics"
+)
+
+// unmatchedRoute is the route of a request that did not match any of the API's routes, so the metrics are not
+// divided by every path that a client tries.
+const unmatchedRoute = "unmatched"
+
+// otherMethod is the method of a request whose method is not a standard one, so a client cannot add a series to the
+// metrics for each method that it makes up.
+const otherMethod = "other"
+
+// standardMethods are the HTTP methods that are measured separately.
+var standardMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// requestMetrics measures the requests that the API processes.
+type requestMetrics struct {
+	// requests is the number of requests that were processed, by method, route and status code.
+	requests *metrics.Counter
+	// duration is the duration of the requests, in seconds, by method, route and status code.
+	duration *metrics.Histogram
+	// inFlight is the number of requests that are being processed.
+	inFlight *metrics.Gauge
+}
+
+// newRequestMetrics creates the metrics of the API's requests, and registers them.
+// Returns (request metrics, nil) on success, otherwise (nil, error).
+func newRequestMetrics(registry *metrics.Registry) (*requestMetrics, error) {
+	requests, err := registry.NewCounter("motominder_http_requests_total",
+		"The number of HTTP requests that were processed.", "method", "route", "status")
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := registry.NewHistogram("motominder_http_request_duration_seconds",
+		"The duration of the HTTP requests, in seconds.", metrics.DefaultDurationBuckets, "method", "route", "status")
+	if err != nil {
+		return nil, err
+	}
+
+	inFlight, err := registry.NewGauge("motominder_http_requests_in_flight",
+		"The number of HTTP requests that are being processed.")
+	if err != nil {
+		return nil, err
+	}
+
+	return &requestMetrics{requests: requests, duration: duration, inFlight: inFlight}, nil
+}
+
+// observe records a request that was processed.
+func (requestMetrics *requestMetrics) observe(method string, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	if !standardMethods[method] {
+		method = otherMethod
+	}
+
+	requestMetrics.requests.Inc(method, route, strconv.Itoa(status))
+	requestMetrics.duration.Observe(duration.Seconds(), method, route, strconv.Itoa(status))
+}
+
+// configureMetrics creates the API's metrics, and measures the operations of its repositories, which are replaced by
+// ones that measure them.
+// Returns nil on success, otherwise error.
+func (api *Api) configureMetrics() error {
+	registry, err := metrics.NewRegistry()
+	if err != nil {
+		return err
+	}
+
+	repositoryMetrics, err := metrics.NewRepositoryMetrics(registry)
+	if err != nil {
+		return err
+	}
+
+	// The number of motorcycles is counted when the metrics are scraped, without measuring it as an operation.
+	motorcycleRepository := api.MotorcycleRepository
+	_, err = registry.NewGaugeFunc("motominder_motorcycles", "The number of motorcycles in the repository.", func() float64 {
+		motorcycles, _, err := motorcycleRepository.List()
+		if err != nil {
+			return math.NaN()
+		}
+		return float64(len(motorcycles))
+	})
+	if err != nil {
+		return err
+	}
+
+	api.requestMetrics, err = newRequestMetrics(registry)
+	if err != nil {
+		return err
+	}
+
+	api.MotorcycleRepository, err = metrics.NewMotorcycleRepository(api.MotorcycleRepository, repositoryMetrics)
+	if err != nil {
+		return err
+	}
+
+	api.OdometerReadingRepository, err = metrics.NewOdometerReadingRepository(api.OdometerReadingRepository, repositoryMetrics)
+	if err != nil {
+		return err
+	}
+
+	api.ServiceRecordRepository, err = metrics.NewServiceRecordRepository(api.ServiceRecordRepository, repositoryMetrics)
+	if err != nil {
+		return err
+	}
+
+	api.ReminderRepository, err = metrics.NewReminderRepository(api.ReminderRepository, repositoryMetrics)
+	if err != nil {
+		return err
+	}
+
+	api.Metrics = registry
+
+	// All okay
+	return nil
+}
+
+// MetricsHandler writes the API's metrics in the Prometheus text format, so they can be scraped by Prometheus.
+// It does not require authentication, since the metrics only count things, so it is only served on the metrics
+// address, which should only be reachable by the monitoring system.
+func (api *Api) MetricsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var buffer bytes.Buffer
+	err := api.Metrics.Write(&buffer)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", metrics.TextContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buffer.Bytes())
+}
+
+// newMetricsHandler creates the handler of the server on the metrics address, which only serves the metrics.
+func (api *Api) newMetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		api.MetricsHandler(w, r, httprouter.Params{})
+	})
+
+	return mux
+}
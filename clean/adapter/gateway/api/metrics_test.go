@@ -0,0 +1,45 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMetricsHandler verifies that the requests, the repository operations and the motorcycles are exposed to Prometheus
+// on the metrics address, and not on the API's.
+func TestMetricsHandler(t *testing.T) {
+
+	// ARRANGE
+	ourApi, _ := newServerApi()
+	list, _ := http.NewRequest(http.MethodGet, "/api/motorcycles", nil)
+	unknown, _ := http.NewRequest(http.MethodGet, "/api/unknown/1", nil)
+	made, _ := http.NewRequest("BREW", "/api/unknown/1", nil)
+	scrape, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	scrapeResp := httptest.NewRecorder()
+	publicScrapeResp := httptest.NewRecorder()
+
+	// ACT
+	ourApi.server.Handler.ServeHTTP(httptest.NewRecorder(), list)
+	ourApi.server.Handler.ServeHTTP(httptest.NewRecorder(), unknown)
+	ourApi.server.Handler.ServeHTTP(httptest.NewRecorder(), made)
+	ourApi.metricsServer.Handler.ServeHTTP(scrapeResp, scrape)
+	ourApi.server.Handler.ServeHTTP(publicScrapeResp, scrape)
+
+	// ASSERT
+	body := scrapeResp.Body.String()
+	assert.True(t, scrapeResp.Code == http.StatusOK)
+	assert.Equal(t, metrics.TextContentType, scrapeResp.Header().Get("Content-Type"))
+	assert.Contains(t, body, `motominder_http_requests_total{method="GET",route="/api/motorcycles",status="200"} 1`)
+	assert.Contains(t, body, `motominder_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `motominder_http_request_duration_seconds_count{method="GET",route="/api/motorcycles",status="200"} 1`)
+	assert.Contains(t, body, `motominder_http_requests_total{method="other",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, "motominder_http_requests_in_flight 0\n")
+	assert.Contains(t, body, `motominder_repository_operation_duration_seconds_count{repository="motorcycle",operation="query"} 1`)
+	assert.Contains(t, body, "motominder_motorcycles 0\n")
+	assert.True(t, publicScrapeResp.Code == http.StatusNotFound)
+}
@@ -0,0 +1,218 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	// Third party packages
+	"github.com/julienschmidt/httprouter"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/presenter"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+)
+
+// ListOdometerReadingsHandler processes requests to get a list of a motorcycle's odometer readings from the repository.
+func (api *Api) ListOdometerReadingsHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Create the listRequest, process it, and get the resulting view model or error.
+	listRequest, err := request.NewListOdometerReadingsRequest(typedef.ID(motorcycleID))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	listInteractor, err := interactor.NewListOdometerReadingsInteractor(api.MotorcycleRepository, api.OdometerReadingRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	listResponse, err := listInteractor.Handle(listRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if listResponse.Error != nil {
+		writeProblem(w, r, httpStatus(listResponse.Status, false), listResponse.Error)
+		return
+	}
+
+	listPresenter, err := presenter.NewListOdometerReadingsPresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewModel, err := listPresenter.Handle(listResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Marshal provided viewModel into JSON structure
+	uj, err := json.Marshal(viewModel)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write content-type, status code, payload
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "%s", uj)
+}
+
+// PostOdometerReadingHandler records an odometer reading for a motorcycle.
+func (api *Api) PostOdometerReadingHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Populate the reading from the readingRequest body.
+	readingDto := dto.TerseOdometerReadingDto{}
+	status, err := api.decodeJsonBody(w, r, &readingDto)
+	if err != nil {
+		writeProblem(w, r, status, err)
+		return
+	}
+
+	// Create the readingRequest, process it, and get the resulting view model or error.
+	readingRequest, err := request.NewInsertOdometerReadingRequest(typedef.ID(motorcycleID), readingDto.Value, readingDto.Unit,
+		readingDto.ReadingUtc, readingDto.Source, readingDto.Rollover)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	session := api.NewSession()
+	defer session.Close()
+
+	readingInteractor, err := interactor.NewInsertOdometerReadingInteractor(session.MotorcycleRepository, session.OdometerReadingRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	readingInteractor.Logger = requestLogger(r)
+
+	insertResponse, err := readingInteractor.Handle(readingRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if insertResponse.Error != nil {
+		writeProblem(w, r, httpStatus(insertResponse.Status, false), insertResponse.Error)
+		return
+	}
+
+	readingPresenter, err := presenter.NewInsertOdometerReadingPresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewModel, err := readingPresenter.Handle(insertResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Marshal provided viewModel into JSON structure
+	uj, err := json.Marshal(viewModel)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write content-type, status code, payload
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/api/motorcycles/%d/odometer/%d", insertResponse.MotorcycleID, insertResponse.ID))
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, "%s", uj)
+}
+
+// DelOdometerReadingHandler deletes a motorcycle's latest odometer reading from the repository.
+func (api *Api) DelOdometerReadingHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	readingID, err := strconv.Atoi(p.ByName("readingId"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Create the deleteRequest, process it, and get the resulting view model or error.
+	deleteRequest, err := request.NewDeleteOdometerReadingRequest(typedef.ID(motorcycleID), typedef.ID(readingID))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	session := api.NewSession()
+	defer session.Close()
+
+	deleteInteractor, err := interactor.NewDeleteOdometerReadingInteractor(session.MotorcycleRepository, session.OdometerReadingRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	deleteInteractor.Logger = requestLogger(r)
+
+	deleteResponse, err := deleteInteractor.Handle(deleteRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if deleteResponse.Error != nil {
+		writeProblem(w, r, httpStatus(deleteResponse.Status, false), deleteResponse.Error)
+		return
+	}
+
+	// Write status code
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,123 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApi_OdometerReadings verifies recording, listing and deleting a motorcycle's odometer readings.
+func TestApi_OdometerReadings(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	insertResponse, _ := InsertMotorcycle(ourApi, motorcycle)
+	insertionViewModel := viewmodel.InsertMotorcycleViewModel{}
+	json.NewDecoder(insertResponse.Body).Decode(&insertionViewModel)
+	motorcycleID := insertionViewModel.ID
+
+	// ACT
+	postResponse, _ := PostOdometerReading(ourApi, motorcycleID, dto.TerseOdometerReadingDto{Value: 1200, Unit: distanceunit.MilesDistanceUnit})
+	postViewModel := viewmodel.InsertOdometerReadingViewModel{}
+	json.NewDecoder(postResponse.Body).Decode(&postViewModel)
+	decreasedResponse, _ := PostOdometerReading(ourApi, motorcycleID, dto.TerseOdometerReadingDto{Value: 1100, Unit: distanceunit.MilesDistanceUnit})
+	listResponse, _ := GetOdometerReadings(ourApi, motorcycleID)
+	listViewModel := viewmodel.ListOdometerReadingsViewModel{}
+	json.NewDecoder(listResponse.Body).Decode(&listViewModel)
+	deleteResponse, _ := DelOdometerReading(ourApi, motorcycleID, postViewModel.ID)
+
+	// ASSERT
+	assert.True(t, postResponse.StatusCode == 201)
+	assert.True(t, decreasedResponse.StatusCode == 400)
+	assert.True(t, listResponse.StatusCode == 200)
+	assert.True(t, len(listViewModel.Readings) == 1)
+	assert.True(t, listViewModel.Readings[0].Unit == distanceunit.MilesDistanceUnit)
+	assert.True(t, deleteResponse.StatusCode == 204)
+}
+
+// TestApi_PostOdometerReading_UnknownUnit verifies that a reading in an unknown unit is a bad request.
+func TestApi_PostOdometerReading_UnknownUnit(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	// ACT
+	resp, _ := serveOdometerReadings(ourApi.PostOdometerReadingHandler, "POST", 1, "", []byte(`{"value": 1200, "unit": "furlongs"}`))
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 400)
+}
+
+// PostOdometerReading records an odometer reading for a motorcycle using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func PostOdometerReading(ourApi *Api, motorcycleID typedef.ID, reading dto.TerseOdometerReadingDto) (*http.Response, error) {
+	readingJson, _ := json.Marshal(reading)
+	return serveOdometerReadings(ourApi.PostOdometerReadingHandler, "POST", motorcycleID, "", readingJson)
+}
+
+// GetOdometerReadings gets a list of a motorcycle's odometer readings using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func GetOdometerReadings(ourApi *Api, motorcycleID typedef.ID) (*http.Response, error) {
+	return serveOdometerReadings(ourApi.ListOdometerReadingsHandler, "GET", motorcycleID, "", nil)
+}
+
+// DelOdometerReading deletes a motorcycle's odometer reading using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func DelOdometerReading(ourApi *Api, motorcycleID typedef.ID, id typedef.ID) (*http.Response, error) {
+	return serveOdometerReadings(ourApi.DelOdometerReadingHandler, "DELETE", motorcycleID, strconv.Itoa(int(id)), nil)
+}
+
+// serveOdometerReadings sends a request with the body to an odometer reading handler, with the motorcycle and reading IDs as parameters.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func serveOdometerReadings(handle httprouter.Handle, method string, motorcycleID typedef.ID, readingID string, body []byte) (*http.Response, error) {
+
+	// An http handler wrapper around httprouter's handler.  It permits us to use
+	// the test server.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, httprouter.Params{
+			httprouter.Param{Key: "id", Value: strconv.Itoa(int(motorcycleID))},
+			httprouter.Param{Key: "readingId", Value: readingID},
+		})
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, err := http.NewRequest(method, server.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	return client.Do(req)
+}
@@ -0,0 +1,135 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	// Third party packages
+	"github.com/julienschmidt/httprouter"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/adapter/presenter"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/patchformat"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+)
+
+// PatchMotorcycleHandler changes some of the fields of an existing motorcycle in the repository.  The body is either a
+// JSON merge patch or a JSON patch, as identified by its Content-Type.  The motorcycle is only changed if the entity tag
+// in an If-Match header is current, when it is present.
+func (api *Api) PatchMotorcycleHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	id, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// The format of the patch is identified by its media type, and the client is told which formats are supported.
+	format, err := parsePatchFormat(r)
+	if err != nil {
+		w.Header().Set("Accept-Patch", acceptPatch())
+		writeProblem(w, r, http.StatusUnsupportedMediaType, err)
+		return
+	}
+
+	contents, status, err := api.readBody(w, r)
+	if err != nil {
+		writeProblem(w, r, status, err)
+		return
+	}
+
+	rowVersion, hasIfMatch, err := parseIfMatch(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	// Create the motorcycleRequest, process it, and get the resulting view model or error.
+	motorcycleRequest, err := request.NewPatchMotorcycleRequest(typedef.ID(id), rowVersion, format, contents)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	session := api.NewSession()
+	defer session.Close()
+
+	motorcycleInteractor, err := interactor.NewPatchMotorcycleInteractor(session.MotorcycleRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	motorcycleInteractor.Logger = requestLogger(r)
+
+	patchResponse, err := motorcycleInteractor.Handle(motorcycleRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if patchResponse.Error != nil {
+		writeProblem(w, r, httpStatus(patchResponse.Status, hasIfMatch), patchResponse.Error)
+		return
+	}
+
+	motorcyclePresenter, err := presenter.NewPatchMotorcyclePresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewModel, err := motorcyclePresenter.Handle(patchResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Marshal provided viewModel into JSON structure
+	uj, err := json.Marshal(viewModel)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write content-type, entity tag, status code, payload
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", formatETag(patchResponse.Motorcycle.RowVersion))
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "%s", uj)
+}
+
+// parsePatchFormat gets the format of a patch from the media type in a request's Content-Type header.
+// Returns (patch format, nil) on success, otherwise (UndefinedPatchFormat, error).
+func parsePatchFormat(r *http.Request) (patchformat.PatchFormat, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return patchformat.UndefinedPatchFormat, err
+	}
+
+	return patchformat.Parse(mediaType)
+}
+
+// acceptPatch lists the media types of the supported patch formats, for an Accept-Patch header.
+func acceptPatch() string {
+	mediaTypes := make([]string, 0, len(patchformat.All))
+	for _, format := range patchformat.All {
+		mediaTypes = append(mediaTypes, format.(patchformat.PatchFormat).ToString())
+	}
+
+	return strings.Join(mediaTypes, ", ")
+}
@@ -0,0 +1,124 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApi_PatchMotorcycle verifies a successful response after patching a motorcycle, with the entity tag of its new version.
+func TestApi_PatchMotorcycle(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repos.Insert(motorcycle)
+
+	// ACT
+	merge, _ := PatchMotorcycle(ourApi, moto.ID, "application/merge-patch+json", `{"model": "Gold Wing"}`, `"1"`)
+	jsonPatch, _ := PatchMotorcycle(ourApi, moto.ID, "application/json-patch+json", `[{"op": "replace", "path": "/year", "value": 2007}]`, "")
+	stale, _ := PatchMotorcycle(ourApi, moto.ID, "application/merge-patch+json", `{"model": "Shadow"}`, `"1"`)
+
+	// ASSERT
+	assert.True(t, merge.StatusCode == 200)
+	assert.True(t, merge.Header.Get("ETag") == `"2"`)
+	assert.True(t, jsonPatch.StatusCode == 200)
+	assert.True(t, stale.StatusCode == 412)
+}
+
+// TestApi_PatchMotorcycle_UnsupportedMediaType verifies that the supported patch formats are listed when the body is not one of them.
+func TestApi_PatchMotorcycle_UnsupportedMediaType(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repos.Insert(motorcycle)
+
+	// ACT
+	resp, _ := PatchMotorcycle(ourApi, moto.ID, "application/json", `{"model": "Gold Wing"}`, "")
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 415)
+	assert.Contains(t, resp.Header.Get("Accept-Patch"), "application/merge-patch+json")
+}
+
+// TestApi_PatchMotorcycle_Invalid verifies the responses to a patch that is not well formed, and to one that cannot be applied.
+func TestApi_PatchMotorcycle_Invalid(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repos.Insert(motorcycle)
+
+	// ACT
+	malformed, _ := PatchMotorcycle(ourApi, moto.ID, "application/json-patch+json", `{"op": "remove"}`, "")
+	unprocessable, _ := PatchMotorcycle(ourApi, moto.ID, "application/json-patch+json", `[{"op": "remove", "path": "/color"}]`, "")
+
+	// ASSERT
+	assert.True(t, malformed.StatusCode == 400)
+	assert.True(t, unprocessable.StatusCode == 422)
+}
+
+// PatchMotorcycle patches a motorcycle in the repository using the RESTful API.
+// The If-Match header is set to ifMatch, unless it is empty.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func PatchMotorcycle(ourApi *Api, id typedef.ID, contentType string, body string, ifMatch string) (*http.Response, error) {
+
+	idText := strconv.Itoa(int(id))
+
+	// An http handler wrapper around httprouter's handler.  It permits us to use
+	// the test server.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ourApi.PatchMotorcycleHandler(w, r, httprouter.Params{httprouter.Param{
+			Key:   "id",
+			Value: idText,
+		}})
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, err := http.NewRequest("PATCH", server.URL+"/"+idText, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	return http.DefaultClient.Do(req)
+}
@@ -10,6 +10,7 @@ import (
 
 	"bytes"
 
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
 	"github.com/abitofhelp/motominderapi/clean/domain/entity"
@@ -33,7 +34,7 @@ func TestApi_InsertMotorcycle(t *testing.T) {
 	router := httprouter.New()
 
 	// Create an instance of the API web service.
-	ourApi, err := NewApi(roles, authService, motorcycleRepository, router)
+	ourApi, err := NewApi(authService, motorcycleRepository, router)
 	if err != nil {
 		println("Failed to create an instance of the API web service: &s", err.Error())
 		return
@@ -50,9 +51,71 @@ func TestApi_InsertMotorcycle(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 }
 
+// TestApi_InsertMotorcycle_InvalidVin verifies that a VIN with the wrong check digit is rejected with a message explaining why.
+func TestApi_InsertMotorcycle_InvalidVin(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, motorcycleRepository, httprouter.New())
+	motorcycle := &entity.Motorcycle{Make: "Honda", Model: "Shadow", Year: 2006, Vin: "01234567190123456"}
+
+	// ACT
+	resp, _ := InsertMotorcycle(ourApi, motorcycle)
+	problem := dto.ProblemDto{}
+	json.NewDecoder(resp.Body).Decode(&problem)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 400)
+	assert.Equal(t, dto.ProblemMediaType, resp.Header.Get("Content-Type"))
+	assert.True(t, problem.Status == 400)
+	assert.Contains(t, problem.Detail, "check digit")
+	assert.True(t, len(motorcycleRepository.Motorcycles) == 0)
+}
+
+// TestApi_InsertMotorcycle_VinAlreadyExists verifies that a duplicate VIN is rejected as a conflict, with a message
+// explaining why.
+func TestApi_InsertMotorcycle_VinAlreadyExists(t *testing.T) {
+
+	// ARRANGE
+	ourApi, _ := newServerApi()
+	first, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	second, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	InsertMotorcycle(ourApi, first)
+
+	// ACT
+	resp, _ := InsertMotorcycle(ourApi, second)
+	problem := dto.ProblemDto{}
+	json.NewDecoder(resp.Body).Decode(&problem)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusConflict)
+	assert.True(t, problem.Status == http.StatusConflict)
+	assert.Contains(t, problem.Detail, "already exists")
+}
+
 // InsertMotorcycle inserts a motorcycle into the repository using the RESTful API.
+// Only the fields that a client can set are sent.
 // Returns (*response, nil) on success, otherwise (nil, error).
 func InsertMotorcycle(ourApi *Api, motorcycle *entity.Motorcycle) (*http.Response, error) {
+	motorcycleJson, _ := json.Marshal(dto.TerseMotorcycleDto{
+		Make:  motorcycle.Make,
+		Model: motorcycle.Model,
+		Year:  motorcycle.Year,
+		Vin:   motorcycle.Vin,
+	})
+
+	return PostMotorcycleBody(ourApi, "application/json", motorcycleJson)
+}
+
+// PostMotorcycleBody posts the body to the RESTful API for inserting a motorcycle.
+// The Content-Type header is set to contentType, unless it is empty.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func PostMotorcycleBody(ourApi *Api, contentType string, motorcycleJson []byte) (*http.Response, error) {
 	// An http handler wrapper around httprouter's handler.  It permits us to use
 	// the test server.
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -64,14 +127,14 @@ func InsertMotorcycle(ourApi *Api, motorcycle *entity.Motorcycle) (*http.Respons
 
 	client := &http.Client{}
 
-	motorcycleJson, _ := json.Marshal(motorcycle)
-
 	req, err := http.NewRequest("POST", server.URL, bytes.NewBuffer(motorcycleJson))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 	req.Header.Add("Content-Length", strconv.Itoa(len(motorcycleJson)))
 
 	return client.Do(req)
@@ -0,0 +1,42 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	// Third party packages
+	log "github.com/sirupsen/logrus"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+)
+
+// writeProblem writes the HTTP status code of a failed request, and a problem details body explaining why it failed,
+// such as the fields of the request that are not valid.  The error is logged, since an unexpected error is not
+// explained to the client.
+// When the user has not been authenticated, the client is challenged to provide a bearer token.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, err error) {
+	entry := requestEntry(r).WithError(err).WithFields(log.Fields{
+		"method": r.Method,
+		"uri":    r.URL.RequestURI(),
+		"status": status,
+	})
+	if status >= http.StatusInternalServerError {
+		entry.Error("The request failed.")
+	} else {
+		entry.Info("The request was rejected.")
+	}
+
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+
+	uj, _ := json.Marshal(dto.NewProblemDto(status, err, r.URL.RequestURI()))
+
+	w.Header().Set("Content-Type", dto.ProblemMediaType)
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "%s", uj)
+}
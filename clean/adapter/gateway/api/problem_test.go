@@ -0,0 +1,159 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/julienschmidt/httprouter"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWriteProblem_ValidationErrors verifies that the fields that are not valid are listed, including nested ones.
+func TestWriteProblem_ValidationErrors(t *testing.T) {
+
+	// ARRANGE
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/motorcycles?dryRun=true", nil)
+	err := pkgerrors.Wrap(validation.Errors{
+		"year": errors.New("must be no less than 1999"),
+		"make": errors.New("cannot be blank"),
+		"service": validation.Errors{
+			"cost": errors.New("must be no greater than 1000000"),
+		},
+	}, "cannot insert the motorcycle")
+
+	// ACT
+	writeProblem(w, r, http.StatusBadRequest, err)
+	problem := dto.ProblemDto{}
+	json.NewDecoder(w.Body).Decode(&problem)
+
+	// ASSERT
+	assert.True(t, w.Code == http.StatusBadRequest)
+	assert.Equal(t, dto.ProblemMediaType, w.Header().Get("Content-Type"))
+	assert.Equal(t, dto.BlankProblemType, problem.Type)
+	assert.Equal(t, "Bad Request", problem.Title)
+	assert.True(t, problem.Status == http.StatusBadRequest)
+	assert.Equal(t, "/api/motorcycles?dryRun=true", problem.Instance)
+	assert.Equal(t, []dto.FieldErrorDto{
+		{Field: "make", Message: "cannot be blank"},
+		{Field: "service.cost", Message: "must be no greater than 1000000"},
+		{Field: "year", Message: "must be no less than 1999"},
+	}, problem.Errors)
+}
+
+// TestWriteProblem_InternalError verifies that the detail of an unexpected error is not disclosed to the client.
+func TestWriteProblem_InternalError(t *testing.T) {
+
+	// ARRANGE
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/motorcycles", nil)
+
+	// ACT
+	writeProblem(w, r, http.StatusInternalServerError, errors.New("database is locked"))
+	problem := dto.ProblemDto{}
+	json.NewDecoder(w.Body).Decode(&problem)
+
+	// ASSERT
+	assert.True(t, problem.Status == http.StatusInternalServerError)
+	assert.Equal(t, "Internal Server Error", problem.Title)
+	assert.Empty(t, problem.Detail)
+}
+
+// TestApi_Problem_NotAuthenticated verifies that a request without a bearer token is challenged with a problem.
+func TestApi_Problem_NotAuthenticated(t *testing.T) {
+
+	// ARRANGE
+	ourApi := newJwtApi()
+
+	// ACT
+	resp, _ := GetMotorcyclesWithToken(ourApi, "")
+	problem := dto.ProblemDto{}
+	json.NewDecoder(resp.Body).Decode(&problem)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusUnauthorized)
+	assert.Equal(t, "Bearer", resp.Header.Get("WWW-Authenticate"))
+	assert.Equal(t, dto.ProblemMediaType, resp.Header.Get("Content-Type"))
+	assert.True(t, problem.Status == http.StatusUnauthorized)
+}
+
+// TestApi_Problem_NotFound verifies that a response with an error is translated into a problem with its status.
+func TestApi_Problem_NotFound(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, motorcycleRepository, httprouter.New())
+
+	// ACT
+	resp, _ := GetMotorcycle(ourApi, 42)
+	problem := dto.ProblemDto{}
+	json.NewDecoder(resp.Body).Decode(&problem)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusNotFound)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Contains(t, problem.Detail, "42")
+}
+
+// TestApi_Problem_InvalidFields verifies that the fields of an invalid motorcycle are listed in the problem.
+func TestApi_Problem_InvalidFields(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, motorcycleRepository, httprouter.New())
+	motorcycle := &entity.Motorcycle{Make: "", Model: "Shadow", Year: 1900, Vin: "01234567890123456"}
+
+	// ACT
+	resp, _ := InsertMotorcycle(ourApi, motorcycle)
+	problem := dto.ProblemDto{}
+	json.NewDecoder(resp.Body).Decode(&problem)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == http.StatusBadRequest)
+	assert.True(t, len(problem.Errors) == 2)
+	assert.Equal(t, "make", problem.Errors[0].Field)
+	assert.Equal(t, "year", problem.Errors[1].Field)
+}
+
+// TestHttpStatus verifies that the statuses of failures are reported as they are, and that a status which does not
+// describe a failure is logged and reported as an internal error.
+func TestHttpStatus(t *testing.T) {
+
+	// ARRANGE
+	hook := test.NewGlobal()
+	defer log.StandardLogger().ReplaceHooks(make(log.LevelHooks))
+
+	// ACT
+	conflict := httpStatus(operationstatus.Conflict, false)
+	precondition := httpStatus(operationstatus.Conflict, true)
+	found := httpStatus(operationstatus.Found, false)
+
+	// ASSERT
+	assert.Equal(t, http.StatusConflict, conflict)
+	assert.Equal(t, http.StatusPreconditionFailed, precondition)
+	assert.Equal(t, http.StatusInternalServerError, found)
+	assert.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, log.ErrorLevel, hook.LastEntry().Level)
+}
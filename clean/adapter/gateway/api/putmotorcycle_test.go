@@ -34,7 +34,7 @@ func TestApi_UpdateMotorcycle(t *testing.T) {
 	router := httprouter.New()
 
 	// Create an instance of the API web service.
-	ourApi, err := NewApi(roles, authService, repos, router)
+	ourApi, err := NewApi(authService, repos, router)
 	if err != nil {
 		println("Failed to create an instance of the API web service: &s", err.Error())
 		return
@@ -51,7 +51,7 @@ func TestApi_UpdateMotorcycle(t *testing.T) {
 	motorcycle, _, _ = repos.FindByID(insertionViewModel.ID)
 
 	// Change its VIN.
-	motorcycle.Vin = "65432109876543210"
+	motorcycle.Vin = "65432109176543210"
 
 	// ACT
 	resp, err := UpdateMotorcycle(ourApi, motorcycle.ID, *motorcycle)
@@ -60,16 +60,124 @@ func TestApi_UpdateMotorcycle(t *testing.T) {
 	assert.True(t, resp.StatusCode == 204)
 }
 
+// TestApi_UpdateMotorcycle_IfMatch verifies that an update with a current entity tag is successful,
+// and that a second update with the same, now stale, entity tag fails its precondition.
+func TestApi_UpdateMotorcycle_IfMatch(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repos.Insert(motorcycle)
+	getResponse, _ := GetMotorcycle(ourApi, moto.ID)
+	etag := getResponse.Header.Get("ETag")
+	moto.Make = "Harley Davidson"
+
+	// ACT
+	first, _ := UpdateMotorcycleIfMatch(ourApi, moto.ID, *moto, etag)
+	second, _ := UpdateMotorcycleIfMatch(ourApi, moto.ID, *moto, etag)
+
+	// ASSERT
+	assert.True(t, first.StatusCode == 204)
+	assert.True(t, second.StatusCode == 412)
+}
+
+// TestApi_UpdateMotorcycle_VinAlreadyExists verifies that an update that takes the VIN of another motorcycle is a
+// conflict, rather than a failed precondition, even when it has a current entity tag.
+func TestApi_UpdateMotorcycle_VinAlreadyExists(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	first, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	second, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "11111111111111111")
+	repos.Insert(first)
+	moto, _, _ := repos.Insert(second)
+	getResponse, _ := GetMotorcycle(ourApi, moto.ID)
+	etag := getResponse.Header.Get("ETag")
+	moto.Vin = first.Vin
+
+	// ACT
+	resp, _ := UpdateMotorcycleIfMatch(ourApi, moto.ID, *moto, etag)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 409)
+}
+
+// TestApi_UpdateMotorcycle_StaleRowVersion verifies a conflict response when the row version in the body is stale.
+func TestApi_UpdateMotorcycle_StaleRowVersion(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repos.Insert(motorcycle)
+	repos.Update(moto.ID, moto)
+
+	// ACT
+	resp, _ := UpdateMotorcycle(ourApi, moto.ID, *moto)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 409)
+}
+
+// TestApi_UpdateMotorcycle_MalformedIfMatch verifies that an If-Match header that is not an entity tag fails its precondition.
+func TestApi_UpdateMotorcycle_MalformedIfMatch(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repos.Insert(motorcycle)
+
+	// ACT
+	resp, _ := UpdateMotorcycleIfMatch(ourApi, moto.ID, *moto, `W/"1"`)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 412)
+}
+
 // UpdateMotorcycle updates a motorcycle in the repository using the RESTful API.
 // Returns (*response, nil) on success, otherwise (nil, error).
 func UpdateMotorcycle(ourApi *Api, id typedef.ID, motorcycle entity.Motorcycle) (*http.Response, error) {
+	return UpdateMotorcycleIfMatch(ourApi, id, motorcycle, "")
+}
+
+// UpdateMotorcycleIfMatch updates a motorcycle in the repository using the RESTful API.
+// The If-Match header is set to ifMatch, unless it is empty.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func UpdateMotorcycleIfMatch(ourApi *Api, id typedef.ID, motorcycle entity.Motorcycle, ifMatch string) (*http.Response, error) {
 
 	idText := strconv.Itoa(int(id))
 
 	// An http handler wrapper around httprouter's handler.  It permits us to use
 	// the test server.
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ourApi.DelMotorcycleHandler(w, r, httprouter.Params{httprouter.Param{
+		ourApi.PutMotorcycleHandler(w, r, httprouter.Params{httprouter.Param{
 			Key:   "id",
 			Value: idText,
 		}})
@@ -89,6 +197,9 @@ func UpdateMotorcycle(ourApi *Api, id typedef.ID, motorcycle entity.Motorcycle)
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Content-Length", strconv.Itoa(len(motorcycleJson)))
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
 
 	return client.Do(req)
 }
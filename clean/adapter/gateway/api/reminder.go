@@ -0,0 +1,207 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	// Third party packages
+	"github.com/julienschmidt/httprouter"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/presenter"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+)
+
+// ListRemindersHandler processes requests to get a list of a motorcycle's reminders from the repository.
+func (api *Api) ListRemindersHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Create the listRequest, process it, and get the resulting view model or error.
+	listRequest, err := request.NewListRemindersRequest(typedef.ID(motorcycleID))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	listInteractor, err := interactor.NewListRemindersInteractor(api.MotorcycleRepository, api.ReminderRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	listResponse, err := listInteractor.Handle(listRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if listResponse.Error != nil {
+		writeProblem(w, r, httpStatus(listResponse.Status, false), listResponse.Error)
+		return
+	}
+
+	listPresenter, err := presenter.NewListRemindersPresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewModel, err := listPresenter.Handle(listResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Marshal provided viewModel into JSON structure
+	uj, err := json.Marshal(viewModel)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write content-type, status code, payload
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "%s", uj)
+}
+
+// SnoozeReminderHandler postpones a reminder of a motorcycle until the time in the request's body.
+func (api *Api) SnoozeReminderHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, id, err := reminderParams(p)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Populate the time from the snoozeRequest body.
+	snoozeDto := dto.SnoozeReminderDto{}
+	status, err := api.decodeJsonBody(w, r, &snoozeDto)
+	if err != nil {
+		writeProblem(w, r, status, err)
+		return
+	}
+
+	// Create the snoozeRequest, process it, and get the resulting view model or error.
+	snoozeRequest, err := request.NewSnoozeReminderRequest(motorcycleID, id, snoozeDto.UntilUtc)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	session := api.NewSession()
+	defer session.Close()
+
+	snoozeInteractor, err := interactor.NewSnoozeReminderInteractor(session.MotorcycleRepository, session.ReminderRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	snoozeInteractor.Logger = requestLogger(r)
+
+	snoozeResponse, err := snoozeInteractor.Handle(snoozeRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if snoozeResponse.Error != nil {
+		writeProblem(w, r, httpStatus(snoozeResponse.Status, false), snoozeResponse.Error)
+		return
+	}
+
+	// Write the new version of the reminder, and the status code
+	w.Header().Set("ETag", formatETag(snoozeResponse.RowVersion))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AcknowledgeReminderHandler acknowledges a reminder of a motorcycle, so its owner is not notified of it again.
+func (api *Api) AcknowledgeReminderHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, id, err := reminderParams(p)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Create the acknowledgeRequest, process it, and get the resulting view model or error.
+	acknowledgeRequest, err := request.NewAcknowledgeReminderRequest(motorcycleID, id)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	session := api.NewSession()
+	defer session.Close()
+
+	acknowledgeInteractor, err := interactor.NewAcknowledgeReminderInteractor(session.MotorcycleRepository, session.ReminderRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	acknowledgeInteractor.Logger = requestLogger(r)
+
+	acknowledgeResponse, err := acknowledgeInteractor.Handle(acknowledgeRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if acknowledgeResponse.Error != nil {
+		writeProblem(w, r, httpStatus(acknowledgeResponse.Status, false), acknowledgeResponse.Error)
+		return
+	}
+
+	// Write the new version of the reminder, and the status code
+	w.Header().Set("ETag", formatETag(acknowledgeResponse.RowVersion))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reminderParams gets the IDs of the motorcycle and its reminder from a request's path.
+// Returns (motorcycle ID, reminder ID, nil) on success, otherwise (0, 0, error).
+func reminderParams(p httprouter.Params) (typedef.ID, typedef.ID, error) {
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.Atoi(p.ByName("reminderId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return typedef.ID(motorcycleID), typedef.ID(id), nil
+}
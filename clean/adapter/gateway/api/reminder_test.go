@@ -0,0 +1,130 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApi_Reminders verifies listing, snoozing and acknowledging a motorcycle's reminders.
+func TestApi_Reminders(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	insertResponse, _ := InsertMotorcycle(ourApi, motorcycle)
+	insertionViewModel := viewmodel.InsertMotorcycleViewModel{}
+	json.NewDecoder(insertResponse.Body).Decode(&insertionViewModel)
+	motorcycleID := insertionViewModel.ID
+	reminder, _ := entity.NewReminder(motorcycleID, "Annual service", time.Now().UTC().AddDate(0, 0, 7))
+	inserted, _, _ := ourApi.ReminderRepository.Insert(reminder)
+
+	// ACT
+	snoozeResponse, _ := SnoozeReminder(ourApi, motorcycleID, inserted.ID, time.Now().UTC().AddDate(0, 0, 3))
+	pastResponse, _ := SnoozeReminder(ourApi, motorcycleID, inserted.ID, time.Now().UTC().AddDate(0, 0, -1))
+	listResponse, _ := GetReminders(ourApi, motorcycleID)
+	listViewModel := viewmodel.ListRemindersViewModel{}
+	json.NewDecoder(listResponse.Body).Decode(&listViewModel)
+	acknowledgeResponse, _ := AcknowledgeReminder(ourApi, motorcycleID, inserted.ID)
+	missingResponse, _ := AcknowledgeReminder(ourApi, motorcycleID, inserted.ID+1)
+	acknowledged, _, _ := ourApi.ReminderRepository.FindByID(inserted.ID)
+
+	// ASSERT
+	assert.True(t, snoozeResponse.StatusCode == 204)
+	assert.True(t, snoozeResponse.Header.Get("ETag") == formatETag(inserted.RowVersion+1))
+	assert.True(t, pastResponse.StatusCode == 400)
+	assert.True(t, listResponse.StatusCode == 200)
+	assert.True(t, len(listViewModel.Reminders) == 1)
+	assert.True(t, listViewModel.Reminders[0].Status == reminderstatus.SnoozedReminderStatus)
+	assert.NotNil(t, listViewModel.Reminders[0].SnoozedUntilUtc)
+	assert.True(t, acknowledgeResponse.StatusCode == 204)
+	assert.True(t, missingResponse.StatusCode == 404)
+	assert.True(t, acknowledged.Status == reminderstatus.AcknowledgedReminderStatus)
+}
+
+// TestApi_GetReminders_MotorcycleNotExist verifies that the reminders of a missing motorcycle are not found.
+func TestApi_GetReminders_MotorcycleNotExist(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	// ACT
+	resp, _ := GetReminders(ourApi, 123)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 404)
+}
+
+// GetReminders gets a motorcycle's reminders using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func GetReminders(ourApi *Api, motorcycleID typedef.ID) (*http.Response, error) {
+	return serveReminders(ourApi.ListRemindersHandler, "GET", motorcycleID, "", nil)
+}
+
+// SnoozeReminder postpones a motorcycle's reminder until a time using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func SnoozeReminder(ourApi *Api, motorcycleID typedef.ID, id typedef.ID, untilUtc time.Time) (*http.Response, error) {
+	snoozeJson, _ := json.Marshal(dto.SnoozeReminderDto{UntilUtc: untilUtc})
+	return serveReminders(ourApi.SnoozeReminderHandler, "POST", motorcycleID, strconv.Itoa(int(id)), snoozeJson)
+}
+
+// AcknowledgeReminder acknowledges a motorcycle's reminder using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func AcknowledgeReminder(ourApi *Api, motorcycleID typedef.ID, id typedef.ID) (*http.Response, error) {
+	return serveReminders(ourApi.AcknowledgeReminderHandler, "POST", motorcycleID, strconv.Itoa(int(id)), nil)
+}
+
+// serveReminders sends a request with the body to a reminder handler, with the motorcycle and reminder IDs as parameters.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func serveReminders(handle httprouter.Handle, method string, motorcycleID typedef.ID, reminderID string, body []byte) (*http.Response, error) {
+
+	// An http handler wrapper around httprouter's handler.  It permits us to use
+	// the test server.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, httprouter.Params{
+			httprouter.Param{Key: "id", Value: strconv.Itoa(int(motorcycleID))},
+			httprouter.Param{Key: "reminderId", Value: reminderID},
+		})
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, err := http.NewRequest(method, server.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	return client.Do(req)
+}
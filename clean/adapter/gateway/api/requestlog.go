@@ -0,0 +1,171 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	// Third party packages
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/logger"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+)
+
+// RequestIDHeader is the header that correlates a request with the entries that were logged while it was processed.
+// A client's own ID is kept, so the request can be followed across services, otherwise one is generated.
+const RequestIDHeader = "X-Request-ID"
+
+// MaxRequestIDLength is the length of the longest request ID that is accepted from a client.
+const MaxRequestIDLength = 128
+
+// requestLogKey is the key of a request's requestLog in its context.
+type requestLogKey struct{}
+
+// requestLog is what is known about a request that is being processed, which is logged when it finishes.
+type requestLog struct {
+	// entry records the request's ID with every entry.
+	entry *log.Entry
+	// route is the pattern of the route that matched the request, or empty when none did.
+	route string
+	// principal is the ID of the user who made the request, or empty when they were not identified.
+	principal string
+}
+
+// responseRecorder remembers the status code and the number of bytes of a response, as they are written.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// WriteHeader implements http.ResponseWriter.WriteHeader().
+func (recorder *responseRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter.Write().
+func (recorder *responseRecorder) Write(contents []byte) (int, error) {
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+	count, err := recorder.ResponseWriter.Write(contents)
+	recorder.bytes += int64(count)
+	return count, err
+}
+
+// newRequestLogHandler creates a handler that gives each request an ID, which is returned in the response, and makes a
+// logger that records it available to the next handler.  When the request finishes, one access entry is logged with its
+// method, route, status, latency, size and principal, and it is counted by the metrics.
+func newRequestLogHandler(requestMetrics *requestMetrics, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		requestMetrics.inFlight.Inc()
+		defer requestMetrics.inFlight.Dec()
+
+		id := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		entry := &requestLog{entry: log.WithField("requestId", id)}
+		recorder := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), requestLogKey{}, entry)))
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		latency := time.Since(started)
+		requestMetrics.observe(r.Method, entry.route, recorder.status, latency)
+
+		entry.entry.WithFields(log.Fields{
+			"method":    r.Method,
+			"route":     entry.route,
+			"status":    recorder.status,
+			"latencyMs": float64(latency) / float64(time.Millisecond),
+			"bytes":     recorder.bytes,
+			"principal": entry.principal,
+		}).Info("The request was processed.")
+	})
+}
+
+// isValidRequestID determines whether a client's request ID can be used, which is when it is not too long and only
+// contains letters, digits and the punctuation that is found in UUIDs and similar IDs, so it cannot forge a log entry.
+// Returns true if the request ID can be used, otherwise false.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-' || c == '_' || c == '.' || c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// newRequestID generates a random request ID.
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// handle registers the handler for requests with the method and a path that matches the route.  The route's pattern
+// is recorded in the request's log, since the router does not reveal which route matched a request.
+func (api *Api) handle(method string, route string, handle httprouter.Handle) {
+	api.Router.Handle(method, route, func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if entry, ok := r.Context().Value(requestLogKey{}).(*requestLog); ok {
+			entry.route = route
+		}
+		handle(w, r, p)
+	})
+}
+
+// authenticate establishes who made the request, and records them in the request's log.
+// Returns (auth service, nil) on success, otherwise (nil, error) when the request's credentials are not valid.
+func (api *Api) authenticate(r *http.Request) (contract.AuthService, error) {
+	authService, err := api.Authenticator.Authenticate(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry, ok := r.Context().Value(requestLogKey{}).(*requestLog); ok {
+		entry.principal = authService.UserID()
+	}
+
+	return authService, nil
+}
+
+// requestEntry gets the logrus entry that records the request's ID, or the standard logger's entry when the request
+// did not pass through the request log handler.
+func requestEntry(r *http.Request) *log.Entry {
+	if entry, ok := r.Context().Value(requestLogKey{}).(*requestLog); ok {
+		return entry.entry
+	}
+
+	return log.NewEntry(log.StandardLogger())
+}
+
+// requestLogger gets the logger that records the request's ID with every entry, which is given to the interactors.
+func requestLogger(r *http.Request) contract.Logger {
+	requestLogger, _ := logger.NewLogrusLogger(requestEntry(r))
+	return requestLogger
+}
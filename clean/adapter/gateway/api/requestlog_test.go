@@ -0,0 +1,156 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRequestLogHandler_RequestID verifies that a client's request ID is returned to it, and that one is generated
+// when the client did not provide one, or provided one that cannot be logged safely.
+func TestRequestLogHandler_RequestID(t *testing.T) {
+
+	// ARRANGE
+	ourApi, _ := newServerApi()
+	given, _ := http.NewRequest(http.MethodGet, "/api/motorcycles", nil)
+	given.Header.Set(RequestIDHeader, "7b2f0c1e-5a44-4c7e-9a65-0d1f2b3c4d5e")
+	missing, _ := http.NewRequest(http.MethodGet, "/api/motorcycles", nil)
+	forged, _ := http.NewRequest(http.MethodGet, "/api/motorcycles", nil)
+	forged.Header.Set(RequestIDHeader, "abc\" level=error msg=forged")
+	tooLong, _ := http.NewRequest(http.MethodGet, "/api/motorcycles", nil)
+	tooLong.Header.Set(RequestIDHeader, strings.Repeat("a", MaxRequestIDLength+1))
+	givenResp := httptest.NewRecorder()
+	missingResp := httptest.NewRecorder()
+	forgedResp := httptest.NewRecorder()
+	tooLongResp := httptest.NewRecorder()
+
+	// ACT
+	ourApi.server.Handler.ServeHTTP(givenResp, given)
+	ourApi.server.Handler.ServeHTTP(missingResp, missing)
+	ourApi.server.Handler.ServeHTTP(forgedResp, forged)
+	ourApi.server.Handler.ServeHTTP(tooLongResp, tooLong)
+
+	// ASSERT
+	assert.Equal(t, "7b2f0c1e-5a44-4c7e-9a65-0d1f2b3c4d5e", givenResp.Header().Get(RequestIDHeader))
+	assert.Len(t, missingResp.Header().Get(RequestIDHeader), 32)
+	assert.Len(t, forgedResp.Header().Get(RequestIDHeader), 32)
+	assert.Len(t, tooLongResp.Header().Get(RequestIDHeader), 32)
+	assert.NotEqual(t, missingResp.Header().Get(RequestIDHeader), forgedResp.Header().Get(RequestIDHeader))
+}
+
+// TestRequestLogHandler_AccessEntry verifies that a failed request's access entry, the problem that was returned, and
+// the error that the interactor recorded are all logged with the request's ID.
+func TestRequestLogHandler_AccessEntry(t *testing.T) {
+
+	// ARRANGE
+	ourApi, saving := newServerApi()
+	saving.err = errors.New("the disk is full")
+	hook := test.NewGlobal()
+	defer log.StandardLogger().ReplaceHooks(make(log.LevelHooks))
+
+	body := `{"make":"Honda","model":"Shadow","year":2006,"vin":"01234567890123456"}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/motorcycles", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIDHeader, "request-1")
+	resp := httptest.NewRecorder()
+
+	// ACT
+	ourApi.server.Handler.ServeHTTP(resp, req)
+
+	// ASSERT
+	assert.True(t, resp.Code == http.StatusInternalServerError)
+	entries := hook.AllEntries()
+	assert.Len(t, entries, 3)
+	for _, entry := range entries {
+		assert.Equal(t, "request-1", entry.Data["requestId"])
+	}
+	assert.Equal(t, "Failed to save the inserted motorcycle.", entries[0].Message)
+	assert.Equal(t, log.ErrorLevel, entries[0].Level)
+	assert.Contains(t, entries[0].Data, "motorcycleId")
+	assert.Equal(t, "The request failed.", entries[1].Message)
+	access := entries[2]
+	assert.Equal(t, "The request was processed.", access.Message)
+	assert.Equal(t, http.MethodPost, access.Data["method"])
+	assert.Equal(t, "/api/motorcycles", access.Data["route"])
+	assert.Equal(t, http.StatusInternalServerError, access.Data["status"])
+	assert.Equal(t, int64(resp.Body.Len()), access.Data["bytes"])
+	assert.Contains(t, access.Data, "latencyMs")
+	assert.Contains(t, access.Data, "principal")
+}
+
+// failingMotorcycleRepository is a motorcycle repository that cannot be read.
+type failingMotorcycleRepository struct {
+	*repository.MotorcycleRepository
+}
+
+// FindByID implements MotorcycleRepository.FindByID().
+func (repo *failingMotorcycleRepository) FindByID(id typedef.ID) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	return nil, operationstatus.InternalError, errors.New("the database is unavailable")
+}
+
+// Query implements MotorcycleRepository.Query().
+func (repo *failingMotorcycleRepository) Query(query entity.MotorcycleQuery) (*entity.MotorcyclePage, operationstatus.OperationStatus, error) {
+	return nil, operationstatus.InternalError, errors.New("the database is unavailable")
+}
+
+// TestRequestLogHandler_ReadFailure verifies that the error that the interactor recorded when a motorcycle could not be
+// read is logged with the request's ID.
+func TestRequestLogHandler_ReadFailure(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, &failingMotorcycleRepository{motorcycleRepository}, httprouter.New())
+	hook := test.NewGlobal()
+	defer log.StandardLogger().ReplaceHooks(make(log.LevelHooks))
+
+	get, _ := http.NewRequest(http.MethodGet, "/api/motorcycles/1", nil)
+	get.Header.Set(RequestIDHeader, "request-1")
+	list, _ := http.NewRequest(http.MethodGet, "/api/motorcycles", nil)
+	list.Header.Set(RequestIDHeader, "request-2")
+
+	// ACT
+	ourApi.server.Handler.ServeHTTP(httptest.NewRecorder(), get)
+	ourApi.server.Handler.ServeHTTP(httptest.NewRecorder(), list)
+
+	// ASSERT
+	entries := hook.AllEntries()
+	assert.Len(t, entries, 6)
+	assert.Equal(t, "Failed to get the motorcycle.", entries[0].Message)
+	assert.Equal(t, "request-1", entries[0].Data["requestId"])
+	assert.Contains(t, entries[0].Data, "motorcycleId")
+	assert.Equal(t, "Failed to list the motorcycles.", entries[3].Message)
+	assert.Equal(t, "request-2", entries[3].Data["requestId"])
+}
+
+// TestNewApi_KeepsLogLevel verifies that creating the API does not change the level of the messages that are logged,
+// so the configured level is the only one that applies.
+func TestNewApi_KeepsLogLevel(t *testing.T) {
+
+	// ARRANGE
+	defer log.SetLevel(log.GetLevel())
+	log.SetLevel(log.DebugLevel)
+
+	// ACT
+	newServerApi()
+
+	// ASSERT
+	assert.Equal(t, log.DebugLevel, log.GetLevel())
+}
@@ -0,0 +1,352 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	// Third party packages
+	log "github.com/sirupsen/logrus"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// ServerConfig contains the settings of the HTTP server that hosts the API web service.
+type ServerConfig struct {
+	// Addr is the TCP address on which the server listens, such as ":8080".
+	Addr string
+	// ReadHeaderTimeout is how long the server waits to read the headers of a request.
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout is how long the server waits to read a whole request, including its body.
+	ReadTimeout time.Duration
+	// WriteTimeout is how long the server waits to write a response, from the end of reading the request's headers.
+	WriteTimeout time.Duration
+	// IdleTimeout is how long the server keeps an idle keep-alive connection open.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes is the maximum size of the headers of a request.
+	MaxHeaderBytes int
+	// MaxBodyBytes is the maximum size of the body of a request.
+	MaxBodyBytes int64
+	// ShutdownTimeout is how long the server waits for the requests in progress to finish when it is stopped.
+	ShutdownTimeout time.Duration
+	// TLSCertFile and TLSKeyFile are the PEM encoded certificate and private key of the server, which serves HTTPS
+	// when they are provided, otherwise HTTP.
+	TLSCertFile string
+	TLSKeyFile  string
+	// CertReloadInterval is how often the certificate's files are checked for changes, so a renewed certificate is
+	// used without restarting the server.  They are checked on every TLS handshake when it is zero.
+	CertReloadInterval time.Duration
+	// ClientCAFile is the PEM encoded bundle of certificate authorities that issue client certificates, which are
+	// not accepted when it is empty.
+	ClientCAFile string
+	// ClientCertRequired rejects a TLS connection without a client certificate, rather than leaving the request to be
+	// authenticated by other means.
+	ClientCertRequired bool
+	// RedirectAddr is the TCP address on which a plain HTTP server redirects every request to the HTTPS server,
+	// or empty when there is no such server.
+	RedirectAddr string
+	// MetricsAddr is the TCP address on which a plain HTTP server exposes the metrics at /metrics, or empty when they
+	// are not exposed.  The metrics are not authenticated, so they are kept off the API's address, and the default
+	// address is only reachable from the host.
+	MetricsAddr string
+	// Cors contains the settings of cross-origin resource sharing.
+	Cors CorsConfig
+}
+
+// IsTLS determines whether the server serves HTTPS.
+func (config ServerConfig) IsTLS() bool {
+	return config.TLSCertFile != ""
+}
+
+// DefaultServerConfig creates the settings used when none are configured.
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		Addr:               ":8080",
+		ReadHeaderTimeout:  5 * time.Second,
+		ReadTimeout:        15 * time.Second,
+		WriteTimeout:       30 * time.Second,
+		IdleTimeout:        60 * time.Second,
+		MaxHeaderBytes:     http.DefaultMaxHeaderBytes,
+		MaxBodyBytes:       MaxRequestBodySize,
+		ShutdownTimeout:    30 * time.Second,
+		CertReloadInterval: time.Minute,
+		MetricsAddr:        "localhost:9090",
+		Cors:               DefaultCorsConfig(),
+	}
+}
+
+// Validate verifies that a ServerConfig's fields contain valid data.
+// Returns nil if the ServerConfig contains valid data, otherwise an error.
+func (config ServerConfig) Validate() error {
+	err := validation.ValidateStruct(&config,
+		// Addr is required.
+		validation.Field(&config.Addr, validation.Required),
+		// The timeouts cannot be negative, and zero means that there is no timeout.
+		validation.Field(&config.ReadHeaderTimeout, validation.Min(time.Duration(0))),
+		validation.Field(&config.ReadTimeout, validation.Min(time.Duration(0))),
+		validation.Field(&config.WriteTimeout, validation.Min(time.Duration(0))),
+		validation.Field(&config.IdleTimeout, validation.Min(time.Duration(0))),
+		// MaxHeaderBytes must leave room for a request line and a few headers.
+		validation.Field(&config.MaxHeaderBytes, validation.Min(1024)),
+		// MaxBodyBytes must be positive.
+		validation.Field(&config.MaxBodyBytes, validation.Min(int64(1))),
+		// ShutdownTimeout is required, so the server cannot wait forever for a request that never finishes.
+		validation.Field(&config.ShutdownTimeout, validation.Required, validation.Min(time.Duration(0))),
+		// CertReloadInterval cannot be negative.
+		validation.Field(&config.CertReloadInterval, validation.Min(time.Duration(0))))
+	if err != nil {
+		return err
+	}
+
+	err = config.Cors.Validate()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case (config.TLSCertFile == "") != (config.TLSKeyFile == ""):
+		return errors.New("the certificate and the private key of the server must be provided together")
+	case config.ClientCAFile != "" && !config.IsTLS():
+		return errors.New("client certificates cannot be accepted unless the server serves HTTPS")
+	case config.ClientCertRequired && config.ClientCAFile == "":
+		return errors.New("client certificates cannot be required without the certificate authorities that issue them")
+	case config.RedirectAddr != "" && !config.IsTLS():
+		return errors.New("requests cannot be redirected to HTTPS unless the server serves HTTPS")
+	case config.RedirectAddr != "" && config.RedirectAddr == config.Addr:
+		return errors.New("the redirect server cannot listen on the same address as the HTTPS server")
+	case config.MetricsAddr != "" && (config.MetricsAddr == config.Addr || config.MetricsAddr == config.RedirectAddr):
+		return errors.New("the metrics server cannot listen on the same address as another server")
+	}
+
+	return nil
+}
+
+// Configure replaces the settings of the HTTP server, which must be done before it is started.
+// Returns nil on success, otherwise error.
+func (api *Api) Configure(config ServerConfig) error {
+	err := config.Validate()
+	if err != nil {
+		return err
+	}
+
+	var handler http.Handler = api.Router
+	if config.Cors.IsEnabled() {
+		handler = newCorsHandler(config.Cors, handler)
+	}
+	handler = newRequestLogHandler(api.requestMetrics, handler)
+
+	server := newHttpServer(config, config.Addr, handler)
+	if config.IsTLS() {
+		server.TLSConfig, err = newTLSConfig(config)
+		if err != nil {
+			return err
+		}
+	}
+
+	var redirectServer *http.Server
+	if config.RedirectAddr != "" {
+		redirectServer = newHttpServer(config, config.RedirectAddr, newRedirectHandler(config.Addr))
+	}
+
+	var metricsServer *http.Server
+	if config.MetricsAddr != "" {
+		metricsServer = newHttpServer(config, config.MetricsAddr, api.newMetricsHandler())
+	}
+
+	api.Config = config
+	api.server = server
+	api.redirectServer = redirectServer
+	api.metricsServer = metricsServer
+
+	// All okay
+	return nil
+}
+
+// newHttpServer creates an HTTP server with the timeouts and limits in the settings, which listens on the address.
+func newHttpServer(config ServerConfig, addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		ReadTimeout:       config.ReadTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
+		MaxHeaderBytes:    config.MaxHeaderBytes,
+	}
+}
+
+// Start launches the web service on the configured address, and runs it until the process is interrupted or
+// terminated, when it is stopped gracefully.  When they are configured, the server that redirects HTTP requests to
+// HTTPS and the server that exposes the metrics run alongside it.
+// Returns nil after it has been stopped, otherwise error.
+func (api *Api) Start() error {
+	listener, err := net.Listen("tcp", api.Config.Addr)
+	if err != nil {
+		return errors.Wrapf(err, "cannot listen on %s", api.Config.Addr)
+	}
+
+	if api.redirectServer != nil {
+		err = serveInBackground(api.redirectServer, "The server that redirects to HTTPS failed.")
+		if err != nil {
+			listener.Close()
+			return err
+		}
+	}
+
+	if api.metricsServer != nil {
+		err = serveInBackground(api.metricsServer, "The server that exposes the metrics failed.")
+		if err != nil {
+			listener.Close()
+			if api.redirectServer != nil {
+				api.redirectServer.Close()
+			}
+			return err
+		}
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	return api.Serve(listener, signals)
+}
+
+// serveInBackground listens on the server's address, and serves its requests in the background until it is shut down.
+// A failure after it has started is logged with the message.
+// Returns nil when it is listening, otherwise error.
+func serveInBackground(server *http.Server, failure string) error {
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return errors.Wrapf(err, "cannot listen on %s", server.Addr)
+	}
+
+	go func() {
+		err := server.Serve(listener)
+		if err != http.ErrServerClosed {
+			log.WithError(err).Error(failure)
+		}
+	}()
+
+	return nil
+}
+
+// Serve runs the web service on the listener until a signal is received, when it is stopped gracefully.
+// Returns nil after it has been stopped, otherwise error when the server fails or cannot be stopped.
+func (api *Api) Serve(listener net.Listener, signals <-chan os.Signal) error {
+	println("Starting the API server on", listener.Addr().String(), "...")
+
+	failed := make(chan error, 1)
+	go func() {
+		if api.Config.IsTLS() {
+			// The certificate is provided by the TLS settings, so it can be reloaded.
+			failed <- api.server.ServeTLS(listener, "", "")
+		} else {
+			failed <- api.server.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-failed:
+		// The server has been stopped by someone else, who has saved the repositories.
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		// The server has failed without being stopped, so save the changes that it made before failing.
+		api.stopBackground()
+		api.save()
+		return errors.Wrap(err, "the API server failed")
+	case received := <-signals:
+		log.WithField("signal", received.String()).Warn("Stopping the API server.")
+	}
+
+	err := api.Stop()
+	<-failed
+
+	return err
+}
+
+// Stop terminates the web service.  New connections are refused, and the requests in progress are given the configured
+// shutdown timeout to finish before the repositories are saved.
+// Returns nil on success, otherwise error.
+func (api *Api) Stop() error {
+	println("Stopping the API server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), api.Config.ShutdownTimeout)
+	defer cancel()
+
+	if api.redirectServer != nil {
+		api.redirectServer.Shutdown(ctx)
+	}
+
+	if api.metricsServer != nil {
+		api.metricsServer.Shutdown(ctx)
+	}
+
+	shutdownErr := api.server.Shutdown(ctx)
+	if shutdownErr != nil {
+		shutdownErr = errors.Wrap(shutdownErr, "the requests in progress did not finish")
+	}
+
+	// The background work is stopped, so its changes are not made while the repositories are being saved.
+	api.stopBackground()
+
+	// Save the repositories even when some requests did not finish, so the ones that did are not lost.
+	_, err := api.save()
+	if err != nil {
+		return err
+	}
+
+	return shutdownErr
+}
+
+// OnStop registers a function that stops background work that uses the repositories, such as a scheduler.
+// It is called when the server is stopped, after the requests in progress have finished, and must not return until the
+// work has stopped, so the repositories are not saved while it is changing them.
+func (api *Api) OnStop(stop func()) {
+	api.onStop = append(api.onStop, stop)
+}
+
+// stopBackground stops the background work that has been registered by OnStop(), in the order it was registered.
+func (api *Api) stopBackground() {
+	for _, stop := range api.onStop {
+		stop()
+	}
+}
+
+// save flushes the changes in each of the repositories to their storage.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error) for the first repository that could not be saved.
+func (api *Api) save() (operationstatus.OperationStatus, error) {
+	savers := []struct {
+		name string
+		save func() (operationstatus.OperationStatus, error)
+	}{
+		{"motorcycle", api.MotorcycleRepository.Save},
+		{"odometer reading", api.OdometerReadingRepository.Save},
+		{"service record", api.ServiceRecordRepository.Save},
+		{"reminder", api.ReminderRepository.Save},
+	}
+
+	var result error
+	var resultStatus operationstatus.OperationStatus = operationstatus.Ok
+	for _, saver := range savers {
+		status, err := saver.save()
+		if err != nil {
+			log.WithError(err).Errorf("Failed to save the %s repository.", saver.name)
+			if result == nil {
+				result = errors.Wrapf(err, "cannot save the %s repository", saver.name)
+				resultStatus = status
+			}
+		}
+	}
+
+	return resultStatus, result
+}
@@ -0,0 +1,193 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// savingMotorcycleRepository is a motorcycle repository that counts how many times it has been saved.
+type savingMotorcycleRepository struct {
+	*repository.MotorcycleRepository
+	saves int
+	err   error
+}
+
+// Save implements MotorcycleRepository.Save().
+func (repo *savingMotorcycleRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.saves++
+	if repo.err != nil {
+		return operationstatus.InternalError, repo.err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// newServerApi creates an instance of the API web service whose motorcycle repository counts how many times it has been saved.
+// Returns the (API, motorcycle repository).
+func newServerApi() (*Api, *savingMotorcycleRepository) {
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	saving := &savingMotorcycleRepository{MotorcycleRepository: motorcycleRepository}
+	ourApi, _ := NewApi(authService, saving, httprouter.New())
+
+	return ourApi, saving
+}
+
+// TestNewApi_Options verifies that the repositories set by the options are used, and the others have their defaults.
+func TestNewApi_Options(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	manufacturerRepository, _ := repository.NewManufacturerRepository(nil)
+
+	// ACT
+	ourApi, err := NewApi(authService, motorcycleRepository, httprouter.New(), WithManufacturerRepository(manufacturerRepository))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, ourApi.ManufacturerRepository == manufacturerRepository)
+	assert.NotNil(t, ourApi.MaintenanceScheduleRepository)
+	assert.NotNil(t, ourApi.OdometerReadingRepository)
+	assert.NotNil(t, ourApi.ServiceRecordRepository)
+	assert.NotNil(t, ourApi.ReminderRepository)
+}
+
+// TestServerConfig_Validate verifies that the default settings are valid, and that invalid ones are rejected.
+func TestServerConfig_Validate(t *testing.T) {
+
+	// ARRANGE
+	ourApi, _ := newServerApi()
+	noAddr := DefaultServerConfig()
+	noAddr.Addr = ""
+	negativeTimeout := DefaultServerConfig()
+	negativeTimeout.ReadTimeout = -time.Second
+	noShutdownTimeout := DefaultServerConfig()
+	noShutdownTimeout.ShutdownTimeout = 0
+	smallHeaders := DefaultServerConfig()
+	smallHeaders.MaxHeaderBytes = 10
+	sharedMetricsAddr := DefaultServerConfig()
+	sharedMetricsAddr.MetricsAddr = sharedMetricsAddr.Addr
+
+	// ACT
+	valid := ourApi.Configure(DefaultServerConfig())
+	noAddrErr := ourApi.Configure(noAddr)
+	negativeTimeoutErr := ourApi.Configure(negativeTimeout)
+	noShutdownTimeoutErr := ourApi.Configure(noShutdownTimeout)
+	smallHeadersErr := ourApi.Configure(smallHeaders)
+	sharedMetricsAddrErr := ourApi.Configure(sharedMetricsAddr)
+
+	// ASSERT
+	assert.Nil(t, valid)
+	assert.NotNil(t, noAddrErr)
+	assert.NotNil(t, negativeTimeoutErr)
+	assert.NotNil(t, noShutdownTimeoutErr)
+	assert.NotNil(t, smallHeadersErr)
+	assert.NotNil(t, sharedMetricsAddrErr)
+	assert.Equal(t, DefaultServerConfig(), ourApi.Config)
+}
+
+// TestApi_Serve_GracefulShutdown verifies that a request in progress when the server is signalled to stop is allowed
+// to finish, and that the repositories are saved afterwards.
+func TestApi_Serve_GracefulShutdown(t *testing.T) {
+
+	// ARRANGE
+	ourApi, saving := newServerApi()
+	started := make(chan bool)
+	release := make(chan bool)
+	ourApi.Router.GET("/slow", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		started <- true
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	signals := make(chan os.Signal, 1)
+	served := make(chan error, 1)
+	go func() {
+		served <- ourApi.Serve(listener, signals)
+	}()
+
+	responses := make(chan *http.Response, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			resp = nil
+		}
+		responses <- resp
+	}()
+	<-started
+
+	// ACT
+	signals <- syscall.SIGTERM
+	time.Sleep(50 * time.Millisecond)
+	savesWhileInProgress := saving.saves
+	close(release)
+	resp := <-responses
+	err := <-served
+
+	// ASSERT
+	assert.True(t, savesWhileInProgress == 0)
+	assert.NotNil(t, resp)
+	assert.True(t, resp != nil && resp.StatusCode == http.StatusOK)
+	assert.Nil(t, err)
+	assert.True(t, saving.saves == 1)
+}
+
+// TestApi_Stop_SaveFailure verifies that a repository that cannot be saved is reported when the server is stopped.
+func TestApi_Stop_SaveFailure(t *testing.T) {
+
+	// ARRANGE
+	ourApi, saving := newServerApi()
+	saving.err = errors.New("the disk is full")
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	signals := make(chan os.Signal, 1)
+
+	// ACT
+	signals <- syscall.SIGINT
+	err := ourApi.Serve(listener, signals)
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, saving.saves == 1)
+}
+
+// TestApi_Stop_OnStop verifies that the background work is stopped before the repositories are saved.
+func TestApi_Stop_OnStop(t *testing.T) {
+
+	// ARRANGE
+	ourApi, saving := newServerApi()
+	savesWhenStopped := -1
+	ourApi.OnStop(func() {
+		savesWhenStopped = saving.saves
+	})
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	signals := make(chan os.Signal, 1)
+
+	// ACT
+	signals <- syscall.SIGTERM
+	err := ourApi.Serve(listener, signals)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, savesWhenStopped == 0)
+	assert.True(t, saving.saves == 1)
+}
@@ -0,0 +1,402 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	// Third party packages
+	"github.com/julienschmidt/httprouter"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/presenter"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+)
+
+// ListServiceRecordsHandler processes requests to get a motorcycle's service history from the repository.
+func (api *Api) ListServiceRecordsHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Create the listRequest, process it, and get the resulting view model or error.
+	listRequest, err := request.NewListServiceRecordsRequest(typedef.ID(motorcycleID))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	listInteractor, err := interactor.NewListServiceRecordsInteractor(api.MotorcycleRepository, api.ServiceRecordRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	listResponse, err := listInteractor.Handle(listRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if listResponse.Error != nil {
+		writeProblem(w, r, httpStatus(listResponse.Status, false), listResponse.Error)
+		return
+	}
+
+	listPresenter, err := presenter.NewListServiceRecordsPresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewModel, err := listPresenter.Handle(listResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Marshal provided viewModel into JSON structure
+	uj, err := json.Marshal(viewModel)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write content-type, status code, payload
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "%s", uj)
+}
+
+// GetServiceRecordHandler processes requests to get a particular service record of a motorcycle from the repository.
+func (api *Api) GetServiceRecordHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, id, err := serviceRecordParams(p)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Create the getRequest, process it, and get the resulting view model or error.
+	getRequest, err := request.NewGetServiceRecordRequest(motorcycleID, id)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	getInteractor, err := interactor.NewGetServiceRecordInteractor(api.MotorcycleRepository, api.ServiceRecordRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	getResponse, err := getInteractor.Handle(getRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if getResponse.Error != nil {
+		writeProblem(w, r, httpStatus(getResponse.Status, false), getResponse.Error)
+		return
+	}
+
+	getPresenter, err := presenter.NewGetServiceRecordPresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewModel, err := getPresenter.Handle(getResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Marshal provided viewModel into JSON structure
+	uj, err := json.Marshal(viewModel)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write content-type, entity tag, status code, payload
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", formatETag(getResponse.ServiceRecord.RowVersion))
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "%s", uj)
+}
+
+// PostServiceRecordHandler records service that was performed on a motorcycle.
+func (api *Api) PostServiceRecordHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Populate the service record from the insertRequest body.
+	recordDto := dto.TerseServiceRecordDto{}
+	status, err := api.decodeJsonBody(w, r, &recordDto)
+	if err != nil {
+		writeProblem(w, r, status, err)
+		return
+	}
+
+	record, err := newServiceRecord(typedef.ID(motorcycleID), recordDto)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Create the insertRequest, process it, and get the resulting view model or error.
+	insertRequest, err := request.NewInsertServiceRecordRequest(typedef.ID(motorcycleID), record)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	session := api.NewSession()
+	defer session.Close()
+
+	insertInteractor, err := interactor.NewInsertServiceRecordInteractor(session.MotorcycleRepository, session.ServiceRecordRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	insertInteractor.Logger = requestLogger(r)
+
+	insertResponse, err := insertInteractor.Handle(insertRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if insertResponse.Error != nil {
+		writeProblem(w, r, httpStatus(insertResponse.Status, false), insertResponse.Error)
+		return
+	}
+
+	insertPresenter, err := presenter.NewInsertServiceRecordPresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewModel, err := insertPresenter.Handle(insertResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Marshal provided viewModel into JSON structure
+	uj, err := json.Marshal(viewModel)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write content-type, location, entity tag, status code, payload
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/api/motorcycles/%d/services/%d", insertResponse.MotorcycleID, insertResponse.ID))
+	w.Header().Set("ETag", formatETag(insertResponse.RowVersion))
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, "%s", uj)
+}
+
+// PutServiceRecordHandler corrects an existing service record of a motorcycle in the repository.
+// The service record is only updated if the entity tag in an If-Match header, or else the row version in the body, is current.
+func (api *Api) PutServiceRecordHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, id, err := serviceRecordParams(p)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Populate the service record from the updateRequest body.
+	recordDto := dto.TerseServiceRecordDto{}
+	status, err := api.decodeJsonBody(w, r, &recordDto)
+	if err != nil {
+		writeProblem(w, r, status, err)
+		return
+	}
+
+	record, err := newServiceRecord(motorcycleID, recordDto)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// An If-Match header takes precedence over the row version in the body.
+	rowVersion, hasIfMatch, err := parseIfMatch(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	if !hasIfMatch {
+		rowVersion = recordDto.RowVersion
+	}
+
+	// Create the updateRequest, process it, and get the resulting view model or error.
+	updateRequest, err := request.NewUpdateServiceRecordRequest(motorcycleID, id, rowVersion, record)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	session := api.NewSession()
+	defer session.Close()
+
+	updateInteractor, err := interactor.NewUpdateServiceRecordInteractor(session.MotorcycleRepository, session.ServiceRecordRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	updateInteractor.Logger = requestLogger(r)
+
+	updateResponse, err := updateInteractor.Handle(updateRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if updateResponse.Error != nil {
+		writeProblem(w, r, httpStatus(updateResponse.Status, hasIfMatch), updateResponse.Error)
+		return
+	}
+
+	updatePresenter, err := presenter.NewUpdateServiceRecordPresenter()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, err = updatePresenter.Handle(updateResponse)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write entity tag, status code
+	w.Header().Set("ETag", formatETag(updateResponse.RowVersion))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DelServiceRecordHandler deletes a service record of a motorcycle from the repository.
+// The service record is only deleted if the entity tag in an If-Match header is current, or the header is missing.
+func (api *Api) DelServiceRecordHandler(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	// Authenticate the user who made the request.
+	authService, err := api.authenticate(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	motorcycleID, id, err := serviceRecordParams(p)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	rowVersion, hasIfMatch, err := parseIfMatch(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	// Create the deleteRequest, process it, and get the resulting view model or error.
+	deleteRequest, err := request.NewDeleteServiceRecordRequest(motorcycleID, id, rowVersion)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	session := api.NewSession()
+	defer session.Close()
+
+	deleteInteractor, err := interactor.NewDeleteServiceRecordInteractor(session.MotorcycleRepository, session.ServiceRecordRepository, authService)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	deleteInteractor.Logger = requestLogger(r)
+
+	deleteResponse, err := deleteInteractor.Handle(deleteRequest)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if deleteResponse.Error != nil {
+		writeProblem(w, r, httpStatus(deleteResponse.Status, hasIfMatch), deleteResponse.Error)
+		return
+	}
+
+	// Write status code
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serviceRecordParams gets the IDs of the motorcycle and its service record from a request's path.
+// Returns (motorcycle ID, service record ID, nil) on success, otherwise (0, 0, error).
+func serviceRecordParams(p httprouter.Params) (typedef.ID, typedef.ID, error) {
+	motorcycleID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.Atoi(p.ByName("serviceId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return typedef.ID(motorcycleID), typedef.ID(id), nil
+}
+
+// newServiceRecord creates a service record for a motorcycle from the data that a client provided.
+// Returns (service record, nil) on success, otherwise (nil, error) when the data is not valid.
+func newServiceRecord(motorcycleID typedef.ID, recordDto dto.TerseServiceRecordDto) (*entity.ServiceRecord, error) {
+	return entity.NewServiceRecord(motorcycleID, recordDto.Type, recordDto.PerformedUtc, recordDto.Odometer, recordDto.Unit,
+		recordDto.Notes, recordDto.Parts, recordDto.LaborHours, recordDto.Cost, recordDto.PerformedBy)
+}
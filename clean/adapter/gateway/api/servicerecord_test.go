@@ -0,0 +1,189 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServiceRecordDto creates the data that a client provides to record an oil change.
+func newTestServiceRecordDto() dto.TerseServiceRecordDto {
+	return dto.TerseServiceRecordDto{
+		Type:         servicetype.OilChangeServiceType,
+		PerformedUtc: time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC),
+		Odometer:     4000,
+		Unit:         distanceunit.MilesDistanceUnit,
+		Parts:        []string{"Oil filter", "10W-40 oil"},
+		LaborHours:   0.5,
+		Cost:         45.50,
+		PerformedBy:  "Owner",
+	}
+}
+
+// TestApi_ServiceRecords verifies recording, getting, correcting, listing and deleting a motorcycle's service records.
+func TestApi_ServiceRecords(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	insertResponse, _ := InsertMotorcycle(ourApi, motorcycle)
+	insertionViewModel := viewmodel.InsertMotorcycleViewModel{}
+	json.NewDecoder(insertResponse.Body).Decode(&insertionViewModel)
+	motorcycleID := insertionViewModel.ID
+	record := newTestServiceRecordDto()
+
+	// ACT
+	postResponse, _ := PostServiceRecord(ourApi, motorcycleID, record)
+	postViewModel := viewmodel.InsertServiceRecordViewModel{}
+	json.NewDecoder(postResponse.Body).Decode(&postViewModel)
+	record.Cost = 50
+	putResponse, _ := PutServiceRecord(ourApi, motorcycleID, postViewModel.ID, record, postResponse.Header.Get("ETag"))
+	staleResponse, _ := PutServiceRecord(ourApi, motorcycleID, postViewModel.ID, record, postResponse.Header.Get("ETag"))
+	getResponse, _ := GetServiceRecord(ourApi, motorcycleID, postViewModel.ID)
+	getViewModel := viewmodel.GetServiceRecordViewModel{}
+	json.NewDecoder(getResponse.Body).Decode(&getViewModel)
+	listResponse, _ := GetServiceRecords(ourApi, motorcycleID)
+	listViewModel := viewmodel.ListServiceRecordsViewModel{}
+	json.NewDecoder(listResponse.Body).Decode(&listViewModel)
+	deleteResponse, _ := DelServiceRecord(ourApi, motorcycleID, postViewModel.ID, getResponse.Header.Get("ETag"))
+	missingResponse, _ := GetServiceRecord(ourApi, motorcycleID, postViewModel.ID)
+
+	// ASSERT
+	assert.True(t, postResponse.StatusCode == 201)
+	assert.True(t, postResponse.Header.Get("Location") == "/api/motorcycles/1/services/1")
+	assert.True(t, putResponse.StatusCode == 204)
+	assert.True(t, staleResponse.StatusCode == 412)
+	assert.True(t, getResponse.StatusCode == 200)
+	assert.True(t, getResponse.Header.Get("ETag") == putResponse.Header.Get("ETag"))
+	assert.True(t, getViewModel.ServiceRecord.Cost == 50)
+	assert.True(t, getViewModel.ServiceRecord.Type == servicetype.OilChangeServiceType)
+	assert.True(t, len(getViewModel.ServiceRecord.Parts) == 2)
+	assert.True(t, listResponse.StatusCode == 200)
+	assert.True(t, len(listViewModel.ServiceRecords) == 1)
+	assert.True(t, deleteResponse.StatusCode == 204)
+	assert.True(t, missingResponse.StatusCode == 404)
+}
+
+// TestApi_PostServiceRecord_UnknownType verifies that a service of an unknown type is a bad request.
+func TestApi_PostServiceRecord_UnknownType(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	// ACT
+	resp, _ := serveServiceRecords(ourApi.PostServiceRecordHandler, "POST", 1, "", nil,
+		[]byte(`{"type": "Detailing", "performedUtc": "2018-05-01T12:00:00Z", "odometer": 4000, "unit": "mi"}`))
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 400)
+}
+
+// TestApi_PostServiceRecord_MotorcycleNotExist verifies that service cannot be recorded for a missing motorcycle.
+func TestApi_PostServiceRecord_MotorcycleNotExist(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	// ACT
+	resp, _ := PostServiceRecord(ourApi, 123, newTestServiceRecordDto())
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 404)
+}
+
+// PostServiceRecord records service that was performed on a motorcycle using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func PostServiceRecord(ourApi *Api, motorcycleID typedef.ID, record dto.TerseServiceRecordDto) (*http.Response, error) {
+	recordJson, _ := json.Marshal(record)
+	return serveServiceRecords(ourApi.PostServiceRecordHandler, "POST", motorcycleID, "", nil, recordJson)
+}
+
+// GetServiceRecords gets a motorcycle's service history using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func GetServiceRecords(ourApi *Api, motorcycleID typedef.ID) (*http.Response, error) {
+	return serveServiceRecords(ourApi.ListServiceRecordsHandler, "GET", motorcycleID, "", nil, nil)
+}
+
+// GetServiceRecord gets a motorcycle's service record using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func GetServiceRecord(ourApi *Api, motorcycleID typedef.ID, id typedef.ID) (*http.Response, error) {
+	return serveServiceRecords(ourApi.GetServiceRecordHandler, "GET", motorcycleID, strconv.Itoa(int(id)), nil, nil)
+}
+
+// PutServiceRecord corrects a motorcycle's service record using the RESTful API, if the entity tag is current.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func PutServiceRecord(ourApi *Api, motorcycleID typedef.ID, id typedef.ID, record dto.TerseServiceRecordDto, etag string) (*http.Response, error) {
+	recordJson, _ := json.Marshal(record)
+	return serveServiceRecords(ourApi.PutServiceRecordHandler, "PUT", motorcycleID, strconv.Itoa(int(id)), map[string]string{"If-Match": etag}, recordJson)
+}
+
+// DelServiceRecord deletes a motorcycle's service record using the RESTful API, if the entity tag is current.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func DelServiceRecord(ourApi *Api, motorcycleID typedef.ID, id typedef.ID, etag string) (*http.Response, error) {
+	return serveServiceRecords(ourApi.DelServiceRecordHandler, "DELETE", motorcycleID, strconv.Itoa(int(id)), map[string]string{"If-Match": etag}, nil)
+}
+
+// serveServiceRecords sends a request with the headers and body to a service record handler, with the motorcycle and service record IDs as parameters.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func serveServiceRecords(handle httprouter.Handle, method string, motorcycleID typedef.ID, serviceID string, headers map[string]string, body []byte) (*http.Response, error) {
+
+	// An http handler wrapper around httprouter's handler.  It permits us to use
+	// the test server.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, httprouter.Params{
+			httprouter.Param{Key: "id", Value: strconv.Itoa(int(motorcycleID))},
+			httprouter.Param{Key: "serviceId", Value: serviceID},
+		})
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, err := http.NewRequest(method, server.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{}
+	return client.Do(req)
+}
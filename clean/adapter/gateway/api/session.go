@@ -0,0 +1,74 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Third party packages
+	log "github.com/sirupsen/logrus"
+
+	// Motominder's entity packages
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+)
+
+// Session is the repositories that are used by one caller, such as a request, which changes them.  When a repository
+// keeps its changes in a unit of work, the session has one of its own, so the caller's changes are not seen by the
+// others, nor saved with theirs, until it saves them.
+type Session struct {
+	MotorcycleRepository      contract.MotorcycleRepository
+	OdometerReadingRepository contract.OdometerReadingRepository
+	ServiceRecordRepository   contract.ServiceRecordRepository
+	ReminderRepository        contract.ReminderRepository
+}
+
+// NewSession creates a session of the API's repositories.  A repository that does not give each caller a session of
+// its own is shared with the API.
+func (api *Api) NewSession() *Session {
+	session := &Session{
+		MotorcycleRepository:      api.MotorcycleRepository,
+		OdometerReadingRepository: api.OdometerReadingRepository,
+		ServiceRecordRepository:   api.ServiceRecordRepository,
+		ReminderRepository:        api.ReminderRepository,
+	}
+
+	if sessions, ok := api.MotorcycleRepository.(contract.MotorcycleRepositorySessions); ok {
+		session.MotorcycleRepository = sessions.Session()
+	}
+
+	if sessions, ok := api.OdometerReadingRepository.(contract.OdometerReadingRepositorySessions); ok {
+		session.OdometerReadingRepository = sessions.Session()
+	}
+
+	if sessions, ok := api.ServiceRecordRepository.(contract.ServiceRecordRepositorySessions); ok {
+		session.ServiceRecordRepository = sessions.Session()
+	}
+
+	if sessions, ok := api.ReminderRepository.(contract.ReminderRepositorySessions); ok {
+		session.ReminderRepository = sessions.Session()
+	}
+
+	return session
+}
+
+// Close discards the changes that were not saved, which are those of a use case that failed.
+func (session *Session) Close() {
+	repositories := []struct {
+		name       string
+		repository interface{}
+	}{
+		{"motorcycle", session.MotorcycleRepository},
+		{"odometer reading", session.OdometerReadingRepository},
+		{"service record", session.ServiceRecordRepository},
+		{"reminder", session.ReminderRepository},
+	}
+
+	for _, r := range repositories {
+		unitOfWork, ok := r.repository.(contract.UnitOfWork)
+		if !ok {
+			continue
+		}
+
+		err := unitOfWork.Rollback()
+		if err != nil {
+			log.WithError(err).Errorf("Failed to discard the unsaved changes of the %s repository.", r.name)
+		}
+	}
+}
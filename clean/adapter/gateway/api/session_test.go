@@ -0,0 +1,26 @@
+// Package api implements unit tests for the Session.
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApi_NewSession verifies that the repositories that do not keep their changes in a unit of work are shared by the
+// sessions, and that closing a session does not affect them.
+func TestApi_NewSession(t *testing.T) {
+
+	// ARRANGE
+	ourApi, _ := newServerApi()
+
+	// ACT
+	session := ourApi.NewSession()
+	session.Close()
+
+	// ASSERT
+	assert.True(t, session.MotorcycleRepository == ourApi.MotorcycleRepository)
+	assert.True(t, session.OdometerReadingRepository == ourApi.OdometerReadingRepository)
+	assert.True(t, session.ServiceRecordRepository == ourApi.ServiceRecordRepository)
+	assert.True(t, session.ReminderRepository == ourApi.ReminderRepository)
+}
@@ -0,0 +1,168 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	// Standard library packages
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	// Third party packages
+	log "github.com/sirupsen/logrus"
+)
+
+// certificateReloader provides the server's certificate to each TLS handshake, and loads it again when its files
+// change, so a renewed certificate is used without restarting the server.
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+	// interval is how often the files are checked for changes, or zero to check them on every handshake.
+	interval time.Duration
+
+	mutex       sync.Mutex
+	certificate *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	checkedUtc  time.Time
+}
+
+// newCertificateReloader creates a new instance of a certificateReloader, and loads the certificate.
+// Returns (certificateReloader, nil) on success, otherwise (nil, error) when the certificate cannot be loaded.
+func newCertificateReloader(certFile string, keyFile string, interval time.Duration) (*certificateReloader, error) {
+	reloader := &certificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: interval,
+	}
+
+	err := reloader.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return reloader, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.  A certificate that cannot be loaded again is logged, and the
+// one that was loaded before continues to be used.
+func (reloader *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	reloader.mutex.Lock()
+	defer reloader.mutex.Unlock()
+
+	now := time.Now().UTC()
+	if now.Sub(reloader.checkedUtc) >= reloader.interval {
+		reloader.checkedUtc = now
+		if reloader.isChanged() {
+			err := reloader.load()
+			if err != nil {
+				log.WithError(err).Error("Failed to reload the server's certificate.")
+			} else {
+				log.WithField("certFile", reloader.certFile).Warn("Reloaded the server's certificate.")
+			}
+		}
+	}
+
+	return reloader.certificate, nil
+}
+
+// isChanged determines whether either of the files has been modified since the certificate was loaded.
+func (reloader *certificateReloader) isChanged() bool {
+	certInfo, err := os.Stat(reloader.certFile)
+	if err != nil {
+		return false
+	}
+
+	keyInfo, err := os.Stat(reloader.keyFile)
+	if err != nil {
+		return false
+	}
+
+	return !certInfo.ModTime().Equal(reloader.certModTime) || !keyInfo.ModTime().Equal(reloader.keyModTime)
+}
+
+// load reads the certificate and its private key from their files.
+// Returns nil on success, otherwise error.
+func (reloader *certificateReloader) load() error {
+	certInfo, err := os.Stat(reloader.certFile)
+	if err != nil {
+		return err
+	}
+
+	keyInfo, err := os.Stat(reloader.keyFile)
+	if err != nil {
+		return err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(reloader.certFile, reloader.keyFile)
+	if err != nil {
+		return fmt.Errorf("cannot load the certificate in %s: %s", reloader.certFile, err.Error())
+	}
+
+	reloader.certificate = &certificate
+	reloader.certModTime = certInfo.ModTime()
+	reloader.keyModTime = keyInfo.ModTime()
+
+	return nil
+}
+
+// newTLSConfig creates the TLS settings of the server.  When there is a bundle of client certificate authorities, a
+// client certificate that they issued is verified, and it is required when clientCertRequired is true.
+// Returns (TLS settings, nil) on success, otherwise (nil, error).
+func newTLSConfig(config ServerConfig) (*tls.Config, error) {
+	reloader, err := newCertificateReloader(config.TLSCertFile, config.TLSKeyFile, config.CertReloadInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if config.ClientCAFile != "" {
+		contents, err := ioutil.ReadFile(config.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(contents) {
+			return nil, fmt.Errorf("there are no certificates in %s", config.ClientCAFile)
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		if config.ClientCertRequired {
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	// All okay
+	return tlsConfig, nil
+}
+
+// newRedirectHandler creates a handler that permanently redirects every request to the same URL on the HTTPS server
+// listening at httpsAddr.
+func newRedirectHandler(httpsAddr string) http.Handler {
+	_, httpsPort, _ := net.SplitHostPort(httpsAddr)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = strings.Trim(r.Host, "[]")
+		}
+		if httpsPort != "" && httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
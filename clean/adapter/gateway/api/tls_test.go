@@ -0,0 +1,240 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// testIssuer is a certificate and its private key, which may issue other certificates.
+type testIssuer struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+// newTestCertificate creates a certificate for the common name, which is issued by the issuer, or is a certificate
+// authority that issues itself when the issuer is nil.  A certificate that is not an authority is valid for 127.0.0.1.
+func newTestCertificate(t *testing.T, commonName string, serial int64, issuer *testIssuer) (*testIssuer, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	parent, parentKey := template, key
+	if issuer == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+		parent, parentKey = issuer.certificate, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	return &testIssuer{certificate: certificate, key: key},
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+// writeTestFile writes the contents to the file in the directory, and sets its modification time.
+// Returns the path of the file.
+func writeTestFile(t *testing.T, dir string, name string, contents []byte, modTime time.Time) string {
+	path := filepath.Join(dir, name)
+	err := ioutil.WriteFile(path, contents, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(path, modTime, modTime)
+
+	return path
+}
+
+// newTestDir creates a temporary directory for the certificates of a test.
+// Returns (path of the directory, function that removes it).
+func newTestDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "motominder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+// TestServerConfig_Validate_TLS verifies that the TLS settings must be consistent with each other.
+func TestServerConfig_Validate_TLS(t *testing.T) {
+
+	// ARRANGE
+	tlsConfig := DefaultServerConfig()
+	tlsConfig.TLSCertFile = "server.pem"
+	tlsConfig.TLSKeyFile = "server.key"
+	noKey := tlsConfig
+	noKey.TLSKeyFile = ""
+	clientCAWithoutTLS := DefaultServerConfig()
+	clientCAWithoutTLS.ClientCAFile = "ca.pem"
+	requiredWithoutCA := tlsConfig
+	requiredWithoutCA.ClientCertRequired = true
+	redirectWithoutTLS := DefaultServerConfig()
+	redirectWithoutTLS.RedirectAddr = ":8081"
+	redirectToItself := tlsConfig
+	redirectToItself.RedirectAddr = tlsConfig.Addr
+
+	// ACT
+	valid := tlsConfig.Validate()
+	noKeyErr := noKey.Validate()
+	clientCAWithoutTLSErr := clientCAWithoutTLS.Validate()
+	requiredWithoutCAErr := requiredWithoutCA.Validate()
+	redirectWithoutTLSErr := redirectWithoutTLS.Validate()
+	redirectToItselfErr := redirectToItself.Validate()
+
+	// ASSERT
+	assert.Nil(t, valid)
+	assert.NotNil(t, noKeyErr)
+	assert.NotNil(t, clientCAWithoutTLSErr)
+	assert.NotNil(t, requiredWithoutCAErr)
+	assert.NotNil(t, redirectWithoutTLSErr)
+	assert.NotNil(t, redirectToItselfErr)
+}
+
+// TestCertificateReloader_Reload verifies that a certificate is loaded again when its files change, and that
+// the previous certificate continues to be used when the changed files are not valid.
+func TestCertificateReloader_Reload(t *testing.T) {
+
+	// ARRANGE
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	ca, _, _ := newTestCertificate(t, "Test CA", 1, nil)
+	_, firstCert, firstKey := newTestCertificate(t, "server", 2, ca)
+	_, secondCert, secondKey := newTestCertificate(t, "server", 3, ca)
+	loaded := time.Now().Add(-time.Hour)
+	certFile := writeTestFile(t, dir, "server.pem", firstCert, loaded)
+	keyFile := writeTestFile(t, dir, "server.key", firstKey, loaded)
+	reloader, _ := newCertificateReloader(certFile, keyFile, 0)
+
+	// ACT
+	first, _ := reloader.GetCertificate(nil)
+	writeTestFile(t, dir, "server.pem", secondCert, loaded.Add(time.Minute))
+	writeTestFile(t, dir, "server.key", secondKey, loaded.Add(time.Minute))
+	second, _ := reloader.GetCertificate(nil)
+	writeTestFile(t, dir, "server.pem", []byte("not a certificate"), loaded.Add(2*time.Minute))
+	invalid, _ := reloader.GetCertificate(nil)
+
+	// ASSERT
+	firstLeaf, _ := x509.ParseCertificate(first.Certificate[0])
+	secondLeaf, _ := x509.ParseCertificate(second.Certificate[0])
+	assert.True(t, firstLeaf.SerialNumber.Int64() == 2)
+	assert.True(t, secondLeaf.SerialNumber.Int64() == 3)
+	assert.True(t, invalid == second)
+}
+
+// TestApi_Serve_ClientCertificate verifies that the server serves HTTPS, and that a request is authenticated by a
+// client certificate issued by the trusted authority, while one from another authority is not presented.
+func TestApi_Serve_ClientCertificate(t *testing.T) {
+
+	// ARRANGE
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	ca, caCert, _ := newTestCertificate(t, "Test CA", 1, nil)
+	_, serverCert, serverKey := newTestCertificate(t, "server", 2, ca)
+	_, terminalCert, terminalKey := newTestCertificate(t, "terminal-01", 3, ca)
+	otherCA, _, _ := newTestCertificate(t, "Other CA", 4, nil)
+	_, otherCert, otherKey := newTestCertificate(t, "terminal-01", 5, otherCA)
+
+	config := DefaultServerConfig()
+	config.TLSCertFile = writeTestFile(t, dir, "server.pem", serverCert, time.Now())
+	config.TLSKeyFile = writeTestFile(t, dir, "server.key", serverKey, time.Now())
+	config.ClientCAFile = writeTestFile(t, dir, "ca.pem", caCert, time.Now())
+
+	roleMap, _ := security.NewCertificateRoleMap(map[string][]authorizationrole.AuthorizationRole{
+		"cn:terminal-01": {authorizationrole.GeneralAuthorizationRole},
+	})
+	authenticator, _ := security.NewCertificateAuthenticator(roleMap, security.DefaultRolePolicy())
+	motorcycleRepository, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authenticator, motorcycleRepository, httprouter.New())
+	configErr := ourApi.Configure(config)
+
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	signals := make(chan os.Signal, 1)
+	served := make(chan error, 1)
+	go func() {
+		served <- ourApi.Serve(listener, signals)
+	}()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM(caCert)
+	terminalPair, _ := tls.X509KeyPair(terminalCert, terminalKey)
+	otherPair, _ := tls.X509KeyPair(otherCert, otherKey)
+	get := func(certificates ...tls.Certificate) (*http.Response, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, Certificates: certificates}}}
+		return client.Get("https://" + listener.Addr().String() + "/api/motorcycles")
+	}
+
+	// ACT
+	terminalResp, terminalErr := get(terminalPair)
+	anonymousResp, anonymousErr := get()
+	otherResp, otherErr := get(otherPair)
+	signals <- os.Interrupt
+	err := <-served
+
+	// ASSERT
+	assert.Nil(t, configErr)
+	assert.Nil(t, terminalErr)
+	assert.True(t, terminalResp != nil && terminalResp.StatusCode == http.StatusOK)
+	assert.Nil(t, anonymousErr)
+	assert.True(t, anonymousResp != nil && anonymousResp.StatusCode == http.StatusUnauthorized)
+	assert.Nil(t, otherErr)
+	assert.True(t, otherResp != nil && otherResp.StatusCode == http.StatusUnauthorized)
+	assert.Nil(t, err)
+}
+
+// TestRedirectHandler verifies that a request is redirected to the same URL on the HTTPS server.
+func TestRedirectHandler(t *testing.T) {
+
+	// ARRANGE
+	r, _ := http.NewRequest("POST", "http://motominder.example.com:8081/api/motorcycles?limit=5", nil)
+	defaultPort := httptest.NewRecorder()
+	otherPort := httptest.NewRecorder()
+
+	// ACT
+	newRedirectHandler(":443").ServeHTTP(defaultPort, r)
+	newRedirectHandler(":8443").ServeHTTP(otherPort, r)
+
+	// ASSERT
+	assert.True(t, defaultPort.Code == http.StatusPermanentRedirect)
+	assert.Equal(t, "https://motominder.example.com/api/motorcycles?limit=5", defaultPort.Header().Get("Location"))
+	assert.Equal(t, "https://motominder.example.com:8443/api/motorcycles?limit=5", otherPort.Header().Get("Location"))
+}
@@ -0,0 +1,76 @@
+// Package api contains the restful web service.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApi_DecodeVin verifies a successful response after decoding a VIN.
+func TestApi_DecodeVin(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.GeneralAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	// ACT
+	resp, _ := DecodeVin(ourApi, "JH2PC35051M200020")
+	viewModel := viewmodel.DecodeVinViewModel{}
+	json.NewDecoder(resp.Body).Decode(&viewModel)
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 200)
+	assert.True(t, viewModel.DecodedVin.Manufacturer == "Honda")
+	assert.True(t, viewModel.DecodedVin.ModelYear == 2001)
+}
+
+// TestApi_DecodeVin_InvalidVin verifies a bad request response when the VIN's check digit is wrong.
+func TestApi_DecodeVin_InvalidVin(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.GeneralAuthorizationRole: true,
+	}
+
+	authService, _ := security.NewAuthService(true, roles)
+	repos, _ := repository.NewMotorcycleRepository()
+	ourApi, _ := NewApi(authService, repos, httprouter.New())
+
+	// ACT
+	resp, _ := DecodeVin(ourApi, "JH2PC35061M200020")
+
+	// ASSERT
+	assert.True(t, resp.StatusCode == 400)
+}
+
+// DecodeVin decodes a VIN using the RESTful API.
+// Returns (*response, nil) on success, otherwise (nil, error).
+func DecodeVin(ourApi *Api, vin string) (*http.Response, error) {
+	// An http handler wrapper around httprouter's handler.  It permits us to use
+	// the test server.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ourApi.DecodeVinHandler(w, r, httprouter.Params{httprouter.Param{
+			Key:   "vin",
+			Value: vin,
+		}})
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	return http.Get(server.URL + "/" + vin + "/decode")
+}
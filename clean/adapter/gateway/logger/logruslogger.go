@@ -0,0 +1,50 @@
+// Package logger contains implementations of contract.Logger.
+package logger
+
+import (
+	"errors"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	log "github.com/sirupsen/logrus"
+)
+
+// LogrusLogger records the entries of a use case with logrus, so they are written in the same format, and filtered
+// by the same level, as the rest of the service's log.
+type LogrusLogger struct {
+	entry *log.Entry
+}
+
+// NewLogrusLogger creates a new instance of a LogrusLogger, which records its entries with the fields of the logrus entry.
+// Returns (LogrusLogger, nil) on success, otherwise (nil, error).
+func NewLogrusLogger(entry *log.Entry) (*LogrusLogger, error) {
+	if entry == nil {
+		return nil, errors.New("the logrus entry is required")
+	}
+
+	logger := &LogrusLogger{
+		entry: entry,
+	}
+
+	// All okay
+	return logger, nil
+}
+
+// WithField implements contract.Logger.WithField().
+func (logger *LogrusLogger) WithField(key string, value interface{}) contract.Logger {
+	return &LogrusLogger{entry: logger.entry.WithField(key, value)}
+}
+
+// Info implements contract.Logger.Info().
+func (logger *LogrusLogger) Info(message string) {
+	logger.entry.Info(message)
+}
+
+// Warn implements contract.Logger.Warn().
+func (logger *LogrusLogger) Warn(err error, message string) {
+	logger.entry.WithError(err).Warn(message)
+}
+
+// Error implements contract.Logger.Error().
+func (logger *LogrusLogger) Error(err error, message string) {
+	logger.entry.WithError(err).Error(message)
+}
@@ -0,0 +1,34 @@
+// Package logger implements unit tests for the LogrusLogger.
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLogrusLogger verifies that an entry is recorded with the fields of the logger, and those of the loggers it was created from.
+func TestLogrusLogger(t *testing.T) {
+
+	// ARRANGE
+	base, hook := test.NewNullLogger()
+	logger, err := NewLogrusLogger(base.WithField("requestId", "request-1"))
+	_, nilErr := NewLogrusLogger(nil)
+
+	// ACT
+	logger.WithField("motorcycleId", 7).Error(errors.New("the disk is full"), "Failed to save the motorcycle.")
+	logger.Warn(errors.New("the notifier is broken"), "Failed to notify the owner.")
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.NotNil(t, nilErr)
+	assert.Len(t, hook.AllEntries(), 2)
+	assert.Equal(t, log.ErrorLevel, hook.AllEntries()[0].Level)
+	assert.Equal(t, "request-1", hook.AllEntries()[0].Data["requestId"])
+	assert.Equal(t, 7, hook.AllEntries()[0].Data["motorcycleId"])
+	assert.Equal(t, log.WarnLevel, hook.AllEntries()[1].Level)
+	assert.NotContains(t, hook.AllEntries()[1].Data, "motorcycleId")
+}
@@ -0,0 +1,105 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"sync"
+)
+
+// Counter is a metric whose value only increases, such as the number of requests that were processed.  It has a
+// separate value for each set of label values with which it is incremented.
+// It is safe for concurrent use by multiple goroutines.
+type Counter struct {
+	metricName string
+	labels     []string
+	mutex      sync.Mutex
+	series     map[string]*counterSeries
+}
+
+// counterSeries is the value of a counter for one set of label values.
+type counterSeries struct {
+	series
+	value float64
+}
+
+// NewCounter creates a new instance of a Counter with the labels, and registers it.
+// Returns (Counter, nil) on success, otherwise (nil, error).
+func (registry *Registry) NewCounter(name string, help string, labels ...string) (*Counter, error) {
+
+	counter := &Counter{
+		metricName: name,
+		labels:     labels,
+		series:     make(map[string]*counterSeries),
+	}
+
+	err := registry.register(counterType, help, counter, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return counter, nil
+}
+
+// Inc adds one to the counter's value for the label values, which are given in the same order as its labels.
+// Panics when the number of values is not the same as the number of labels.
+func (counter *Counter) Inc(labelValues ...string) {
+	counter.Add(1, labelValues...)
+}
+
+// Add adds the amount, which cannot be negative, to the counter's value for the label values.
+// Panics when the amount is negative, or the number of values is not the same as the number of labels.
+func (counter *Counter) Add(amount float64, labelValues ...string) {
+	if amount < 0 {
+		panic(fmt.Sprintf("the counter %s cannot be decreased", counter.metricName))
+	}
+
+	s := newSeries(counter.metricName, counter.labels, labelValues)
+
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	existing, ok := counter.series[s.key()]
+	if !ok {
+		existing = &counterSeries{series: s}
+		counter.series[s.key()] = existing
+	}
+	existing.value += amount
+}
+
+// Value gets the counter's value for the label values, which is zero when it has not been incremented.
+// Panics when the number of values is not the same as the number of labels.
+func (counter *Counter) Value(labelValues ...string) float64 {
+	s := newSeries(counter.metricName, counter.labels, labelValues)
+
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	if existing, ok := counter.series[s.key()]; ok {
+		return existing.value
+	}
+
+	return 0
+}
+
+// name implements metric.name().
+func (counter *Counter) name() string {
+	return counter.metricName
+}
+
+// write implements metric.write().
+func (counter *Counter) write(w *bufio.Writer) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	keys := make([]string, 0, len(counter.series))
+	for key := range counter.series {
+		keys = append(keys, key)
+	}
+
+	for _, key := range sortedKeys(keys) {
+		s := counter.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", counter.metricName, s.format("", ""), formatValue(s.value))
+	}
+}
@@ -0,0 +1,105 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"sync"
+)
+
+// Gauge is a metric whose value goes up and down, such as the number of requests that are being processed.
+// It is safe for concurrent use by multiple goroutines.
+type Gauge struct {
+	metricName string
+	mutex      sync.Mutex
+	value      float64
+	// measure gets the value when the gauge is written, or is nil when the value is set instead.
+	measure func() float64
+}
+
+// NewGauge creates a new instance of a Gauge, whose value is set by the service, and registers it.
+// Returns (Gauge, nil) on success, otherwise (nil, error).
+func (registry *Registry) NewGauge(name string, help string) (*Gauge, error) {
+
+	gauge := &Gauge{
+		metricName: name,
+	}
+
+	err := registry.register(gaugeType, help, gauge, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return gauge, nil
+}
+
+// NewGaugeFunc creates a new instance of a Gauge, whose value is measured each time that it is written, and registers it.
+// The function must be safe for concurrent use by multiple goroutines.
+// Returns (Gauge, nil) on success, otherwise (nil, error).
+func (registry *Registry) NewGaugeFunc(name string, help string, measure func() float64) (*Gauge, error) {
+	if measure == nil {
+		return nil, fmt.Errorf("the gauge %s requires a function that measures its value", name)
+	}
+
+	gauge := &Gauge{
+		metricName: name,
+		measure:    measure,
+	}
+
+	err := registry.register(gaugeType, help, gauge, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return gauge, nil
+}
+
+// Add adds the amount, which may be negative, to the gauge's value.
+func (gauge *Gauge) Add(amount float64) {
+	gauge.mutex.Lock()
+	defer gauge.mutex.Unlock()
+
+	gauge.value += amount
+}
+
+// Inc adds one to the gauge's value.
+func (gauge *Gauge) Inc() {
+	gauge.Add(1)
+}
+
+// Dec subtracts one from the gauge's value.
+func (gauge *Gauge) Dec() {
+	gauge.Add(-1)
+}
+
+// Set replaces the gauge's value.
+func (gauge *Gauge) Set(value float64) {
+	gauge.mutex.Lock()
+	defer gauge.mutex.Unlock()
+
+	gauge.value = value
+}
+
+// Value gets the gauge's value, which is measured when the gauge was created with a function.
+func (gauge *Gauge) Value() float64 {
+	if gauge.measure != nil {
+		return gauge.measure()
+	}
+
+	gauge.mutex.Lock()
+	defer gauge.mutex.Unlock()
+
+	return gauge.value
+}
+
+// name implements metric.name().
+func (gauge *Gauge) name() string {
+	return gauge.metricName
+}
+
+// write implements metric.write().
+func (gauge *Gauge) write(w *bufio.Writer) {
+	fmt.Fprintf(w, "%s %s\n", gauge.metricName, formatValue(gauge.Value()))
+}
@@ -0,0 +1,126 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// DefaultDurationBuckets are the upper bounds, in seconds, of the buckets of a histogram of request durations.
+var DefaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram is a metric that counts observations, such as the durations of requests, in buckets with upper bounds.
+// It has a separate set of buckets for each set of label values with which it is observed.
+// It is safe for concurrent use by multiple goroutines.
+type Histogram struct {
+	metricName string
+	labels     []string
+	buckets    []float64
+	mutex      sync.Mutex
+	series     map[string]*histogramSeries
+}
+
+// histogramSeries is the observations of a histogram for one set of label values.
+type histogramSeries struct {
+	series
+	// counts is the number of observations in each bucket, which are not cumulative.  The last is for +Inf.
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram creates a new instance of a Histogram with the buckets and labels, and registers it.
+// The buckets are their upper bounds, in increasing order, and a bucket for +Inf is always added.
+// Returns (Histogram, nil) on success, otherwise (nil, error).
+func (registry *Registry) NewHistogram(name string, help string, buckets []float64, labels ...string) (*Histogram, error) {
+	if len(buckets) == 0 {
+		return nil, fmt.Errorf("the histogram %s requires at least one bucket", name)
+	}
+
+	if !sort.Float64sAreSorted(buckets) || math.IsInf(buckets[len(buckets)-1], 1) {
+		return nil, fmt.Errorf("the buckets of the histogram %s must be in increasing order, without +Inf", name)
+	}
+
+	histogram := &Histogram{
+		metricName: name,
+		labels:     labels,
+		buckets:    append([]float64{}, buckets...),
+		series:     make(map[string]*histogramSeries),
+	}
+
+	err := registry.register(histogramType, help, histogram, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return histogram, nil
+}
+
+// Observe counts the value in the first bucket whose upper bound is not less than it, for the label values.
+// Panics when the number of values is not the same as the number of labels.
+func (histogram *Histogram) Observe(value float64, labelValues ...string) {
+	s := newSeries(histogram.metricName, histogram.labels, labelValues)
+
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	existing, ok := histogram.series[s.key()]
+	if !ok {
+		existing = &histogramSeries{series: s, counts: make([]uint64, len(histogram.buckets)+1)}
+		histogram.series[s.key()] = existing
+	}
+
+	existing.counts[sort.SearchFloat64s(histogram.buckets, value)]++
+	existing.sum += value
+	existing.count++
+}
+
+// Count gets the number of values that were observed for the label values.
+// Panics when the number of values is not the same as the number of labels.
+func (histogram *Histogram) Count(labelValues ...string) uint64 {
+	s := newSeries(histogram.metricName, histogram.labels, labelValues)
+
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	if existing, ok := histogram.series[s.key()]; ok {
+		return existing.count
+	}
+
+	return 0
+}
+
+// name implements metric.name().
+func (histogram *Histogram) name() string {
+	return histogram.metricName
+}
+
+// write implements metric.write().  The buckets are cumulative, as Prometheus requires.
+func (histogram *Histogram) write(w *bufio.Writer) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	keys := make([]string, 0, len(histogram.series))
+	for key := range histogram.series {
+		keys = append(keys, key)
+	}
+
+	for _, key := range sortedKeys(keys) {
+		s := histogram.series[key]
+		cumulative := uint64(0)
+		for i, count := range s.counts {
+			cumulative += count
+			bound := math.Inf(1)
+			if i < len(histogram.buckets) {
+				bound = histogram.buckets[i]
+			}
+			fmt.Fprintf(w, "%s_bucket%s %d\n", histogram.metricName, s.format("le", formatValue(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_sum%s %s\n", histogram.metricName, s.format("", ""), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", histogram.metricName, s.format("", ""), s.count)
+	}
+}
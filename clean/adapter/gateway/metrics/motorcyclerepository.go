@@ -0,0 +1,152 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format.
+package metrics
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// MotorcycleRepository measures the operations of a motorcycle repository, which it performs.
+type MotorcycleRepository struct {
+	Repository contract.MotorcycleRepository
+	Metrics    *RepositoryMetrics
+}
+
+// NewMotorcycleRepository creates a new instance of a MotorcycleRepository, which measures the operations of the repository.
+// Returns (nil, error) when there is an error, otherwise (MotorcycleRepository, nil).
+func NewMotorcycleRepository(repository contract.MotorcycleRepository, metrics *RepositoryMetrics) (*MotorcycleRepository, error) {
+
+	repo := &MotorcycleRepository{
+		Repository: repository,
+		Metrics:    metrics,
+	}
+
+	// Validate the repository
+	err := repo.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return repo, nil
+}
+
+// Validate verifies that a MotorcycleRepository's fields contain valid data.
+// Returns nil if the MotorcycleRepository contains valid data, otherwise an error.
+func (repo MotorcycleRepository) Validate() error {
+	return validation.ValidateStruct(&repo,
+		// Repository is required and cannot be null.
+		validation.Field(&repo.Repository, validation.Required),
+		// Metrics is required and cannot be null.
+		validation.Field(&repo.Metrics, validation.Required))
+}
+
+// FindByVin performs contract.MotorcycleRepository.FindByVin() with the repository, and measures it.
+func (repo *MotorcycleRepository) FindByVin(vin string) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	motorcycle, status, err := repo.Repository.FindByVin(vin)
+	repo.Metrics.observe("motorcycle", "findByVin", started, status, err)
+	return motorcycle, status, err
+}
+
+// ExistsByVin performs contract.MotorcycleRepository.ExistsByVin() with the repository, and measures it.
+func (repo *MotorcycleRepository) ExistsByVin(vin string) (bool, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	exists, status, err := repo.Repository.ExistsByVin(vin)
+	repo.Metrics.observe("motorcycle", "existsByVin", started, status, err)
+	return exists, status, err
+}
+
+// ExistsByID performs contract.MotorcycleRepository.ExistsByID() with the repository, and measures it.
+func (repo *MotorcycleRepository) ExistsByID(id typedef.ID) (bool, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	exists, status, err := repo.Repository.ExistsByID(id)
+	repo.Metrics.observe("motorcycle", "existsByID", started, status, err)
+	return exists, status, err
+}
+
+// List performs contract.MotorcycleRepository.List() with the repository, and measures it.
+func (repo *MotorcycleRepository) List() ([]entity.Motorcycle, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	motorcycles, status, err := repo.Repository.List()
+	repo.Metrics.observe("motorcycle", "list", started, status, err)
+	return motorcycles, status, err
+}
+
+// Query performs contract.MotorcycleRepository.Query() with the repository, and measures it.
+func (repo *MotorcycleRepository) Query(query entity.MotorcycleQuery) (*entity.MotorcyclePage, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	page, status, err := repo.Repository.Query(query)
+	repo.Metrics.observe("motorcycle", "query", started, status, err)
+	return page, status, err
+}
+
+// Insert performs contract.MotorcycleRepository.Insert() with the repository, and measures it.
+func (repo *MotorcycleRepository) Insert(motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	inserted, status, err := repo.Repository.Insert(motorcycle)
+	repo.Metrics.observe("motorcycle", "insert", started, status, err)
+	return inserted, status, err
+}
+
+// Update performs contract.MotorcycleRepository.Update() with the repository, and measures it.
+func (repo *MotorcycleRepository) Update(id typedef.ID, motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	updated, status, err := repo.Repository.Update(id, motorcycle)
+	repo.Metrics.observe("motorcycle", "update", started, status, err)
+	return updated, status, err
+}
+
+// Delete performs contract.MotorcycleRepository.Delete() with the repository, and measures it.
+func (repo *MotorcycleRepository) Delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.Delete(id, rowVersion)
+	repo.Metrics.observe("motorcycle", "delete", started, status, err)
+	return status, err
+}
+
+// FindByID performs contract.MotorcycleRepository.FindByID() with the repository, and measures it.
+func (repo *MotorcycleRepository) FindByID(id typedef.ID) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	motorcycle, status, err := repo.Repository.FindByID(id)
+	repo.Metrics.observe("motorcycle", "findByID", started, status, err)
+	return motorcycle, status, err
+}
+
+// Save performs contract.MotorcycleRepository.Save() with the repository, and measures it.
+func (repo *MotorcycleRepository) Save() (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.Save()
+	repo.Metrics.observe("motorcycle", "save", started, status, err)
+	return status, err
+}
+
+// Session creates a repository that measures a session of the repository, when it gives each caller a session of its
+// own, otherwise it gets this repository, which the callers share.
+func (repo *MotorcycleRepository) Session() contract.MotorcycleRepository {
+	sessions, ok := repo.Repository.(contract.MotorcycleRepositorySessions)
+	if !ok {
+		return repo
+	}
+
+	return &MotorcycleRepository{
+		Repository: sessions.Session(),
+		Metrics:    repo.Metrics,
+	}
+}
+
+// Rollback discards the changes that have not been saved, when the repository keeps them in a unit of work.
+// Returns nil on success, otherwise an error.
+func (repo *MotorcycleRepository) Rollback() error {
+	unitOfWork, ok := repo.Repository.(contract.UnitOfWork)
+	if !ok {
+		return nil
+	}
+
+	return unitOfWork.Rollback()
+}
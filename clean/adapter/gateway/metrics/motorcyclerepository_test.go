@@ -0,0 +1,74 @@
+// Package metrics implements unit tests for the MotorcycleRepository.
+package metrics
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMotorcycleRepository_Measured verifies that each operation is timed, and that a failed one is counted by its status.
+func TestMotorcycleRepository_Measured(t *testing.T) {
+
+	// ARRANGE
+	registry, _ := NewRegistry()
+	repositoryMetrics, _ := NewRepositoryMetrics(registry)
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	repo, err := NewMotorcycleRepository(motorcycles, repositoryMetrics)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+
+	// ACT
+	inserted, insertStatus, insertErr := repo.Insert(motorcycle)
+	deleteStatus, deleteErr := repo.Delete(inserted.ID+1, constant.AnyRowVersion)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Nil(t, insertErr)
+	assert.True(t, insertStatus == operationstatus.Ok)
+	assert.NotNil(t, deleteErr)
+	assert.True(t, deleteStatus == operationstatus.NotFound)
+	assert.True(t, len(motorcycles.Motorcycles) == 1)
+	assert.True(t, repositoryMetrics.Duration.Count("motorcycle", "insert") == 1)
+	assert.True(t, repositoryMetrics.Duration.Count("motorcycle", "delete") == 1)
+	assert.True(t, repositoryMetrics.Errors.Value("motorcycle", "insert", "Not Found") == 0)
+	assert.True(t, repositoryMetrics.Errors.Value("motorcycle", "delete", "Not Found") == 1)
+}
+
+// TestNewMotorcycleRepository_Invalid verifies that the repository and the metrics are required.
+func TestNewMotorcycleRepository_Invalid(t *testing.T) {
+
+	// ARRANGE
+	registry, _ := NewRegistry()
+	repositoryMetrics, _ := NewRepositoryMetrics(registry)
+	motorcycles, _ := repository.NewMotorcycleRepository()
+
+	// ACT
+	_, noRepository := NewMotorcycleRepository(nil, repositoryMetrics)
+	_, noMetrics := NewMotorcycleRepository(motorcycles, nil)
+
+	// ASSERT
+	assert.NotNil(t, noRepository)
+	assert.NotNil(t, noMetrics)
+}
+
+// TestMotorcycleRepository_Session verifies that a repository which the callers share is its own session.
+func TestMotorcycleRepository_Session(t *testing.T) {
+
+	// ARRANGE
+	registry, _ := NewRegistry()
+	repositoryMetrics, _ := NewRepositoryMetrics(registry)
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	repo, _ := NewMotorcycleRepository(motorcycles, repositoryMetrics)
+
+	// ACT
+	session := repo.Session()
+	err := repo.Rollback()
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, session == repo)
+}
@@ -0,0 +1,128 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format.
+package metrics
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// OdometerReadingRepository measures the operations of an odometer reading repository, which it performs.
+type OdometerReadingRepository struct {
+	Repository contract.OdometerReadingRepository
+	Metrics    *RepositoryMetrics
+}
+
+// NewOdometerReadingRepository creates a new instance of a OdometerReadingRepository, which measures the operations of the repository.
+// Returns (nil, error) when there is an error, otherwise (OdometerReadingRepository, nil).
+func NewOdometerReadingRepository(repository contract.OdometerReadingRepository, metrics *RepositoryMetrics) (*OdometerReadingRepository, error) {
+
+	repo := &OdometerReadingRepository{
+		Repository: repository,
+		Metrics:    metrics,
+	}
+
+	// Validate the repository
+	err := repo.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return repo, nil
+}
+
+// Validate verifies that a OdometerReadingRepository's fields contain valid data.
+// Returns nil if the OdometerReadingRepository contains valid data, otherwise an error.
+func (repo OdometerReadingRepository) Validate() error {
+	return validation.ValidateStruct(&repo,
+		// Repository is required and cannot be null.
+		validation.Field(&repo.Repository, validation.Required),
+		// Metrics is required and cannot be null.
+		validation.Field(&repo.Metrics, validation.Required))
+}
+
+// ListByMotorcycle performs contract.OdometerReadingRepository.ListByMotorcycle() with the repository, and measures it.
+func (repo *OdometerReadingRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.OdometerReading, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	readings, status, err := repo.Repository.ListByMotorcycle(motorcycleID)
+	repo.Metrics.observe("odometerReading", "listByMotorcycle", started, status, err)
+	return readings, status, err
+}
+
+// Latest performs contract.OdometerReadingRepository.Latest() with the repository, and measures it.
+func (repo *OdometerReadingRepository) Latest(motorcycleID typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	reading, status, err := repo.Repository.Latest(motorcycleID)
+	repo.Metrics.observe("odometerReading", "latest", started, status, err)
+	return reading, status, err
+}
+
+// FindByID performs contract.OdometerReadingRepository.FindByID() with the repository, and measures it.
+func (repo *OdometerReadingRepository) FindByID(id typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	reading, status, err := repo.Repository.FindByID(id)
+	repo.Metrics.observe("odometerReading", "findByID", started, status, err)
+	return reading, status, err
+}
+
+// Insert performs contract.OdometerReadingRepository.Insert() with the repository, and measures it.
+func (repo *OdometerReadingRepository) Insert(reading *entity.OdometerReading) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	inserted, status, err := repo.Repository.Insert(reading)
+	repo.Metrics.observe("odometerReading", "insert", started, status, err)
+	return inserted, status, err
+}
+
+// Delete performs contract.OdometerReadingRepository.Delete() with the repository, and measures it.
+func (repo *OdometerReadingRepository) Delete(id typedef.ID) (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.Delete(id)
+	repo.Metrics.observe("odometerReading", "delete", started, status, err)
+	return status, err
+}
+
+// DeleteByMotorcycle performs contract.OdometerReadingRepository.DeleteByMotorcycle() with the repository, and measures it.
+func (repo *OdometerReadingRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.DeleteByMotorcycle(motorcycleID)
+	repo.Metrics.observe("odometerReading", "deleteByMotorcycle", started, status, err)
+	return status, err
+}
+
+// Save performs contract.OdometerReadingRepository.Save() with the repository, and measures it.
+func (repo *OdometerReadingRepository) Save() (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.Save()
+	repo.Metrics.observe("odometerReading", "save", started, status, err)
+	return status, err
+}
+
+// Session creates a repository that measures a session of the repository, when it gives each caller a session of its
+// own, otherwise it gets this repository, which the callers share.
+func (repo *OdometerReadingRepository) Session() contract.OdometerReadingRepository {
+	sessions, ok := repo.Repository.(contract.OdometerReadingRepositorySessions)
+	if !ok {
+		return repo
+	}
+
+	return &OdometerReadingRepository{
+		Repository: sessions.Session(),
+		Metrics:    repo.Metrics,
+	}
+}
+
+// Rollback discards the changes that have not been saved, when the repository keeps them in a unit of work.
+// Returns nil on success, otherwise an error.
+func (repo *OdometerReadingRepository) Rollback() error {
+	unitOfWork, ok := repo.Repository.(contract.UnitOfWork)
+	if !ok {
+		return nil
+	}
+
+	return unitOfWork.Rollback()
+}
@@ -0,0 +1,182 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format, so the service
+// can be monitored without depending on a Prometheus server or client library.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TextContentType is the media type of the Prometheus text format, in which a Registry writes its metrics.
+const TextContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// The types of the metrics, as they are declared in the Prometheus text format.
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+)
+
+// namePattern matches the valid names of metrics and labels.
+var namePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// metric is a family of measurements with the same name, one for each set of label values.
+type metric interface {
+	// name gets the name of the metric.
+	name() string
+
+	// write writes the metric's samples, after its HELP and TYPE lines have been written.
+	write(w *bufio.Writer)
+}
+
+// Registry is a set of metrics, which are written together when the service is scraped.
+// It is safe for concurrent use by multiple goroutines.
+type Registry struct {
+	mutex   sync.Mutex
+	metrics map[string]registeredMetric
+}
+
+// registeredMetric is a metric with the HELP and TYPE lines that describe it.
+type registeredMetric struct {
+	help       string
+	metricType string
+	metric     metric
+}
+
+// NewRegistry creates a new instance of a Registry, without any metrics.
+// Returns (Registry, nil).
+func NewRegistry() (*Registry, error) {
+
+	registry := &Registry{
+		metrics: make(map[string]registeredMetric),
+	}
+
+	// All okay
+	return registry, nil
+}
+
+// register adds the metric to the registry.
+// Returns nil on success, otherwise an error when the metric's name or labels are not valid, or the name is already in use.
+func (registry *Registry) register(metricType string, help string, m metric, labels []string) error {
+	if !namePattern.MatchString(m.name()) || strings.HasPrefix(m.name(), "__") {
+		return fmt.Errorf("%q is not a valid metric name", m.name())
+	}
+
+	for _, label := range labels {
+		if !namePattern.MatchString(label) || strings.HasPrefix(label, "__") || label == "le" {
+			return fmt.Errorf("%q is not a valid label name for the metric %s", label, m.name())
+		}
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, ok := registry.metrics[m.name()]; ok {
+		return fmt.Errorf("the metric %s has already been registered", m.name())
+	}
+
+	registry.metrics[m.name()] = registeredMetric{help: help, metricType: metricType, metric: m}
+	return nil
+}
+
+// Write writes all of the metrics in the Prometheus text format, ordered by their names.
+// Returns nil on success, otherwise an error when they cannot be written.
+func (registry *Registry) Write(w io.Writer) error {
+	registry.mutex.Lock()
+	names := make([]string, 0, len(registry.metrics))
+	for name := range registry.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	metrics := make([]registeredMetric, 0, len(names))
+	for _, name := range names {
+		metrics = append(metrics, registry.metrics[name])
+	}
+	registry.mutex.Unlock()
+
+	writer := bufio.NewWriter(w)
+	for _, registered := range metrics {
+		name := registered.metric.name()
+		fmt.Fprintf(writer, "# HELP %s %s\n", name, escapeHelp(registered.help))
+		fmt.Fprintf(writer, "# TYPE %s %s\n", name, registered.metricType)
+		registered.metric.write(writer)
+	}
+
+	return writer.Flush()
+}
+
+// series is the values of a metric's labels, which identify one of its measurements.
+type series struct {
+	labels []string
+	values []string
+}
+
+// newSeries creates the series with the label values.  Since a metric is always used with the same labels, a
+// mismatch is a programming error.
+// Panics when the number of values is not the same as the number of labels.
+func newSeries(name string, labels []string, values []string) series {
+	if len(values) != len(labels) {
+		panic(fmt.Sprintf("the metric %s has %d labels, but %d values were given", name, len(labels), len(values)))
+	}
+
+	return series{labels: labels, values: values}
+}
+
+// key identifies the series among the others of the same metric.
+func (s series) key() string {
+	return strings.Join(s.values, "\xff")
+}
+
+// format writes the labels of the series, and the extra label, in the Prometheus text format.
+func (s series) format(extraLabel string, extraValue string) string {
+	pairs := make([]string, 0, len(s.labels)+1)
+	for i, label := range s.labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", label, escapeLabelValue(s.values[i])))
+	}
+	if extraLabel != "" {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extraLabel, escapeLabelValue(extraValue)))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// sortedKeys gets the keys of the series in order, so a metric is always written in the same order.
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+// formatValue formats a sample's value as it is written in the Prometheus text format.
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// escapeHelp escapes the backslashes and line feeds in the help text of a metric.
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// escapeLabelValue escapes the backslashes, double quotes and line feeds in the value of a label.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
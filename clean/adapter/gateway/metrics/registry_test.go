@@ -0,0 +1,88 @@
+// Package metrics implements unit tests for the Registry.
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRegistry_Write verifies that each kind of metric is written in the Prometheus text format, ordered by name.
+func TestRegistry_Write(t *testing.T) {
+
+	// ARRANGE
+	registry, _ := NewRegistry()
+	counter, _ := registry.NewCounter("test_requests_total", "The number of requests.", "route", "status")
+	gauge, _ := registry.NewGauge("test_in_flight", "The number of requests\nin flight.")
+	registry.NewGaugeFunc("test_motorcycles", "The number of motorcycles.", func() float64 { return 3 })
+	histogram, _ := registry.NewHistogram("test_duration_seconds", "The duration of the requests.", []float64{0.1, 1}, "route")
+	var buffer bytes.Buffer
+
+	// ACT
+	counter.Inc("/api/motorcycles", "200")
+	counter.Add(2, "/api/motorcycles", "200")
+	counter.Inc(`/a"b\c`, "404")
+	gauge.Inc()
+	gauge.Inc()
+	gauge.Dec()
+	histogram.Observe(0.1, "/api/motorcycles")
+	histogram.Observe(0.5, "/api/motorcycles")
+	histogram.Observe(2, "/api/motorcycles")
+	err := registry.Write(&buffer)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Equal(t, `# HELP test_duration_seconds The duration of the requests.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{route="/api/motorcycles",le="0.1"} 1
+test_duration_seconds_bucket{route="/api/motorcycles",le="1"} 2
+test_duration_seconds_bucket{route="/api/motorcycles",le="+Inf"} 3
+test_duration_seconds_sum{route="/api/motorcycles"} 2.6
+test_duration_seconds_count{route="/api/motorcycles"} 3
+# HELP test_in_flight The number of requests\nin flight.
+# TYPE test_in_flight gauge
+test_in_flight 1
+# HELP test_motorcycles The number of motorcycles.
+# TYPE test_motorcycles gauge
+test_motorcycles 3
+# HELP test_requests_total The number of requests.
+# TYPE test_requests_total counter
+test_requests_total{route="/a\"b\\c",status="404"} 1
+test_requests_total{route="/api/motorcycles",status="200"} 3
+`, buffer.String())
+}
+
+// TestRegistry_Register verifies that a metric with a name that is in use, or that is not valid, cannot be registered.
+func TestRegistry_Register(t *testing.T) {
+
+	// ARRANGE
+	registry, _ := NewRegistry()
+	registry.NewCounter("test_requests_total", "The number of requests.")
+
+	// ACT
+	_, duplicate := registry.NewGauge("test_requests_total", "The number of requests.")
+	_, name := registry.NewCounter("test-requests", "The number of requests.")
+	_, label := registry.NewCounter("test_errors_total", "The number of errors.", "le")
+	_, buckets := registry.NewHistogram("test_duration_seconds", "The duration.", []float64{1, 0.1})
+
+	// ASSERT
+	assert.NotNil(t, duplicate)
+	assert.NotNil(t, name)
+	assert.NotNil(t, label)
+	assert.NotNil(t, buckets)
+}
+
+// TestCounter_Inc_LabelMismatch verifies that a counter cannot be incremented without a value for each of its labels.
+func TestCounter_Inc_LabelMismatch(t *testing.T) {
+
+	// ARRANGE
+	registry, _ := NewRegistry()
+	counter, _ := registry.NewCounter("test_requests_total", "The number of requests.", "route", "status")
+
+	// ACT
+	increment := func() { counter.Inc("/api/motorcycles") }
+
+	// ASSERT
+	assert.Panics(t, increment)
+}
@@ -0,0 +1,128 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format.
+package metrics
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ReminderRepository measures the operations of a reminder repository, which it performs.
+type ReminderRepository struct {
+	Repository contract.ReminderRepository
+	Metrics    *RepositoryMetrics
+}
+
+// NewReminderRepository creates a new instance of a ReminderRepository, which measures the operations of the repository.
+// Returns (nil, error) when there is an error, otherwise (ReminderRepository, nil).
+func NewReminderRepository(repository contract.ReminderRepository, metrics *RepositoryMetrics) (*ReminderRepository, error) {
+
+	repo := &ReminderRepository{
+		Repository: repository,
+		Metrics:    metrics,
+	}
+
+	// Validate the repository
+	err := repo.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return repo, nil
+}
+
+// Validate verifies that a ReminderRepository's fields contain valid data.
+// Returns nil if the ReminderRepository contains valid data, otherwise an error.
+func (repo ReminderRepository) Validate() error {
+	return validation.ValidateStruct(&repo,
+		// Repository is required and cannot be null.
+		validation.Field(&repo.Repository, validation.Required),
+		// Metrics is required and cannot be null.
+		validation.Field(&repo.Metrics, validation.Required))
+}
+
+// ListByMotorcycle performs contract.ReminderRepository.ListByMotorcycle() with the repository, and measures it.
+func (repo *ReminderRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.Reminder, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	reminders, status, err := repo.Repository.ListByMotorcycle(motorcycleID)
+	repo.Metrics.observe("reminder", "listByMotorcycle", started, status, err)
+	return reminders, status, err
+}
+
+// FindByID performs contract.ReminderRepository.FindByID() with the repository, and measures it.
+func (repo *ReminderRepository) FindByID(id typedef.ID) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	reminder, status, err := repo.Repository.FindByID(id)
+	repo.Metrics.observe("reminder", "findByID", started, status, err)
+	return reminder, status, err
+}
+
+// FindByDue performs contract.ReminderRepository.FindByDue() with the repository, and measures it.
+func (repo *ReminderRepository) FindByDue(motorcycleID typedef.ID, name string, dueUtc time.Time) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	reminder, status, err := repo.Repository.FindByDue(motorcycleID, name, dueUtc)
+	repo.Metrics.observe("reminder", "findByDue", started, status, err)
+	return reminder, status, err
+}
+
+// Insert performs contract.ReminderRepository.Insert() with the repository, and measures it.
+func (repo *ReminderRepository) Insert(reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	inserted, status, err := repo.Repository.Insert(reminder)
+	repo.Metrics.observe("reminder", "insert", started, status, err)
+	return inserted, status, err
+}
+
+// Update performs contract.ReminderRepository.Update() with the repository, and measures it.
+func (repo *ReminderRepository) Update(id typedef.ID, reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	updated, status, err := repo.Repository.Update(id, reminder)
+	repo.Metrics.observe("reminder", "update", started, status, err)
+	return updated, status, err
+}
+
+// DeleteByMotorcycle performs contract.ReminderRepository.DeleteByMotorcycle() with the repository, and measures it.
+func (repo *ReminderRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.DeleteByMotorcycle(motorcycleID)
+	repo.Metrics.observe("reminder", "deleteByMotorcycle", started, status, err)
+	return status, err
+}
+
+// Save performs contract.ReminderRepository.Save() with the repository, and measures it.
+func (repo *ReminderRepository) Save() (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.Save()
+	repo.Metrics.observe("reminder", "save", started, status, err)
+	return status, err
+}
+
+// Session creates a repository that measures a session of the repository, when it gives each caller a session of its
+// own, otherwise it gets this repository, which the callers share.
+func (repo *ReminderRepository) Session() contract.ReminderRepository {
+	sessions, ok := repo.Repository.(contract.ReminderRepositorySessions)
+	if !ok {
+		return repo
+	}
+
+	return &ReminderRepository{
+		Repository: sessions.Session(),
+		Metrics:    repo.Metrics,
+	}
+}
+
+// Rollback discards the changes that have not been saved, when the repository keeps them in a unit of work.
+// Returns nil on success, otherwise an error.
+func (repo *ReminderRepository) Rollback() error {
+	unitOfWork, ok := repo.Repository.(contract.UnitOfWork)
+	if !ok {
+		return nil
+	}
+
+	return unitOfWork.Rollback()
+}
@@ -0,0 +1,52 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format.
+package metrics
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+)
+
+// DefaultRepositoryBuckets are the upper bounds, in seconds, of the buckets of a histogram of repository operations,
+// which are usually much faster than the requests that perform them.
+var DefaultRepositoryBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+// RepositoryMetrics measures how long the operations of the repositories take, and how many of them fail.
+type RepositoryMetrics struct {
+	// Duration is the duration of each operation, in seconds, by repository and operation.
+	Duration *Histogram
+	// Errors is the number of failed operations, by repository, operation and operation status.
+	Errors *Counter
+}
+
+// NewRepositoryMetrics creates a new instance of a RepositoryMetrics, and registers its metrics.
+// Returns (RepositoryMetrics, nil) on success, otherwise (nil, error).
+func NewRepositoryMetrics(registry *Registry) (*RepositoryMetrics, error) {
+	duration, err := registry.NewHistogram("motominder_repository_operation_duration_seconds",
+		"The duration of the operations of the repositories, in seconds.", DefaultRepositoryBuckets, "repository", "operation")
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := registry.NewCounter("motominder_repository_operation_errors_total",
+		"The number of operations of the repositories that failed, by operation status.", "repository", "operation", "status")
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryMetrics := &RepositoryMetrics{
+		Duration: duration,
+		Errors:   failures,
+	}
+
+	// All okay
+	return repositoryMetrics, nil
+}
+
+// observe records an operation of a repository that began at the time, and its outcome.
+func (repositoryMetrics *RepositoryMetrics) observe(repository string, operation string, started time.Time, status operationstatus.OperationStatus, err error) {
+	repositoryMetrics.Duration.Observe(time.Since(started).Seconds(), repository, operation)
+	if err != nil {
+		repositoryMetrics.Errors.Inc(repository, operation, status.ToString())
+	}
+}
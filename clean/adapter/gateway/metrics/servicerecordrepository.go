@@ -0,0 +1,128 @@
+// Package metrics measures the service, and exposes the measurements in the Prometheus text format.
+package metrics
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ServiceRecordRepository measures the operations of a service record repository, which it performs.
+type ServiceRecordRepository struct {
+	Repository contract.ServiceRecordRepository
+	Metrics    *RepositoryMetrics
+}
+
+// NewServiceRecordRepository creates a new instance of a ServiceRecordRepository, which measures the operations of the repository.
+// Returns (nil, error) when there is an error, otherwise (ServiceRecordRepository, nil).
+func NewServiceRecordRepository(repository contract.ServiceRecordRepository, metrics *RepositoryMetrics) (*ServiceRecordRepository, error) {
+
+	repo := &ServiceRecordRepository{
+		Repository: repository,
+		Metrics:    metrics,
+	}
+
+	// Validate the repository
+	err := repo.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return repo, nil
+}
+
+// Validate verifies that a ServiceRecordRepository's fields contain valid data.
+// Returns nil if the ServiceRecordRepository contains valid data, otherwise an error.
+func (repo ServiceRecordRepository) Validate() error {
+	return validation.ValidateStruct(&repo,
+		// Repository is required and cannot be null.
+		validation.Field(&repo.Repository, validation.Required),
+		// Metrics is required and cannot be null.
+		validation.Field(&repo.Metrics, validation.Required))
+}
+
+// ListByMotorcycle performs contract.ServiceRecordRepository.ListByMotorcycle() with the repository, and measures it.
+func (repo *ServiceRecordRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	records, status, err := repo.Repository.ListByMotorcycle(motorcycleID)
+	repo.Metrics.observe("serviceRecord", "listByMotorcycle", started, status, err)
+	return records, status, err
+}
+
+// FindByID performs contract.ServiceRecordRepository.FindByID() with the repository, and measures it.
+func (repo *ServiceRecordRepository) FindByID(id typedef.ID) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	record, status, err := repo.Repository.FindByID(id)
+	repo.Metrics.observe("serviceRecord", "findByID", started, status, err)
+	return record, status, err
+}
+
+// Insert performs contract.ServiceRecordRepository.Insert() with the repository, and measures it.
+func (repo *ServiceRecordRepository) Insert(record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	inserted, status, err := repo.Repository.Insert(record)
+	repo.Metrics.observe("serviceRecord", "insert", started, status, err)
+	return inserted, status, err
+}
+
+// Update performs contract.ServiceRecordRepository.Update() with the repository, and measures it.
+func (repo *ServiceRecordRepository) Update(id typedef.ID, record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	started := time.Now()
+	updated, status, err := repo.Repository.Update(id, record)
+	repo.Metrics.observe("serviceRecord", "update", started, status, err)
+	return updated, status, err
+}
+
+// Delete performs contract.ServiceRecordRepository.Delete() with the repository, and measures it.
+func (repo *ServiceRecordRepository) Delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.Delete(id, rowVersion)
+	repo.Metrics.observe("serviceRecord", "delete", started, status, err)
+	return status, err
+}
+
+// DeleteByMotorcycle performs contract.ServiceRecordRepository.DeleteByMotorcycle() with the repository, and measures it.
+func (repo *ServiceRecordRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.DeleteByMotorcycle(motorcycleID)
+	repo.Metrics.observe("serviceRecord", "deleteByMotorcycle", started, status, err)
+	return status, err
+}
+
+// Save performs contract.ServiceRecordRepository.Save() with the repository, and measures it.
+func (repo *ServiceRecordRepository) Save() (operationstatus.OperationStatus, error) {
+	started := time.Now()
+	status, err := repo.Repository.Save()
+	repo.Metrics.observe("serviceRecord", "save", started, status, err)
+	return status, err
+}
+
+// Session creates a repository that measures a session of the repository, when it gives each caller a session of its
+// own, otherwise it gets this repository, which the callers share.
+func (repo *ServiceRecordRepository) Session() contract.ServiceRecordRepository {
+	sessions, ok := repo.Repository.(contract.ServiceRecordRepositorySessions)
+	if !ok {
+		return repo
+	}
+
+	return &ServiceRecordRepository{
+		Repository: sessions.Session(),
+		Metrics:    repo.Metrics,
+	}
+}
+
+// Rollback discards the changes that have not been saved, when the repository keeps them in a unit of work.
+// Returns nil on success, otherwise an error.
+func (repo *ServiceRecordRepository) Rollback() error {
+	unitOfWork, ok := repo.Repository.(contract.UnitOfWork)
+	if !ok {
+		return nil
+	}
+
+	return unitOfWork.Rollback()
+}
@@ -0,0 +1,37 @@
+// Package notifier contains implementations of contract.Notifier, which deliver reminders to the owners of motorcycles.
+package notifier
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	log "github.com/sirupsen/logrus"
+)
+
+// LogNotifier writes reminders to the log, rather than delivering them to the owners.  It is useful during development,
+// and when another process follows the log.
+type LogNotifier struct {
+}
+
+// NewLogNotifier creates a new instance of a LogNotifier.
+// Returns (LogNotifier, nil).
+func NewLogNotifier() (*LogNotifier, error) {
+
+	notifier := &LogNotifier{}
+
+	// All okay
+	return notifier, nil
+}
+
+// Notify writes the reminder to the log.
+// Returns nil.
+func (notifier *LogNotifier) Notify(reminder entity.Reminder, motorcycle entity.Motorcycle) error {
+	subject, _ := reminderMessage(reminder, motorcycle)
+
+	log.WithFields(log.Fields{
+		"reminderId":   reminder.ID,
+		"motorcycleId": motorcycle.ID,
+		"ownerId":      motorcycle.OwnerID,
+		"dueUtc":       reminder.DueUtc,
+	}).Info(subject)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+// Package notifier contains implementations of contract.Notifier, which deliver reminders to the owners of motorcycles.
+package notifier
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+)
+
+// dueDateLayout is the layout of a reminder's due date in a message.
+const dueDateLayout = "Monday, January 2, 2006"
+
+// reminderMessage composes the text that tells the owner of the motorcycle about the reminder.
+// The subject is a single line, since it may become a header of an email.
+// Returns the (subject, body).
+func reminderMessage(reminder entity.Reminder, motorcycle entity.Motorcycle) (string, string) {
+	subject := singleLine(fmt.Sprintf("Reminder: %s for your %d %s %s", reminder.Name, motorcycle.Year, motorcycle.Make, motorcycle.Model))
+	body := fmt.Sprintf("%s is due on %s for your %d %s %s (VIN %s).", reminder.Name, reminder.DueUtc.Format(dueDateLayout),
+		motorcycle.Year, motorcycle.Make, motorcycle.Model, motorcycle.Vin)
+
+	return subject, body
+}
+
+// singleLine replaces the line breaks and other control characters in the text with spaces, so text provided by users,
+// such as the name of a reminder or the model of a motorcycle, cannot add lines to a message.
+// Returns the text on a single line.
+func singleLine(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,105 @@
+// Package notifier contains implementations of contract.Notifier, which deliver reminders to the owners of motorcycles.
+package notifier
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// SmtpNotifier emails each reminder through an SMTP server, such as a local relay or a stand-in server for development.
+// The email is sent to the owner when their user ID is an email address, and to each of the configured addresses.
+// It does not authenticate with the server, so the server must accept mail from the API web service's host.
+// It is safe for concurrent use by multiple goroutines.
+type SmtpNotifier struct {
+	// Addr is the host and port of the SMTP server, such as "localhost:1025".
+	Addr string
+	From string
+	To   []string
+}
+
+// NewSmtpNotifier creates a new instance of a SmtpNotifier.
+// Returns (nil, error) when there is an error, otherwise (SmtpNotifier, nil).
+func NewSmtpNotifier(addr string, from string, to []string) (*SmtpNotifier, error) {
+
+	notifier := &SmtpNotifier{
+		Addr: addr,
+		From: from,
+		To:   to,
+	}
+
+	err := notifier.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return notifier, nil
+}
+
+// Validate verifies that a SmtpNotifier's fields contain valid data.
+// Returns nil if the SmtpNotifier contains valid data, otherwise an error.
+func (notifier SmtpNotifier) Validate() error {
+	err := validation.ValidateStruct(&notifier,
+		// Addr is required.
+		validation.Field(&notifier.Addr, validation.Required),
+		// From is required.
+		validation.Field(&notifier.From, validation.Required))
+	if err != nil {
+		return err
+	}
+
+	_, _, err = net.SplitHostPort(notifier.Addr)
+	if err != nil {
+		return fmt.Errorf("the SMTP server address %q must be a host and port: %s", notifier.Addr, err.Error())
+	}
+
+	for _, address := range append([]string{notifier.From}, notifier.To...) {
+		if !isEmailAddress(address) {
+			return fmt.Errorf("%q is not an email address", address)
+		}
+	}
+
+	return nil
+}
+
+// Notify emails the reminder to the owner and the configured addresses.
+// Returns nil when the SMTP server accepts the email, otherwise an error.
+func (notifier *SmtpNotifier) Notify(reminder entity.Reminder, motorcycle entity.Motorcycle) error {
+	recipients := append([]string{}, notifier.To...)
+	if isEmailAddress(motorcycle.OwnerID) {
+		recipients = append(recipients, motorcycle.OwnerID)
+	}
+
+	if len(recipients) == 0 {
+		return errors.New("there isn't anyone to email the reminder to, because the owner's user ID is not an email address")
+	}
+
+	subject, body := reminderMessage(reminder, motorcycle)
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", notifier.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(recipients, ", "))
+	// The subject is encoded when it is not plain ASCII, such as the make of a motorcycle with an accent.
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&message, "\r\n%s\r\n", body)
+
+	return smtp.SendMail(notifier.Addr, nil, notifier.From, recipients, message.Bytes())
+}
+
+// isEmailAddress determines whether the text looks like an email address, which is enough to choose recipients.
+// Returns true if it has a local part and a domain, otherwise false.
+func isEmailAddress(text string) bool {
+	at := strings.LastIndex(text, "@")
+	return at > 0 && at < len(text)-1 && !strings.ContainsAny(text, " \r\n<>,")
+}
@@ -0,0 +1,134 @@
+// Package notifier implements unit tests for the SmtpNotifier.
+package notifier
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startStandInSmtpServer starts a minimal SMTP server, which accepts one email and sends what it received on the channel.
+// Returns the (address of the server, channel of received transcripts).
+func startStandInSmtpServer(t *testing.T) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received := make(chan string, 1)
+	go func() {
+		defer listener.Close()
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		var transcript strings.Builder
+		reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+
+		reply("220 localhost stand-in")
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			transcript.WriteString(line)
+
+			switch {
+			case inData && line == ".\r\n":
+				inData = false
+				reply("250 OK")
+			case inData:
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(line, "DATA"):
+				inData = true
+				reply("354 Go ahead")
+			case strings.HasPrefix(line, "QUIT"):
+				reply("221 Bye")
+				received <- transcript.String()
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+		received <- transcript.String()
+	}()
+
+	return listener.Addr().String(), received
+}
+
+// TestSmtpNotifier_FromIsNotAnEmailAddress verifies that the sender must be an email address.
+func TestSmtpNotifier_FromIsNotAnEmailAddress(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewSmtpNotifier("localhost:1025", "motominder", nil)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestSmtpNotifier_Notify verifies that the reminder is emailed to the owner and the configured addresses.
+func TestSmtpNotifier_Notify(t *testing.T) {
+
+	// ARRANGE
+	addr, received := startStandInSmtpServer(t)
+	notifier, _ := NewSmtpNotifier(addr, "reminders@motominder.test", []string{"garage@example.com"})
+	reminder, motorcycle := newTestReminder()
+
+	// ACT
+	err := notifier.Notify(reminder, motorcycle)
+	transcript := <-received
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(transcript, "RCPT TO:<garage@example.com>"))
+	assert.True(t, strings.Contains(transcript, "RCPT TO:<alice@example.com>"))
+	assert.True(t, strings.Contains(transcript, "Subject: Reminder: Registration renewal for your 2006 Honda Shadow"))
+	assert.True(t, strings.Contains(transcript, "Saturday, March 31, 2018"))
+}
+
+// TestSmtpNotifier_Notify_LineBreakInSubject verifies that a line break in the text of the subject cannot add a header.
+func TestSmtpNotifier_Notify_LineBreakInSubject(t *testing.T) {
+
+	// ARRANGE
+	addr, received := startStandInSmtpServer(t)
+	notifier, _ := NewSmtpNotifier(addr, "reminders@motominder.test", nil)
+	reminder, motorcycle := newTestReminder()
+	motorcycle.Model = "Shadow\r\nBcc: mallory@example.com"
+
+	// ACT
+	err := notifier.Notify(reminder, motorcycle)
+	transcript := <-received
+	headers := strings.SplitN(transcript, "\r\n\r\n", 2)[0]
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(headers, "\r\nBcc:"))
+	assert.True(t, strings.Contains(headers, "Subject: Reminder: Registration renewal for your 2006 Honda Shadow  Bcc: mallory@example.com\r\n"))
+}
+
+// TestSmtpNotifier_NoRecipients verifies that a reminder cannot be emailed when nobody has an email address.
+func TestSmtpNotifier_NoRecipients(t *testing.T) {
+
+	// ARRANGE
+	notifier, _ := NewSmtpNotifier("localhost:1025", "reminders@motominder.test", nil)
+	reminder, motorcycle := newTestReminder()
+	motorcycle.OwnerID = "alice"
+
+	// ACT
+	err := notifier.Notify(reminder, motorcycle)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,105 @@
+// Package notifier contains implementations of contract.Notifier, which deliver reminders to the owners of motorcycles.
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// webhookTimeout is how long a webhook has to accept a reminder, before the attempt fails.
+const webhookTimeout = 10 * time.Second
+
+// webhookPayload is the JSON document that is posted to a webhook for each reminder.
+type webhookPayload struct {
+	ReminderID   typedef.ID `json:"reminderId"`
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	OwnerID      string     `json:"ownerId"`
+	Name         string     `json:"name"`
+	DueUtc       time.Time  `json:"dueUtc"`
+	Subject      string     `json:"subject"`
+	Message      string     `json:"message"`
+}
+
+// WebhookNotifier posts each reminder as JSON to a URL, which is expected to deliver it to the owner.
+// It is safe for concurrent use by multiple goroutines.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookNotifier creates a new instance of a WebhookNotifier, which posts reminders to the HTTP or HTTPS URL.
+// Returns (nil, error) when there is an error, otherwise (WebhookNotifier, nil).
+func NewWebhookNotifier(webhookURL string) (*WebhookNotifier, error) {
+
+	notifier := &WebhookNotifier{
+		URL:    webhookURL,
+		Client: &http.Client{Timeout: webhookTimeout},
+	}
+
+	err := notifier.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return notifier, nil
+}
+
+// Validate verifies that a WebhookNotifier's fields contain valid data.
+// Returns nil if the WebhookNotifier contains valid data, otherwise an error.
+func (notifier WebhookNotifier) Validate() error {
+	err := validation.ValidateStruct(&notifier,
+		// URL is required.
+		validation.Field(&notifier.URL, validation.Required),
+		// Client is required and cannot be null.
+		validation.Field(&notifier.Client, validation.Required))
+	if err != nil {
+		return err
+	}
+
+	parsed, err := url.Parse(notifier.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("the webhook URL %q must be an absolute HTTP or HTTPS URL", notifier.URL)
+	}
+
+	return nil
+}
+
+// Notify posts the reminder to the webhook.
+// Returns nil when the webhook accepts the reminder with a 2xx status, otherwise an error.
+func (notifier *WebhookNotifier) Notify(reminder entity.Reminder, motorcycle entity.Motorcycle) error {
+	subject, message := reminderMessage(reminder, motorcycle)
+
+	payload, err := json.Marshal(webhookPayload{
+		ReminderID:   reminder.ID,
+		MotorcycleID: motorcycle.ID,
+		OwnerID:      motorcycle.OwnerID,
+		Name:         reminder.Name,
+		DueUtc:       reminder.DueUtc,
+		Subject:      subject,
+		Message:      message,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := notifier.Client.Post(notifier.URL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("the webhook rejected the reminder with ID %d with the status %s", reminder.ID, resp.Status)
+	}
+
+	return nil
+}
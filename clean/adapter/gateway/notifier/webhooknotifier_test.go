@@ -0,0 +1,78 @@
+// Package notifier implements unit tests for the WebhookNotifier.
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestReminder creates a reminder for a motorcycle owned by alice.
+// Returns the (reminder, motorcycle).
+func newTestReminder() (entity.Reminder, entity.Motorcycle) {
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	motorcycle.ID = 7
+	motorcycle.OwnerID = "alice@example.com"
+	reminder, _ := entity.NewReminder(motorcycle.ID, "Registration renewal", time.Date(2018, 3, 31, 0, 0, 0, 0, time.UTC))
+	reminder.ID = 3
+
+	return *reminder, *motorcycle
+}
+
+// TestWebhookNotifier_URLIsRelative verifies that a webhook requires an absolute URL.
+func TestWebhookNotifier_URLIsRelative(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewWebhookNotifier("/hooks/reminders")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestWebhookNotifier_Notify verifies that the reminder is posted to the webhook as JSON.
+func TestWebhookNotifier_Notify(t *testing.T) {
+
+	// ARRANGE
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	notifier, _ := NewWebhookNotifier(server.URL)
+	reminder, motorcycle := newTestReminder()
+
+	// ACT
+	err := notifier.Notify(reminder, motorcycle)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, payload.ReminderID == 3)
+	assert.True(t, payload.OwnerID == "alice@example.com")
+	assert.True(t, payload.Subject == "Reminder: Registration renewal for your 2006 Honda Shadow")
+}
+
+// TestWebhookNotifier_Rejected verifies that the reminder has not been delivered when the webhook fails.
+func TestWebhookNotifier_Rejected(t *testing.T) {
+
+	// ARRANGE
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	notifier, _ := NewWebhookNotifier(server.URL)
+	reminder, motorcycle := newTestReminder()
+
+	// ACT
+	err := notifier.Notify(reminder, motorcycle)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,258 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ApiKeyRepository provides operations against a collection of API keys.
+// It is safe for concurrent use by multiple goroutines.  API keys returned by the repository
+// are copies, so they are not affected by subsequent changes to the repository.
+// API keys are never deleted, so a revoked key remains as a record of its use.
+type ApiKeyRepository struct {
+	// NextID is the next primary key ID value for an API key being inserted into the repository.
+	NextID typedef.ID `json:"nextId"`
+
+	// These items are ordered by their ID.
+	ApiKeys []entity.ApiKey `json:"apiKeys"`
+
+	// hashIndex maps the hash of each API key to its index in ApiKeys.
+	hashIndex map[string]int
+
+	// mutex guards NextID, ApiKeys and the index.
+	mutex sync.RWMutex
+}
+
+// NewApiKeyRepository creates a new instance of an ApiKeyRepository.
+// Returns (nil, error) when there is an error, otherwise an (ApiKeyRepository, nil).
+func NewApiKeyRepository() (*ApiKeyRepository, error) {
+	apiKeyRepository := &ApiKeyRepository{
+		NextID: 0,
+
+		// Ensure that we create an empty slice rather than the default for []entity.ApiKey, which is a null pointer.
+		ApiKeys: make([]entity.ApiKey, 0),
+
+		hashIndex: make(map[string]int),
+	}
+
+	err := apiKeyRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return apiKeyRepository, nil
+}
+
+// Validate test that an API key repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *ApiKeyRepository) Validate() error {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return validation.ValidateStruct(repo,
+		// ApiKeys can be empty, but not nil
+		validation.Field(&repo.ApiKeys, validation.NotNil))
+}
+
+// List gets a snapshot of the list of API keys in the repository.
+// Returns the (list of API keys, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *ApiKeyRepository) List() ([]entity.ApiKey, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	apiKeys := make([]entity.ApiKey, len(repo.ApiKeys))
+	for i := range repo.ApiKeys {
+		apiKeys[i] = copyApiKey(repo.ApiKeys[i])
+	}
+
+	return apiKeys, operationstatus.Ok, nil
+}
+
+// FindByID an API key in the repository using its primary key, ID.
+// Returns (API key, Ok, nil) on found, (nil, NotFound, nil) for not found.
+func (repo *ApiKeyRepository) FindByID(id typedef.ID) (*entity.ApiKey, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	apiKey := copyApiKey(repo.ApiKeys[i])
+	return &apiKey, operationstatus.Ok, nil
+}
+
+// FindByHash an API key in the repository using the hash of the key.
+// Returns (API key, Found, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *ApiKeyRepository) FindByHash(hash string) (*entity.ApiKey, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if repo.hashIndex == nil {
+		return nil, operationstatus.InternalError, errors.New("the hash index is nil, so create the repository with NewApiKeyRepository()")
+	}
+
+	i, found := repo.hashIndex[hash]
+	if !found {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	apiKey := copyApiKey(repo.ApiKeys[i])
+	return &apiKey, operationstatus.Found, nil
+}
+
+// Insert adds an API key to the repository.
+// Does not permit duplicate hashes.
+// Returns the (new API key, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *ApiKeyRepository) Insert(apiKey *entity.ApiKey) (*entity.ApiKey, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.hashIndex == nil {
+		return nil, operationstatus.InternalError, errors.New("the hash index is nil, so create the repository with NewApiKeyRepository()")
+	}
+
+	if _, found := repo.hashIndex[apiKey.Hash]; found {
+		return nil, operationstatus.Found, fmt.Errorf("cannot insert the API key named %s because its hash already exists in the repository", apiKey.Name)
+	}
+
+	// Work on a copy, so the repository is unchanged if the API key is invalid.
+	newKey := copyApiKey(*apiKey)
+	newKey.ID = repo.NextID + 1
+	newKey.CreatedUtc = time.Now().UTC()
+
+	err := newKey.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	repo.NextID = newKey.ID
+	repo.ApiKeys = append(repo.ApiKeys, newKey)
+	repo.hashIndex[newKey.Hash] = len(repo.ApiKeys) - 1
+
+	inserted := copyApiKey(newKey)
+	return &inserted, operationstatus.Ok, nil
+}
+
+// Update replaces the mutable fields of an existing API key, which are its name, roles and timestamps.
+// The hash of a key cannot change, so a new key is inserted instead.  A revocation cannot be undone, an expiry cannot
+// be postponed, and the last use cannot be moved back, so an update with a stale copy of the key does not undo them.
+// Returns (updated API key, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *ApiKeyRepository) Update(id typedef.ID, apiKey *entity.ApiKey) (*entity.ApiKey, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return nil, operationstatus.NotFound, fmt.Errorf("cannot update the API key with ID %d because it doesn't exist in the repository", id)
+	}
+
+	updated := copyApiKey(repo.ApiKeys[i])
+	updated.Name = apiKey.Name
+	updated.Roles = append([]authorizationrole.AuthorizationRole{}, apiKey.Roles...)
+	updated.ExpiresUtc = earliestUtc(updated.ExpiresUtc, apiKey.ExpiresUtc)
+	updated.RevokedUtc = earliestUtc(updated.RevokedUtc, apiKey.RevokedUtc)
+	if apiKey.LastUsedUtc.After(updated.LastUsedUtc) {
+		updated.LastUsedUtc = apiKey.LastUsedUtc
+	}
+
+	err := updated.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	repo.ApiKeys[i] = updated
+
+	result := copyApiKey(updated)
+	return &result, operationstatus.Ok, nil
+}
+
+// Save all of the changes to the repository (assuming some kind of unit of work/dbContext).
+// Returns nil on success, otherwise an error.
+func (repo *ApiKeyRepository) Save() (operationstatus.OperationStatus, error) {
+	return operationstatus.Ok, nil
+}
+
+// earliestUtc gets the earlier of two times at which something happens, where a zero time means that it never does.
+func earliestUtc(current time.Time, proposed time.Time) time.Time {
+	if current.IsZero() || (!proposed.IsZero() && proposed.Before(current)) {
+		return proposed
+	}
+
+	return current
+}
+
+// findByID finds the index of the API key with the ID.
+// The API keys are ordered by their ID, so this is a binary search.
+// The caller must hold a lock.
+// Returns the index on found, otherwise -1.
+func (repo *ApiKeyRepository) findByID(id typedef.ID) int {
+	low, high := 0, len(repo.ApiKeys)-1
+	for low <= high {
+		middle := (low + high) / 2
+		switch {
+		case repo.ApiKeys[middle].ID == id:
+			return middle
+		case repo.ApiKeys[middle].ID < id:
+			low = middle + 1
+		default:
+			high = middle - 1
+		}
+	}
+
+	return -1
+}
+
+// reindex rebuilds the hash index from the list of API keys.
+// The caller must hold the write lock, or have exclusive access to the repository.
+// Returns nil on success, otherwise an error when the keys are out of order, or a hash is duplicated.
+func (repo *ApiKeyRepository) reindex() error {
+	hashIndex := make(map[string]int, len(repo.ApiKeys))
+
+	for i, apiKey := range repo.ApiKeys {
+		if i > 0 && apiKey.ID <= repo.ApiKeys[i-1].ID {
+			return fmt.Errorf("the API key ID %d is duplicated or out of order", apiKey.ID)
+		}
+
+		if _, exists := hashIndex[apiKey.Hash]; exists {
+			return fmt.Errorf("the hash of the API key with ID %d is duplicated", apiKey.ID)
+		}
+		hashIndex[apiKey.Hash] = i
+	}
+
+	repo.hashIndex = hashIndex
+
+	return nil
+}
+
+// snapshot gets a consistent copy of the repository's state.
+// Returns the (next ID, list of API keys).
+func (repo *ApiKeyRepository) snapshot() (typedef.ID, []entity.ApiKey) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	apiKeys := make([]entity.ApiKey, len(repo.ApiKeys))
+	for i := range repo.ApiKeys {
+		apiKeys[i] = copyApiKey(repo.ApiKeys[i])
+	}
+
+	return repo.NextID, apiKeys
+}
+
+// copyApiKey copies an API key, including its list of roles, so the copy does not share memory with the original.
+// Returns the copy.
+func copyApiKey(apiKey entity.ApiKey) entity.ApiKey {
+	apiKey.Roles = append([]authorizationrole.AuthorizationRole{}, apiKey.Roles...)
+	return apiKey
+}
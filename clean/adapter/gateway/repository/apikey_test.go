@@ -0,0 +1,235 @@
+// Package repository implements unit tests for the ApiKeyRepository.
+package repository
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestApiKey creates an API key whose hash is the character repeated.
+func newTestApiKey(name string, hashCharacter string) *entity.ApiKey {
+	apiKey, _ := entity.NewApiKey(name, "rider", strings.Repeat(hashCharacter, 64),
+		[]authorizationrole.AuthorizationRole{authorizationrole.GeneralAuthorizationRole}, time.Time{})
+	return apiKey
+}
+
+// TestApiKeyRepository_InsertAndFind verifies that an inserted API key can be found by its ID and hash.
+func TestApiKeyRepository_InsertAndFind(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewApiKeyRepository()
+
+	// ACT
+	apiKey, status, err := repo.Insert(newTestApiKey("Backup", "a"))
+	byID, _, _ := repo.FindByID(apiKey.ID)
+	byHash, hashStatus, _ := repo.FindByHash(apiKey.Hash)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, apiKey.ID == 1)
+	assert.False(t, apiKey.CreatedUtc.IsZero())
+	assert.True(t, byID.Name == "Backup")
+	assert.True(t, hashStatus == operationstatus.Found)
+	assert.True(t, byHash.ID == apiKey.ID)
+}
+
+// TestApiKeyRepository_FindByHash_NotFound verifies that an unknown hash is not found.
+func TestApiKeyRepository_FindByHash_NotFound(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewApiKeyRepository()
+	repo.Insert(newTestApiKey("Backup", "a"))
+
+	// ACT
+	apiKey, status, err := repo.FindByHash(strings.Repeat("b", 64))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Nil(t, apiKey)
+	assert.True(t, status == operationstatus.NotFound)
+}
+
+// TestApiKeyRepository_Insert_HashAlreadyExists verifies that a duplicate hash is rejected.
+func TestApiKeyRepository_Insert_HashAlreadyExists(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewApiKeyRepository()
+	repo.Insert(newTestApiKey("Backup", "a"))
+
+	// ACT
+	_, status, err := repo.Insert(newTestApiKey("Reports", "a"))
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Found)
+}
+
+// TestApiKeyRepository_Update verifies that an update changes the timestamps, but not the hash.
+func TestApiKeyRepository_Update(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewApiKeyRepository()
+	apiKey, _, _ := repo.Insert(newTestApiKey("Backup", "a"))
+	hash := apiKey.Hash
+	apiKey.RevokedUtc = time.Now().UTC()
+	apiKey.Hash = strings.Repeat("b", 64)
+
+	// ACT
+	_, status, err := repo.Update(apiKey.ID, apiKey)
+	found, _, _ := repo.FindByID(apiKey.ID)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.False(t, found.RevokedUtc.IsZero())
+	assert.True(t, found.Hash == hash)
+}
+
+// TestApiKeyRepository_Update_StaleCopy verifies that an update with a stale copy of a key does not undo its revocation
+// or postpone its expiry.
+func TestApiKeyRepository_Update_StaleCopy(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewApiKeyRepository()
+	apiKey, _, _ := repo.Insert(newTestApiKey("Backup", "a"))
+	stale := *apiKey
+	apiKey.RevokedUtc = time.Now().UTC()
+	apiKey.ExpiresUtc = time.Now().UTC().Add(time.Hour)
+	repo.Update(apiKey.ID, apiKey)
+	stale.LastUsedUtc = time.Now().UTC()
+
+	// ACT
+	_, status, err := repo.Update(stale.ID, &stale)
+	found, _, _ := repo.FindByID(apiKey.ID)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, found.RevokedUtc.Equal(apiKey.RevokedUtc))
+	assert.True(t, found.ExpiresUtc.Equal(apiKey.ExpiresUtc))
+	assert.True(t, found.LastUsedUtc.Equal(stale.LastUsedUtc))
+}
+
+// TestApiKeyRepository_Update_NotExist verifies that an update fails if the API key does not exist.
+func TestApiKeyRepository_Update_NotExist(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewApiKeyRepository()
+
+	// ACT
+	_, status, err := repo.Update(123, newTestApiKey("Backup", "a"))
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.NotFound)
+}
+
+// TestFileApiKeyRepository_SaveAndReload verifies that saved API keys are loaded by a new repository.
+func TestFileApiKeyRepository_SaveAndReload(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	repo, _ := NewFileApiKeyRepository(path)
+	repo.Insert(newTestApiKey("Backup", "a"))
+	apiKey, _, _ := repo.Insert(newTestApiKey("Reports", "b"))
+
+	// ACT
+	status, err := repo.Save()
+	reloaded, reloadErr := NewFileApiKeyRepository(path)
+	found, _, _ := reloaded.FindByHash(apiKey.Hash)
+	next, _, _ := reloaded.Insert(newTestApiKey("Alerts", "c"))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.Nil(t, reloadErr)
+	assert.True(t, found.Name == "Reports")
+	assert.True(t, next.ID == 3)
+}
+
+// TestFileApiKeyRepository_Corrupt verifies that a repository file that has been tampered with is rejected.
+func TestFileApiKeyRepository_Corrupt(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	repo, _ := NewFileApiKeyRepository(path)
+	repo.Insert(newTestApiKey("Backup", "a"))
+	repo.Save()
+	contents, _ := ioutil.ReadFile(path)
+	ioutil.WriteFile(path, []byte(strings.Replace(string(contents), "Backup", "Attack", 1)), 0600)
+
+	// ACT
+	_, err := NewFileApiKeyRepository(path)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestFileApiKeyRepository_ChangedByAnotherProcess verifies that a key which another process revoked is seen as revoked,
+// and that it is not brought back when this process records the use of its stale copy.
+func TestFileApiKeyRepository_ChangedByAnotherProcess(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	server, _ := NewFileApiKeyRepository(path)
+	apiKey, _, _ := server.Insert(newTestApiKey("Backup", "a"))
+	used, _, _ := server.FindByHash(apiKey.Hash)
+	command, _ := NewFileApiKeyRepository(path)
+	revoked, _, _ := command.FindByID(apiKey.ID)
+	revoked.RevokedUtc = time.Now().UTC()
+	command.Update(revoked.ID, revoked)
+	issued, _, _ := command.Insert(newTestApiKey("Reports", "b"))
+
+	// ACT
+	found, _, findErr := server.FindByHash(apiKey.Hash)
+	used.LastUsedUtc = time.Now().UTC()
+	_, _, updateErr := server.Update(used.ID, used)
+	reloaded, _ := NewFileApiKeyRepository(path)
+	reloadedKey, _, _ := reloaded.FindByID(apiKey.ID)
+	reloadedIssued, _, _ := reloaded.FindByHash(issued.Hash)
+
+	// ASSERT
+	assert.Nil(t, findErr)
+	assert.Nil(t, updateErr)
+	assert.False(t, found.RevokedUtc.IsZero())
+	assert.False(t, reloadedKey.RevokedUtc.IsZero())
+	assert.True(t, reloadedKey.LastUsedUtc.Equal(used.LastUsedUtc))
+	assert.NotNil(t, reloadedIssued)
+}
+
+// TestLockFile verifies that a lock cannot be acquired while another process holds it, unless the lock was abandoned.
+func TestLockFile(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	unlock, err := lockFile(path, time.Second)
+
+	// ACT
+	_, heldErr := lockFile(path, 50*time.Millisecond)
+	abandoned := time.Now().Add(-2 * staleLockAge)
+	os.Chtimes(path+".lock", abandoned, abandoned)
+	unlockStale, staleErr := lockFile(path, 50*time.Millisecond)
+	unlockStale()
+	unlock()
+	unlockAgain, againErr := lockFile(path, 50*time.Millisecond)
+	unlockAgain()
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.NotNil(t, heldErr)
+	assert.Nil(t, staleErr)
+	assert.Nil(t, againErr)
+}
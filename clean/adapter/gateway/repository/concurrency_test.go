@@ -0,0 +1,162 @@
+// Package repository implements concurrency tests for the motorcycle repositories.
+// Run them with the race detector enabled (go test -race) to detect unsynchronized access.
+package repository
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrentWorkers is the number of goroutines sharing a repository.
+const concurrentWorkers = 8
+
+// concurrentOperations is the number of motorcycles inserted by each goroutine.
+const concurrentOperations = 25
+
+// testVin creates a distinct VIN for the nth motorcycle, with a valid check digit.
+func testVin(n int) string {
+	vin := fmt.Sprintf("%017d", n)
+	checkDigit, _ := entity.VinCheckDigit(vin)
+	return vin[:constant.VinCheckDigitPosition-1] + string(checkDigit) + vin[constant.VinCheckDigitPosition:]
+}
+
+// exerciseConcurrently has several goroutines insert, find, update, list, save and delete motorcycles in the repository.
+// Each goroutine deletes every other motorcycle that it inserted.
+// Returns the number of motorcycles that should remain in the repository.
+func exerciseConcurrently(t *testing.T, repo contract.MotorcycleRepository) int {
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < concurrentWorkers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			for n := 0; n < concurrentOperations; n++ {
+				motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, testVin(worker*concurrentOperations+n))
+
+				moto, _, err := repo.Insert(motorcycle)
+				if !assert.Nil(t, err) {
+					return
+				}
+
+				found, _, err := repo.FindByID(moto.ID)
+				assert.Nil(t, err)
+				assert.NotNil(t, found)
+
+				found.Model = "Spirit"
+				_, _, err = repo.Update(found.ID, found)
+				assert.Nil(t, err)
+
+				motorcycles, _, err := repo.List()
+				assert.Nil(t, err)
+				for _, m := range motorcycles {
+					// A snapshot never contains a partially updated motorcycle.
+					assert.True(t, m.Model == "Shadow" || m.Model == "Spirit")
+				}
+
+				if n%2 == 0 {
+					_, err = repo.Delete(moto.ID, constant.AnyRowVersion)
+					assert.Nil(t, err)
+				}
+
+				_, err = repo.Save()
+				assert.Nil(t, err)
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+
+	return concurrentWorkers * (concurrentOperations / 2)
+}
+
+// TestMotorcycleRepository_Concurrency verifies that the in-memory repository is safe for concurrent use.
+func TestMotorcycleRepository_Concurrency(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+
+	// ACT
+	expected := exerciseConcurrently(t, repo)
+	motorcycles, _, _ := repo.List()
+
+	// ASSERT
+	assert.True(t, len(motorcycles) == expected)
+}
+
+// TestFileMotorcycleRepository_Concurrency verifies that the file repository is safe for concurrent use.
+func TestFileMotorcycleRepository_Concurrency(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	repo, _ := NewFileMotorcycleRepository(path)
+
+	// ACT
+	expected := exerciseConcurrently(t, repo)
+	reloaded, err := NewFileMotorcycleRepository(path)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(reloaded.Motorcycles) == expected)
+}
+
+// TestSqlMotorcycleRepository_Concurrency verifies that the SQL repository is safe for concurrent use.
+func TestSqlMotorcycleRepository_Concurrency(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+
+	// ACT
+	expected := exerciseConcurrently(t, repo)
+	motorcycles, _, _ := repo.List()
+
+	// ASSERT
+	assert.True(t, len(motorcycles) == expected)
+}
+
+// TestMotorcycleRepository_ListIsSnapshot verifies that a list is not changed by a subsequent deletion.
+func TestMotorcycleRepository_ListIsSnapshot(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	for n := 0; n < 3; n++ {
+		motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, testVin(n))
+		repo.Insert(motorcycle)
+	}
+	motorcycles, _, _ := repo.List()
+	first := motorcycles[0]
+	second := motorcycles[1]
+
+	// ACT
+	repo.Delete(first.ID, constant.AnyRowVersion)
+
+	// ASSERT
+	assert.True(t, len(motorcycles) == 3)
+	assert.True(t, motorcycles[0] == first)
+	assert.True(t, motorcycles[1] == second)
+}
+
+// TestMotorcycleRepository_FindByIDIsCopy verifies that changing a found motorcycle does not change the repository.
+func TestMotorcycleRepository_FindByIDIsCopy(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, testVin(1))
+	moto, _, _ := repo.Insert(motorcycle)
+	found, _, _ := repo.FindByID(moto.ID)
+
+	// ACT
+	found.Make = "Yamaha"
+
+	// ASSERT
+	assert.True(t, repo.Motorcycles[0].Make == "Honda")
+}
@@ -0,0 +1,280 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// apiKeyLockTimeout is how long a change waits for another process to finish changing the file of API keys.
+const apiKeyLockTimeout = 5 * time.Second
+
+// FileApiKeyRepository provides operations against a collection of API keys, which is persisted to a file that is
+// shared with other processes, such as the command that manages the keys while the web service is running.
+// The file is loaded again whenever another process has changed it, and each change is written to the file as it is
+// made, under a lock on the file, after loading the changes of the other processes, so none of them are lost.
+// It is safe for concurrent use by multiple goroutines.
+type FileApiKeyRepository struct {
+	// ApiKeyRepository holds the API keys that were loaded from the file.
+	*ApiKeyRepository
+
+	// Path is the location of the file containing the persisted repository.
+	Path string `json:"-"`
+
+	// fileMutex serializes the loads and changes of this process, and guards modTime and size.
+	fileMutex sync.Mutex
+
+	// modTime and size identify the version of the file that was loaded, so a change by another process is noticed.
+	modTime time.Time
+	size    int64
+}
+
+// NewFileApiKeyRepository creates a new instance of a FileApiKeyRepository.
+// If the file at path exists, the repository is loaded from it, otherwise the repository is empty.
+// Returns (nil, error) when there is an error, otherwise a (FileApiKeyRepository, nil).
+func NewFileApiKeyRepository(path string) (*FileApiKeyRepository, error) {
+	apiKeyRepository, err := NewApiKeyRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	fileRepository := &FileApiKeyRepository{
+		ApiKeyRepository: apiKeyRepository,
+		Path:             path,
+	}
+
+	err = fileRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = fileRepository.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return fileRepository, nil
+}
+
+// Validate test that a file API key repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *FileApiKeyRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// Path cannot be empty.
+		validation.Field(&repo.Path, validation.Required),
+		// ApiKeyRepository cannot be nil.
+		validation.Field(&repo.ApiKeyRepository, validation.NotNil))
+}
+
+// List gets a snapshot of the list of API keys in the file.
+// Returns the (list of API keys, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *FileApiKeyRepository) List() ([]entity.ApiKey, operationstatus.OperationStatus, error) {
+	err := repo.refresh()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return repo.ApiKeyRepository.List()
+}
+
+// FindByID an API key in the file using its primary key, ID.
+// Returns (API key, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *FileApiKeyRepository) FindByID(id typedef.ID) (*entity.ApiKey, operationstatus.OperationStatus, error) {
+	err := repo.refresh()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return repo.ApiKeyRepository.FindByID(id)
+}
+
+// FindByHash an API key in the file using the hash of the key.
+// Returns (API key, Found, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *FileApiKeyRepository) FindByHash(hash string) (*entity.ApiKey, operationstatus.OperationStatus, error) {
+	err := repo.refresh()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return repo.ApiKeyRepository.FindByHash(hash)
+}
+
+// Insert adds an API key to the file.
+// Returns the (new API key, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *FileApiKeyRepository) Insert(apiKey *entity.ApiKey) (*entity.ApiKey, operationstatus.OperationStatus, error) {
+	var inserted *entity.ApiKey
+	status, err := repo.change(func() (operationstatus.OperationStatus, error) {
+		var status operationstatus.OperationStatus
+		var err error
+		inserted, status, err = repo.ApiKeyRepository.Insert(apiKey)
+		return status, err
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	return inserted, status, nil
+}
+
+// Update replaces the mutable fields of an existing API key in the file.
+// Returns (updated API key, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *FileApiKeyRepository) Update(id typedef.ID, apiKey *entity.ApiKey) (*entity.ApiKey, operationstatus.OperationStatus, error) {
+	var updated *entity.ApiKey
+	status, err := repo.change(func() (operationstatus.OperationStatus, error) {
+		var status operationstatus.OperationStatus
+		var err error
+		updated, status, err = repo.ApiKeyRepository.Update(id, apiKey)
+		return status, err
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	return updated, status, nil
+}
+
+// Save has nothing to write, since each change is written to the file as it is made.
+// Returns (Ok, nil).
+func (repo *FileApiKeyRepository) Save() (operationstatus.OperationStatus, error) {
+	return operationstatus.Ok, nil
+}
+
+// change makes a change to the API keys, and writes them to the file.  The file is locked, so another process cannot
+// change it at the same time, and it is loaded before the change is made, so the changes of the others are kept.
+// When the file cannot be written, the change is discarded by loading the file again.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *FileApiKeyRepository) change(apply func() (operationstatus.OperationStatus, error)) (operationstatus.OperationStatus, error) {
+	repo.fileMutex.Lock()
+	defer repo.fileMutex.Unlock()
+
+	unlock, err := lockFile(repo.Path, apiKeyLockTimeout)
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+	defer unlock()
+
+	err = repo.load()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	status, err := apply()
+	if err != nil {
+		return status, err
+	}
+
+	nextID, apiKeys := repo.snapshot()
+	err = writeRepositoryFile(repo.Path, &ApiKeyRepository{
+		NextID:  nextID,
+		ApiKeys: apiKeys,
+	})
+	if err != nil {
+		repo.load()
+		return operationstatus.InternalError, err
+	}
+
+	err = repo.stat()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return status, nil
+}
+
+// refresh loads the file again, when it has been changed by another process since it was loaded.
+// Returns nil on success, otherwise an error.
+func (repo *FileApiKeyRepository) refresh() error {
+	repo.fileMutex.Lock()
+	defer repo.fileMutex.Unlock()
+
+	info, err := os.Stat(repo.Path)
+	if os.IsNotExist(err) && repo.modTime.IsZero() {
+		return nil
+	}
+	if err == nil && info.ModTime().Equal(repo.modTime) && info.Size() == repo.size {
+		return nil
+	}
+
+	return repo.load()
+}
+
+// stat records the version of the file, so a change by another process is noticed.  The caller must hold the fileMutex.
+// Returns nil on success, otherwise an error.
+func (repo *FileApiKeyRepository) stat() error {
+	info, err := os.Stat(repo.Path)
+	if os.IsNotExist(err) {
+		repo.modTime, repo.size = time.Time{}, 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	repo.modTime, repo.size = info.ModTime(), info.Size()
+	return nil
+}
+
+// load reads the repository from its file, when the file exists, otherwise the repository is empty.
+// The version of the file is recorded before it is read, so a change that is made while it is read is noticed later.
+// The caller must hold the fileMutex, unless the repository is being created.
+// Returns nil on success, otherwise an error.
+func (repo *FileApiKeyRepository) load() error {
+	err := repo.stat()
+	if err != nil {
+		return err
+	}
+
+	loaded := &ApiKeyRepository{
+		ApiKeys:   make([]entity.ApiKey, 0),
+		hashIndex: make(map[string]int),
+	}
+	exists, err := readRepositoryFile(repo.Path, loaded)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		err = loaded.Validate()
+		if err == nil {
+			err = loaded.reindex()
+		}
+		if err != nil {
+			return fmt.Errorf("the repository file %s is corrupt: %s", repo.Path, err.Error())
+		}
+	}
+
+	for i := range loaded.ApiKeys {
+		apiKey := &loaded.ApiKeys[i]
+
+		// Files saved before API keys had subjects make requests on behalf of the key's name.
+		if apiKey.Subject == "" {
+			apiKey.Subject = apiKey.Name
+		}
+
+		if apiKey.ID > loaded.NextID {
+			return fmt.Errorf("the repository file %s is corrupt: the API key ID %d is greater than the next ID %d", repo.Path, apiKey.ID, loaded.NextID)
+		}
+
+		err = apiKey.Validate()
+		if err != nil {
+			return fmt.Errorf("the repository file %s is corrupt: the API key with ID %d is invalid: %s", repo.Path, apiKey.ID, err.Error())
+		}
+	}
+
+	repo.ApiKeyRepository.mutex.Lock()
+	defer repo.ApiKeyRepository.mutex.Unlock()
+
+	repo.NextID = loaded.NextID
+	repo.ApiKeys = loaded.ApiKeys
+	repo.hashIndex = loaded.hashIndex
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// lockRetryInterval is how long a process waits before it tries again to acquire a lock that is held by another.
+const lockRetryInterval = 10 * time.Millisecond
+
+// staleLockAge is the age of a lock file after which it is assumed to have been left behind by a process that died.
+const staleLockAge = 30 * time.Second
+
+// lockFile acquires the lock that the processes which change the file at path share, by creating a lock file beside it.
+// A lock file that is older than staleLockAge is removed, since no change takes that long.
+// Returns (function that releases the lock, nil) on success, otherwise (nil, error) when the lock cannot be acquired
+// within the timeout.
+func lockFile(path string, timeout time.Duration) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(timeout)
+
+	for {
+		lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			lock.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		info, err := os.Stat(lockPath)
+		if err == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("cannot lock the file %s because another process has held the lock for more than %s", path, timeout)
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
@@ -0,0 +1,263 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// FileMotorcycleRepository provides CRUD operations against a collection of motorcycles,
+// which is persisted to a file each time that the changes are saved.
+// It is safe for concurrent use by multiple goroutines.
+type FileMotorcycleRepository struct {
+	// MotorcycleRepository holds the working set of motorcycles between saves.
+	*MotorcycleRepository
+
+	// Path is the location of the file containing the persisted repository.
+	Path string `json:"-"`
+
+	// saveMutex serializes saves, so a snapshot is never overwritten by an older one.
+	saveMutex sync.Mutex
+}
+
+// repositoryFile is the layout of the file containing a persisted repository.
+// The checksum permits detection of a corrupt or partially written file.
+type repositoryFile struct {
+	// Checksum is the hex encoded SHA-256 hash of the Repository.
+	Checksum string `json:"checksum"`
+
+	// Repository is the JSON representation of the repository.
+	Repository json.RawMessage `json:"repository"`
+}
+
+// NewFileMotorcycleRepository creates a new instance of a FileMotorcycleRepository.
+// If the file at path exists, the repository is loaded from it, otherwise the repository is empty.
+// Returns (nil, error) when there is an error, otherwise a (FileMotorcycleRepository, nil).
+func NewFileMotorcycleRepository(path string) (*FileMotorcycleRepository, error) {
+	motorcycleRepository, err := NewMotorcycleRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	fileRepository := &FileMotorcycleRepository{
+		MotorcycleRepository: motorcycleRepository,
+		Path:                 path,
+	}
+
+	err = fileRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = fileRepository.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return fileRepository, nil
+}
+
+// Validate test that a file motorcycle repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *FileMotorcycleRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// Path cannot be empty.
+		validation.Field(&repo.Path, validation.Required),
+		// MotorcycleRepository cannot be nil, and it must be valid.
+		validation.Field(&repo.MotorcycleRepository, validation.NotNil))
+}
+
+// Save writes all of the changes in the repository to its file.
+// The file is replaced atomically, so a failure will never leave a partially written repository behind.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *FileMotorcycleRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.saveMutex.Lock()
+	defer repo.saveMutex.Unlock()
+
+	nextID, motorcycles := repo.snapshot()
+	err := writeRepositoryFile(repo.Path, &MotorcycleRepository{
+		NextID:      nextID,
+		Motorcycles: motorcycles,
+	})
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// load reads the repository from its file, if the file exists.
+// Returns nil on success, otherwise an error.
+func (repo *FileMotorcycleRepository) load() error {
+	loaded := &MotorcycleRepository{}
+	exists, err := readRepositoryFile(repo.Path, loaded)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		// There isn't a persisted repository yet, so we start with an empty one.
+		return nil
+	}
+
+	err = verifyLoadedRepository(loaded)
+	if err != nil {
+		return fmt.Errorf("the repository file %s is corrupt: %s", repo.Path, err.Error())
+	}
+
+	repo.NextID = loaded.NextID
+	repo.Motorcycles = loaded.Motorcycles
+	repo.idIndex = loaded.idIndex
+	repo.vinIndex = loaded.vinIndex
+
+	return nil
+}
+
+// verifyLoadedRepository checks the consistency of a repository that was read from a file.
+// Returns nil on success, otherwise an error.
+func verifyLoadedRepository(repo *MotorcycleRepository) error {
+	err := repo.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = repo.reindex()
+	if err != nil {
+		return err
+	}
+
+	for i := range repo.Motorcycles {
+		motorcycle := &repo.Motorcycles[i]
+
+		// Files saved before motorcycles had row versions start at the initial version.
+		if motorcycle.RowVersion == constant.AnyRowVersion {
+			motorcycle.RowVersion = constant.InitialRowVersion
+		}
+
+		if motorcycle.ID > repo.NextID {
+			return fmt.Errorf("the motorcycle ID %d is greater than the next ID %d", motorcycle.ID, repo.NextID)
+		}
+
+		err = motorcycle.Validate()
+		if err != nil {
+			return fmt.Errorf("the motorcycle with ID %d is invalid: %s", motorcycle.ID, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// writeRepositoryFile persists the repository to the file at path, together with its checksum.
+// Returns nil on success, otherwise an error.
+func writeRepositoryFile(path string, repository interface{}) error {
+	data, err := json.Marshal(repository)
+	if err != nil {
+		return err
+	}
+
+	checksum := sha256.Sum256(data)
+	contents, err := json.Marshal(repositoryFile{
+		Checksum:   hex.EncodeToString(checksum[:]),
+		Repository: data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(path, contents)
+}
+
+// readRepositoryFile reads the repository persisted by writeRepositoryFile from the file at path, after verifying its checksum.
+// Returns (true, nil) on success, (false, nil) when the file does not exist, otherwise (false, error).
+func readRepositoryFile(path string, repository interface{}) (bool, error) {
+	contents, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	file := repositoryFile{}
+	err = json.Unmarshal(contents, &file)
+	if err != nil {
+		return false, fmt.Errorf("the repository file %s is corrupt or was partially written: %s", path, err.Error())
+	}
+
+	checksum := sha256.Sum256(file.Repository)
+	if hex.EncodeToString(checksum[:]) != file.Checksum {
+		return false, fmt.Errorf("the repository file %s is corrupt because its checksum does not match its contents", path)
+	}
+
+	err = json.Unmarshal(file.Repository, repository)
+	if err != nil {
+		return false, fmt.Errorf("the repository file %s is corrupt: %s", path, err.Error())
+	}
+
+	return true, nil
+}
+
+// writeFileAtomically replaces the file at path with contents.  The contents are written to a temporary file
+// in the same directory, flushed to disk, and then renamed over the original file.
+// Returns nil on success, otherwise an error.
+func writeFileAtomically(path string, contents []byte) error {
+	dir := filepath.Dir(path)
+
+	tempFile, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	// Clean up the temporary file unless it has been renamed.
+	tempPath := tempFile.Name()
+	renamed := false
+	defer func() {
+		if !renamed {
+			os.Remove(tempPath)
+		}
+	}()
+
+	_, err = tempFile.Write(contents)
+	if err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	err = tempFile.Sync()
+	if err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	err = tempFile.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return err
+	}
+	renamed = true
+
+	// Flush the directory entry, so the rename survives a crash.
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return nil
+	}
+	defer dirFile.Close()
+	dirFile.Sync()
+
+	return nil
+}
@@ -0,0 +1,151 @@
+// Package repository implements unit tests for the FileMotorcycleRepository.
+package repository
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/stretchr/testify/assert"
+)
+
+// tempRepositoryPath creates a temporary directory for a repository file.
+// Returns the (path of the repository file, function that removes the directory).
+func tempRepositoryPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "motominder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "motorcycles.json"), func() { os.RemoveAll(dir) }
+}
+
+// TestFileMotorcycleRepository_PathIsEmpty verifies that a repository requires a file path.
+func TestFileMotorcycleRepository_PathIsEmpty(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewFileMotorcycleRepository("")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestFileMotorcycleRepository_FileNotExist verifies that a missing file results in an empty repository.
+func TestFileMotorcycleRepository_FileNotExist(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+
+	// ACT
+	repo, err := NewFileMotorcycleRepository(path)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(repo.Motorcycles) == 0)
+}
+
+// TestFileMotorcycleRepository_SaveAndReload verifies that saved motorcycles survive a reload of the repository.
+func TestFileMotorcycleRepository_SaveAndReload(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+
+	repo, _ := NewFileMotorcycleRepository(path)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+
+	// ACT
+	status, err := repo.Save()
+	reloaded, reloadErr := NewFileMotorcycleRepository(path)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.Nil(t, reloadErr)
+	assert.True(t, len(reloaded.Motorcycles) == 1)
+	assert.True(t, reloaded.NextID == repo.NextID)
+	assert.True(t, reloaded.Motorcycles[0].Vin == moto.Vin)
+	assert.True(t, reloaded.Motorcycles[0].CreatedUtc.Equal(moto.CreatedUtc))
+}
+
+// TestFileMotorcycleRepository_SaveLeavesNoTemporaryFiles verifies that a save only leaves the repository file behind.
+func TestFileMotorcycleRepository_SaveLeavesNoTemporaryFiles(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+
+	repo, _ := NewFileMotorcycleRepository(path)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	repo.Insert(motorcycle)
+
+	// ACT
+	repo.Save()
+	repo.Save()
+	files, _ := ioutil.ReadDir(filepath.Dir(path))
+
+	// ASSERT
+	assert.True(t, len(files) == 1)
+	assert.True(t, files[0].Name() == filepath.Base(path))
+}
+
+// TestFileMotorcycleRepository_PartiallyWrittenFile verifies that a truncated file is detected.
+func TestFileMotorcycleRepository_PartiallyWrittenFile(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+
+	repo, _ := NewFileMotorcycleRepository(path)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	repo.Insert(motorcycle)
+	repo.Save()
+
+	contents, _ := ioutil.ReadFile(path)
+	ioutil.WriteFile(path, contents[:len(contents)/2], 0600)
+
+	// ACT
+	_, err := NewFileMotorcycleRepository(path)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestFileMotorcycleRepository_ChecksumMismatch verifies that a file whose contents were altered is detected.
+func TestFileMotorcycleRepository_ChecksumMismatch(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+
+	ioutil.WriteFile(path, []byte(`{"checksum":"0000","repository":{"nextId":0,"motorcycles":[]}}`), 0600)
+
+	// ACT
+	_, err := NewFileMotorcycleRepository(path)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestFileMotorcycleRepository_Garbage verifies that a file that isn't a repository is detected.
+func TestFileMotorcycleRepository_Garbage(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+
+	ioutil.WriteFile(path, []byte("not a repository"), 0600)
+
+	// ACT
+	_, err := NewFileMotorcycleRepository(path)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
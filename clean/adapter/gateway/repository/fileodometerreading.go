@@ -0,0 +1,121 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// FileOdometerReadingRepository provides operations against a collection of odometer readings,
+// which is persisted to a file each time that the changes are saved.
+// It is safe for concurrent use by multiple goroutines.
+type FileOdometerReadingRepository struct {
+	// OdometerReadingRepository holds the working set of readings between saves.
+	*OdometerReadingRepository
+
+	// Path is the location of the file containing the persisted repository.
+	Path string `json:"-"`
+
+	// saveMutex serializes saves, so a snapshot is never overwritten by an older one.
+	saveMutex sync.Mutex
+}
+
+// NewFileOdometerReadingRepository creates a new instance of a FileOdometerReadingRepository.
+// If the file at path exists, the repository is loaded from it, otherwise the repository is empty.
+// Returns (nil, error) when there is an error, otherwise a (FileOdometerReadingRepository, nil).
+func NewFileOdometerReadingRepository(path string) (*FileOdometerReadingRepository, error) {
+	readingRepository, err := NewOdometerReadingRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	fileRepository := &FileOdometerReadingRepository{
+		OdometerReadingRepository: readingRepository,
+		Path:                      path,
+	}
+
+	err = fileRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = fileRepository.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return fileRepository, nil
+}
+
+// Validate test that a file odometer reading repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *FileOdometerReadingRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// Path cannot be empty.
+		validation.Field(&repo.Path, validation.Required),
+		// OdometerReadingRepository cannot be nil.
+		validation.Field(&repo.OdometerReadingRepository, validation.NotNil))
+}
+
+// Save writes all of the changes in the repository to its file.
+// The file is replaced atomically, so a failure will never leave a partially written repository behind.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *FileOdometerReadingRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.saveMutex.Lock()
+	defer repo.saveMutex.Unlock()
+
+	nextID, readings := repo.snapshot()
+	err := writeRepositoryFile(repo.Path, &OdometerReadingRepository{
+		NextID:   nextID,
+		Readings: readings,
+	})
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// load reads the repository from its file, if the file exists.
+// Returns nil on success, otherwise an error.
+func (repo *FileOdometerReadingRepository) load() error {
+	loaded := &OdometerReadingRepository{}
+	exists, err := readRepositoryFile(repo.Path, loaded)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		// There isn't a persisted repository yet, so we start with an empty one.
+		return nil
+	}
+
+	err = loaded.Validate()
+	if err == nil {
+		err = loaded.reindex()
+	}
+	if err != nil {
+		return fmt.Errorf("the repository file %s is corrupt: %s", repo.Path, err.Error())
+	}
+
+	for _, reading := range loaded.Readings {
+		if reading.ID > loaded.NextID {
+			return fmt.Errorf("the repository file %s is corrupt: the odometer reading ID %d is greater than the next ID %d", repo.Path, reading.ID, loaded.NextID)
+		}
+
+		err = reading.Validate()
+		if err != nil {
+			return fmt.Errorf("the repository file %s is corrupt: the odometer reading with ID %d is invalid: %s", repo.Path, reading.ID, err.Error())
+		}
+	}
+
+	repo.NextID = loaded.NextID
+	repo.Readings = loaded.Readings
+	repo.motorcycleIndex = loaded.motorcycleIndex
+
+	return nil
+}
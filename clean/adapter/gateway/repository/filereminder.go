@@ -0,0 +1,121 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// FileReminderRepository provides CRUD operations against a collection of reminders,
+// which is persisted to a file each time that the changes are saved.
+// It is safe for concurrent use by multiple goroutines.
+type FileReminderRepository struct {
+	// ReminderRepository holds the working set of reminders between saves.
+	*ReminderRepository
+
+	// Path is the location of the file containing the persisted repository.
+	Path string `json:"-"`
+
+	// saveMutex serializes saves, so a snapshot is never overwritten by an older one.
+	saveMutex sync.Mutex
+}
+
+// NewFileReminderRepository creates a new instance of a FileReminderRepository.
+// If the file at path exists, the repository is loaded from it, otherwise the repository is empty.
+// Returns (nil, error) when there is an error, otherwise a (FileReminderRepository, nil).
+func NewFileReminderRepository(path string) (*FileReminderRepository, error) {
+	reminderRepository, err := NewReminderRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	fileRepository := &FileReminderRepository{
+		ReminderRepository: reminderRepository,
+		Path:               path,
+	}
+
+	err = fileRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = fileRepository.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return fileRepository, nil
+}
+
+// Validate test that a file reminder repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *FileReminderRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// Path cannot be empty.
+		validation.Field(&repo.Path, validation.Required),
+		// ReminderRepository cannot be nil.
+		validation.Field(&repo.ReminderRepository, validation.NotNil))
+}
+
+// Save writes all of the changes in the repository to its file.
+// The file is replaced atomically, so a failure will never leave a partially written repository behind.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *FileReminderRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.saveMutex.Lock()
+	defer repo.saveMutex.Unlock()
+
+	nextID, reminders := repo.snapshot()
+	err := writeRepositoryFile(repo.Path, &ReminderRepository{
+		NextID:    nextID,
+		Reminders: reminders,
+	})
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// load reads the repository from its file, if the file exists.
+// Returns nil on success, otherwise an error.
+func (repo *FileReminderRepository) load() error {
+	loaded := &ReminderRepository{}
+	exists, err := readRepositoryFile(repo.Path, loaded)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		// There isn't a persisted repository yet, so we start with an empty one.
+		return nil
+	}
+
+	err = loaded.Validate()
+	if err != nil {
+		return fmt.Errorf("the repository file %s is corrupt: %s", repo.Path, err.Error())
+	}
+
+	for i, reminder := range loaded.Reminders {
+		if i > 0 && reminder.ID <= loaded.Reminders[i-1].ID {
+			return fmt.Errorf("the repository file %s is corrupt: the reminder ID %d is duplicated or out of order", repo.Path, reminder.ID)
+		}
+
+		if reminder.ID > loaded.NextID {
+			return fmt.Errorf("the repository file %s is corrupt: the reminder ID %d is greater than the next ID %d", repo.Path, reminder.ID, loaded.NextID)
+		}
+
+		err = reminder.Validate()
+		if err != nil {
+			return fmt.Errorf("the repository file %s is corrupt: the reminder with ID %d is invalid: %s", repo.Path, reminder.ID, err.Error())
+		}
+	}
+
+	repo.NextID = loaded.NextID
+	repo.Reminders = loaded.Reminders
+
+	return nil
+}
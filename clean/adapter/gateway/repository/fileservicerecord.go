@@ -0,0 +1,121 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// FileServiceRecordRepository provides CRUD operations against a collection of service records,
+// which is persisted to a file each time that the changes are saved.
+// It is safe for concurrent use by multiple goroutines.
+type FileServiceRecordRepository struct {
+	// ServiceRecordRepository holds the working set of service records between saves.
+	*ServiceRecordRepository
+
+	// Path is the location of the file containing the persisted repository.
+	Path string `json:"-"`
+
+	// saveMutex serializes saves, so a snapshot is never overwritten by an older one.
+	saveMutex sync.Mutex
+}
+
+// NewFileServiceRecordRepository creates a new instance of a FileServiceRecordRepository.
+// If the file at path exists, the repository is loaded from it, otherwise the repository is empty.
+// Returns (nil, error) when there is an error, otherwise a (FileServiceRecordRepository, nil).
+func NewFileServiceRecordRepository(path string) (*FileServiceRecordRepository, error) {
+	recordRepository, err := NewServiceRecordRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	fileRepository := &FileServiceRecordRepository{
+		ServiceRecordRepository: recordRepository,
+		Path:                    path,
+	}
+
+	err = fileRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = fileRepository.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return fileRepository, nil
+}
+
+// Validate test that a file service record repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *FileServiceRecordRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// Path cannot be empty.
+		validation.Field(&repo.Path, validation.Required),
+		// ServiceRecordRepository cannot be nil.
+		validation.Field(&repo.ServiceRecordRepository, validation.NotNil))
+}
+
+// Save writes all of the changes in the repository to its file.
+// The file is replaced atomically, so a failure will never leave a partially written repository behind.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *FileServiceRecordRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.saveMutex.Lock()
+	defer repo.saveMutex.Unlock()
+
+	nextID, records := repo.snapshot()
+	err := writeRepositoryFile(repo.Path, &ServiceRecordRepository{
+		NextID:         nextID,
+		ServiceRecords: records,
+	})
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// load reads the repository from its file, if the file exists.
+// Returns nil on success, otherwise an error.
+func (repo *FileServiceRecordRepository) load() error {
+	loaded := &ServiceRecordRepository{}
+	exists, err := readRepositoryFile(repo.Path, loaded)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		// There isn't a persisted repository yet, so we start with an empty one.
+		return nil
+	}
+
+	err = loaded.Validate()
+	if err != nil {
+		return fmt.Errorf("the repository file %s is corrupt: %s", repo.Path, err.Error())
+	}
+
+	for i, record := range loaded.ServiceRecords {
+		if i > 0 && record.ID <= loaded.ServiceRecords[i-1].ID {
+			return fmt.Errorf("the repository file %s is corrupt: the service record ID %d is duplicated or out of order", repo.Path, record.ID)
+		}
+
+		if record.ID > loaded.NextID {
+			return fmt.Errorf("the repository file %s is corrupt: the service record ID %d is greater than the next ID %d", repo.Path, record.ID, loaded.NextID)
+		}
+
+		err = record.Validate()
+		if err != nil {
+			return fmt.Errorf("the repository file %s is corrupt: the service record with ID %d is invalid: %s", repo.Path, record.ID, err.Error())
+		}
+	}
+
+	repo.NextID = loaded.NextID
+	repo.ServiceRecords = loaded.ServiceRecords
+
+	return nil
+}
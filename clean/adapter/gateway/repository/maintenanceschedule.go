@@ -0,0 +1,103 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// MaintenanceScheduleRepository provides read only access to the maintenance rules that define how often each kind of
+// service is performed.  It is safe for concurrent use by multiple goroutines, because the rules are never changed
+// once the repository has been created.
+type MaintenanceScheduleRepository struct {
+	Rules []entity.MaintenanceRule `json:"rules"`
+}
+
+// NewMaintenanceScheduleRepository creates a new instance of a MaintenanceScheduleRepository containing the rules.
+// Returns (nil, error) when there is an error, otherwise a (MaintenanceScheduleRepository, nil).
+func NewMaintenanceScheduleRepository(rules []entity.MaintenanceRule) (*MaintenanceScheduleRepository, error) {
+	scheduleRepository := &MaintenanceScheduleRepository{
+		// Ensure that we create an empty slice rather than the default for []entity.MaintenanceRule, which is a null pointer.
+		Rules: append(make([]entity.MaintenanceRule, 0, len(rules)), rules...),
+	}
+
+	err := scheduleRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return scheduleRepository, nil
+}
+
+// DefaultMaintenanceScheduleRepository creates the schedule that is used when one has not been configured.
+// It contains typical intervals for every motorcycle, which a configured schedule can override by make, model or motorcycle.
+// Returns a (MaintenanceScheduleRepository, nil).
+func DefaultMaintenanceScheduleRepository() (*MaintenanceScheduleRepository, error) {
+	return NewMaintenanceScheduleRepository([]entity.MaintenanceRule{
+		{Type: servicetype.OilChangeServiceType, Distance: 6000, Unit: distanceunit.KilometersDistanceUnit, Months: 12},
+		{Type: servicetype.ChainAdjustmentServiceType, Distance: 1000, Unit: distanceunit.KilometersDistanceUnit, Months: 3},
+		{Type: servicetype.ValveCheckServiceType, Distance: 24000, Unit: distanceunit.KilometersDistanceUnit},
+		{Type: servicetype.TireReplacementServiceType, Distance: 15000, Unit: distanceunit.KilometersDistanceUnit, Months: 60},
+		{Type: servicetype.BrakeServiceType, Months: 24},
+		{Type: servicetype.InspectionServiceType, Months: 12},
+	})
+}
+
+// LoadMaintenanceScheduleRepository reads a schedule from a JSON file, which is a list of maintenance rules.
+// For example, [{"type": "OilChange", "distance": 5000, "unit": "km", "months": 12},
+// {"make": "Honda", "model": "Shadow", "type": "ValveCheck", "distance": 16000, "unit": "mi"}].
+// Returns (MaintenanceScheduleRepository, nil) on success, otherwise (nil, error).
+func LoadMaintenanceScheduleRepository(path string) (*MaintenanceScheduleRepository, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]entity.MaintenanceRule, 0)
+	err = json.Unmarshal(contents, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("the maintenance schedule is not valid: %s", err.Error())
+	}
+
+	return NewMaintenanceScheduleRepository(rules)
+}
+
+// Validate test that a maintenance schedule repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *MaintenanceScheduleRepository) Validate() error {
+	err := validation.ValidateStruct(repo,
+		// Rules can be empty, but not nil
+		validation.Field(&repo.Rules, validation.NotNil))
+	if err != nil {
+		return err
+	}
+
+	for i, rule := range repo.Rules {
+		err = rule.Validate()
+		if err != nil {
+			return fmt.Errorf("maintenance rule %d is not valid: %s", i+1, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// List gets a snapshot of all of the rules in the schedule.
+// Returns the (list of maintenance rules, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *MaintenanceScheduleRepository) List() ([]entity.MaintenanceRule, operationstatus.OperationStatus, error) {
+	return append(make([]entity.MaintenanceRule, 0, len(repo.Rules)), repo.Rules...), operationstatus.Ok, nil
+}
+
+// ListByMotorcycle gets the most specific rule for each kind of service that applies to the motorcycle.
+// Returns the (list of maintenance rules, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *MaintenanceScheduleRepository) ListByMotorcycle(motorcycle entity.Motorcycle) ([]entity.MaintenanceRule, operationstatus.OperationStatus, error) {
+	return entity.SelectMaintenanceRules(repo.Rules, motorcycle), operationstatus.Ok, nil
+}
@@ -0,0 +1,79 @@
+// Package repository implements unit tests for the MaintenanceScheduleRepository.
+package repository
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMaintenanceScheduleRepository_Default verifies that the default schedule applies to every motorcycle.
+func TestMaintenanceScheduleRepository_Default(t *testing.T) {
+
+	// ARRANGE
+	repo, err := DefaultMaintenanceScheduleRepository()
+
+	// ACT
+	rules, status, _ := repo.ListByMotorcycle(entity.Motorcycle{ID: 1, Make: "Honda", Model: "Shadow"})
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(rules) == len(repo.Rules))
+}
+
+// TestMaintenanceScheduleRepository_InvalidRule verifies that a schedule cannot contain an invalid rule.
+func TestMaintenanceScheduleRepository_InvalidRule(t *testing.T) {
+
+	// ARRANGE
+	rules := []entity.MaintenanceRule{{Type: servicetype.OilChangeServiceType}}
+
+	// ACT
+	_, err := NewMaintenanceScheduleRepository(rules)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestLoadMaintenanceScheduleRepository verifies that a schedule is loaded from a file, and that a make and model's rule is chosen.
+func TestLoadMaintenanceScheduleRepository(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	ioutil.WriteFile(path, []byte(`[
+		{"type": "OilChange", "distance": 5000, "unit": "km", "months": 12},
+		{"make": "Honda", "model": "Shadow", "type": "OilChange", "distance": 4000, "unit": "mi"}]`), 0600)
+
+	// ACT
+	repo, err := LoadMaintenanceScheduleRepository(path)
+	shadow, _, _ := repo.ListByMotorcycle(entity.Motorcycle{ID: 1, Make: "Honda", Model: "Shadow"})
+	bolt, _, _ := repo.ListByMotorcycle(entity.Motorcycle{ID: 2, Make: "Yamaha", Model: "Bolt"})
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(shadow) == 1)
+	assert.True(t, shadow[0].Unit == distanceunit.MilesDistanceUnit)
+	assert.True(t, len(bolt) == 1)
+	assert.True(t, bolt[0].Months == 12)
+}
+
+// TestLoadMaintenanceScheduleRepository_UnknownType verifies that a schedule with an unknown kind of service is rejected.
+func TestLoadMaintenanceScheduleRepository_UnknownType(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	ioutil.WriteFile(path, []byte(`[{"type": "Detailing", "months": 12}]`), 0600)
+
+	// ACT
+	_, err := LoadMaintenanceScheduleRepository(path)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,125 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ManufacturerRepository provides read only access to the table of manufacturers that is used to decode VINs offline.
+// It is safe for concurrent use by multiple goroutines, because the manufacturers are never changed once the repository
+// has been created.
+type ManufacturerRepository struct {
+	Manufacturers []entity.Manufacturer `json:"manufacturers"`
+}
+
+// NewManufacturerRepository creates a new instance of a ManufacturerRepository containing the manufacturers.
+// Returns (nil, error) when there is an error, otherwise a (ManufacturerRepository, nil).
+func NewManufacturerRepository(manufacturers []entity.Manufacturer) (*ManufacturerRepository, error) {
+	manufacturerRepository := &ManufacturerRepository{
+		// Ensure that we create an empty slice rather than the default for []entity.Manufacturer, which is a null pointer.
+		Manufacturers: append(make([]entity.Manufacturer, 0, len(manufacturers)), manufacturers...),
+	}
+
+	err := manufacturerRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return manufacturerRepository, nil
+}
+
+// DefaultManufacturerRepository creates the table of manufacturers that is used when one has not been configured.
+// It contains the WMIs of the major motorcycle manufacturers.  A configured table replaces it, so it can be updated
+// as manufacturers are assigned new WMIs.
+// Returns a (ManufacturerRepository, nil).
+func DefaultManufacturerRepository() (*ManufacturerRepository, error) {
+	return NewManufacturerRepository([]entity.Manufacturer{
+		{Wmi: "JH2", Name: "Honda"},
+		{Wmi: "1HF", Name: "Honda"},
+		{Wmi: "MLH", Name: "Honda"},
+		{Wmi: "JYA", Name: "Yamaha"},
+		{Wmi: "JKA", Name: "Kawasaki"},
+		{Wmi: "JS1", Name: "Suzuki"},
+		{Wmi: "1HD", Name: "Harley-Davidson", Plants: map[string]string{"K": "Kansas City, Missouri", "Y": "York, Pennsylvania"}},
+		{Wmi: "5HD", Name: "Harley-Davidson", Plants: map[string]string{"K": "Kansas City, Missouri", "Y": "York, Pennsylvania"}},
+		{Wmi: "56K", Name: "Indian"},
+		{Wmi: "5VP", Name: "Victory"},
+		{Wmi: "WB1", Name: "BMW"},
+		{Wmi: "ZDM", Name: "Ducati"},
+		{Wmi: "SMT", Name: "Triumph"},
+		{Wmi: "ZD4", Name: "Aprilia"},
+		{Wmi: "VBK", Name: "KTM"},
+	})
+}
+
+// LoadManufacturerRepository reads a table of manufacturers from a JSON file, which is a list of manufacturers.
+// For example, [{"wmi": "JH2", "name": "Honda"}, {"wmi": "1HD", "name": "Harley-Davidson", "plants": {"Y": "York, Pennsylvania"}}].
+// Returns (ManufacturerRepository, nil) on success, otherwise (nil, error).
+func LoadManufacturerRepository(path string) (*ManufacturerRepository, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manufacturers := make([]entity.Manufacturer, 0)
+	err = json.Unmarshal(contents, &manufacturers)
+	if err != nil {
+		return nil, fmt.Errorf("the table of manufacturers is not valid: %s", err.Error())
+	}
+
+	return NewManufacturerRepository(manufacturers)
+}
+
+// Validate test that a manufacturer repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *ManufacturerRepository) Validate() error {
+	err := validation.ValidateStruct(repo,
+		// Manufacturers can be empty, but not nil
+		validation.Field(&repo.Manufacturers, validation.NotNil))
+	if err != nil {
+		return err
+	}
+
+	wmis := make(map[string]bool)
+	for i, manufacturer := range repo.Manufacturers {
+		err = manufacturer.Validate()
+		if err != nil {
+			return fmt.Errorf("manufacturer %d is not valid: %s", i+1, err.Error())
+		}
+
+		wmi := strings.ToUpper(manufacturer.Wmi)
+		if wmis[wmi] {
+			return fmt.Errorf("the WMI %s belongs to more than one manufacturer", manufacturer.Wmi)
+		}
+		wmis[wmi] = true
+	}
+
+	return nil
+}
+
+// List gets a snapshot of all of the manufacturers in the table.
+// Returns the (list of manufacturers, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *ManufacturerRepository) List() ([]entity.Manufacturer, operationstatus.OperationStatus, error) {
+	return append(make([]entity.Manufacturer, 0, len(repo.Manufacturers)), repo.Manufacturers...), operationstatus.Ok, nil
+}
+
+// FindByWmi gets the manufacturer with the WMI, ignoring case.
+// Returns (manufacturer, Ok, nil) on success, otherwise (nil, NotFound, nil) when the WMI is not in the table.
+func (repo *ManufacturerRepository) FindByWmi(wmi string) (*entity.Manufacturer, operationstatus.OperationStatus, error) {
+	for _, manufacturer := range repo.Manufacturers {
+		if strings.EqualFold(manufacturer.Wmi, wmi) {
+			found := manufacturer
+			return &found, operationstatus.Ok, nil
+		}
+	}
+
+	return nil, operationstatus.NotFound, nil
+}
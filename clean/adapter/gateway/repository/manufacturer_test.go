@@ -0,0 +1,61 @@
+// Package repository implements unit tests for the ManufacturerRepository.
+package repository
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestManufacturerRepository_Default verifies that a manufacturer is found by its WMI in the default table, ignoring case.
+func TestManufacturerRepository_Default(t *testing.T) {
+
+	// ARRANGE
+	repo, err := DefaultManufacturerRepository()
+
+	// ACT
+	honda, status, _ := repo.FindByWmi("jh2")
+	unknown, unknownStatus, unknownErr := repo.FindByWmi("XXX")
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, honda.Name == "Honda")
+	assert.Nil(t, unknown)
+	assert.True(t, unknownStatus == operationstatus.NotFound)
+	assert.Nil(t, unknownErr)
+}
+
+// TestManufacturerRepository_DuplicateWmi verifies that a WMI cannot belong to more than one manufacturer.
+func TestManufacturerRepository_DuplicateWmi(t *testing.T) {
+
+	// ARRANGE
+	manufacturers := []entity.Manufacturer{{Wmi: "JH2", Name: "Honda"}, {Wmi: "jh2", Name: "Yamaha"}}
+
+	// ACT
+	_, err := NewManufacturerRepository(manufacturers)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestLoadManufacturerRepository verifies that a table of manufacturers is loaded from a file.
+func TestLoadManufacturerRepository(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	ioutil.WriteFile(path, []byte(`[{"wmi": "1HD", "name": "Harley-Davidson", "plants": {"Y": "York, Pennsylvania"}}]`), 0600)
+
+	// ACT
+	repo, err := LoadManufacturerRepository(path)
+	manufacturers, _, _ := repo.List()
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(manufacturers) == 1)
+	assert.True(t, manufacturers[0].Plants["Y"] == "York, Pennsylvania")
+}
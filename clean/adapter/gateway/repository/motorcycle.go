@@ -6,7 +6,8 @@ import (
 
 	"fmt"
 	"github.com/pkg/errors"
-	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/abitofhelp/motominderapi/clean/domain/constant"
@@ -16,12 +17,23 @@ import (
 )
 
 // MotorcycleRepository provides CRUD operations against a collection of motorcycles.
+// It is safe for concurrent use by multiple goroutines.  Motorcycles returned by the repository
+// are copies, so they are not affected by subsequent changes to the repository.
 type MotorcycleRepository struct {
 	// NextID is the next primary key ID value for an object being inserted into the repository.
 	NextID typedef.ID `json:"nextId"`
 
 	// These items are unordered.
 	Motorcycles []entity.Motorcycle `json:"motorcycles"`
+
+	// idIndex maps the ID of each motorcycle to its index in Motorcycles.
+	idIndex map[typedef.ID]int
+
+	// vinIndex maps the normalized VIN of each motorcycle to its ID.
+	vinIndex map[string]typedef.ID
+
+	// mutex guards NextID, Motorcycles and the indexes.
+	mutex sync.RWMutex
 }
 
 // NewMotorcycleRepository creates a new instance of a MotorcycleRepository.
@@ -34,6 +46,9 @@ func NewMotorcycleRepository() (*MotorcycleRepository, error) {
 
 		// Ensure that we create an empty slice rather than the default for []entity.Motorcycle, which is a null pointer.
 		Motorcycles: make([]entity.Motorcycle, 0),
+
+		idIndex:  make(map[typedef.ID]int),
+		vinIndex: make(map[string]typedef.ID),
 	}
 	err := motorcycleRepository.Validate()
 	if err != nil {
@@ -46,19 +61,44 @@ func NewMotorcycleRepository() (*MotorcycleRepository, error) {
 
 // Validate test that a motorcycle repository is valid.
 // Returns nil on success, otherwise an error.
-func (repo MotorcycleRepository) Validate() error {
-	return validation.ValidateStruct(&repo,
+func (repo *MotorcycleRepository) Validate() error {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return validation.ValidateStruct(repo,
 		// Motorcycles can be empty, but not nil
 		validation.Field(&repo.Motorcycles, validation.NotNil))
 }
 
-// List gets the unordered list of motorcycles in the repository.
+// List gets a snapshot of the unordered list of motorcycles in the repository.
 // Returns the (list of motorcycles, Ok, nil), otherwise a (nil, operationStatus, error).
 func (repo *MotorcycleRepository) List() ([]entity.Motorcycle, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if repo.Motorcycles == nil {
+		return nil, operationstatus.InternalError, errors.New("list of motorcycles is nil, so create an instance of []entity.Motorcycle")
+	}
+
+	// Copy the motorcycles, so the caller's list is not changed by a subsequent insertion or deletion.
+	motorcycles := make([]entity.Motorcycle, len(repo.Motorcycles))
+	copy(motorcycles, repo.Motorcycles)
+
+	return motorcycles, operationstatus.Ok, nil
+}
+
+// Query gets a page of the motorcycles in the repository that satisfy the query's filters, in the query's sort order.
+// Returns the (page of motorcycles, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *MotorcycleRepository) Query(query entity.MotorcycleQuery) (*entity.MotorcyclePage, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
 	if repo.Motorcycles == nil {
 		return nil, operationstatus.InternalError, errors.New("list of motorcycles is nil, so create an instance of []entity.Motorcycle")
 	}
-	return repo.Motorcycles, operationstatus.Ok, nil
+
+	// The page contains copies of the motorcycles, so it is not changed by a subsequent update.
+	return query.Apply(repo.Motorcycles), operationstatus.Ok, nil
 }
 
 // ExistsByVin determines whether a motorcycle with the VIN exists in the repository.
@@ -101,18 +141,22 @@ func (repo *MotorcycleRepository) ExistsByID(id typedef.ID) (bool, operationstat
 // Does not permit duplicate VIN values.
 // Returns the (new motorcycle, Ok, nil) on success, otherwise an (nil, operationStatus, error).
 func (repo *MotorcycleRepository) Insert(motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
-	exists, status, err := repo.ExistsByVin(motorcycle.Vin)
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	i, err := repo.findByVin(motorcycle.Vin)
 	if err != nil {
-		return nil, status, err
+		return nil, operationstatus.InternalError, err
 	}
 
-	if exists {
-		return nil, status, fmt.Errorf("cannot insert the motorcycle with VIN %s because the VIN already exists in the repository", motorcycle.Vin)
+	if i != constant.InvalidEntityID {
+		return nil, operationstatus.DuplicateKey, fmt.Errorf("cannot insert the motorcycle with VIN %s because the VIN already exists in the repository", motorcycle.Vin)
 	}
 
 	// Assign the ID to the new motorcycle, and save the time when this entity was created in the repository.
 	motorcycle.ID = repo.getNextID()
 	motorcycle.CreatedUtc = time.Now().UTC()
+	motorcycle.RowVersion = constant.InitialRowVersion
 
 	// Validate the object
 	err = motorcycle.Validate()
@@ -121,6 +165,8 @@ func (repo *MotorcycleRepository) Insert(motorcycle *entity.Motorcycle) (*entity
 	}
 
 	repo.Motorcycles = append(repo.Motorcycles, *motorcycle)
+	repo.idIndex[motorcycle.ID] = len(repo.Motorcycles) - 1
+	repo.vinIndex[normalizeVin(motorcycle.Vin)] = motorcycle.ID
 
 	return motorcycle, operationstatus.Ok, nil
 }
@@ -128,27 +174,45 @@ func (repo *MotorcycleRepository) Insert(motorcycle *entity.Motorcycle) (*entity
 // Update replaces an existing motorcycle in the repository.
 // If the motorcycle does not exist, an error is returned.
 // Does not permit duplicate VIN values.
+// The motorcycle's row version must match the current one, unless it is AnyRowVersion.
 // Returns (updated motorcycle, Ok, nil) on success, otherwise an (nil, operationStatus, error).
 func (repo *MotorcycleRepository) Update(id typedef.ID, motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
-	moto, status, err := repo.FindByID(id)
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	i, err := repo.findByID(id)
 	if err != nil {
-		return nil, status, err
+		return nil, operationstatus.InternalError, err
+	}
+
+	if i == constant.InvalidEntityID {
+		return nil, operationstatus.NotFound, fmt.Errorf("cannot update the motorcycle with ID %d because it doesn't exist in the repository", id)
 	}
 
-	if moto == nil {
-		return nil, status, fmt.Errorf("cannot update the motorcycle with ID %d because it doesn't exist in the repository", id)
+	err = checkRowVersion(id, motorcycle.RowVersion, repo.Motorcycles[i].RowVersion)
+	if err != nil {
+		return nil, operationstatus.Conflict, err
 	}
 
-	// Update all fields...
-	moto.ID = motorcycle.ID
+	// The VIN can change, but it cannot become the VIN of another motorcycle.
+	otherID, exists := repo.vinIndex[normalizeVin(motorcycle.Vin)]
+	if exists && otherID != id {
+		return nil, operationstatus.DuplicateKey, fmt.Errorf("cannot update the motorcycle with ID %d because the VIN %s already exists in the repository", id, motorcycle.Vin)
+	}
+
+	// Work on a copy, so the repository is unchanged if the updated motorcycle is invalid.
+	moto := repo.Motorcycles[i]
+	oldVin := moto.Vin
+
+	// Update the fields that can be modified, but not the ones managed by the repository, such as the primary key...
 	moto.Make = motorcycle.Make
 	moto.Model = motorcycle.Model
 	moto.Year = motorcycle.Year
 	moto.Vin = motorcycle.Vin
-	moto.CreatedUtc = motorcycle.CreatedUtc
 
-	// Save the time when this entity was updated in the repository.
+	// Save the time when this entity was updated in the repository, and its new version.
 	moto.ModifiedUtc = time.Now().UTC()
+	moto.RowVersion++
 
 	// Validate the object
 	err = moto.Validate()
@@ -156,9 +220,11 @@ func (repo *MotorcycleRepository) Update(id typedef.ID, motorcycle *entity.Motor
 		return nil, operationstatus.InternalError, err
 	}
 
-	// We are updating the actual object in the slice, so the slice doesn't need to be manipulated.
+	repo.Motorcycles[i] = moto
+	delete(repo.vinIndex, normalizeVin(oldVin))
+	repo.vinIndex[normalizeVin(moto.Vin)] = moto.ID
 
-	return moto, operationstatus.Ok, nil
+	return &moto, operationstatus.Ok, nil
 }
 
 // findByID a motorcycle in the repository using its primary key, ID.
@@ -168,24 +234,25 @@ func (repo *MotorcycleRepository) findByID(id typedef.ID) (int, error) {
 		return constant.InvalidEntityID, errors.New("list of motorcycles is nil")
 	}
 
-	// Sort the list of motorcycles by id and find the index to the motorcycle.
-	// The result is the slice index for the single element or -1.
-	i := sort.Search(len(repo.Motorcycles), func(i int) bool {
-		return repo.Motorcycles[i].ID >= id
-	})
+	if repo.idIndex == nil {
+		return constant.InvalidEntityID, errors.New("the ID index is nil, so create the repository with NewMotorcycleRepository()")
+	}
 
-	if i < len(repo.Motorcycles) && repo.Motorcycles[i].ID == id {
-		// Found the motorcycle
-		return i, nil
+	i, found := repo.idIndex[id]
+	if !found {
+		// Motorcycle was not found.
+		return constant.InvalidEntityID, nil
 	}
 
-	// Motorcycle was not found.
-	return constant.InvalidEntityID, nil
+	// Found the motorcycle
+	return i, nil
 }
 
 // FindByID a motorcycle in the repository using its primary key, ID.
 // Returns (motorcycle, nil) on found, (nil, nil) for not found,, otherwise (nil, error).
 func (repo *MotorcycleRepository) FindByID(id typedef.ID) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
 
 	// Try to find the index for the motorcycle in the repository.
 	i, err := repo.findByID(id)
@@ -199,8 +266,9 @@ func (repo *MotorcycleRepository) FindByID(id typedef.ID) (*entity.Motorcycle, o
 		return nil, operationstatus.NotFound, nil
 	}
 
-	// Motorcycle was found.
-	return &repo.Motorcycles[i], operationstatus.Ok, nil
+	// Motorcycle was found, so return a copy of it.
+	motorcycle := repo.Motorcycles[i]
+	return &motorcycle, operationstatus.Ok, nil
 }
 
 // findByVin a motorcycle in the repository using its VIN.
@@ -210,24 +278,26 @@ func (repo *MotorcycleRepository) findByVin(vin string) (int, error) {
 		return constant.InvalidEntityID, errors.New("list of motorcycles is nil, so create an instance of []entity.Motorcycle")
 	}
 
-	// Sort the list of motorcycles by id and find the index to the motorcycle.
-	// The result is the slice index for the single element or -1.
-	i := sort.Search(len(repo.Motorcycles), func(i int) bool {
-		return repo.Motorcycles[i].Vin >= vin
-	})
+	if repo.vinIndex == nil {
+		return constant.InvalidEntityID, errors.New("the VIN index is nil, so create the repository with NewMotorcycleRepository()")
+	}
 
-	if i < len(repo.Motorcycles) && repo.Motorcycles[i].Vin == vin {
-		// Found the motorcycle
-		return i, nil
+	id, found := repo.vinIndex[normalizeVin(vin)]
+	if !found {
+		// Motorcycle was not found.
+		return constant.InvalidEntityID, nil
 	}
 
-	// Motorcycle was not found.
-	return constant.InvalidEntityID, nil
+	// Found the motorcycle
+	return repo.findByID(id)
 }
 
 // FindByVin a motorcycle in the repository using its VIN.
 // Returns (motorcycle, Ok, nil) on success, otherwise an (nil, operationStatus, error).
 func (repo *MotorcycleRepository) FindByVin(vin string) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
 	// Try to find the index for the motorcycle in the repository.
 	i, err := repo.findByVin(vin)
 
@@ -239,14 +309,18 @@ func (repo *MotorcycleRepository) FindByVin(vin string) (*entity.Motorcycle, ope
 		return nil, operationstatus.NotFound, nil
 	}
 
-	// Motorcycle was found.
-	return &repo.Motorcycles[i], operationstatus.Found, nil
+	// Motorcycle was found, so return a copy of it.
+	motorcycle := repo.Motorcycles[i]
+	return &motorcycle, operationstatus.Found, nil
 }
 
 // Delete an existing motorcycle from the repository.
 // If the motorcycle does not exist, an error is returned.
+// The row version must match the motorcycle's current one, unless it is AnyRowVersion.
 // Returns (Ok, nil) on success, otherwise an (operationStatus, error).
-func (repo *MotorcycleRepository) Delete(id typedef.ID) (operationstatus.OperationStatus, error) {
+func (repo *MotorcycleRepository) Delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
 
 	i, err := repo.findByID(id)
 	if err != nil {
@@ -257,8 +331,20 @@ func (repo *MotorcycleRepository) Delete(id typedef.ID) (operationstatus.Operati
 		return operationstatus.NotFound, fmt.Errorf("cannot delete the motorcycle with ID %d because it was not found", id)
 	}
 
+	err = checkRowVersion(id, rowVersion, repo.Motorcycles[i].RowVersion)
+	if err != nil {
+		return operationstatus.Conflict, err
+	}
+
+	delete(repo.idIndex, id)
+	delete(repo.vinIndex, normalizeVin(repo.Motorcycles[i].Vin))
 	repo.Motorcycles = repo.removeAtIndex(i)
 
+	// The motorcycles after the deleted one have moved down by one position.
+	for j := i; j < len(repo.Motorcycles); j++ {
+		repo.idIndex[repo.Motorcycles[j].ID] = j
+	}
+
 	return operationstatus.Ok, nil
 }
 
@@ -266,7 +352,10 @@ func (repo *MotorcycleRepository) Delete(id typedef.ID) (operationstatus.Operati
 // This is an internal method.
 // Returns the updated list of motorcycles in the repository.
 func (repo *MotorcycleRepository) removeAtIndex(index int) []entity.Motorcycle {
-	return append(repo.Motorcycles[:index], repo.Motorcycles[index+1:]...)
+	// Build a new list rather than shifting the elements in place, so a previous snapshot's backing array is never modified.
+	motorcycles := make([]entity.Motorcycle, 0, len(repo.Motorcycles)-1)
+	motorcycles = append(motorcycles, repo.Motorcycles[:index]...)
+	return append(motorcycles, repo.Motorcycles[index+1:]...)
 }
 
 // Save all of the changes to the repository (assuming some kind of unit of work/dbContext).
@@ -275,7 +364,63 @@ func (repo *MotorcycleRepository) Save() (operationstatus.OperationStatus, error
 	return operationstatus.Ok, nil
 }
 
-// GetNextID determines the next primary key ID value when an item is inserted into the repository.
+// reindex rebuilds the ID and VIN indexes from the list of motorcycles.
+// The caller must hold the write lock, or have exclusive access to the repository.
+// Returns nil on success, otherwise an error when an ID or VIN is duplicated.
+func (repo *MotorcycleRepository) reindex() error {
+	idIndex := make(map[typedef.ID]int, len(repo.Motorcycles))
+	vinIndex := make(map[string]typedef.ID, len(repo.Motorcycles))
+
+	for i, motorcycle := range repo.Motorcycles {
+		if _, exists := idIndex[motorcycle.ID]; exists {
+			return fmt.Errorf("the motorcycle ID %d is duplicated", motorcycle.ID)
+		}
+		idIndex[motorcycle.ID] = i
+
+		vin := normalizeVin(motorcycle.Vin)
+		if _, exists := vinIndex[vin]; exists {
+			return fmt.Errorf("the motorcycle VIN %s is duplicated", motorcycle.Vin)
+		}
+		vinIndex[vin] = motorcycle.ID
+	}
+
+	repo.idIndex = idIndex
+	repo.vinIndex = vinIndex
+
+	return nil
+}
+
+// checkRowVersion verifies that a change to the motorcycle with ID is based on its current row version.
+// Returns nil if the expected row version is AnyRowVersion or the current row version, otherwise an error.
+func checkRowVersion(id typedef.ID, expected typedef.RowVersion, current typedef.RowVersion) error {
+	if expected == constant.AnyRowVersion || expected == current {
+		return nil
+	}
+
+	return fmt.Errorf("cannot change the motorcycle with ID %d because its row version is %d rather than %d, so it has been changed by someone else", id, current, expected)
+}
+
+// normalizeVin converts a VIN into the form used by the VIN index, so VINs that differ only by case or
+// surrounding whitespace are treated as duplicates.
+// Returns the normalized VIN.
+func normalizeVin(vin string) string {
+	return strings.ToUpper(strings.TrimSpace(vin))
+}
+
+// snapshot gets a consistent copy of the repository's state.
+// Returns the (next ID, list of motorcycles).
+func (repo *MotorcycleRepository) snapshot() (typedef.ID, []entity.Motorcycle) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	motorcycles := make([]entity.Motorcycle, len(repo.Motorcycles))
+	copy(motorcycles, repo.Motorcycles)
+
+	return repo.NextID, motorcycles
+}
+
+// getNextID determines the next primary key ID value when an item is inserted into the repository.
+// The caller must hold the write lock.
 // Returns the next ID.
 func (repo *MotorcycleRepository) getNextID() typedef.ID {
 	repo.NextID = repo.NextID + 1
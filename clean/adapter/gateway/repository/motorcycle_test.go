@@ -2,10 +2,15 @@
 package repository
 
 import (
+	"math/rand"
 	"testing"
+	"time"
 
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
 	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -139,6 +144,25 @@ func TestMotorcycleRepository_Update(t *testing.T) {
 	assert.True(t, repo.Motorcycles[0].Make == "Harley Davidson")
 }
 
+// TestMotorcycleRepository_Update_CreatedUtc verifies that an update cannot change when the motorcycle was created,
+// since it is managed by the repository.
+func TestMotorcycleRepository_Update_CreatedUtc(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	created := moto.CreatedUtc
+	changed := *moto
+	changed.CreatedUtc = time.Time{}
+
+	// ACT
+	updated, _, _ := repo.Update(moto.ID, &changed)
+
+	// ASSERT
+	assert.True(t, updated.CreatedUtc.Equal(created))
+}
+
 // TestMotorcycleRepository_Update_NotExist verifies that an update
 // fails if the entity does not exist.
 func TestMotorcycleRepository_Update_NotExist(t *testing.T) {
@@ -164,7 +188,7 @@ func TestMotorcycleRepository_Delete(t *testing.T) {
 	moto, _, _ := repo.Insert(motorcycle)
 
 	// ACT
-	repo.Delete(moto.ID)
+	repo.Delete(moto.ID, constant.AnyRowVersion)
 
 	// ASSERT
 	assert.True(t, len(repo.Motorcycles) == 0)
@@ -180,10 +204,66 @@ func TestMotorcycleRepository_Delete_NotExist(t *testing.T) {
 	motorcycle.ID = 123
 
 	// ACT
-	_, err := repo.Delete(motorcycle.ID)
+	_, err := repo.Delete(motorcycle.ID, constant.AnyRowVersion)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestMotorcycleRepository_RowVersion verifies that inserts and updates set the row version.
+func TestMotorcycleRepository_RowVersion(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	inserted, _, _ := repo.Insert(motorcycle)
+
+	// ACT
+	updated, _, err := repo.Update(inserted.ID, inserted)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, inserted.RowVersion == constant.InitialRowVersion)
+	assert.True(t, updated.RowVersion == constant.InitialRowVersion+1)
+}
+
+// TestMotorcycleRepository_Update_StaleRowVersion verifies that an update of an out of date motorcycle fails with a conflict.
+func TestMotorcycleRepository_Update_StaleRowVersion(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	stale := *moto
+	repo.Update(moto.ID, moto)
+	stale.Make = "Harley Davidson"
+
+	// ACT
+	updated, status, err := repo.Update(stale.ID, &stale)
+
+	// ASSERT
+	assert.Nil(t, updated)
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+	assert.True(t, repo.Motorcycles[0].Make == "Honda")
+}
+
+// TestMotorcycleRepository_Delete_StaleRowVersion verifies that a delete of an out of date motorcycle fails with a conflict.
+func TestMotorcycleRepository_Delete_StaleRowVersion(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	repo.Update(moto.ID, moto)
+
+	// ACT
+	status, err := repo.Delete(moto.ID, moto.RowVersion)
 
 	// ASSERT
 	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+	assert.True(t, len(repo.Motorcycles) == 1)
 }
 
 // TestMotorcycleRepository_Save verifies that a save of the unit of work/dbContext is successful.
@@ -198,3 +278,259 @@ func TestMotorcycleRepository_Save(t *testing.T) {
 	// ASSERT
 	assert.Nil(t, err)
 }
+
+// TestMotorcycleRepository_ExistsByVin_InsertionOrder verifies that a VIN is found regardless of the order of insertion.
+func TestMotorcycleRepository_ExistsByVin_InsertionOrder(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	for _, vin := range []string{"99999999999999999", "11111111111111111", "55555555555555555"} {
+		motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, vin)
+		repo.Insert(motorcycle)
+	}
+
+	// ACT
+	exists, _, _ := repo.ExistsByVin("11111111111111111")
+
+	// ASSERT
+	assert.True(t, exists)
+}
+
+// TestMotorcycleRepository_Insert_VinAlreadyExists verifies that a duplicate VIN is rejected, even when it differs by case.
+func TestMotorcycleRepository_Insert_VinAlreadyExists(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	first, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "ZZZZZZZZ9ZZZZZZZZ")
+	second, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "zzzzzzzz9zzzzzzzz")
+	repo.Insert(first)
+
+	// ACT
+	_, status, err := repo.Insert(second)
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.DuplicateKey)
+	assert.True(t, len(repo.Motorcycles) == 1)
+}
+
+// TestMotorcycleRepository_Update_VinAlreadyExists verifies that an update cannot take the VIN of another motorcycle.
+func TestMotorcycleRepository_Update_VinAlreadyExists(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	first, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "11111111111111111")
+	second, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "22222222222222222")
+	repo.Insert(first)
+	moto, _, _ := repo.Insert(second)
+	moto.Vin = first.Vin
+
+	// ACT
+	_, status, err := repo.Update(moto.ID, moto)
+	found, _, _ := repo.FindByVin("22222222222222222")
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.DuplicateKey)
+	assert.NotNil(t, found)
+}
+
+// TestMotorcycleRepository_FindByID_AfterDelete verifies that the motorcycles following a deleted one can still be found.
+func TestMotorcycleRepository_FindByID_AfterDelete(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	first, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "11111111111111111")
+	second, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "22222222222222222")
+	third, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "33333333333333333")
+	repo.Insert(first)
+	repo.Insert(second)
+	repo.Insert(third)
+
+	// ACT
+	repo.Delete(first.ID, constant.AnyRowVersion)
+	found, _, _ := repo.FindByID(third.ID)
+
+	// ASSERT
+	assert.NotNil(t, found)
+	assert.True(t, found.Vin == third.Vin)
+}
+
+// TestMotorcycleRepository_RandomizedOperations verifies that the indexes stay consistent with the list of motorcycles
+// across hundreds of randomized insertions, updates and deletions.
+func TestMotorcycleRepository_RandomizedOperations(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	random := rand.New(rand.NewSource(1999))
+
+	// expected is the model of the repository, which maps each ID to its VIN.
+	expected := make(map[typedef.ID]string)
+	randomVin := func() string {
+		// A small range of VINs, so collisions are frequent.
+		return testVin(random.Intn(50))
+	}
+	randomID := func() typedef.ID {
+		return typedef.ID(random.Int63n(int64(repo.NextID) + 2))
+	}
+	vinExists := func(vin string) bool {
+		for _, v := range expected {
+			if v == vin {
+				return true
+			}
+		}
+		return false
+	}
+
+	// ACT & ASSERT
+	for n := 0; n < 500; n++ {
+		switch random.Intn(3) {
+		case 0:
+			vin := randomVin()
+			motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, vin)
+			moto, _, err := repo.Insert(motorcycle)
+			if vinExists(vin) {
+				assert.NotNil(t, err, "insert of duplicate VIN %s", vin)
+			} else if assert.Nil(t, err) {
+				expected[moto.ID] = vin
+			}
+		case 1:
+			id := randomID()
+			vin := randomVin()
+			motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, vin)
+			_, _, err := repo.Update(id, motorcycle)
+			current, exists := expected[id]
+			if !exists || (vinExists(vin) && current != vin) {
+				assert.NotNil(t, err, "update of ID %d to VIN %s", id, vin)
+			} else if assert.Nil(t, err) {
+				expected[id] = vin
+			}
+		case 2:
+			id := randomID()
+			_, err := repo.Delete(id, constant.AnyRowVersion)
+			if _, exists := expected[id]; exists {
+				assert.Nil(t, err)
+				delete(expected, id)
+			} else {
+				assert.NotNil(t, err, "delete of ID %d", id)
+			}
+		}
+
+		// The repository agrees with the model.
+		assert.Len(t, repo.Motorcycles, len(expected))
+		for id, vin := range expected {
+			byID, _, _ := repo.FindByID(id)
+			if assert.NotNil(t, byID) {
+				assert.Equal(t, vin, byID.Vin)
+			}
+			byVin, _, _ := repo.FindByVin(vin)
+			if assert.NotNil(t, byVin) {
+				assert.Equal(t, id, byVin.ID)
+			}
+		}
+	}
+}
+
+// TestMotorcycleRepository_Update_KeepsOwner verifies that an update does not change the owner of a motorcycle.
+func TestMotorcycleRepository_Update_KeepsOwner(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, testVin(0))
+	motorcycle.OwnerID = "alice"
+	moto, _, _ := repo.Insert(motorcycle)
+	update, _ := entity.NewMotorcycle("Honda", "Spirit", 2006, testVin(0))
+	update.OwnerID = "bob"
+
+	// ACT
+	_, status, err := repo.Update(moto.ID, update)
+	found, _, _ := repo.FindByID(moto.ID)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, found.OwnerID == "alice")
+	assert.True(t, found.Model == "Spirit")
+}
+
+// insertQueryMotorcycles inserts motorcycles with several makes, models and years, which are owned by alice or bob.
+func insertQueryMotorcycles(repo contract.MotorcycleRepository) {
+	motorcycles := []struct {
+		make  string
+		model string
+		year  int
+		vin   string
+		owner string
+	}{
+		{"Honda", "Shadow", 2006, "JH2PC35051M200020", "alice"},
+		{"Yamaha", "Bolt", 2015, "JYAVP27E4FA000001", "bob"},
+		{"honda", "Gold Wing", 2018, "JH2SC79A9JK000001", "alice"},
+		{"Harley-Davidson", "Sportster", 2007, "1HD1KB4147Y700000", "alice"},
+		{"Honda", "Rebel", 2018, "JH2PC56A2JK000002", "bob"},
+	}
+
+	for _, m := range motorcycles {
+		motorcycle, _ := entity.NewMotorcycle(m.make, m.model, m.year, m.vin)
+		motorcycle.OwnerID = m.owner
+		repo.Insert(motorcycle)
+	}
+}
+
+// queryAllPages follows the cursors of the query until the last page.
+// Returns the (IDs of the motorcycles in order, number of pages).
+func queryAllPages(t *testing.T, repo contract.MotorcycleRepository, query entity.MotorcycleQuery) ([]typedef.ID, int) {
+	ids := make([]typedef.ID, 0)
+	for pages := 1; ; pages++ {
+		page, _, err := repo.Query(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, motorcycle := range page.Motorcycles {
+			ids = append(ids, motorcycle.ID)
+		}
+
+		if page.Next == nil {
+			return ids, pages
+		}
+		query.After = page.Next
+	}
+}
+
+// TestMotorcycleRepository_Query verifies that the filters are applied, ignoring case, and the motorcycles are sorted.
+func TestMotorcycleRepository_Query(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	insertQueryMotorcycles(repo)
+	sorts, _ := entity.ParseMotorcycleSort("-year,model")
+
+	// ACT
+	ids, pages := queryAllPages(t, repo, entity.MotorcycleQuery{Make: "HONDA", VinPrefix: "jh2", Sort: sorts})
+
+	// ASSERT
+	assert.True(t, pages == 1)
+	assert.Equal(t, []typedef.ID{3, 5, 1}, ids)
+}
+
+// TestMotorcycleRepository_Query_Pages verifies that following the cursors visits each of the owner's motorcycles once,
+// even when one of them is deleted between pages.
+func TestMotorcycleRepository_Query_Pages(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewMotorcycleRepository()
+	insertQueryMotorcycles(repo)
+	sorts, _ := entity.ParseMotorcycleSort("make,-year")
+	query := entity.MotorcycleQuery{OwnerID: "alice", Sort: sorts, Limit: 1}
+	first, _, _ := repo.Query(query)
+	repo.Delete(first.Motorcycles[0].ID, constant.AnyRowVersion)
+	query.After = first.Next
+
+	// ACT
+	ids, pages := queryAllPages(t, repo, query)
+
+	// ASSERT
+	assert.True(t, first.Motorcycles[0].ID == 4)
+	assert.True(t, pages == 2)
+	assert.Equal(t, []typedef.ID{3, 1}, ids)
+}
@@ -0,0 +1,275 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// OdometerReadingRepository provides operations against a collection of odometer readings.
+// It is safe for concurrent use by multiple goroutines.  Readings returned by the repository
+// are copies, so they are not affected by subsequent changes to the repository.
+type OdometerReadingRepository struct {
+	// NextID is the next primary key ID value for a reading being inserted into the repository.
+	NextID typedef.ID `json:"nextId"`
+
+	// These items are ordered by their ID, so each motorcycle's readings are in chronological order.
+	Readings []entity.OdometerReading `json:"readings"`
+
+	// motorcycleIndex maps the ID of each motorcycle to the IDs of its readings, in order.
+	motorcycleIndex map[typedef.ID][]typedef.ID
+
+	// mutex guards NextID, Readings and the index.
+	mutex sync.RWMutex
+}
+
+// NewOdometerReadingRepository creates a new instance of an OdometerReadingRepository.
+// Returns (nil, error) when there is an error, otherwise an (OdometerReadingRepository, nil).
+func NewOdometerReadingRepository() (*OdometerReadingRepository, error) {
+	readingRepository := &OdometerReadingRepository{
+		NextID: 0,
+
+		// Ensure that we create an empty slice rather than the default for []entity.OdometerReading, which is a null pointer.
+		Readings: make([]entity.OdometerReading, 0),
+
+		motorcycleIndex: make(map[typedef.ID][]typedef.ID),
+	}
+
+	err := readingRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return readingRepository, nil
+}
+
+// Validate test that an odometer reading repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *OdometerReadingRepository) Validate() error {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return validation.ValidateStruct(repo,
+		// Readings can be empty, but not nil
+		validation.Field(&repo.Readings, validation.NotNil))
+}
+
+// ListByMotorcycle gets a snapshot of the motorcycle's readings in chronological order.
+// Returns the (list of readings, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *OdometerReadingRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.OdometerReading, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if repo.motorcycleIndex == nil {
+		return nil, operationstatus.InternalError, errors.New("the motorcycle index is nil, so create the repository with NewOdometerReadingRepository()")
+	}
+
+	// Ensure that we create an empty slice rather than the default for []entity.OdometerReading, which is a null pointer.
+	readings := make([]entity.OdometerReading, 0, len(repo.motorcycleIndex[motorcycleID]))
+	for _, id := range repo.motorcycleIndex[motorcycleID] {
+		readings = append(readings, repo.Readings[repo.findByID(id)])
+	}
+
+	return readings, operationstatus.Ok, nil
+}
+
+// Latest gets the motorcycle's most recent reading.
+// Returns (reading, Ok, nil) on found, (nil, NotFound, nil) when the motorcycle does not have any readings, otherwise (nil, operationStatus, error).
+func (repo *OdometerReadingRepository) Latest(motorcycleID typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if repo.motorcycleIndex == nil {
+		return nil, operationstatus.InternalError, errors.New("the motorcycle index is nil, so create the repository with NewOdometerReadingRepository()")
+	}
+
+	latest := repo.latest(motorcycleID)
+	if latest == nil {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	reading := *latest
+	return &reading, operationstatus.Ok, nil
+}
+
+// FindByID a reading in the repository using its primary key, ID.
+// Returns (reading, Ok, nil) on found, (nil, NotFound, nil) for not found.
+func (repo *OdometerReadingRepository) FindByID(id typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	reading := repo.Readings[i]
+	return &reading, operationstatus.Ok, nil
+}
+
+// Insert adds a reading to the repository, after verifying that it follows the motorcycle's latest reading.
+// Returns the (new reading, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *OdometerReadingRepository) Insert(reading *entity.OdometerReading) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.motorcycleIndex == nil {
+		return nil, operationstatus.InternalError, errors.New("the motorcycle index is nil, so create the repository with NewOdometerReadingRepository()")
+	}
+
+	// Work on a copy, so the repository is unchanged if the reading is invalid.
+	newReading := *reading
+
+	err := newReading.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	err = newReading.Follow(repo.latest(newReading.MotorcycleID))
+	if err != nil {
+		return nil, operationstatus.BadRequest, err
+	}
+
+	newReading.ID = repo.NextID + 1
+	newReading.CreatedUtc = time.Now().UTC()
+
+	repo.NextID = newReading.ID
+	repo.Readings = append(repo.Readings, newReading)
+	repo.motorcycleIndex[newReading.MotorcycleID] = append(repo.motorcycleIndex[newReading.MotorcycleID], newReading.ID)
+
+	inserted := newReading
+	return &inserted, operationstatus.Ok, nil
+}
+
+// Delete removes a reading from the repository.  Only the latest reading of a motorcycle can be deleted.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *OdometerReadingRepository) Delete(id typedef.ID) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return operationstatus.NotFound, fmt.Errorf("cannot delete the odometer reading with ID %d because it doesn't exist in the repository", id)
+	}
+
+	motorcycleID := repo.Readings[i].MotorcycleID
+	ids := repo.motorcycleIndex[motorcycleID]
+	if ids[len(ids)-1] != id {
+		return operationstatus.Conflict, fmt.Errorf("cannot delete the odometer reading with ID %d because it is not the motorcycle's latest reading", id)
+	}
+
+	// Build a new list rather than shifting the elements in place, so a previous snapshot's backing array is never modified.
+	readings := make([]entity.OdometerReading, 0, len(repo.Readings)-1)
+	readings = append(readings, repo.Readings[:i]...)
+	repo.Readings = append(readings, repo.Readings[i+1:]...)
+
+	if len(ids) == 1 {
+		delete(repo.motorcycleIndex, motorcycleID)
+	} else {
+		repo.motorcycleIndex[motorcycleID] = ids[:len(ids)-1]
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// DeleteByMotorcycle removes all of the motorcycle's readings from the repository.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *OdometerReadingRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.motorcycleIndex == nil {
+		return operationstatus.InternalError, errors.New("the motorcycle index is nil, so create the repository with NewOdometerReadingRepository()")
+	}
+
+	// Build a new list rather than removing the elements in place, so a previous snapshot's backing array is never modified.
+	readings := make([]entity.OdometerReading, 0, len(repo.Readings)-len(repo.motorcycleIndex[motorcycleID]))
+	for _, reading := range repo.Readings {
+		if reading.MotorcycleID != motorcycleID {
+			readings = append(readings, reading)
+		}
+	}
+
+	repo.Readings = readings
+	delete(repo.motorcycleIndex, motorcycleID)
+
+	return operationstatus.Ok, nil
+}
+
+// Save all of the changes to the repository (assuming some kind of unit of work/dbContext).
+// Returns nil on success, otherwise an error.
+func (repo *OdometerReadingRepository) Save() (operationstatus.OperationStatus, error) {
+	return operationstatus.Ok, nil
+}
+
+// latest finds the motorcycle's most recent reading.
+// The caller must hold a lock.
+// Returns the reading on found, otherwise nil.
+func (repo *OdometerReadingRepository) latest(motorcycleID typedef.ID) *entity.OdometerReading {
+	ids := repo.motorcycleIndex[motorcycleID]
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return &repo.Readings[repo.findByID(ids[len(ids)-1])]
+}
+
+// findByID finds the index of the reading with the ID.
+// The readings are ordered by their ID, so this is a binary search.
+// The caller must hold a lock.
+// Returns the index on found, otherwise -1.
+func (repo *OdometerReadingRepository) findByID(id typedef.ID) int {
+	low, high := 0, len(repo.Readings)-1
+	for low <= high {
+		middle := (low + high) / 2
+		switch {
+		case repo.Readings[middle].ID == id:
+			return middle
+		case repo.Readings[middle].ID < id:
+			low = middle + 1
+		default:
+			high = middle - 1
+		}
+	}
+
+	return -1
+}
+
+// reindex rebuilds the motorcycle index from the list of readings.
+// The caller must hold the write lock, or have exclusive access to the repository.
+// Returns nil on success, otherwise an error when the readings are out of order.
+func (repo *OdometerReadingRepository) reindex() error {
+	motorcycleIndex := make(map[typedef.ID][]typedef.ID)
+
+	for i, reading := range repo.Readings {
+		if i > 0 && reading.ID <= repo.Readings[i-1].ID {
+			return fmt.Errorf("the odometer reading ID %d is duplicated or out of order", reading.ID)
+		}
+
+		motorcycleIndex[reading.MotorcycleID] = append(motorcycleIndex[reading.MotorcycleID], reading.ID)
+	}
+
+	repo.motorcycleIndex = motorcycleIndex
+
+	return nil
+}
+
+// snapshot gets a consistent copy of the repository's state.
+// Returns the (next ID, list of readings).
+func (repo *OdometerReadingRepository) snapshot() (typedef.ID, []entity.OdometerReading) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	readings := make([]entity.OdometerReading, len(repo.Readings))
+	copy(readings, repo.Readings)
+
+	return repo.NextID, readings
+}
@@ -0,0 +1,161 @@
+// Package repository implements unit tests for the OdometerReadingRepository.
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/stretchr/testify/assert"
+)
+
+// testReadingUtc is the time of the first odometer reading in a test.
+var testReadingUtc = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestOdometerReading creates a reading of the motorcycle in miles, taken the number of hours after testReadingUtc.
+func newTestOdometerReading(motorcycleID typedef.ID, value float64, hours int, rollover bool) *entity.OdometerReading {
+	reading, _ := entity.NewOdometerReading(motorcycleID, value, distanceunit.MilesDistanceUnit,
+		testReadingUtc.Add(time.Duration(hours)*time.Hour), readingsource.ManualReadingSource, rollover)
+	return reading
+}
+
+// exerciseOdometerReadings verifies the behavior that every odometer reading repository must provide.
+func exerciseOdometerReadings(t *testing.T, repo contract.OdometerReadingRepository) {
+	_, status, _ := repo.Latest(1)
+	assert.True(t, status == operationstatus.NotFound)
+
+	first, status, err := repo.Insert(newTestOdometerReading(1, 99900, 0, false))
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, first.ID > 0)
+	assert.False(t, first.CreatedUtc.IsZero())
+
+	// Another motorcycle's readings are independent.
+	_, _, err = repo.Insert(newTestOdometerReading(2, 10, 0, false))
+	assert.Nil(t, err)
+
+	// A failed change discards the unsaved changes of a repository that keeps them in a unit of work.
+	repo.Save()
+
+	// A reading that is less than the previous one is rejected, unless the odometer rolled over.
+	_, status, err = repo.Insert(newTestOdometerReading(1, 150, 1, false))
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.BadRequest)
+
+	second, _, err := repo.Insert(newTestOdometerReading(1, 150, 1, true))
+	assert.Nil(t, err)
+	assert.True(t, second.Distance == 100150)
+
+	latest, _, _ := repo.Latest(1)
+	assert.True(t, latest.ID == second.ID)
+
+	readings, status, err := repo.ListByMotorcycle(1)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(readings) == 2)
+	assert.True(t, readings[0].ID == first.ID)
+	assert.True(t, readings[1].Rollover)
+
+	repo.Save()
+
+	// Only the latest reading can be deleted.
+	status, err = repo.Delete(first.ID)
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+
+	status, err = repo.Delete(second.ID)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+
+	found, status, _ := repo.FindByID(second.ID)
+	assert.Nil(t, found)
+	assert.True(t, status == operationstatus.NotFound)
+
+	latest, _, _ = repo.Latest(1)
+	assert.True(t, latest.ID == first.ID)
+	assert.True(t, latest.Distance == 99900)
+
+	// Deleting a motorcycle's readings leaves none of them behind.
+	status, err = repo.DeleteByMotorcycle(1)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+
+	remaining, _, _ := repo.ListByMotorcycle(1)
+	assert.True(t, len(remaining) == 0)
+
+	status, err = repo.Save()
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+}
+
+// TestOdometerReadingRepository_ListEmpty verifies that an empty list of readings is returned.
+func TestOdometerReadingRepository_ListEmpty(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewOdometerReadingRepository()
+
+	// ACT
+	readings, status, err := repo.ListByMotorcycle(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.NotNil(t, readings)
+	assert.True(t, len(readings) == 0)
+}
+
+// TestOdometerReadingRepository_Operations verifies inserting, listing and deleting readings.
+func TestOdometerReadingRepository_Operations(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewOdometerReadingRepository()
+
+	// ACT & ASSERT
+	exerciseOdometerReadings(t, repo)
+}
+
+// TestOdometerReadingRepository_Delete_NotExist verifies that a delete fails if the reading does not exist.
+func TestOdometerReadingRepository_Delete_NotExist(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewOdometerReadingRepository()
+
+	// ACT
+	status, err := repo.Delete(123)
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.NotFound)
+}
+
+// TestFileOdometerReadingRepository_SaveAndReload verifies that saved readings survive a reload of the repository.
+func TestFileOdometerReadingRepository_SaveAndReload(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	path = filepath.Join(filepath.Dir(path), "odometer.json")
+
+	repo, _ := NewFileOdometerReadingRepository(path)
+	repo.Insert(newTestOdometerReading(1, 1200, 0, false))
+	repo.Insert(newTestOdometerReading(1, 1300, 1, false))
+	repo.Save()
+
+	// ACT
+	reloaded, err := NewFileOdometerReadingRepository(path)
+	inserted, _, insertErr := reloaded.Insert(newTestOdometerReading(1, 1100, 2, false))
+	latest, _, _ := reloaded.Latest(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(reloaded.Readings) == 2)
+	assert.Nil(t, inserted)
+	assert.NotNil(t, insertErr)
+	assert.True(t, latest.Value == 1300)
+}
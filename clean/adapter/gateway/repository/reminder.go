@@ -0,0 +1,263 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ReminderRepository provides CRUD operations against a collection of reminders.
+// It is safe for concurrent use by multiple goroutines.  Reminders returned by the repository
+// are copies, so they are not affected by subsequent changes to the repository.
+type ReminderRepository struct {
+	// NextID is the next primary key ID value for a reminder being inserted into the repository.
+	NextID typedef.ID `json:"nextId"`
+
+	// These items are ordered by their ID.
+	Reminders []entity.Reminder `json:"reminders"`
+
+	// mutex guards NextID and Reminders.
+	mutex sync.RWMutex
+}
+
+// NewReminderRepository creates a new instance of a ReminderRepository.
+// Returns (nil, error) when there is an error, otherwise a (ReminderRepository, nil).
+func NewReminderRepository() (*ReminderRepository, error) {
+	reminderRepository := &ReminderRepository{
+		NextID: 0,
+
+		// Ensure that we create an empty slice rather than the default for []entity.Reminder, which is a null pointer.
+		Reminders: make([]entity.Reminder, 0),
+	}
+
+	err := reminderRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return reminderRepository, nil
+}
+
+// Validate test that a reminder repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *ReminderRepository) Validate() error {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return validation.ValidateStruct(repo,
+		// Reminders can be empty, but not nil
+		validation.Field(&repo.Reminders, validation.NotNil))
+}
+
+// ListByMotorcycle gets a snapshot of the motorcycle's reminders, in the order that they are due.
+// Returns the (list of reminders, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *ReminderRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if repo.Reminders == nil {
+		return nil, operationstatus.InternalError, errors.New("list of reminders is nil, so create the repository with NewReminderRepository()")
+	}
+
+	// Ensure that we create an empty slice rather than the default for []entity.Reminder, which is a null pointer.
+	reminders := make([]entity.Reminder, 0)
+	for _, reminder := range repo.Reminders {
+		if reminder.MotorcycleID == motorcycleID {
+			reminders = append(reminders, reminder)
+		}
+	}
+
+	// The reminders are already ordered by their ID, which breaks ties between reminders that are due at the same time.
+	sort.SliceStable(reminders, func(i, j int) bool {
+		return reminders[i].DueUtc.Before(reminders[j].DueUtc)
+	})
+
+	return reminders, operationstatus.Ok, nil
+}
+
+// FindByID a reminder in the repository using its primary key, ID.
+// Returns (reminder, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *ReminderRepository) FindByID(id typedef.ID) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	reminder := repo.Reminders[i]
+	return &reminder, operationstatus.Ok, nil
+}
+
+// FindByDue finds the motorcycle's reminder with the name that is due at the time.
+// Returns (reminder, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *ReminderRepository) FindByDue(motorcycleID typedef.ID, name string, dueUtc time.Time) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	i := repo.findByDue(motorcycleID, name, dueUtc)
+	if i < 0 {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	reminder := repo.Reminders[i]
+	return &reminder, operationstatus.Ok, nil
+}
+
+// Insert adds a reminder to the repository, unless the motorcycle already has a reminder with the name and due date.
+// Returns the (new reminder, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *ReminderRepository) Insert(reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// Work on a copy, so the caller's reminder is unchanged.
+	newReminder := *reminder
+
+	err := newReminder.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	if repo.findByDue(newReminder.MotorcycleID, newReminder.Name, newReminder.DueUtc) >= 0 {
+		return nil, operationstatus.Conflict, fmt.Errorf("the motorcycle with ID %d already has a reminder for %q on %s", newReminder.MotorcycleID,
+			newReminder.Name, newReminder.DueUtc.Format("2006-01-02"))
+	}
+
+	// Save the time when this entity was created in the repository, and its initial version.
+	newReminder.ID = repo.NextID + 1
+	newReminder.CreatedUtc = time.Now().UTC()
+	newReminder.ModifiedUtc = time.Time{}
+	newReminder.RowVersion = constant.InitialRowVersion
+
+	repo.NextID = newReminder.ID
+	repo.Reminders = append(repo.Reminders, newReminder)
+
+	return &newReminder, operationstatus.Ok, nil
+}
+
+// Update replaces an existing reminder in the repository.  The motorcycle, name and due date cannot be changed.
+// The reminder's row version must match the current one, unless it is AnyRowVersion.
+// Returns (updated reminder, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *ReminderRepository) Update(id typedef.ID, reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return nil, operationstatus.NotFound, fmt.Errorf("cannot update the reminder with ID %d because it doesn't exist in the repository", id)
+	}
+
+	err := checkReminderRowVersion(id, reminder.RowVersion, repo.Reminders[i].RowVersion)
+	if err != nil {
+		return nil, operationstatus.Conflict, err
+	}
+
+	// Work on a copy, so the repository is unchanged if the updated reminder is invalid.
+	updated := *reminder
+	updated.ID = id
+	updated.MotorcycleID = repo.Reminders[i].MotorcycleID
+	updated.Name = repo.Reminders[i].Name
+	updated.DueUtc = repo.Reminders[i].DueUtc
+	updated.CreatedUtc = repo.Reminders[i].CreatedUtc
+
+	// Save the time when this entity was updated in the repository, and its new version.
+	updated.ModifiedUtc = time.Now().UTC()
+	updated.RowVersion = repo.Reminders[i].RowVersion + 1
+
+	err = updated.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	repo.Reminders[i] = updated
+
+	return &updated, operationstatus.Ok, nil
+}
+
+// DeleteByMotorcycle removes all of the motorcycle's reminders from the repository.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *ReminderRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// Build a new list rather than removing the elements in place, so a previous snapshot's backing array is never modified.
+	reminders := make([]entity.Reminder, 0, len(repo.Reminders))
+	for _, reminder := range repo.Reminders {
+		if reminder.MotorcycleID != motorcycleID {
+			reminders = append(reminders, reminder)
+		}
+	}
+
+	repo.Reminders = reminders
+
+	return operationstatus.Ok, nil
+}
+
+// Save all of the changes to the repository (assuming some kind of unit of work/dbContext).
+// Returns nil on success, otherwise an error.
+func (repo *ReminderRepository) Save() (operationstatus.OperationStatus, error) {
+	return operationstatus.Ok, nil
+}
+
+// findByID finds the index of the reminder with the ID.
+// The reminders are ordered by their ID, so this is a binary search.
+// The caller must hold a lock.
+// Returns the index on found, otherwise -1.
+func (repo *ReminderRepository) findByID(id typedef.ID) int {
+	i := sort.Search(len(repo.Reminders), func(i int) bool {
+		return repo.Reminders[i].ID >= id
+	})
+
+	if i < len(repo.Reminders) && repo.Reminders[i].ID == id {
+		return i
+	}
+
+	return -1
+}
+
+// findByDue finds the index of the motorcycle's reminder with the name that is due at the time.
+// The caller must hold a lock.
+// Returns the index on found, otherwise -1.
+func (repo *ReminderRepository) findByDue(motorcycleID typedef.ID, name string, dueUtc time.Time) int {
+	for i, reminder := range repo.Reminders {
+		if reminder.MotorcycleID == motorcycleID && reminder.Name == name && reminder.DueUtc.Equal(dueUtc) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// snapshot gets a consistent copy of the repository's state.
+// Returns the (next ID, list of reminders).
+func (repo *ReminderRepository) snapshot() (typedef.ID, []entity.Reminder) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	reminders := make([]entity.Reminder, len(repo.Reminders))
+	copy(reminders, repo.Reminders)
+
+	return repo.NextID, reminders
+}
+
+// checkReminderRowVersion verifies that the expected row version of a reminder matches its current row version.
+// AnyRowVersion matches every row version.
+// Returns nil when the row versions match, otherwise an error.
+func checkReminderRowVersion(id typedef.ID, expected typedef.RowVersion, current typedef.RowVersion) error {
+	if expected == constant.AnyRowVersion || expected == current {
+		return nil
+	}
+
+	return fmt.Errorf("cannot change the reminder with ID %d because its row version is %d rather than %d, so it has been changed by someone else", id, current, expected)
+}
@@ -0,0 +1,150 @@
+// Package repository implements unit tests for the ReminderRepository.
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestReminder creates a pending reminder for the motorcycle, which is due the number of days after testReadingUtc.
+func newTestReminder(motorcycleID typedef.ID, name string, days int) *entity.Reminder {
+	reminder, _ := entity.NewReminder(motorcycleID, name, testReadingUtc.AddDate(0, 0, days))
+	return reminder
+}
+
+// exerciseReminders verifies the behavior that every reminder repository must provide.
+func exerciseReminders(t *testing.T, repo contract.ReminderRepository) {
+	_, status, _ := repo.FindByID(1)
+	assert.True(t, status == operationstatus.NotFound)
+
+	// Reminders are listed in the order that they are due, rather than the order they were created.
+	later, status, err := repo.Insert(newTestReminder(1, "Registration renewal", 30))
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, later.ID > 0)
+	assert.True(t, later.RowVersion == constant.InitialRowVersion)
+	assert.False(t, later.CreatedUtc.IsZero())
+
+	earlier, _, err := repo.Insert(newTestReminder(1, "Annual service", 0))
+	assert.Nil(t, err)
+
+	// A failed change discards the unsaved changes of a repository that keeps them in a unit of work.
+	repo.Save()
+
+	// A motorcycle is only reminded once of a date, but another motorcycle's reminders are independent.
+	_, status, err = repo.Insert(newTestReminder(1, "Annual service", 0))
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+
+	_, _, err = repo.Insert(newTestReminder(2, "Annual service", 0))
+	assert.Nil(t, err)
+
+	reminders, status, err := repo.ListByMotorcycle(1)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(reminders) == 2)
+	assert.True(t, reminders[0].ID == earlier.ID)
+	assert.True(t, reminders[1].ID == later.ID)
+
+	found, status, _ := repo.FindByDue(1, "Annual service", testReadingUtc)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, found.ID == earlier.ID)
+
+	found, status, _ = repo.FindByDue(1, "Annual service", testReadingUtc.AddDate(1, 0, 0))
+	assert.Nil(t, found)
+	assert.True(t, status == operationstatus.NotFound)
+
+	// An update keeps the motorcycle, name and due date, and a stale row version is a conflict.
+	snoozed := *earlier
+	snoozed.Name = "Something else"
+	snoozed.Snooze(testReadingUtc.AddDate(0, 0, 7))
+	updated, status, err := repo.Update(earlier.ID, &snoozed)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, updated.Name == "Annual service")
+	assert.True(t, updated.RowVersion == earlier.RowVersion+1)
+	repo.Save()
+
+	_, status, err = repo.Update(earlier.ID, &snoozed)
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+
+	found, _, _ = repo.FindByID(earlier.ID)
+	assert.True(t, found.Status == reminderstatus.SnoozedReminderStatus)
+	assert.True(t, found.SnoozedUntilUtc.Equal(testReadingUtc.AddDate(0, 0, 7)))
+	assert.True(t, found.CreatedUtc.Equal(earlier.CreatedUtc))
+
+	// Deleting a motorcycle's reminders leaves none of them behind.
+	status, err = repo.DeleteByMotorcycle(1)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+
+	remaining, _, _ := repo.ListByMotorcycle(1)
+	assert.True(t, len(remaining) == 0)
+
+	status, err = repo.Save()
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+}
+
+// TestReminderRepository_ListEmpty verifies that an empty list of reminders is returned.
+func TestReminderRepository_ListEmpty(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewReminderRepository()
+
+	// ACT
+	reminders, status, err := repo.ListByMotorcycle(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.NotNil(t, reminders)
+	assert.True(t, len(reminders) == 0)
+}
+
+// TestReminderRepository_Operations verifies inserting, listing, finding and updating reminders.
+func TestReminderRepository_Operations(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewReminderRepository()
+
+	// ACT & ASSERT
+	exerciseReminders(t, repo)
+}
+
+// TestFileReminderRepository_SaveAndReload verifies that saved reminders survive a reload of the repository.
+func TestFileReminderRepository_SaveAndReload(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	path = filepath.Join(filepath.Dir(path), "reminders.json")
+
+	repo, _ := NewFileReminderRepository(path)
+	repo.Insert(newTestReminder(1, "Annual service", 0))
+	repo.Insert(newTestReminder(1, "Registration renewal", 1))
+	repo.Save()
+
+	// ACT
+	reloaded, err := NewFileReminderRepository(path)
+	inserted, _, _ := reloaded.Insert(newTestReminder(1, "Insurance renewal", 2))
+	reminders, _, _ := reloaded.ListByMotorcycle(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(reminders) == 3)
+	assert.True(t, inserted.ID == 3)
+	assert.True(t, reminders[1].Name == "Registration renewal")
+	assert.True(t, reminders[1].Status == reminderstatus.PendingReminderStatus)
+	assert.True(t, reminders[1].DueUtc.Equal(testReadingUtc.Add(24*time.Hour)))
+}
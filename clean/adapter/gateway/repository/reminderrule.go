@@ -0,0 +1,96 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ReminderRuleRepository provides read only access to the reminder rules that define the calendar dates when owners are
+// reminded.  It is safe for concurrent use by multiple goroutines, because the rules are never changed once the repository
+// has been created.
+type ReminderRuleRepository struct {
+	Rules []entity.ReminderRule `json:"rules"`
+}
+
+// NewReminderRuleRepository creates a new instance of a ReminderRuleRepository containing the rules.
+// Returns (nil, error) when there is an error, otherwise a (ReminderRuleRepository, nil).
+func NewReminderRuleRepository(rules []entity.ReminderRule) (*ReminderRuleRepository, error) {
+	ruleRepository := &ReminderRuleRepository{
+		// Ensure that we create an empty slice rather than the default for []entity.ReminderRule, which is a null pointer.
+		Rules: append(make([]entity.ReminderRule, 0, len(rules)), rules...),
+	}
+
+	err := ruleRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return ruleRepository, nil
+}
+
+// DefaultReminderRuleRepository creates the rules that are used when they have not been configured.
+// Every motorcycle has an annual service, and its owner is reminded two weeks before.
+// Returns a (ReminderRuleRepository, nil).
+func DefaultReminderRuleRepository() (*ReminderRuleRepository, error) {
+	return NewReminderRuleRepository([]entity.ReminderRule{
+		{Name: "Annual service", Months: 12, LeadDays: 14},
+	})
+}
+
+// LoadReminderRuleRepository reads the rules from a JSON file, which is a list of reminder rules.
+// For example, [{"name": "Annual service", "months": 12, "leadDays": 14},
+// {"motorcycleId": 7, "name": "Registration renewal", "month": 3, "day": 31, "leadDays": 30}].
+// Returns (ReminderRuleRepository, nil) on success, otherwise (nil, error).
+func LoadReminderRuleRepository(path string) (*ReminderRuleRepository, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]entity.ReminderRule, 0)
+	err = json.Unmarshal(contents, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("the reminder rules are not valid: %s", err.Error())
+	}
+
+	return NewReminderRuleRepository(rules)
+}
+
+// Validate test that a reminder rule repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *ReminderRuleRepository) Validate() error {
+	err := validation.ValidateStruct(repo,
+		// Rules can be empty, but not nil
+		validation.Field(&repo.Rules, validation.NotNil))
+	if err != nil {
+		return err
+	}
+
+	for i, rule := range repo.Rules {
+		err = rule.Validate()
+		if err != nil {
+			return fmt.Errorf("reminder rule %d is not valid: %s", i+1, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// List gets a snapshot of all of the reminder rules.
+// Returns the (list of reminder rules, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *ReminderRuleRepository) List() ([]entity.ReminderRule, operationstatus.OperationStatus, error) {
+	return append(make([]entity.ReminderRule, 0, len(repo.Rules)), repo.Rules...), operationstatus.Ok, nil
+}
+
+// ListByMotorcycle gets the most specific rule for each name that applies to the motorcycle.
+// Returns the (list of reminder rules, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *ReminderRuleRepository) ListByMotorcycle(motorcycle entity.Motorcycle) ([]entity.ReminderRule, operationstatus.OperationStatus, error) {
+	return entity.SelectReminderRules(repo.Rules, motorcycle), operationstatus.Ok, nil
+}
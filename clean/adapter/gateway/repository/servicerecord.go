@@ -0,0 +1,267 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ServiceRecordRepository provides CRUD operations against a collection of service records.
+// It is safe for concurrent use by multiple goroutines.  Service records returned by the repository
+// are copies, so they are not affected by subsequent changes to the repository.
+type ServiceRecordRepository struct {
+	// NextID is the next primary key ID value for a service record being inserted into the repository.
+	NextID typedef.ID `json:"nextId"`
+
+	// These items are ordered by their ID.
+	ServiceRecords []entity.ServiceRecord `json:"serviceRecords"`
+
+	// mutex guards NextID and ServiceRecords.
+	mutex sync.RWMutex
+}
+
+// NewServiceRecordRepository creates a new instance of a ServiceRecordRepository.
+// Returns (nil, error) when there is an error, otherwise a (ServiceRecordRepository, nil).
+func NewServiceRecordRepository() (*ServiceRecordRepository, error) {
+	recordRepository := &ServiceRecordRepository{
+		NextID: 0,
+
+		// Ensure that we create an empty slice rather than the default for []entity.ServiceRecord, which is a null pointer.
+		ServiceRecords: make([]entity.ServiceRecord, 0),
+	}
+
+	err := recordRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return recordRepository, nil
+}
+
+// Validate test that a service record repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *ServiceRecordRepository) Validate() error {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return validation.ValidateStruct(repo,
+		// ServiceRecords can be empty, but not nil
+		validation.Field(&repo.ServiceRecords, validation.NotNil))
+}
+
+// ListByMotorcycle gets a snapshot of the motorcycle's service records, in the order that the services were performed.
+// Returns the (list of service records, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *ServiceRecordRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if repo.ServiceRecords == nil {
+		return nil, operationstatus.InternalError, errors.New("list of service records is nil, so create the repository with NewServiceRecordRepository()")
+	}
+
+	// Ensure that we create an empty slice rather than the default for []entity.ServiceRecord, which is a null pointer.
+	records := make([]entity.ServiceRecord, 0)
+	for _, record := range repo.ServiceRecords {
+		if record.MotorcycleID == motorcycleID {
+			records = append(records, copyServiceRecord(record))
+		}
+	}
+
+	// The records are already ordered by their ID, which breaks ties between services performed at the same time.
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].PerformedUtc.Before(records[j].PerformedUtc)
+	})
+
+	return records, operationstatus.Ok, nil
+}
+
+// FindByID a service record in the repository using its primary key, ID.
+// Returns (service record, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *ServiceRecordRepository) FindByID(id typedef.ID) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	record := copyServiceRecord(repo.ServiceRecords[i])
+	return &record, operationstatus.Ok, nil
+}
+
+// Insert adds a service record to the repository.
+// Returns the (new service record, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *ServiceRecordRepository) Insert(record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// Work on a copy, so the caller's service record is unchanged.
+	newRecord := copyServiceRecord(*record)
+
+	err := newRecord.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Save the time when this entity was created in the repository, and its initial version.
+	newRecord.ID = repo.NextID + 1
+	newRecord.CreatedUtc = time.Now().UTC()
+	newRecord.ModifiedUtc = time.Time{}
+	newRecord.RowVersion = constant.InitialRowVersion
+
+	repo.NextID = newRecord.ID
+	repo.ServiceRecords = append(repo.ServiceRecords, newRecord)
+
+	inserted := copyServiceRecord(newRecord)
+	return &inserted, operationstatus.Ok, nil
+}
+
+// Update replaces an existing service record in the repository.  The motorcycle cannot be changed.
+// The service record's row version must match the current one, unless it is AnyRowVersion.
+// Returns (updated service record, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *ServiceRecordRepository) Update(id typedef.ID, record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return nil, operationstatus.NotFound, fmt.Errorf("cannot update the service record with ID %d because it doesn't exist in the repository", id)
+	}
+
+	err := checkServiceRecordRowVersion(id, record.RowVersion, repo.ServiceRecords[i].RowVersion)
+	if err != nil {
+		return nil, operationstatus.Conflict, err
+	}
+
+	// Work on a copy, so the repository is unchanged if the updated service record is invalid.
+	updated := copyServiceRecord(*record)
+	updated.ID = id
+	updated.MotorcycleID = repo.ServiceRecords[i].MotorcycleID
+	updated.CreatedUtc = repo.ServiceRecords[i].CreatedUtc
+
+	// Save the time when this entity was updated in the repository, and its new version.
+	updated.ModifiedUtc = time.Now().UTC()
+	updated.RowVersion = repo.ServiceRecords[i].RowVersion + 1
+
+	err = updated.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	repo.ServiceRecords[i] = updated
+
+	result := copyServiceRecord(updated)
+	return &result, operationstatus.Ok, nil
+}
+
+// Delete removes a service record from the repository.
+// The row version must match the service record's current one, unless it is AnyRowVersion.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *ServiceRecordRepository) Delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	i := repo.findByID(id)
+	if i < 0 {
+		return operationstatus.NotFound, fmt.Errorf("cannot delete the service record with ID %d because it doesn't exist in the repository", id)
+	}
+
+	err := checkServiceRecordRowVersion(id, rowVersion, repo.ServiceRecords[i].RowVersion)
+	if err != nil {
+		return operationstatus.Conflict, err
+	}
+
+	// Build a new list rather than shifting the elements in place, so a previous snapshot's backing array is never modified.
+	records := make([]entity.ServiceRecord, 0, len(repo.ServiceRecords)-1)
+	records = append(records, repo.ServiceRecords[:i]...)
+	repo.ServiceRecords = append(records, repo.ServiceRecords[i+1:]...)
+
+	return operationstatus.Ok, nil
+}
+
+// DeleteByMotorcycle removes all of the motorcycle's service records from the repository.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *ServiceRecordRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// Build a new list rather than removing the elements in place, so a previous snapshot's backing array is never modified.
+	records := make([]entity.ServiceRecord, 0, len(repo.ServiceRecords))
+	for _, record := range repo.ServiceRecords {
+		if record.MotorcycleID != motorcycleID {
+			records = append(records, record)
+		}
+	}
+
+	repo.ServiceRecords = records
+
+	return operationstatus.Ok, nil
+}
+
+// Save all of the changes to the repository (assuming some kind of unit of work/dbContext).
+// Returns nil on success, otherwise an error.
+func (repo *ServiceRecordRepository) Save() (operationstatus.OperationStatus, error) {
+	return operationstatus.Ok, nil
+}
+
+// findByID finds the index of the service record with the ID.
+// The service records are ordered by their ID, so this is a binary search.
+// The caller must hold a lock.
+// Returns the index on found, otherwise -1.
+func (repo *ServiceRecordRepository) findByID(id typedef.ID) int {
+	i := sort.Search(len(repo.ServiceRecords), func(i int) bool {
+		return repo.ServiceRecords[i].ID >= id
+	})
+
+	if i < len(repo.ServiceRecords) && repo.ServiceRecords[i].ID == id {
+		return i
+	}
+
+	return -1
+}
+
+// snapshot gets a consistent copy of the repository's state.
+// Returns the (next ID, list of service records).
+func (repo *ServiceRecordRepository) snapshot() (typedef.ID, []entity.ServiceRecord) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	records := make([]entity.ServiceRecord, len(repo.ServiceRecords))
+	for i, record := range repo.ServiceRecords {
+		records[i] = copyServiceRecord(record)
+	}
+
+	return repo.NextID, records
+}
+
+// copyServiceRecord copies a service record, including its list of parts, so the copy does not share any state with the original.
+// Returns the copy.
+func copyServiceRecord(record entity.ServiceRecord) entity.ServiceRecord {
+	parts := make([]string, len(record.Parts))
+	copy(parts, record.Parts)
+	record.Parts = parts
+
+	return record
+}
+
+// checkServiceRecordRowVersion verifies that the expected row version of a service record matches its current row version.
+// AnyRowVersion matches every row version.
+// Returns nil when the row versions match, otherwise an error.
+func checkServiceRecordRowVersion(id typedef.ID, expected typedef.RowVersion, current typedef.RowVersion) error {
+	if expected == constant.AnyRowVersion || expected == current {
+		return nil
+	}
+
+	return fmt.Errorf("cannot change the service record with ID %d because its row version is %d rather than %d, so it has been changed by someone else", id, current, expected)
+}
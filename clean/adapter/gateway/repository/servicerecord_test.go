@@ -0,0 +1,183 @@
+// Package repository implements unit tests for the ServiceRecordRepository.
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServiceRecord creates a record of the service performed on the motorcycle the number of days after testReadingUtc.
+func newTestServiceRecord(motorcycleID typedef.ID, serviceType servicetype.ServiceType, days int, odometer float64) *entity.ServiceRecord {
+	record, _ := entity.NewServiceRecord(motorcycleID, serviceType, testReadingUtc.AddDate(0, 0, days), odometer, distanceunit.MilesDistanceUnit,
+		"", []string{"Oil filter"}, 0.5, 45.50, "Owner")
+	return record
+}
+
+// exerciseServiceRecords verifies the behavior that every service record repository must provide.
+func exerciseServiceRecords(t *testing.T, repo contract.ServiceRecordRepository) {
+	_, status, _ := repo.FindByID(1)
+	assert.True(t, status == operationstatus.NotFound)
+
+	// Records are listed in the order that the service was performed, rather than the order they were recorded.
+	later, status, err := repo.Insert(newTestServiceRecord(1, servicetype.OilChangeServiceType, 30, 5000))
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, later.ID > 0)
+	assert.True(t, later.RowVersion == constant.InitialRowVersion)
+	assert.False(t, later.CreatedUtc.IsZero())
+
+	earlier, _, err := repo.Insert(newTestServiceRecord(1, servicetype.ChainAdjustmentServiceType, 0, 4000))
+	assert.Nil(t, err)
+
+	// Another motorcycle's records are independent.
+	_, _, err = repo.Insert(newTestServiceRecord(2, servicetype.InspectionServiceType, 0, 10))
+	assert.Nil(t, err)
+
+	records, status, err := repo.ListByMotorcycle(1)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(records) == 2)
+	assert.True(t, records[0].ID == earlier.ID)
+	assert.True(t, records[1].ID == later.ID)
+	assert.True(t, records[1].Parts[0] == "Oil filter")
+
+	// An update keeps the motorcycle, and a stale row version is a conflict.
+	correction := newTestServiceRecord(2, servicetype.OilChangeServiceType, 30, 5100)
+	correction.RowVersion = later.RowVersion
+	updated, status, err := repo.Update(later.ID, correction)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, updated.MotorcycleID == 1)
+	assert.True(t, updated.Odometer == 5100)
+	assert.True(t, updated.RowVersion == later.RowVersion+1)
+
+	// A failed change discards the unsaved changes of a repository that keeps them in a unit of work.
+	repo.Save()
+
+	_, status, err = repo.Update(later.ID, correction)
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+
+	found, _, _ := repo.FindByID(later.ID)
+	assert.True(t, found.Odometer == 5100)
+	assert.True(t, found.CreatedUtc.Equal(later.CreatedUtc))
+
+	status, err = repo.Delete(later.ID, later.RowVersion)
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+
+	status, err = repo.Delete(later.ID, updated.RowVersion)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+
+	found, status, _ = repo.FindByID(later.ID)
+	assert.Nil(t, found)
+	assert.True(t, status == operationstatus.NotFound)
+
+	// Deleting a motorcycle's service records leaves none of them behind.
+	status, err = repo.DeleteByMotorcycle(1)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+
+	remaining, _, _ := repo.ListByMotorcycle(1)
+	assert.True(t, len(remaining) == 0)
+
+	status, err = repo.Save()
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+}
+
+// TestServiceRecordRepository_ListEmpty verifies that an empty list of service records is returned.
+func TestServiceRecordRepository_ListEmpty(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewServiceRecordRepository()
+
+	// ACT
+	records, status, err := repo.ListByMotorcycle(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.NotNil(t, records)
+	assert.True(t, len(records) == 0)
+}
+
+// TestServiceRecordRepository_Operations verifies inserting, listing, updating and deleting service records.
+func TestServiceRecordRepository_Operations(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewServiceRecordRepository()
+
+	// ACT & ASSERT
+	exerciseServiceRecords(t, repo)
+}
+
+// TestServiceRecordRepository_FindByID_IsCopy verifies that changing a found service record does not change the repository.
+func TestServiceRecordRepository_FindByID_IsCopy(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewServiceRecordRepository()
+	inserted, _, _ := repo.Insert(newTestServiceRecord(1, servicetype.OilChangeServiceType, 0, 4000))
+	found, _, _ := repo.FindByID(inserted.ID)
+
+	// ACT
+	found.Parts[0] = "Air filter"
+	found.Cost = 0
+	again, _, _ := repo.FindByID(inserted.ID)
+
+	// ASSERT
+	assert.True(t, again.Parts[0] == "Oil filter")
+	assert.True(t, again.Cost == 45.50)
+}
+
+// TestServiceRecordRepository_Update_NotExist verifies that an update fails if the service record does not exist.
+func TestServiceRecordRepository_Update_NotExist(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := NewServiceRecordRepository()
+
+	// ACT
+	record, status, err := repo.Update(123, newTestServiceRecord(1, servicetype.OilChangeServiceType, 0, 4000))
+
+	// ASSERT
+	assert.Nil(t, record)
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.NotFound)
+}
+
+// TestFileServiceRecordRepository_SaveAndReload verifies that saved service records survive a reload of the repository.
+func TestFileServiceRecordRepository_SaveAndReload(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := tempRepositoryPath(t)
+	defer cleanup()
+	path = filepath.Join(filepath.Dir(path), "services.json")
+
+	repo, _ := NewFileServiceRecordRepository(path)
+	repo.Insert(newTestServiceRecord(1, servicetype.OilChangeServiceType, 0, 4000))
+	repo.Insert(newTestServiceRecord(1, servicetype.TireReplacementServiceType, 1, 4100))
+	repo.Save()
+
+	// ACT
+	reloaded, err := NewFileServiceRecordRepository(path)
+	inserted, _, _ := reloaded.Insert(newTestServiceRecord(1, servicetype.BrakeServiceType, 2, 4200))
+	records, _, _ := reloaded.ListByMotorcycle(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(records) == 3)
+	assert.True(t, inserted.ID == 3)
+	assert.True(t, records[1].Type == servicetype.TireReplacementServiceType)
+	assert.True(t, records[1].PerformedUtc.Equal(testReadingUtc.Add(24*time.Hour)))
+}
@@ -0,0 +1,679 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/motorcyclefield"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// motorcycleSchema creates the table and indexes for motorcycles, when they do not exist.
+// The dialect is SQLite's.
+var motorcycleSchema = []string{
+	`CREATE TABLE IF NOT EXISTS motorcycles (
+		id           INTEGER  PRIMARY KEY AUTOINCREMENT,
+		make         TEXT     NOT NULL,
+		model        TEXT     NOT NULL,
+		year         INTEGER  NOT NULL,
+		vin          TEXT     NOT NULL,
+		created_utc  DATETIME NOT NULL,
+		modified_utc DATETIME NOT NULL,
+		row_version  INTEGER  NOT NULL DEFAULT 1,
+		owner_id     TEXT     NOT NULL DEFAULT ''
+	)`,
+}
+
+// motorcycleIndexes creates the indexes on columns that may have been added by a migration, when they do not exist.
+// VINs are unique regardless of case and surrounding spaces, as they are in the MotorcycleRepository, so the index on
+// the VIN as it was entered, which was created by an earlier version of the schema, is replaced by one on the normalized VIN.
+var motorcycleIndexes = []string{
+	`DROP INDEX IF EXISTS ux_motorcycles_vin`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS ux_motorcycles_normalized_vin ON motorcycles (UPPER(TRIM(vin)))`,
+	`CREATE INDEX IF NOT EXISTS ix_motorcycles_owner_id ON motorcycles (owner_id)`,
+}
+
+// motorcycleColumnMigrations are the columns that are added to a motorcycles table created by an earlier version of the schema.
+var motorcycleColumnMigrations = []struct {
+	name       string
+	definition string
+}{
+	{"row_version", "INTEGER NOT NULL DEFAULT 1"},
+	{"owner_id", "TEXT NOT NULL DEFAULT ''"},
+}
+
+// motorcycleColumns is the list of columns that are selected for a motorcycle.
+const motorcycleColumns = "id, make, model, year, vin, created_utc, modified_utc, row_version, owner_id"
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// SqlMotorcycleRepository provides CRUD operations against a SQL database of motorcycles.
+// Changes are made within a transaction, which is committed by Save(), and rolled back when a change fails.
+// It is safe for concurrent use by multiple goroutines, but they share the unit of work, so each caller that changes the
+// motorcycles, such as a request, uses a Session() of its own.
+type SqlMotorcycleRepository struct {
+	// DB is the database containing the motorcycles.
+	DB *sql.DB
+
+	// tx is the unit of work containing the changes that have not been saved.
+	tx *sql.Tx
+
+	// mutex guards tx, and makes each operation atomic.
+	mutex sync.Mutex
+}
+
+// NewSqlMotorcycleRepository creates a new instance of a SqlMotorcycleRepository, and creates its schema if it does not exist.
+// Returns (nil, error) when there is an error, otherwise a (SqlMotorcycleRepository, nil).
+func NewSqlMotorcycleRepository(db *sql.DB) (*SqlMotorcycleRepository, error) {
+	motorcycleRepository := &SqlMotorcycleRepository{
+		DB: db,
+	}
+
+	err := motorcycleRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range motorcycleSchema {
+		_, err = db.Exec(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = migrateMotorcycleSchema(db)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range motorcycleIndexes {
+		_, err = db.Exec(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// All okay
+	return motorcycleRepository, nil
+}
+
+// migrateMotorcycleSchema adds the columns that are missing from the motorcycles table.
+// Returns nil on success, otherwise an error.
+func migrateMotorcycleSchema(db *sql.DB) error {
+	rows, err := db.Query("PRAGMA table_info(motorcycles)")
+	if err != nil {
+		return err
+	}
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var cid, notNull, primaryKey int
+		var name, columnType string
+		var defaultValue sql.NullString
+		err = rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		columns[name] = true
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, column := range motorcycleColumnMigrations {
+		if columns[column.name] {
+			continue
+		}
+
+		_, err = db.Exec("ALTER TABLE motorcycles ADD COLUMN " + column.name + " " + column.definition)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate test that a SQL motorcycle repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *SqlMotorcycleRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// DB cannot be nil.
+		validation.Field(&repo.DB, validation.NotNil))
+}
+
+// List gets the list of motorcycles in the repository ordered by their ID.
+// Returns the (list of motorcycles, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) List() ([]entity.Motorcycle, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.findMany("SELECT " + motorcycleColumns + " FROM motorcycles ORDER BY id")
+}
+
+// motorcycleSortColumns are the expressions by which the motorcycles are sorted and compared for each field.
+// Text is compared without regard to case, as entity.MotorcycleQuery.Compare() does.
+var motorcycleSortColumns = map[motorcyclefield.MotorcycleField]string{
+	motorcyclefield.IDMotorcycleField:    "id",
+	motorcyclefield.MakeMotorcycleField:  "make COLLATE NOCASE",
+	motorcyclefield.ModelMotorcycleField: "model COLLATE NOCASE",
+	motorcyclefield.YearMotorcycleField:  "year",
+	motorcyclefield.VinMotorcycleField:   "vin COLLATE NOCASE",
+}
+
+// Query gets a page of the motorcycles in the repository that satisfy the query's filters, in the query's sort order.
+// The page after a cursor begins with the first motorcycle that is sorted after it, so it is not affected by the
+// motorcycles that are inserted or deleted in the meantime.
+// Returns the (page of motorcycles, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) Query(query entity.MotorcycleQuery) (*entity.MotorcyclePage, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if query.OwnerID != "" {
+		conditions, args = append(conditions, "owner_id = ?"), append(args, query.OwnerID)
+	}
+	if query.Make != "" {
+		conditions, args = append(conditions, "TRIM(make) = ? COLLATE NOCASE"), append(args, strings.TrimSpace(query.Make))
+	}
+	if query.Model != "" {
+		conditions, args = append(conditions, "TRIM(model) = ? COLLATE NOCASE"), append(args, strings.TrimSpace(query.Model))
+	}
+	if query.MinYear > 0 {
+		conditions, args = append(conditions, "year >= ?"), append(args, query.MinYear)
+	}
+	if query.MaxYear > 0 {
+		conditions, args = append(conditions, "year <= ?"), append(args, query.MaxYear)
+	}
+	if query.VinPrefix != "" {
+		// The prefix only contains letters and digits, so it cannot contain a wildcard.
+		conditions, args = append(conditions, "vin LIKE ?"), append(args, query.VinPrefix+"%")
+	}
+
+	// The motorcycles after the cursor are those that have the same values for the first few fields of the sort order,
+	// and are sorted after it by the next one.
+	order := query.SortOrder()
+	if query.After != nil {
+		alternatives := make([]string, 0, len(order))
+		for i, s := range order {
+			terms := make([]string, 0, i+1)
+			for _, previous := range order[:i] {
+				terms, args = append(terms, motorcycleSortColumns[previous.Field]+" = ?"), append(args, query.After.Value(previous.Field))
+			}
+
+			comparison := " > ?"
+			if s.Descending {
+				comparison = " < ?"
+			}
+			terms, args = append(terms, motorcycleSortColumns[s.Field]+comparison), append(args, query.After.Value(s.Field))
+			alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	statement := "SELECT " + motorcycleColumns + " FROM motorcycles"
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	orderBy := make([]string, 0, len(order))
+	for _, s := range order {
+		direction := " ASC"
+		if s.Descending {
+			direction = " DESC"
+		}
+		orderBy = append(orderBy, motorcycleSortColumns[s.Field]+direction)
+	}
+
+	// One more than the page size is selected to determine whether there is another page.
+	statement += " ORDER BY " + strings.Join(orderBy, ", ") + " LIMIT ?"
+	args = append(args, query.PageSize()+1)
+
+	motorcycles, status, err := repo.findMany(statement, args...)
+	if err != nil {
+		return nil, status, err
+	}
+
+	page := &entity.MotorcyclePage{Motorcycles: motorcycles}
+	if len(motorcycles) > query.PageSize() {
+		page.Motorcycles = motorcycles[:query.PageSize()]
+		page.Next = query.Cursor(page.Motorcycles[len(page.Motorcycles)-1])
+	}
+
+	return page, operationstatus.Ok, nil
+}
+
+// findMany gets the motorcycles selected by a query.  The caller must hold the lock.
+// Returns the (list of motorcycles, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) findMany(query string, args ...interface{}) ([]entity.Motorcycle, operationstatus.OperationStatus, error) {
+	rows, err := repo.queryer().Query(query, args...)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+	defer rows.Close()
+
+	// Ensure that we create an empty slice rather than the default for []entity.Motorcycle, which is a null pointer.
+	motorcycles := make([]entity.Motorcycle, 0)
+	for rows.Next() {
+		motorcycle := entity.Motorcycle{}
+		err = rows.Scan(&motorcycle.ID, &motorcycle.Make, &motorcycle.Model, &motorcycle.Year, &motorcycle.Vin, &motorcycle.CreatedUtc, &motorcycle.ModifiedUtc, &motorcycle.RowVersion, &motorcycle.OwnerID)
+		if err != nil {
+			return nil, operationstatus.InternalError, err
+		}
+		motorcycles = append(motorcycles, motorcycle)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return motorcycles, operationstatus.Ok, nil
+}
+
+// ExistsByVin determines whether a motorcycle with the VIN exists in the repository.
+// Returns (true, Ok, nil) for found, (false, Ok, nil) for not found, otherwise (false, operationStatus, error).
+func (repo *SqlMotorcycleRepository) ExistsByVin(vin string) (bool, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	moto, status, err := repo.findByVin(vin)
+	if err != nil {
+		return false, status, err
+	}
+
+	return moto != nil, status, nil
+}
+
+// ExistsByID determines whether a motorcycle with the ID exists in the repository.
+// Returns (true, Ok, nil) for found, (false, Ok, nil) for not found, otherwise (false, operationStatus, error).
+func (repo *SqlMotorcycleRepository) ExistsByID(id typedef.ID) (bool, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	moto, status, err := repo.findByID(id)
+	if err != nil {
+		return false, status, err
+	}
+
+	return moto != nil, status, nil
+}
+
+// Insert adds a motorcycle to the repository.
+// Does not permit duplicate VIN values.
+// Returns the (new motorcycle, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) Insert(motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	inserted, status, err := repo.insert(motorcycle)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return inserted, status, err
+}
+
+// insert adds a motorcycle to the repository.  The caller must hold the lock.
+func (repo *SqlMotorcycleRepository) insert(motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	moto, status, err := repo.findByVin(motorcycle.Vin)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if moto != nil {
+		return nil, operationstatus.DuplicateKey, fmt.Errorf("cannot insert the motorcycle with VIN %s because the VIN already exists in the repository", motorcycle.Vin)
+	}
+
+	// Validate the object
+	err = motorcycle.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	tx, err := repo.begin()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Save the time when this entity was created in the repository.
+	createdUtc := time.Now().UTC()
+
+	result, err := tx.Exec("INSERT INTO motorcycles (make, model, year, vin, created_utc, modified_utc, row_version, owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		motorcycle.Make, motorcycle.Model, motorcycle.Year, motorcycle.Vin, createdUtc, time.Time{}, constant.InitialRowVersion, motorcycle.OwnerID)
+	if isUniqueViolation(err) {
+		// Another unit of work has inserted the VIN since it was checked.
+		return nil, operationstatus.DuplicateKey, fmt.Errorf("cannot insert the motorcycle with VIN %s because the VIN already exists in the repository", motorcycle.Vin)
+	}
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Assign the ID to the new motorcycle.
+	motorcycle.ID = typedef.ID(id)
+	motorcycle.CreatedUtc = createdUtc
+	motorcycle.RowVersion = constant.InitialRowVersion
+
+	return motorcycle, operationstatus.Ok, nil
+}
+
+// Update replaces an existing motorcycle in the repository.
+// If the motorcycle does not exist, an error is returned.
+// Does not permit duplicate VIN values.
+// The motorcycle's row version must match the current one, unless it is AnyRowVersion.
+// Returns (updated motorcycle, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) Update(id typedef.ID, motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	updated, status, err := repo.update(id, motorcycle)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return updated, status, err
+}
+
+// update replaces an existing motorcycle in the repository.  The caller must hold the lock.
+func (repo *SqlMotorcycleRepository) update(id typedef.ID, motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	moto, status, err := repo.findByID(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if moto == nil {
+		return nil, status, fmt.Errorf("cannot update the motorcycle with ID %d because it doesn't exist in the repository", id)
+	}
+
+	err = checkRowVersion(id, motorcycle.RowVersion, moto.RowVersion)
+	if err != nil {
+		return nil, operationstatus.Conflict, err
+	}
+
+	other, status, err := repo.findByVin(motorcycle.Vin)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if other != nil && other.ID != id {
+		return nil, operationstatus.DuplicateKey, fmt.Errorf("cannot update the motorcycle with ID %d because the VIN %s already exists in the repository", id, motorcycle.Vin)
+	}
+
+	// Update the fields that can be modified...
+	moto.Make = motorcycle.Make
+	moto.Model = motorcycle.Model
+	moto.Year = motorcycle.Year
+	moto.Vin = motorcycle.Vin
+
+	// Save the time when this entity was updated in the repository, and its new version.
+	currentRowVersion := moto.RowVersion
+	moto.ModifiedUtc = time.Now().UTC()
+	moto.RowVersion++
+
+	// Validate the object
+	err = moto.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	tx, err := repo.begin()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// The row version is checked again, in case another process has changed the motorcycle.
+	result, err := tx.Exec("UPDATE motorcycles SET make = ?, model = ?, year = ?, vin = ?, modified_utc = ?, row_version = ? WHERE id = ? AND row_version = ?",
+		moto.Make, moto.Model, moto.Year, moto.Vin, moto.ModifiedUtc, moto.RowVersion, id, currentRowVersion)
+	if isUniqueViolation(err) {
+		// Another unit of work has given the VIN to a motorcycle since it was checked.
+		return nil, operationstatus.DuplicateKey, fmt.Errorf("cannot update the motorcycle with ID %d because the VIN %s already exists in the repository", id, motorcycle.Vin)
+	}
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	if count == 0 {
+		return nil, operationstatus.Conflict, fmt.Errorf("cannot update the motorcycle with ID %d because it has been changed by someone else", id)
+	}
+
+	return moto, operationstatus.Ok, nil
+}
+
+// FindByID a motorcycle in the repository using its primary key, ID.
+// Returns (motorcycle, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) FindByID(id typedef.ID) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.findByID(id)
+}
+
+// findByID a motorcycle in the repository using its primary key, ID.
+// The caller must hold the mutex.
+// Returns (motorcycle, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) findByID(id typedef.ID) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	motorcycle, err := repo.findOne("SELECT "+motorcycleColumns+" FROM motorcycles WHERE id = ?", id)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Not Found
+	if motorcycle == nil {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	// Motorcycle was found.
+	return motorcycle, operationstatus.Ok, nil
+}
+
+// FindByVin a motorcycle in the repository using its VIN.
+// Returns (motorcycle, Found, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) FindByVin(vin string) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.findByVin(vin)
+}
+
+// findByVin a motorcycle in the repository using its VIN.
+// The caller must hold the mutex.
+// Returns (motorcycle, Found, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlMotorcycleRepository) findByVin(vin string) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	motorcycle, err := repo.findOne("SELECT "+motorcycleColumns+" FROM motorcycles WHERE UPPER(TRIM(vin)) = ?", normalizeVin(vin))
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Not Found
+	if motorcycle == nil {
+		return nil, operationstatus.NotFound, nil
+	}
+
+	// Motorcycle was found.
+	return motorcycle, operationstatus.Found, nil
+}
+
+// Delete an existing motorcycle from the repository.
+// If the motorcycle does not exist, an error is returned.
+// The row version must match the motorcycle's current one, unless it is AnyRowVersion.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *SqlMotorcycleRepository) Delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	status, err := repo.delete(id, rowVersion)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return status, err
+}
+
+// delete an existing motorcycle from the repository.  The caller must hold the lock.
+func (repo *SqlMotorcycleRepository) delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error) {
+	moto, status, err := repo.findByID(id)
+	if err != nil {
+		return status, err
+	}
+
+	if moto == nil {
+		return operationstatus.NotFound, fmt.Errorf("cannot delete the motorcycle with ID %d because it was not found", id)
+	}
+
+	err = checkRowVersion(id, rowVersion, moto.RowVersion)
+	if err != nil {
+		return operationstatus.Conflict, err
+	}
+
+	tx, err := repo.begin()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	result, err := tx.Exec("DELETE FROM motorcycles WHERE id = ? AND row_version = ?", id, moto.RowVersion)
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	if count == 0 {
+		return operationstatus.Conflict, fmt.Errorf("cannot delete the motorcycle with ID %d because it has been changed by someone else", id)
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// Save commits all of the changes to the repository.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *SqlMotorcycleRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.tx == nil {
+		// Nothing has changed.
+		return operationstatus.Ok, nil
+	}
+
+	tx := repo.tx
+	repo.tx = nil
+
+	err := tx.Commit()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// Session creates a repository of the same motorcycles, which has a unit of work of its own.
+func (repo *SqlMotorcycleRepository) Session() contract.MotorcycleRepository {
+	return &SqlMotorcycleRepository{
+		DB: repo.DB,
+	}
+}
+
+// Rollback discards the changes that have not been saved.
+// Returns nil on success, otherwise an error.
+func (repo *SqlMotorcycleRepository) Rollback() error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.rollback()
+}
+
+// rollback discards the changes that have not been saved.  The caller must hold the lock.
+// Returns nil on success, otherwise an error.
+func (repo *SqlMotorcycleRepository) rollback() error {
+	if repo.tx == nil {
+		// Nothing has changed.
+		return nil
+	}
+
+	tx := repo.tx
+	repo.tx = nil
+
+	return tx.Rollback()
+}
+
+// begin gets the transaction for the unit of work, and starts one when there isn't one in progress.
+// Returns (transaction, nil) on success, otherwise (nil, error).
+func (repo *SqlMotorcycleRepository) begin() (*sql.Tx, error) {
+	if repo.tx != nil {
+		return repo.tx, nil
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	repo.tx = tx
+	return tx, nil
+}
+
+// queryer gets the transaction for the unit of work when one is in progress, so queries can see its changes,
+// otherwise it gets the database.
+func (repo *SqlMotorcycleRepository) queryer() queryer {
+	if repo.tx != nil {
+		return repo.tx
+	}
+
+	return repo.DB
+}
+
+// isUniqueViolation determines whether an error is the database's report that a change would duplicate a unique key,
+// such as the VIN of another motorcycle.
+func isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// findOne gets the single motorcycle selected by a query.
+// Returns (motorcycle, nil) on found, (nil, nil) for not found, otherwise (nil, error).
+func (repo *SqlMotorcycleRepository) findOne(query string, args ...interface{}) (*entity.Motorcycle, error) {
+	motorcycle := &entity.Motorcycle{}
+
+	err := repo.queryer().QueryRow(query, args...).Scan(&motorcycle.ID, &motorcycle.Make, &motorcycle.Model, &motorcycle.Year, &motorcycle.Vin, &motorcycle.CreatedUtc, &motorcycle.ModifiedUtc, &motorcycle.RowVersion, &motorcycle.OwnerID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return motorcycle, nil
+}
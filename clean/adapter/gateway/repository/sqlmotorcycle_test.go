@@ -0,0 +1,512 @@
+// Package repository implements unit tests for the SqlMotorcycleRepository.
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+// openTestDatabase creates an empty SQLite database in a temporary directory.
+// Returns the (database, function that closes and removes the database).
+func openTestDatabase(t *testing.T) (*sql.DB, func()) {
+	path, cleanup := tempRepositoryPath(t)
+
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	return db, func() {
+		db.Close()
+		cleanup()
+	}
+}
+
+// TestSqlMotorcycleRepository_DBIsNil verifies that a repository requires a database.
+func TestSqlMotorcycleRepository_DBIsNil(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewSqlMotorcycleRepository(nil)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestSqlMotorcycleRepository_MigrateRowVersion verifies that the row version and owner columns are added to an existing table.
+func TestSqlMotorcycleRepository_MigrateRowVersion(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	db.Exec("CREATE TABLE motorcycles (id INTEGER PRIMARY KEY AUTOINCREMENT, make TEXT NOT NULL, model TEXT NOT NULL, year INTEGER NOT NULL, vin TEXT NOT NULL, created_utc DATETIME NOT NULL, modified_utc DATETIME NOT NULL)")
+	db.Exec("INSERT INTO motorcycles (make, model, year, vin, created_utc, modified_utc) VALUES ('Honda', 'Shadow', 2006, '01234567890123456', '2018-01-01', '2018-01-01')")
+
+	// ACT
+	repo, err := NewSqlMotorcycleRepository(db)
+	motorcycles, _, _ := repo.List()
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(motorcycles) == 1)
+	assert.True(t, motorcycles[0].RowVersion == constant.InitialRowVersion)
+	assert.True(t, motorcycles[0].OwnerID == "")
+}
+
+// TestSqlMotorcycleRepository_Update_KeepsOwner verifies that an update does not change the owner of a motorcycle.
+func TestSqlMotorcycleRepository_Update_KeepsOwner(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, testVin(0))
+	motorcycle.OwnerID = "alice"
+	moto, _, _ := repo.Insert(motorcycle)
+	update, _ := entity.NewMotorcycle("Honda", "Spirit", 2006, testVin(0))
+	update.OwnerID = "bob"
+
+	// ACT
+	_, status, err := repo.Update(moto.ID, update)
+	found, _, _ := repo.FindByID(moto.ID)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, found.OwnerID == "alice")
+	assert.True(t, found.Model == "Spirit")
+}
+
+// TestSqlMotorcycleRepository_ListEmpty verifies that an empty list of motorcycles is returned.
+func TestSqlMotorcycleRepository_ListEmpty(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+
+	// ACT
+	motorcycles, status, err := repo.List()
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.NotNil(t, motorcycles)
+	assert.True(t, len(motorcycles) == 0)
+}
+
+// TestSqlMotorcycleRepository_InsertAndFind verifies that an inserted motorcycle can be found by its ID and VIN.
+func TestSqlMotorcycleRepository_InsertAndFind(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+
+	// ACT
+	moto, status, err := repo.Insert(motorcycle)
+	byID, _, _ := repo.FindByID(moto.ID)
+	byVin, _, _ := repo.FindByVin(moto.Vin)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, moto.ID > 0)
+	assert.True(t, byID.Vin == moto.Vin)
+	assert.True(t, byVin.ID == moto.ID)
+}
+
+// TestSqlMotorcycleRepository_Insert_VinAlreadyExists verifies that a duplicate VIN is rejected.
+func TestSqlMotorcycleRepository_Insert_VinAlreadyExists(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	first, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	second, _ := entity.NewMotorcycle("Yamaha", "Bolt", 2016, "01234567890123456")
+	repo.Insert(first)
+
+	// ACT
+	_, status, err := repo.Insert(second)
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.DuplicateKey)
+}
+
+// TestSqlMotorcycleRepository_Update_VinAlreadyExists verifies that an update cannot take the VIN of another motorcycle,
+// even when it differs by case.
+func TestSqlMotorcycleRepository_Update_VinAlreadyExists(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	first, _ := entity.NewMotorcycle("Honda", "Accord", 2003, "1HGCM82633A004352")
+	second, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	repo.Insert(first)
+	moto, _, _ := repo.Insert(second)
+	repo.Save()
+	update := *moto
+	update.Vin = "1hgcm82633a004352"
+
+	// ACT
+	_, status, err := repo.Update(moto.ID, &update)
+	found, _, _ := repo.FindByVin("01234567890123456")
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.DuplicateKey)
+	assert.NotNil(t, found)
+}
+
+// TestSqlMotorcycleRepository_Insert_VinDiffersInCase verifies that a VIN that differs from an existing one only in case is
+// rejected, and that a motorcycle can be found by its VIN in either case.
+func TestSqlMotorcycleRepository_Insert_VinDiffersInCase(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	first, _ := entity.NewMotorcycle("Honda", "Accord", 2003, "1HGCM82633A004352")
+	second, _ := entity.NewMotorcycle("Honda", "Accord", 2003, "1hgcm82633a004352")
+	repo.Insert(first)
+	repo.Save()
+
+	// ACT
+	_, status, err := repo.Insert(second)
+	byVin, _, _ := repo.FindByVin("1hgcm82633a004352")
+	motorcycles, _, _ := repo.List()
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.DuplicateKey)
+	assert.NotNil(t, byVin)
+	assert.True(t, len(motorcycles) == 1)
+}
+
+// TestSqlMotorcycleRepository_Insert_ConcurrentVin verifies that when two sessions insert the same VIN at the same time,
+// the one that is saved last is rejected with a conflict rather than an internal error.
+func TestSqlMotorcycleRepository_Insert_ConcurrentVin(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	winner := repo.Session()
+	loser := repo.Session()
+	first, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	second, _ := entity.NewMotorcycle("Yamaha", "Bolt", 2016, "01234567890123456")
+	winner.Insert(first)
+
+	// ACT
+	statuses := make(chan operationstatus.OperationStatus, 1)
+	go func() {
+		// The VIN has not been saved yet, so it passes the check, and the insert waits for the winner to be saved.
+		_, status, _ := loser.Insert(second)
+		statuses <- status
+	}()
+	time.Sleep(100 * time.Millisecond)
+	winner.Save()
+	status := <-statuses
+
+	// ASSERT
+	assert.True(t, status == operationstatus.DuplicateKey)
+}
+
+// TestSqlMotorcycleRepository_UniqueVinIndex verifies that the schema rejects a duplicate VIN, regardless of its case.
+func TestSqlMotorcycleRepository_UniqueVinIndex(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	NewSqlMotorcycleRepository(db)
+	insert := "INSERT INTO motorcycles (make, model, year, vin, created_utc, modified_utc) VALUES ('Honda', 'Accord', 2003, ?, '2018-01-01', '2018-01-01')"
+	db.Exec(insert, "1HGCM82633A004352")
+
+	// ACT
+	_, err := db.Exec(insert, "1HGCM82633A004352")
+	_, lowerErr := db.Exec(insert, "1hgcm82633a004352")
+
+	// ASSERT
+	assert.True(t, isUniqueViolation(err))
+	assert.True(t, isUniqueViolation(lowerErr))
+}
+
+// TestSqlMotorcycleRepository_MigrateVinIndex verifies that the index on the VIN as it was entered is replaced by one on the
+// normalized VIN.
+func TestSqlMotorcycleRepository_MigrateVinIndex(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	db.Exec("CREATE TABLE motorcycles (id INTEGER PRIMARY KEY AUTOINCREMENT, make TEXT NOT NULL, model TEXT NOT NULL, year INTEGER NOT NULL, vin TEXT NOT NULL, created_utc DATETIME NOT NULL, modified_utc DATETIME NOT NULL)")
+	db.Exec("CREATE UNIQUE INDEX ux_motorcycles_vin ON motorcycles (vin)")
+	repo, _ := NewSqlMotorcycleRepository(db)
+	first, _ := entity.NewMotorcycle("Honda", "Accord", 2003, "1HGCM82633A004352")
+	second, _ := entity.NewMotorcycle("Honda", "Accord", 2003, "1hgcm82633a004352")
+	repo.Insert(first)
+	repo.Save()
+
+	// ACT
+	var count int
+	db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'ux_motorcycles_vin'").Scan(&count)
+	_, status, _ := repo.Insert(second)
+
+	// ASSERT
+	assert.True(t, count == 0)
+	assert.True(t, status == operationstatus.DuplicateKey)
+}
+
+// TestSqlMotorcycleRepository_Update verifies that an update is successful.
+func TestSqlMotorcycleRepository_Update(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	moto.Make = "Harley Davidson"
+
+	// ACT
+	_, status, err := repo.Update(moto.ID, moto)
+	found, _, _ := repo.FindByID(moto.ID)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, found.Make == "Harley Davidson")
+	assert.False(t, found.ModifiedUtc.IsZero())
+}
+
+// TestSqlMotorcycleRepository_Update_NotExist verifies that an update fails if the entity does not exist.
+func TestSqlMotorcycleRepository_Update_NotExist(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+
+	// ACT
+	moto, _, err := repo.Update(123, motorcycle)
+
+	// ASSERT
+	assert.Nil(t, moto)
+	assert.NotNil(t, err)
+}
+
+// TestSqlMotorcycleRepository_Delete verifies that a delete is successful.
+func TestSqlMotorcycleRepository_Delete(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+
+	// ACT
+	status, err := repo.Delete(moto.ID, constant.AnyRowVersion)
+	exists, _, _ := repo.ExistsByID(moto.ID)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.False(t, exists)
+}
+
+// TestSqlMotorcycleRepository_Delete_NotExist verifies that a delete fails if the entity does not exist.
+func TestSqlMotorcycleRepository_Delete_NotExist(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+
+	// ACT
+	status, err := repo.Delete(123, constant.AnyRowVersion)
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.NotFound)
+}
+
+// TestSqlMotorcycleRepository_Update_StaleRowVersion verifies that an update of an out of date motorcycle fails with a conflict.
+func TestSqlMotorcycleRepository_Update_StaleRowVersion(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	stale := *moto
+	updated, _, _ := repo.Update(moto.ID, moto)
+	repo.Save()
+	stale.Make = "Harley Davidson"
+
+	// ACT
+	_, status, err := repo.Update(stale.ID, &stale)
+	found, _, _ := repo.FindByID(moto.ID)
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+	assert.True(t, found.Make == "Honda")
+	assert.True(t, found.RowVersion == updated.RowVersion)
+	assert.True(t, updated.RowVersion == constant.InitialRowVersion+1)
+}
+
+// TestSqlMotorcycleRepository_Delete_StaleRowVersion verifies that a delete of an out of date motorcycle fails with a conflict.
+func TestSqlMotorcycleRepository_Delete_StaleRowVersion(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	repo.Update(moto.ID, moto)
+	repo.Save()
+
+	// ACT
+	status, err := repo.Delete(moto.ID, constant.InitialRowVersion)
+	exists, _, _ := repo.ExistsByID(moto.ID)
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+	assert.True(t, exists)
+}
+
+// TestSqlMotorcycleRepository_Save verifies that changes are only visible to another repository after they are saved.
+func TestSqlMotorcycleRepository_Save(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	other, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	repo.Insert(motorcycle)
+	beforeSave, _, _ := other.List()
+
+	// ACT
+	status, err := repo.Save()
+	afterSave, _, _ := other.List()
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(beforeSave) == 0)
+	assert.True(t, len(afterSave) == 1)
+}
+
+// TestSqlMotorcycleRepository_FailedChangeRollsBack verifies that a failed change discards the unsaved changes of the
+// unit of work, so they cannot be saved by a later Save().
+func TestSqlMotorcycleRepository_FailedChangeRollsBack(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+
+	// ACT
+	status, err := repo.Delete(moto.ID, constant.InitialRowVersion+1)
+	repo.Save()
+	motorcycles, _, _ := repo.List()
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.True(t, status == operationstatus.Conflict)
+	assert.True(t, len(motorcycles) == 0)
+}
+
+// TestSqlMotorcycleRepository_Session verifies that the unsaved changes of a session are not seen by the other sessions,
+// and that they are discarded by Rollback(), rather than being saved by another session.
+func TestSqlMotorcycleRepository_Session(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	discarded := repo.Session()
+	saved := repo.Session()
+	harley, _ := entity.NewMotorcycle("Harley Davidson", "Softail", 2012, testVin(1))
+	honda, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	discarded.Insert(harley)
+	unsaved, _, _ := saved.List()
+
+	// ACT
+	rollbackErr := discarded.(*SqlMotorcycleRepository).Rollback()
+	saved.Insert(honda)
+	status, err := saved.Save()
+	motorcycles, _, _ := repo.List()
+
+	// ASSERT
+	assert.Nil(t, rollbackErr)
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(unsaved) == 0)
+	assert.True(t, len(motorcycles) == 1)
+	assert.True(t, motorcycles[0].Vin == honda.Vin)
+}
+
+// TestSqlMotorcycleRepository_Query verifies that the filters are applied, ignoring case, and the motorcycles are sorted
+// as they are by the MotorcycleRepository.
+func TestSqlMotorcycleRepository_Query(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	insertQueryMotorcycles(repo)
+	sorts, _ := entity.ParseMotorcycleSort("-year,model")
+
+	// ACT
+	ids, pages := queryAllPages(t, repo, entity.MotorcycleQuery{Make: "HONDA", VinPrefix: "jh2", Sort: sorts})
+
+	// ASSERT
+	assert.True(t, pages == 1)
+	assert.Equal(t, []typedef.ID{3, 5, 1}, ids)
+}
+
+// TestSqlMotorcycleRepository_Query_Pages verifies that following the cursors visits each motorcycle once, in the same
+// order as the MotorcycleRepository.
+func TestSqlMotorcycleRepository_Query_Pages(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlMotorcycleRepository(db)
+	insertQueryMotorcycles(repo)
+	memory, _ := NewMotorcycleRepository()
+	insertQueryMotorcycles(memory)
+	sorts, _ := entity.ParseMotorcycleSort("make,-year,model")
+	query := entity.MotorcycleQuery{MinYear: 2007, MaxYear: 2018, Sort: sorts, Limit: 2}
+
+	// ACT
+	ids, pages := queryAllPages(t, repo, query)
+	expected, _ := queryAllPages(t, memory, query)
+
+	// ASSERT
+	assert.True(t, pages == 2)
+	assert.Equal(t, []typedef.ID{4, 3, 5, 2}, ids)
+	assert.Equal(t, expected, ids)
+}
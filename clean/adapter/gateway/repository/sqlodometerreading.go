@@ -0,0 +1,397 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// odometerReadingSchema creates the table and indexes for odometer readings, when they do not exist.
+// The dialect is SQLite's.
+var odometerReadingSchema = []string{
+	`CREATE TABLE IF NOT EXISTS odometer_readings (
+		id            INTEGER  PRIMARY KEY AUTOINCREMENT,
+		motorcycle_id INTEGER  NOT NULL,
+		value         REAL     NOT NULL,
+		unit          TEXT     NOT NULL,
+		reading_utc   DATETIME NOT NULL,
+		source        TEXT     NOT NULL,
+		rollover      INTEGER  NOT NULL,
+		distance      REAL     NOT NULL,
+		created_utc   DATETIME NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS ix_odometer_readings_motorcycle_id ON odometer_readings (motorcycle_id, id)`,
+}
+
+// odometerReadingColumns is the list of columns that are selected for an odometer reading.
+const odometerReadingColumns = "id, motorcycle_id, value, unit, reading_utc, source, rollover, distance, created_utc"
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// SqlOdometerReadingRepository provides operations against a SQL database of odometer readings.
+// Changes are made within a transaction, which is committed by Save(), and rolled back when a change fails.
+// It is safe for concurrent use by multiple goroutines, but they share the unit of work, so each caller that changes the
+// readings, such as a request, uses a Session() of its own.
+type SqlOdometerReadingRepository struct {
+	// DB is the database containing the odometer readings.
+	DB *sql.DB
+
+	// tx is the unit of work containing the changes that have not been saved.
+	tx *sql.Tx
+
+	// mutex guards tx, and makes each operation atomic.
+	mutex sync.Mutex
+}
+
+// NewSqlOdometerReadingRepository creates a new instance of a SqlOdometerReadingRepository, and creates its schema if it does not exist.
+// Returns (nil, error) when there is an error, otherwise a (SqlOdometerReadingRepository, nil).
+func NewSqlOdometerReadingRepository(db *sql.DB) (*SqlOdometerReadingRepository, error) {
+	readingRepository := &SqlOdometerReadingRepository{
+		DB: db,
+	}
+
+	err := readingRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range odometerReadingSchema {
+		_, err = db.Exec(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// All okay
+	return readingRepository, nil
+}
+
+// Validate test that a SQL odometer reading repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *SqlOdometerReadingRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// DB cannot be nil.
+		validation.Field(&repo.DB, validation.NotNil))
+}
+
+// ListByMotorcycle gets the motorcycle's readings in chronological order.
+// Returns the (list of readings, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *SqlOdometerReadingRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.OdometerReading, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	rows, err := repo.queryer().Query("SELECT "+odometerReadingColumns+" FROM odometer_readings WHERE motorcycle_id = ? ORDER BY id", motorcycleID)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+	defer rows.Close()
+
+	// Ensure that we create an empty slice rather than the default for []entity.OdometerReading, which is a null pointer.
+	readings := make([]entity.OdometerReading, 0)
+	for rows.Next() {
+		reading, err := scanOdometerReading(rows)
+		if err != nil {
+			return nil, operationstatus.InternalError, err
+		}
+		readings = append(readings, *reading)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return readings, operationstatus.Ok, nil
+}
+
+// Latest gets the motorcycle's most recent reading.
+// Returns (reading, Ok, nil) on found, (nil, NotFound, nil) when the motorcycle does not have any readings, otherwise (nil, operationStatus, error).
+func (repo *SqlOdometerReadingRepository) Latest(motorcycleID typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.latest(motorcycleID)
+}
+
+// FindByID a reading in the repository using its primary key, ID.
+// Returns (reading, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlOdometerReadingRepository) FindByID(id typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.findOne("SELECT "+odometerReadingColumns+" FROM odometer_readings WHERE id = ?", id)
+}
+
+// Insert adds a reading to the repository, after verifying that it follows the motorcycle's latest reading.
+// Returns the (new reading, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *SqlOdometerReadingRepository) Insert(reading *entity.OdometerReading) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	inserted, status, err := repo.insert(reading)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return inserted, status, err
+}
+
+// insert adds a reading to the repository.  The caller must hold the lock.
+func (repo *SqlOdometerReadingRepository) insert(reading *entity.OdometerReading) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	// Work on a copy, so the caller's reading is unchanged if it is invalid.
+	newReading := *reading
+
+	err := newReading.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	tx, err := repo.begin()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	latest, status, err := repo.latest(newReading.MotorcycleID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	err = newReading.Follow(latest)
+	if err != nil {
+		return nil, operationstatus.BadRequest, err
+	}
+
+	// Save the time when this entity was created in the repository.
+	newReading.CreatedUtc = time.Now().UTC()
+
+	result, err := tx.Exec("INSERT INTO odometer_readings (motorcycle_id, value, unit, reading_utc, source, rollover, distance, created_utc) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		newReading.MotorcycleID, newReading.Value, newReading.Unit.ToString(), newReading.ReadingUtc, newReading.Source.ToString(),
+		newReading.Rollover, newReading.Distance, newReading.CreatedUtc)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Assign the ID to the new reading.
+	newReading.ID = typedef.ID(id)
+
+	return &newReading, operationstatus.Ok, nil
+}
+
+// Delete removes a reading from the repository.  Only the latest reading of a motorcycle can be deleted.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *SqlOdometerReadingRepository) Delete(id typedef.ID) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	status, err := repo.delete(id)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return status, err
+}
+
+// delete removes a reading from the repository.  The caller must hold the lock.
+func (repo *SqlOdometerReadingRepository) delete(id typedef.ID) (operationstatus.OperationStatus, error) {
+	reading, status, err := repo.findOne("SELECT "+odometerReadingColumns+" FROM odometer_readings WHERE id = ?", id)
+	if err != nil {
+		return status, err
+	}
+
+	if reading == nil {
+		return operationstatus.NotFound, fmt.Errorf("cannot delete the odometer reading with ID %d because it doesn't exist in the repository", id)
+	}
+
+	latest, status, err := repo.latest(reading.MotorcycleID)
+	if err != nil {
+		return status, err
+	}
+
+	if latest.ID != id {
+		return operationstatus.Conflict, fmt.Errorf("cannot delete the odometer reading with ID %d because it is not the motorcycle's latest reading", id)
+	}
+
+	tx, err := repo.begin()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	_, err = tx.Exec("DELETE FROM odometer_readings WHERE id = ?", id)
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// DeleteByMotorcycle removes all of the motorcycle's readings from the repository.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *SqlOdometerReadingRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	status, err := repo.deleteByMotorcycle(motorcycleID)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return status, err
+}
+
+// deleteByMotorcycle removes all of the motorcycle's readings from the repository.  The caller must hold the lock.
+func (repo *SqlOdometerReadingRepository) deleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	tx, err := repo.begin()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	_, err = tx.Exec("DELETE FROM odometer_readings WHERE motorcycle_id = ?", motorcycleID)
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// Save commits all of the changes to the repository.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *SqlOdometerReadingRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.tx == nil {
+		// Nothing has changed.
+		return operationstatus.Ok, nil
+	}
+
+	tx := repo.tx
+	repo.tx = nil
+
+	err := tx.Commit()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// Session creates a repository of the same readings, which has a unit of work of its own.
+func (repo *SqlOdometerReadingRepository) Session() contract.OdometerReadingRepository {
+	return &SqlOdometerReadingRepository{
+		DB: repo.DB,
+	}
+}
+
+// Rollback discards the changes that have not been saved.
+// Returns nil on success, otherwise an error.
+func (repo *SqlOdometerReadingRepository) Rollback() error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.rollback()
+}
+
+// rollback discards the changes that have not been saved.  The caller must hold the lock.
+// Returns nil on success, otherwise an error.
+func (repo *SqlOdometerReadingRepository) rollback() error {
+	if repo.tx == nil {
+		// Nothing has changed.
+		return nil
+	}
+
+	tx := repo.tx
+	repo.tx = nil
+
+	return tx.Rollback()
+}
+
+// latest gets the motorcycle's most recent reading.  The caller must hold the lock.
+// Returns (reading, Ok, nil) on found, (nil, NotFound, nil) when the motorcycle does not have any readings, otherwise (nil, operationStatus, error).
+func (repo *SqlOdometerReadingRepository) latest(motorcycleID typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	return repo.findOne("SELECT "+odometerReadingColumns+" FROM odometer_readings WHERE motorcycle_id = ? ORDER BY id DESC LIMIT 1", motorcycleID)
+}
+
+// begin gets the transaction for the unit of work, and starts one when there isn't one in progress.
+// Returns (transaction, nil) on success, otherwise (nil, error).
+func (repo *SqlOdometerReadingRepository) begin() (*sql.Tx, error) {
+	if repo.tx != nil {
+		return repo.tx, nil
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	repo.tx = tx
+	return tx, nil
+}
+
+// queryer gets the transaction for the unit of work when one is in progress, so queries can see its changes,
+// otherwise it gets the database.
+func (repo *SqlOdometerReadingRepository) queryer() queryer {
+	if repo.tx != nil {
+		return repo.tx
+	}
+
+	return repo.DB
+}
+
+// findOne gets the single reading selected by a query.  The caller must hold the lock.
+// Returns (reading, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlOdometerReadingRepository) findOne(query string, args ...interface{}) (*entity.OdometerReading, operationstatus.OperationStatus, error) {
+	reading, err := scanOdometerReading(repo.queryer().QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, operationstatus.NotFound, nil
+	}
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return reading, operationstatus.Ok, nil
+}
+
+// scanOdometerReading reads the odometerReadingColumns of a row into a reading.
+// Returns (reading, nil) on success, otherwise (nil, error).
+func scanOdometerReading(row rowScanner) (*entity.OdometerReading, error) {
+	reading := &entity.OdometerReading{}
+	var unit, source string
+
+	err := row.Scan(&reading.ID, &reading.MotorcycleID, &reading.Value, &unit, &reading.ReadingUtc, &source, &reading.Rollover, &reading.Distance, &reading.CreatedUtc)
+	if err != nil {
+		return nil, err
+	}
+
+	reading.Unit, err = distanceunit.Parse(unit)
+	if err != nil {
+		return nil, err
+	}
+
+	reading.Source, err = readingsource.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return reading, nil
+}
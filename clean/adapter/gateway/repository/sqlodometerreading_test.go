@@ -0,0 +1,56 @@
+// Package repository implements unit tests for the SqlOdometerReadingRepository.
+package repository
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSqlOdometerReadingRepository_DBIsNil verifies that a repository requires a database.
+func TestSqlOdometerReadingRepository_DBIsNil(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewSqlOdometerReadingRepository(nil)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestSqlOdometerReadingRepository_Operations verifies inserting, listing and deleting readings.
+func TestSqlOdometerReadingRepository_Operations(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlOdometerReadingRepository(db)
+
+	// ACT & ASSERT
+	exerciseOdometerReadings(t, repo)
+}
+
+// TestSqlOdometerReadingRepository_Save verifies that readings are only visible to another repository after they are saved.
+func TestSqlOdometerReadingRepository_Save(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlOdometerReadingRepository(db)
+	other, _ := NewSqlOdometerReadingRepository(db)
+	repo.Insert(newTestOdometerReading(1, 1200, 0, false))
+	beforeSave, _, _ := other.ListByMotorcycle(1)
+
+	// ACT
+	status, err := repo.Save()
+	afterSave, _, _ := other.ListByMotorcycle(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(beforeSave) == 0)
+	assert.True(t, len(afterSave) == 1)
+	assert.True(t, afterSave[0].Unit == newTestOdometerReading(1, 1200, 0, false).Unit)
+}
@@ -0,0 +1,411 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// reminderSchema creates the table and indexes for reminders, when they do not exist.
+// The dialect is SQLite's.  The unique index prevents a motorcycle from being reminded twice of the same date.
+var reminderSchema = []string{
+	`CREATE TABLE IF NOT EXISTS reminders (
+		id                INTEGER  PRIMARY KEY AUTOINCREMENT,
+		motorcycle_id     INTEGER  NOT NULL,
+		name              TEXT     NOT NULL,
+		due_utc           DATETIME NOT NULL,
+		status            TEXT     NOT NULL,
+		snoozed_until_utc DATETIME NOT NULL,
+		notified_utc      DATETIME NOT NULL,
+		acknowledged_utc  DATETIME NOT NULL,
+		created_utc       DATETIME NOT NULL,
+		modified_utc      DATETIME NOT NULL,
+		row_version       INTEGER  NOT NULL
+	)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS ux_reminders_due ON reminders (motorcycle_id, name, due_utc)`,
+}
+
+// reminderColumns is the list of columns that are selected for a reminder.
+const reminderColumns = "id, motorcycle_id, name, due_utc, status, snoozed_until_utc, notified_utc, acknowledged_utc, created_utc, modified_utc, row_version"
+
+// SqlReminderRepository provides CRUD operations against a SQL database of reminders.
+// Changes are made within a transaction, which is committed by Save(), and rolled back when a change fails.
+// It is safe for concurrent use by multiple goroutines, but they share the unit of work, so each caller that changes the
+// reminders, such as a request, uses a Session() of its own.
+type SqlReminderRepository struct {
+	// DB is the database containing the reminders.
+	DB *sql.DB
+
+	// tx is the unit of work containing the changes that have not been saved.
+	tx *sql.Tx
+
+	// mutex guards tx, and makes each operation atomic.
+	mutex sync.Mutex
+}
+
+// NewSqlReminderRepository creates a new instance of a SqlReminderRepository, and creates its schema if it does not exist.
+// Returns (nil, error) when there is an error, otherwise a (SqlReminderRepository, nil).
+func NewSqlReminderRepository(db *sql.DB) (*SqlReminderRepository, error) {
+	reminderRepository := &SqlReminderRepository{
+		DB: db,
+	}
+
+	err := reminderRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range reminderSchema {
+		_, err = db.Exec(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// All okay
+	return reminderRepository, nil
+}
+
+// Validate test that a SQL reminder repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *SqlReminderRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// DB cannot be nil.
+		validation.Field(&repo.DB, validation.NotNil))
+}
+
+// ListByMotorcycle gets the motorcycle's reminders, in the order that they are due.
+// Returns the (list of reminders, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *SqlReminderRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	rows, err := repo.queryer().Query("SELECT "+reminderColumns+" FROM reminders WHERE motorcycle_id = ? ORDER BY due_utc, id", motorcycleID)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+	defer rows.Close()
+
+	// Ensure that we create an empty slice rather than the default for []entity.Reminder, which is a null pointer.
+	reminders := make([]entity.Reminder, 0)
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, operationstatus.InternalError, err
+		}
+		reminders = append(reminders, *reminder)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return reminders, operationstatus.Ok, nil
+}
+
+// FindByID a reminder in the repository using its primary key, ID.
+// Returns (reminder, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlReminderRepository) FindByID(id typedef.ID) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.find("id = ?", id)
+}
+
+// FindByDue finds the motorcycle's reminder with the name that is due at the time.
+// Returns (reminder, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlReminderRepository) FindByDue(motorcycleID typedef.ID, name string, dueUtc time.Time) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.find("motorcycle_id = ? AND name = ? AND due_utc = ?", motorcycleID, name, dueUtc.UTC())
+}
+
+// Insert adds a reminder to the repository, unless the motorcycle already has a reminder with the name and due date.
+// Returns the (new reminder, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *SqlReminderRepository) Insert(reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	inserted, status, err := repo.insert(reminder)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return inserted, status, err
+}
+
+// insert adds a reminder to the repository.  The caller must hold the lock.
+func (repo *SqlReminderRepository) insert(reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	// Work on a copy, so the caller's reminder is unchanged.
+	newReminder := *reminder
+	newReminder.DueUtc = newReminder.DueUtc.UTC()
+
+	err := newReminder.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	existing, status, err := repo.find("motorcycle_id = ? AND name = ? AND due_utc = ?", newReminder.MotorcycleID, newReminder.Name, newReminder.DueUtc)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if existing != nil {
+		return nil, operationstatus.Conflict, fmt.Errorf("the motorcycle with ID %d already has a reminder for %q on %s", newReminder.MotorcycleID,
+			newReminder.Name, newReminder.DueUtc.Format("2006-01-02"))
+	}
+
+	// Save the time when this entity was created in the repository, and its initial version.
+	newReminder.CreatedUtc = time.Now().UTC()
+	newReminder.ModifiedUtc = time.Time{}
+	newReminder.RowVersion = constant.InitialRowVersion
+
+	tx, err := repo.begin()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	result, err := tx.Exec("INSERT INTO reminders (motorcycle_id, name, due_utc, status, snoozed_until_utc, notified_utc, acknowledged_utc, created_utc, modified_utc, row_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		newReminder.MotorcycleID, newReminder.Name, newReminder.DueUtc, newReminder.Status.ToString(), newReminder.SnoozedUntilUtc, newReminder.NotifiedUtc,
+		newReminder.AcknowledgedUtc, newReminder.CreatedUtc, newReminder.ModifiedUtc, newReminder.RowVersion)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Assign the ID to the new reminder.
+	newReminder.ID = typedef.ID(id)
+
+	return &newReminder, operationstatus.Ok, nil
+}
+
+// Update replaces an existing reminder in the repository.  The motorcycle, name and due date cannot be changed.
+// The reminder's row version must match the current one, unless it is AnyRowVersion.
+// Returns (updated reminder, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *SqlReminderRepository) Update(id typedef.ID, reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	updated, status, err := repo.update(id, reminder)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return updated, status, err
+}
+
+// update replaces an existing reminder in the repository.  The caller must hold the lock.
+func (repo *SqlReminderRepository) update(id typedef.ID, reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	existing, status, err := repo.find("id = ?", id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if existing == nil {
+		return nil, operationstatus.NotFound, fmt.Errorf("cannot update the reminder with ID %d because it doesn't exist in the repository", id)
+	}
+
+	err = checkReminderRowVersion(id, reminder.RowVersion, existing.RowVersion)
+	if err != nil {
+		return nil, operationstatus.Conflict, err
+	}
+
+	updated := *reminder
+	updated.ID = id
+	updated.MotorcycleID = existing.MotorcycleID
+	updated.Name = existing.Name
+	updated.DueUtc = existing.DueUtc
+	updated.CreatedUtc = existing.CreatedUtc
+
+	// Save the time when this entity was updated in the repository, and its new version.
+	updated.ModifiedUtc = time.Now().UTC()
+	updated.RowVersion = existing.RowVersion + 1
+
+	err = updated.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	tx, err := repo.begin()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// The row version is checked again, in case another process has changed the reminder.
+	result, err := tx.Exec("UPDATE reminders SET status = ?, snoozed_until_utc = ?, notified_utc = ?, acknowledged_utc = ?, modified_utc = ?, row_version = ? WHERE id = ? AND row_version = ?",
+		updated.Status.ToString(), updated.SnoozedUntilUtc, updated.NotifiedUtc, updated.AcknowledgedUtc, updated.ModifiedUtc, updated.RowVersion, id, existing.RowVersion)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	if count == 0 {
+		return nil, operationstatus.Conflict, fmt.Errorf("cannot update the reminder with ID %d because it has been changed by someone else", id)
+	}
+
+	return &updated, operationstatus.Ok, nil
+}
+
+// DeleteByMotorcycle removes all of the motorcycle's reminders from the repository.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *SqlReminderRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	status, err := repo.deleteByMotorcycle(motorcycleID)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return status, err
+}
+
+// deleteByMotorcycle removes all of the motorcycle's reminders from the repository.  The caller must hold the lock.
+func (repo *SqlReminderRepository) deleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	tx, err := repo.begin()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	_, err = tx.Exec("DELETE FROM reminders WHERE motorcycle_id = ?", motorcycleID)
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// Save commits all of the changes to the repository.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *SqlReminderRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.tx == nil {
+		// Nothing has changed.
+		return operationstatus.Ok, nil
+	}
+
+	tx := repo.tx
+	repo.tx = nil
+
+	err := tx.Commit()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// Session creates a repository of the same reminders, which has a unit of work of its own.
+func (repo *SqlReminderRepository) Session() contract.ReminderRepository {
+	return &SqlReminderRepository{
+		DB: repo.DB,
+	}
+}
+
+// Rollback discards the changes that have not been saved.
+// Returns nil on success, otherwise an error.
+func (repo *SqlReminderRepository) Rollback() error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.rollback()
+}
+
+// rollback discards the changes that have not been saved.  The caller must hold the lock.
+// Returns nil on success, otherwise an error.
+func (repo *SqlReminderRepository) rollback() error {
+	if repo.tx == nil {
+		// Nothing has changed.
+		return nil
+	}
+
+	tx := repo.tx
+	repo.tx = nil
+
+	return tx.Rollback()
+}
+
+// begin gets the transaction for the unit of work, and starts one when there isn't one in progress.
+// Returns (transaction, nil) on success, otherwise (nil, error).
+func (repo *SqlReminderRepository) begin() (*sql.Tx, error) {
+	if repo.tx != nil {
+		return repo.tx, nil
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	repo.tx = tx
+	return tx, nil
+}
+
+// queryer gets the transaction for the unit of work when one is in progress, so queries can see its changes,
+// otherwise it gets the database.
+func (repo *SqlReminderRepository) queryer() queryer {
+	if repo.tx != nil {
+		return repo.tx
+	}
+
+	return repo.DB
+}
+
+// find gets the reminder that satisfies the condition.  The caller must hold the lock.
+// Returns (reminder, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlReminderRepository) find(condition string, args ...interface{}) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	reminder, err := scanReminder(repo.queryer().QueryRow("SELECT "+reminderColumns+" FROM reminders WHERE "+condition, args...))
+	if err == sql.ErrNoRows {
+		return nil, operationstatus.NotFound, nil
+	}
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return reminder, operationstatus.Ok, nil
+}
+
+// scanReminder reads the reminderColumns of a row into a reminder.
+// Returns (reminder, nil) on success, otherwise (nil, error).
+func scanReminder(row rowScanner) (*entity.Reminder, error) {
+	reminder := &entity.Reminder{}
+	var status string
+
+	err := row.Scan(&reminder.ID, &reminder.MotorcycleID, &reminder.Name, &reminder.DueUtc, &status, &reminder.SnoozedUntilUtc, &reminder.NotifiedUtc,
+		&reminder.AcknowledgedUtc, &reminder.CreatedUtc, &reminder.ModifiedUtc, &reminder.RowVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder.Status, err = reminderstatus.Parse(status)
+	if err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
@@ -0,0 +1,56 @@
+// Package repository implements unit tests for the SqlReminderRepository.
+package repository
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSqlReminderRepository_DBIsNil verifies that a repository requires a database.
+func TestSqlReminderRepository_DBIsNil(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewSqlReminderRepository(nil)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestSqlReminderRepository_Operations verifies inserting, listing, finding and updating reminders.
+func TestSqlReminderRepository_Operations(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlReminderRepository(db)
+
+	// ACT & ASSERT
+	exerciseReminders(t, repo)
+}
+
+// TestSqlReminderRepository_Save verifies that reminders are only visible to another repository after they are saved.
+func TestSqlReminderRepository_Save(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlReminderRepository(db)
+	other, _ := NewSqlReminderRepository(db)
+	repo.Insert(newTestReminder(1, "Annual service", 0))
+	beforeSave, _, _ := other.ListByMotorcycle(1)
+
+	// ACT
+	status, err := repo.Save()
+	afterSave, _, _ := other.ListByMotorcycle(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(beforeSave) == 0)
+	assert.True(t, len(afterSave) == 1)
+	assert.True(t, afterSave[0].DueUtc.Equal(testReadingUtc))
+}
@@ -0,0 +1,469 @@
+// Package repository contains implementations of data repositories.
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// serviceRecordSchema creates the table and indexes for service records, when they do not exist.
+// The dialect is SQLite's.  The parts are stored as a JSON array.
+var serviceRecordSchema = []string{
+	`CREATE TABLE IF NOT EXISTS service_records (
+		id            INTEGER  PRIMARY KEY AUTOINCREMENT,
+		motorcycle_id INTEGER  NOT NULL,
+		type          TEXT     NOT NULL,
+		performed_utc DATETIME NOT NULL,
+		odometer      REAL     NOT NULL,
+		unit          TEXT     NOT NULL,
+		notes         TEXT     NOT NULL,
+		parts         TEXT     NOT NULL,
+		labor_hours   REAL     NOT NULL,
+		cost          REAL     NOT NULL,
+		performed_by  TEXT     NOT NULL,
+		created_utc   DATETIME NOT NULL,
+		modified_utc  DATETIME NOT NULL,
+		row_version   INTEGER  NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS ix_service_records_motorcycle_id ON service_records (motorcycle_id, performed_utc)`,
+}
+
+// serviceRecordColumns is the list of columns that are selected for a service record.
+const serviceRecordColumns = "id, motorcycle_id, type, performed_utc, odometer, unit, notes, parts, labor_hours, cost, performed_by, created_utc, modified_utc, row_version"
+
+// SqlServiceRecordRepository provides CRUD operations against a SQL database of service records.
+// Changes are made within a transaction, which is committed by Save(), and rolled back when a change fails.
+// It is safe for concurrent use by multiple goroutines, but they share the unit of work, so each caller that changes the
+// service records, such as a request, uses a Session() of its own.
+type SqlServiceRecordRepository struct {
+	// DB is the database containing the service records.
+	DB *sql.DB
+
+	// tx is the unit of work containing the changes that have not been saved.
+	tx *sql.Tx
+
+	// mutex guards tx, and makes each operation atomic.
+	mutex sync.Mutex
+}
+
+// NewSqlServiceRecordRepository creates a new instance of a SqlServiceRecordRepository, and creates its schema if it does not exist.
+// Returns (nil, error) when there is an error, otherwise a (SqlServiceRecordRepository, nil).
+func NewSqlServiceRecordRepository(db *sql.DB) (*SqlServiceRecordRepository, error) {
+	recordRepository := &SqlServiceRecordRepository{
+		DB: db,
+	}
+
+	err := recordRepository.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range serviceRecordSchema {
+		_, err = db.Exec(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// All okay
+	return recordRepository, nil
+}
+
+// Validate test that a SQL service record repository is valid.
+// Returns nil on success, otherwise an error.
+func (repo *SqlServiceRecordRepository) Validate() error {
+	return validation.ValidateStruct(repo,
+		// DB cannot be nil.
+		validation.Field(&repo.DB, validation.NotNil))
+}
+
+// ListByMotorcycle gets the motorcycle's service records, in the order that the services were performed.
+// Returns the (list of service records, Ok, nil), otherwise a (nil, operationStatus, error).
+func (repo *SqlServiceRecordRepository) ListByMotorcycle(motorcycleID typedef.ID) ([]entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	rows, err := repo.queryer().Query("SELECT "+serviceRecordColumns+" FROM service_records WHERE motorcycle_id = ? ORDER BY performed_utc, id", motorcycleID)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+	defer rows.Close()
+
+	// Ensure that we create an empty slice rather than the default for []entity.ServiceRecord, which is a null pointer.
+	records := make([]entity.ServiceRecord, 0)
+	for rows.Next() {
+		record, err := scanServiceRecord(rows)
+		if err != nil {
+			return nil, operationstatus.InternalError, err
+		}
+		records = append(records, *record)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return records, operationstatus.Ok, nil
+}
+
+// FindByID a service record in the repository using its primary key, ID.
+// Returns (service record, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlServiceRecordRepository) FindByID(id typedef.ID) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.findByID(id)
+}
+
+// Insert adds a service record to the repository.
+// Returns the (new service record, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *SqlServiceRecordRepository) Insert(record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	inserted, status, err := repo.insert(record)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return inserted, status, err
+}
+
+// insert adds a service record to the repository.  The caller must hold the lock.
+func (repo *SqlServiceRecordRepository) insert(record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	// Work on a copy, so the caller's service record is unchanged.
+	newRecord := copyServiceRecord(*record)
+
+	err := newRecord.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	parts, err := json.Marshal(newRecord.Parts)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Save the time when this entity was created in the repository, and its initial version.
+	newRecord.CreatedUtc = time.Now().UTC()
+	newRecord.ModifiedUtc = time.Time{}
+	newRecord.RowVersion = constant.InitialRowVersion
+
+	tx, err := repo.begin()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	result, err := tx.Exec("INSERT INTO service_records (motorcycle_id, type, performed_utc, odometer, unit, notes, parts, labor_hours, cost, performed_by, created_utc, modified_utc, row_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		newRecord.MotorcycleID, newRecord.Type.ToString(), newRecord.PerformedUtc, newRecord.Odometer, newRecord.Unit.ToString(), newRecord.Notes, string(parts),
+		newRecord.LaborHours, newRecord.Cost, newRecord.PerformedBy, newRecord.CreatedUtc, newRecord.ModifiedUtc, newRecord.RowVersion)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// Assign the ID to the new service record.
+	newRecord.ID = typedef.ID(id)
+
+	return &newRecord, operationstatus.Ok, nil
+}
+
+// Update replaces an existing service record in the repository.  The motorcycle cannot be changed.
+// The service record's row version must match the current one, unless it is AnyRowVersion.
+// Returns (updated service record, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *SqlServiceRecordRepository) Update(id typedef.ID, record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	updated, status, err := repo.update(id, record)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return updated, status, err
+}
+
+// update replaces an existing service record in the repository.  The caller must hold the lock.
+func (repo *SqlServiceRecordRepository) update(id typedef.ID, record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	existing, status, err := repo.findByID(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if existing == nil {
+		return nil, operationstatus.NotFound, fmt.Errorf("cannot update the service record with ID %d because it doesn't exist in the repository", id)
+	}
+
+	err = checkServiceRecordRowVersion(id, record.RowVersion, existing.RowVersion)
+	if err != nil {
+		return nil, operationstatus.Conflict, err
+	}
+
+	updated := copyServiceRecord(*record)
+	updated.ID = id
+	updated.MotorcycleID = existing.MotorcycleID
+	updated.CreatedUtc = existing.CreatedUtc
+
+	// Save the time when this entity was updated in the repository, and its new version.
+	updated.ModifiedUtc = time.Now().UTC()
+	updated.RowVersion = existing.RowVersion + 1
+
+	err = updated.Validate()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	parts, err := json.Marshal(updated.Parts)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	tx, err := repo.begin()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	// The row version is checked again, in case another process has changed the service record.
+	result, err := tx.Exec("UPDATE service_records SET type = ?, performed_utc = ?, odometer = ?, unit = ?, notes = ?, parts = ?, labor_hours = ?, cost = ?, performed_by = ?, modified_utc = ?, row_version = ? WHERE id = ? AND row_version = ?",
+		updated.Type.ToString(), updated.PerformedUtc, updated.Odometer, updated.Unit.ToString(), updated.Notes, string(parts), updated.LaborHours, updated.Cost,
+		updated.PerformedBy, updated.ModifiedUtc, updated.RowVersion, id, existing.RowVersion)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	if count == 0 {
+		return nil, operationstatus.Conflict, fmt.Errorf("cannot update the service record with ID %d because it has been changed by someone else", id)
+	}
+
+	return &updated, operationstatus.Ok, nil
+}
+
+// Delete removes a service record from the repository.
+// The row version must match the service record's current one, unless it is AnyRowVersion.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *SqlServiceRecordRepository) Delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	status, err := repo.delete(id, rowVersion)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return status, err
+}
+
+// delete removes a service record from the repository.  The caller must hold the lock.
+func (repo *SqlServiceRecordRepository) delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error) {
+	existing, status, err := repo.findByID(id)
+	if err != nil {
+		return status, err
+	}
+
+	if existing == nil {
+		return operationstatus.NotFound, fmt.Errorf("cannot delete the service record with ID %d because it doesn't exist in the repository", id)
+	}
+
+	err = checkServiceRecordRowVersion(id, rowVersion, existing.RowVersion)
+	if err != nil {
+		return operationstatus.Conflict, err
+	}
+
+	tx, err := repo.begin()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	result, err := tx.Exec("DELETE FROM service_records WHERE id = ? AND row_version = ?", id, existing.RowVersion)
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	if count == 0 {
+		return operationstatus.Conflict, fmt.Errorf("cannot delete the service record with ID %d because it has been changed by someone else", id)
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// DeleteByMotorcycle removes all of the motorcycle's service records from the repository.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (repo *SqlServiceRecordRepository) DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	status, err := repo.deleteByMotorcycle(motorcycleID)
+	if err != nil {
+		// The unit of work has failed, so none of its changes are kept.
+		repo.rollback()
+	}
+
+	return status, err
+}
+
+// deleteByMotorcycle removes all of the motorcycle's service records from the repository.  The caller must hold the lock.
+func (repo *SqlServiceRecordRepository) deleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	tx, err := repo.begin()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	_, err = tx.Exec("DELETE FROM service_records WHERE motorcycle_id = ?", motorcycleID)
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// Save commits all of the changes to the repository.
+// Returns (Ok, nil) on success, otherwise an (operationStatus, error).
+func (repo *SqlServiceRecordRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.tx == nil {
+		// Nothing has changed.
+		return operationstatus.Ok, nil
+	}
+
+	tx := repo.tx
+	repo.tx = nil
+
+	err := tx.Commit()
+	if err != nil {
+		return operationstatus.InternalError, err
+	}
+
+	return operationstatus.Ok, nil
+}
+
+// Session creates a repository of the same service records, which has a unit of work of its own.
+func (repo *SqlServiceRecordRepository) Session() contract.ServiceRecordRepository {
+	return &SqlServiceRecordRepository{
+		DB: repo.DB,
+	}
+}
+
+// Rollback discards the changes that have not been saved.
+// Returns nil on success, otherwise an error.
+func (repo *SqlServiceRecordRepository) Rollback() error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.rollback()
+}
+
+// rollback discards the changes that have not been saved.  The caller must hold the lock.
+// Returns nil on success, otherwise an error.
+func (repo *SqlServiceRecordRepository) rollback() error {
+	if repo.tx == nil {
+		// Nothing has changed.
+		return nil
+	}
+
+	tx := repo.tx
+	repo.tx = nil
+
+	return tx.Rollback()
+}
+
+// begin gets the transaction for the unit of work, and starts one when there isn't one in progress.
+// Returns (transaction, nil) on success, otherwise (nil, error).
+func (repo *SqlServiceRecordRepository) begin() (*sql.Tx, error) {
+	if repo.tx != nil {
+		return repo.tx, nil
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	repo.tx = tx
+	return tx, nil
+}
+
+// queryer gets the transaction for the unit of work when one is in progress, so queries can see its changes,
+// otherwise it gets the database.
+func (repo *SqlServiceRecordRepository) queryer() queryer {
+	if repo.tx != nil {
+		return repo.tx
+	}
+
+	return repo.DB
+}
+
+// findByID gets the service record with the ID.  The caller must hold the lock.
+// Returns (service record, Ok, nil) on found, (nil, NotFound, nil) for not found, otherwise (nil, operationStatus, error).
+func (repo *SqlServiceRecordRepository) findByID(id typedef.ID) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	record, err := scanServiceRecord(repo.queryer().QueryRow("SELECT "+serviceRecordColumns+" FROM service_records WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, operationstatus.NotFound, nil
+	}
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	return record, operationstatus.Ok, nil
+}
+
+// scanServiceRecord reads the serviceRecordColumns of a row into a service record.
+// Returns (service record, nil) on success, otherwise (nil, error).
+func scanServiceRecord(row rowScanner) (*entity.ServiceRecord, error) {
+	record := &entity.ServiceRecord{}
+	var serviceType, unit, parts string
+
+	err := row.Scan(&record.ID, &record.MotorcycleID, &serviceType, &record.PerformedUtc, &record.Odometer, &unit, &record.Notes, &parts,
+		&record.LaborHours, &record.Cost, &record.PerformedBy, &record.CreatedUtc, &record.ModifiedUtc, &record.RowVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Type, err = servicetype.Parse(serviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Unit, err = distanceunit.Parse(unit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal([]byte(parts), &record.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
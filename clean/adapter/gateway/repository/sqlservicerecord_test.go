@@ -0,0 +1,58 @@
+// Package repository implements unit tests for the SqlServiceRecordRepository.
+package repository
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSqlServiceRecordRepository_DBIsNil verifies that a repository requires a database.
+func TestSqlServiceRecordRepository_DBIsNil(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewSqlServiceRecordRepository(nil)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestSqlServiceRecordRepository_Operations verifies inserting, listing, updating and deleting service records.
+func TestSqlServiceRecordRepository_Operations(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlServiceRecordRepository(db)
+
+	// ACT & ASSERT
+	exerciseServiceRecords(t, repo)
+}
+
+// TestSqlServiceRecordRepository_Save verifies that service records are only visible to another repository after they are saved.
+func TestSqlServiceRecordRepository_Save(t *testing.T) {
+
+	// ARRANGE
+	db, cleanup := openTestDatabase(t)
+	defer cleanup()
+	repo, _ := NewSqlServiceRecordRepository(db)
+	other, _ := NewSqlServiceRecordRepository(db)
+	repo.Insert(newTestServiceRecord(1, servicetype.ValveCheckServiceType, 0, 16000))
+	beforeSave, _, _ := other.ListByMotorcycle(1)
+
+	// ACT
+	status, err := repo.Save()
+	afterSave, _, _ := other.ListByMotorcycle(1)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, len(beforeSave) == 0)
+	assert.True(t, len(afterSave) == 1)
+	assert.True(t, afterSave[0].Type == servicetype.ValveCheckServiceType)
+	assert.True(t, afterSave[0].Parts[0] == "Oil filter")
+}
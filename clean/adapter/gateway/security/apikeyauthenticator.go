@@ -0,0 +1,229 @@
+// Package security contains implementations of interfaces dealing security, authentication, and authorization.
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ApiKeyHeader is the HTTP header that carries an API key.
+const ApiKeyHeader = "X-API-Key"
+
+// apiKeyPrefix is at the start of every API key, so that a leaked key is easy to recognize.
+const apiKeyPrefix = "mmk_"
+
+// apiKeyRandomBytes is the number of random bytes in an API key.
+const apiKeyRandomBytes = 32
+
+// lastUsedResolution is how stale the last-used time of an API key may become before it is saved again,
+// which avoids writing to the key store on every request.
+const lastUsedResolution = time.Minute
+
+// ApiKeyAuthenticator authenticates each HTTP request from the API key in its X-API-Key header.
+type ApiKeyAuthenticator struct {
+	ApiKeys contract.ApiKeyRepository
+	Policy  *RolePolicy
+}
+
+// Validate verifies that an ApiKeyAuthenticator's fields contain valid data.
+// Returns nil if the ApiKeyAuthenticator contains valid data, otherwise an error.
+func (authenticator ApiKeyAuthenticator) Validate() error {
+	return validation.ValidateStruct(&authenticator,
+		// ApiKeys cannot be nil.
+		validation.Field(&authenticator.ApiKeys, validation.NotNil),
+		// Policy cannot be nil.
+		validation.Field(&authenticator.Policy, validation.NotNil),
+	)
+}
+
+// NewApiKeyAuthenticator creates a new instance of an ApiKeyAuthenticator.
+// Returns (nil, error) when there is an error, otherwise (ApiKeyAuthenticator, nil).
+func NewApiKeyAuthenticator(apiKeys contract.ApiKeyRepository, policy *RolePolicy) (*ApiKeyAuthenticator, error) {
+
+	authenticator := &ApiKeyAuthenticator{
+		ApiKeys: apiKeys,
+		Policy:  policy,
+	}
+
+	err := authenticator.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return authenticator, nil
+}
+
+// Authenticate creates the auth service for the API key that made the request.
+// A request without an X-API-Key header has not been authenticated.
+// Returns (auth service, nil) on success, otherwise (nil, error) when the key is unknown, revoked or expired.
+func (authenticator *ApiKeyAuthenticator) Authenticate(r *http.Request) (contract.AuthService, error) {
+	key := r.Header.Get(ApiKeyHeader)
+	if key == "" {
+		return NewApiKeyAuthService(nil, authenticator.Policy)
+	}
+
+	apiKey, _, err := authenticator.ApiKeys.FindByHash(HashApiKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if apiKey == nil || !apiKey.IsActive(now) {
+		return nil, errors.New("the API key is unknown, revoked or expired")
+	}
+
+	authenticator.recordUse(apiKey, now)
+
+	return NewApiKeyAuthService(apiKey, authenticator.Policy)
+}
+
+// recordUse saves the time that the API key was used, unless it was recorded recently.
+// A failure to record the use does not prevent the key from authenticating the request.
+func (authenticator *ApiKeyAuthenticator) recordUse(apiKey *entity.ApiKey, now time.Time) {
+	if now.Sub(apiKey.LastUsedUtc) < lastUsedResolution {
+		return
+	}
+
+	apiKey.LastUsedUtc = now
+	_, _, err := authenticator.ApiKeys.Update(apiKey.ID, apiKey)
+	if err == nil {
+		authenticator.ApiKeys.Save()
+	}
+}
+
+// HashApiKey creates the hash of an API key, which is what is kept in the key store.
+// Returns the hex encoded SHA-256 hash of the key.
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// IssueApiKey creates a new API key that is bound to the roles, and saves its hash in the key store.
+// The key makes requests on behalf of the subject, or of its name when the subject is empty.
+// The key expires after the lifetime, unless the lifetime is zero.
+// The key itself is not kept, so it must be given to its user now.
+// Returns (key, API key, nil) on success, otherwise ("", nil, error).
+func IssueApiKey(apiKeys contract.ApiKeyRepository, name string, subject string, roles []authorizationrole.AuthorizationRole, lifetime time.Duration) (string, *entity.ApiKey, error) {
+	random := make([]byte, apiKeyRandomBytes)
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	var expiresUtc time.Time
+	if lifetime > 0 {
+		expiresUtc = time.Now().UTC().Add(lifetime)
+	}
+
+	if subject == "" {
+		subject = name
+	}
+
+	apiKey, err := entity.NewApiKey(name, subject, HashApiKey(key), roles, expiresUtc)
+	if err != nil {
+		return "", nil, err
+	}
+
+	apiKey, _, err = apiKeys.Insert(apiKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	_, err = apiKeys.Save()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return key, apiKey, nil
+}
+
+// RevokeApiKey stops the API key with the ID from being accepted.
+// Returns nil on success, otherwise an error.
+func RevokeApiKey(apiKeys contract.ApiKeyRepository, id typedef.ID) error {
+	apiKey, err := findApiKey(apiKeys, id)
+	if err != nil {
+		return err
+	}
+
+	if !apiKey.RevokedUtc.IsZero() {
+		return nil
+	}
+
+	apiKey.RevokedUtc = time.Now().UTC()
+	_, _, err = apiKeys.Update(apiKey.ID, apiKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiKeys.Save()
+	return err
+}
+
+// RotateApiKey replaces the API key with the ID by a new key with the same name, subject and roles.
+// The old key continues to be accepted for the overlap, so its users have time to change to the new key.
+// The new key expires after the lifetime, unless the lifetime is zero.
+// Returns (new key, new API key, nil) on success, otherwise ("", nil, error).
+func RotateApiKey(apiKeys contract.ApiKeyRepository, id typedef.ID, overlap time.Duration, lifetime time.Duration) (string, *entity.ApiKey, error) {
+	apiKey, err := findApiKey(apiKeys, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now().UTC()
+	if !apiKey.IsActive(now) {
+		return "", nil, fmt.Errorf("cannot rotate the API key with ID %d because it has been revoked or has expired", id)
+	}
+
+	key, newKey, err := IssueApiKey(apiKeys, apiKey.Name, apiKey.Subject, apiKey.Roles, lifetime)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// The old key expires after the overlap, unless it was going to expire sooner.
+	retiredUtc := now.Add(overlap)
+	if apiKey.ExpiresUtc.IsZero() || retiredUtc.Before(apiKey.ExpiresUtc) {
+		apiKey.ExpiresUtc = retiredUtc
+	}
+
+	_, _, err = apiKeys.Update(apiKey.ID, apiKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	_, err = apiKeys.Save()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return key, newKey, nil
+}
+
+// findApiKey gets the API key with the ID from the key store.
+// Returns (API key, nil) on success, otherwise (nil, error).
+func findApiKey(apiKeys contract.ApiKeyRepository, id typedef.ID) (*entity.ApiKey, error) {
+	apiKey, _, err := apiKeys.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey == nil {
+		return nil, fmt.Errorf("the API key with ID %d was not found", id)
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,216 @@
+// Package security implements unit tests for the ApiKeyAuthenticator and ChainAuthenticator.
+package security
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+// newApiKeyRequest creates a request with the key in its X-API-Key header, unless the key is empty.
+func newApiKeyRequest(key string) *http.Request {
+	r, _ := http.NewRequest("GET", "/api/motorcycles", nil)
+	if key != "" {
+		r.Header.Set(ApiKeyHeader, key)
+	}
+
+	return r
+}
+
+// newTestApiKeyAuthenticator creates an authenticator with an empty key store.
+func newTestApiKeyAuthenticator() (*ApiKeyAuthenticator, *repository.ApiKeyRepository) {
+	apiKeys, _ := repository.NewApiKeyRepository()
+	authenticator, _ := NewApiKeyAuthenticator(apiKeys, DefaultRolePolicy())
+	return authenticator, apiKeys
+}
+
+// TestApiKeyAuthenticator_ApiKeysIsNil verifies that an authenticator requires a key store.
+func TestApiKeyAuthenticator_ApiKeysIsNil(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewApiKeyAuthenticator(nil, DefaultRolePolicy())
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestApiKeyAuthenticator_IssuedKey verifies that an issued key authenticates with its roles, and only its hash is kept.
+func TestApiKeyAuthenticator_IssuedKey(t *testing.T) {
+
+	// ARRANGE
+	authenticator, apiKeys := newTestApiKeyAuthenticator()
+	key, apiKey, _ := IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.AccountingAuthorizationRole}, 0)
+
+	// ACT
+	authService, err := authenticator.Authenticate(newApiKeyRequest(key))
+	used, _, _ := apiKeys.FindByID(apiKey.ID)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, authService.IsAuthenticated())
+	assert.True(t, authService.IsAuthorized(authorizationrole.AccountingAuthorizationRole))
+	assert.True(t, authService.UserID() == "Backup")
+	assert.True(t, authService.IsPermitted(permission.ListMotorcyclesPermission))
+	assert.False(t, authService.IsPermitted(permission.InsertMotorcyclePermission))
+	assert.True(t, apiKey.Hash == HashApiKey(key))
+	assert.True(t, apiKey.Hash != key)
+	assert.False(t, used.LastUsedUtc.IsZero())
+}
+
+// TestApiKeyAuthenticator_NoKey verifies that a request without a key is not authenticated.
+func TestApiKeyAuthenticator_NoKey(t *testing.T) {
+
+	// ARRANGE
+	authenticator, _ := newTestApiKeyAuthenticator()
+
+	// ACT
+	authService, err := authenticator.Authenticate(newApiKeyRequest(""))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.False(t, authService.IsAuthenticated())
+	assert.False(t, authService.IsPermitted(permission.ListMotorcyclesPermission))
+}
+
+// TestApiKeyAuthenticator_UnknownKey verifies that a key that was never issued is rejected.
+func TestApiKeyAuthenticator_UnknownKey(t *testing.T) {
+
+	// ARRANGE
+	authenticator, _ := newTestApiKeyAuthenticator()
+
+	// ACT
+	_, err := authenticator.Authenticate(newApiKeyRequest("mmk_unknown"))
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestApiKeyAuthenticator_RevokedKey verifies that a revoked key is rejected.
+func TestApiKeyAuthenticator_RevokedKey(t *testing.T) {
+
+	// ARRANGE
+	authenticator, apiKeys := newTestApiKeyAuthenticator()
+	key, apiKey, _ := IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.GeneralAuthorizationRole}, 0)
+
+	// ACT
+	revokeErr := RevokeApiKey(apiKeys, apiKey.ID)
+	_, err := authenticator.Authenticate(newApiKeyRequest(key))
+
+	// ASSERT
+	assert.Nil(t, revokeErr)
+	assert.NotNil(t, err)
+}
+
+// TestApiKeyAuthenticator_ExpiredKey verifies that an expired key is rejected.
+func TestApiKeyAuthenticator_ExpiredKey(t *testing.T) {
+
+	// ARRANGE
+	authenticator, apiKeys := newTestApiKeyAuthenticator()
+	key, _, _ := IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.GeneralAuthorizationRole}, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	// ACT
+	_, err := authenticator.Authenticate(newApiKeyRequest(key))
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestApiKeyAuthenticator_RotateKey verifies that both keys are accepted during the overlap, and the old key expires after it.
+func TestApiKeyAuthenticator_RotateKey(t *testing.T) {
+
+	// ARRANGE
+	authenticator, apiKeys := newTestApiKeyAuthenticator()
+	oldKey, apiKey, _ := IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.GeneralAuthorizationRole}, 0)
+
+	// ACT
+	newKey, newApiKey, err := RotateApiKey(apiKeys, apiKey.ID, time.Hour, 0)
+	_, oldErr := authenticator.Authenticate(newApiKeyRequest(oldKey))
+	newAuthService, newErr := authenticator.Authenticate(newApiKeyRequest(newKey))
+	retired, _, _ := apiKeys.FindByID(apiKey.ID)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Nil(t, oldErr)
+	assert.Nil(t, newErr)
+	assert.True(t, newAuthService.IsAuthorized(authorizationrole.GeneralAuthorizationRole))
+	assert.True(t, newApiKey.Name == "Backup")
+	assert.False(t, retired.IsActive(time.Now().UTC().Add(2*time.Hour)))
+}
+
+// TestApiKeyAuthenticator_RotateRevokedKey verifies that a revoked key cannot be rotated.
+func TestApiKeyAuthenticator_RotateRevokedKey(t *testing.T) {
+
+	// ARRANGE
+	_, apiKeys := newTestApiKeyAuthenticator()
+	_, apiKey, _ := IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.GeneralAuthorizationRole}, 0)
+	RevokeApiKey(apiKeys, apiKey.ID)
+
+	// ACT
+	_, _, err := RotateApiKey(apiKeys, apiKey.ID, time.Hour, 0)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestChainAuthenticator_IsEmpty verifies that a chain requires an authenticator.
+func TestChainAuthenticator_IsEmpty(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewChainAuthenticator()
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestChainAuthenticator_EitherCredential verifies that a request is authenticated by either a bearer token or an API key.
+func TestChainAuthenticator_EitherCredential(t *testing.T) {
+
+	// ARRANGE
+	jwtAuthenticator, _ := NewJwtAuthenticator("HS256", testSecret, DefaultRolePolicy())
+	apiKeyAuthenticator, apiKeys := newTestApiKeyAuthenticator()
+	authenticator, _ := NewChainAuthenticator(jwtAuthenticator, apiKeyAuthenticator)
+	key, _, _ := IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.AdminAuthorizationRole}, 0)
+	token := signTestToken(jwt.SigningMethodHS256, testSecret, "mechanic", "General")
+
+	// ACT
+	byToken, tokenErr := authenticator.Authenticate(newBearerRequest(token))
+	byKey, keyErr := authenticator.Authenticate(newApiKeyRequest(key))
+	anonymous, anonymousErr := authenticator.Authenticate(newApiKeyRequest(""))
+
+	// ASSERT
+	assert.Nil(t, tokenErr)
+	assert.True(t, byToken.IsAuthorized(authorizationrole.GeneralAuthorizationRole))
+	assert.Nil(t, keyErr)
+	assert.True(t, byKey.IsAuthorized(authorizationrole.AdminAuthorizationRole))
+	assert.Nil(t, anonymousErr)
+	assert.False(t, anonymous.IsAuthenticated())
+}
+
+// TestChainAuthenticator_InvalidCredential verifies that an invalid bearer token is rejected, even with a valid API key.
+func TestChainAuthenticator_InvalidCredential(t *testing.T) {
+
+	// ARRANGE
+	jwtAuthenticator, _ := NewJwtAuthenticator("HS256", testSecret, DefaultRolePolicy())
+	apiKeyAuthenticator, apiKeys := newTestApiKeyAuthenticator()
+	authenticator, _ := NewChainAuthenticator(jwtAuthenticator, apiKeyAuthenticator)
+	key, _, _ := IssueApiKey(apiKeys, "Backup", "", []authorizationrole.AuthorizationRole{authorizationrole.AdminAuthorizationRole}, 0)
+	r := newBearerRequest("not.a.token")
+	r.Header.Set(ApiKeyHeader, key)
+
+	// ACT
+	_, err := authenticator.Authenticate(r)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,93 @@
+// Package security contains implementations of interfaces dealing security, authentication, and authorization.
+package security
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ApiKeyAuthService provides authentication and authorization services for a request made with an API key.
+type ApiKeyAuthService struct {
+	// ApiKey is the key that authenticated the request, or nil when the request did not have a valid key.
+	ApiKey *entity.ApiKey
+	Policy *RolePolicy
+}
+
+// Validate verifies that an ApiKeyAuthService's fields contain valid data.
+// Returns nil if the ApiKeyAuthService contains valid data, otherwise an error.
+func (authService ApiKeyAuthService) Validate() error {
+	return validation.ValidateStruct(&authService,
+		// ApiKey can be nil, when the request has not been authenticated.
+
+		// Policy cannot be nil.
+		validation.Field(&authService.Policy, validation.NotNil),
+	)
+}
+
+// NewApiKeyAuthService creates a new instance of an ApiKeyAuthService.
+// Returns (nil, error) when there is an error, otherwise (authService, nil).
+func NewApiKeyAuthService(apiKey *entity.ApiKey, policy *RolePolicy) (*ApiKeyAuthService, error) {
+
+	authService := &ApiKeyAuthService{
+		ApiKey: apiKey,
+		Policy: policy,
+	}
+
+	err := authService.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return authService, nil
+}
+
+// IsAuthenticated determines whether the request was made with a valid API key.
+// Returns true if the request has passed authentication, otherwise false.
+func (authService *ApiKeyAuthService) IsAuthenticated() bool {
+	return authService.ApiKey != nil
+}
+
+// UserID identifies the user on whose behalf the API key makes requests.
+// Returns the key's subject, which is empty when the request has not been authenticated.
+func (authService *ApiKeyAuthService) UserID() string {
+	if authService.ApiKey == nil {
+		return ""
+	}
+
+	return authService.ApiKey.Subject
+}
+
+// IsAuthorized determines whether the API key is bound to the required authorization role.
+// Returns true if the role is bound to the key, otherwise false.
+func (authService *ApiKeyAuthService) IsAuthorized(role authorizationrole.AuthorizationRole) bool {
+	if authService.ApiKey == nil {
+		return false
+	}
+
+	for _, keyRole := range authService.ApiKey.Roles {
+		if keyRole == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPermitted determines whether any of the roles bound to the API key grants the required permission.
+// Returns true if the permission is granted, otherwise false.
+func (authService *ApiKeyAuthService) IsPermitted(required permission.Permission) bool {
+	if authService.ApiKey == nil {
+		return false
+	}
+
+	for _, role := range authService.ApiKey.Roles {
+		if authService.Policy.IsGranted(role, required) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,14 +2,21 @@
 package security
 
 import (
+	"net/http"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
 	"github.com/go-ozzo/ozzo-validation"
 )
 
 // AuthService is a contract that provides authentication and authorization services.
 type AuthService struct {
 	Authenticated bool
-	Roles         map[authorizationrole.AuthorizationRole]bool
+	// Subject identifies the authenticated user.  It is empty when the user is not known.
+	Subject string
+	Roles   map[authorizationrole.AuthorizationRole]bool
+	Policy  *RolePolicy
 }
 
 // Validate verifies that an AuthService's fields contain valid data.
@@ -20,6 +27,8 @@ func (authService AuthService) Validate() error {
 
 		// Roles cannot be nil.
 		validation.Field(&authService.Roles, validation.NotNil),
+		// Policy cannot be nil.
+		validation.Field(&authService.Policy, validation.NotNil),
 	)
 
 	if err != nil {
@@ -29,13 +38,20 @@ func (authService AuthService) Validate() error {
 	return nil
 }
 
-// NewAuthService creates a new instance of an AuthService.
+// NewAuthService creates a new instance of an AuthService, which uses the default role policy.
 // Returns (nil, error) when there is an error, otherwise (authService, nil).
 func NewAuthService(authenticated bool, roles map[authorizationrole.AuthorizationRole]bool) (*AuthService, error) {
+	return NewAuthServiceWithPolicy(authenticated, roles, DefaultRolePolicy())
+}
+
+// NewAuthServiceWithPolicy creates a new instance of an AuthService, which uses the role policy to grant permissions.
+// Returns (nil, error) when there is an error, otherwise (authService, nil).
+func NewAuthServiceWithPolicy(authenticated bool, roles map[authorizationrole.AuthorizationRole]bool, policy *RolePolicy) (*AuthService, error) {
 
 	authService := &AuthService{
 		Authenticated: authenticated,
 		Roles:         roles,
+		Policy:        policy,
 	}
 
 	err := authService.Validate()
@@ -47,6 +63,13 @@ func NewAuthService(authenticated bool, roles map[authorizationrole.Authorizatio
 	return authService, nil
 }
 
+// Authenticate provides this auth service for every request, regardless of who made it.
+// It is used when the user is established by other means than the request, such as in tests.
+// Returns (auth service, nil).
+func (authService *AuthService) Authenticate(r *http.Request) (contract.AuthService, error) {
+	return authService, nil
+}
+
 // IsAuthenticated determines whether the User has been authenticated by the system.
 // Returns true if the User has passed authentication, otherwise false.
 func (authService *AuthService) IsAuthenticated() bool {
@@ -54,8 +77,26 @@ func (authService *AuthService) IsAuthenticated() bool {
 
 }
 
-// IsAuthorized determines whether the User possesses the required authorization role(s).
-// Returns true if "Admin" is in the roles, otherwise false.
+// UserID identifies the authenticated User.
+// Returns the subject, which is empty when the User is not known.
+func (authService *AuthService) UserID() string {
+	return authService.Subject
+}
+
+// IsAuthorized determines whether the User possesses the required authorization role.
+// Returns true if the role is in the roles, otherwise false.
 func (authService *AuthService) IsAuthorized(role authorizationrole.AuthorizationRole) bool {
-	return authService.Roles[authorizationrole.AdminAuthorizationRole]
+	return authService.Roles[role]
+}
+
+// IsPermitted determines whether any of the User's authorization roles grants the required permission.
+// Returns true if the permission is granted, otherwise false.
+func (authService *AuthService) IsPermitted(required permission.Permission) bool {
+	for role, hasRole := range authService.Roles {
+		if hasRole && authService.Policy.IsGranted(role, required) {
+			return true
+		}
+	}
+
+	return false
 }
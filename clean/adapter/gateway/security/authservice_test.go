@@ -3,6 +3,7 @@ package security
 
 import (
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -75,3 +76,65 @@ func TestAuthService_DoesNotHaveRoleInMap(t *testing.T) {
 	// ASSERT
 	assert.False(t, authService.IsAuthorized(authorizationrole.AdminAuthorizationRole))
 }
+
+// TestAuthService_HasOtherRole verifies that a user with one role is not authorized for a different role.
+func TestAuthService_HasOtherRole(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.GeneralAuthorizationRole: true,
+	}
+
+	// ACT
+	authService, _ := NewAuthService(true, roles)
+
+	// ASSERT
+	assert.True(t, authService.IsAuthorized(authorizationrole.GeneralAuthorizationRole))
+	assert.False(t, authService.IsAuthorized(authorizationrole.AdminAuthorizationRole))
+}
+
+// TestAuthService_IsPermitted verifies that the default policy grants the general role everything except deletion.
+func TestAuthService_IsPermitted(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.GeneralAuthorizationRole: true,
+	}
+
+	// ACT
+	authService, _ := NewAuthService(true, roles)
+
+	// ASSERT
+	assert.True(t, authService.IsPermitted(permission.UpdateMotorcyclePermission))
+	assert.False(t, authService.IsPermitted(permission.DeleteMotorcyclePermission))
+}
+
+// TestAuthService_IsPermitted_ConfiguredPolicy verifies that a configured policy replaces the default one.
+func TestAuthService_IsPermitted_ConfiguredPolicy(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AccountingAuthorizationRole: true,
+	}
+	policy, _ := ParseRolePolicy([]byte(`{"Accounting": ["DeleteMotorcycle"]}`))
+
+	// ACT
+	authService, _ := NewAuthServiceWithPolicy(true, roles, policy)
+
+	// ASSERT
+	assert.True(t, authService.IsPermitted(permission.DeleteMotorcyclePermission))
+	assert.False(t, authService.IsPermitted(permission.ListMotorcyclesPermission))
+}
+
+// TestAuthService_PolicyIsNil verifies that an auth service requires a policy.
+func TestAuthService_PolicyIsNil(t *testing.T) {
+
+	// ARRANGE
+	roles := make(map[authorizationrole.AuthorizationRole]bool)
+
+	// ACT
+	_, err := NewAuthServiceWithPolicy(true, roles, nil)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
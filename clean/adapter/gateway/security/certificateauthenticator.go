@@ -0,0 +1,249 @@
+// Package security contains implementations of interfaces dealing security, authentication, and authorization.
+package security
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// The kinds of identity in a client certificate, which prefix the identities in a CertificateRoleMap.
+const (
+	commonNameIdentity = "cn"
+	dnsIdentity        = "dns"
+	emailIdentity      = "email"
+	uriIdentity        = "uri"
+)
+
+// CertificateRoleMap is the mapping of the identities in client certificates to the authorization roles that they are
+// bound to.  An identity is the kind of name followed by the name, which is "cn:" for the subject's common name, and
+// "dns:", "email:" or "uri:" for a subject alternative name, such as "cn:terminal-01" or "dns:*.shop.example.com".
+// A DNS name that begins with "*." matches any name with one more label.
+type CertificateRoleMap struct {
+	Identities map[string][]authorizationrole.AuthorizationRole
+}
+
+// Validate verifies that a CertificateRoleMap's fields contain valid data.
+// Returns nil if the CertificateRoleMap contains valid data, otherwise an error.
+func (roleMap CertificateRoleMap) Validate() error {
+	err := validation.ValidateStruct(&roleMap,
+		// Identities cannot be empty.
+		validation.Field(&roleMap.Identities, validation.Required),
+	)
+	if err != nil {
+		return err
+	}
+
+	for identity := range roleMap.Identities {
+		kind, name, ok := splitIdentity(identity)
+		if !ok || name == "" {
+			return fmt.Errorf("the certificate identity %q is not one of cn:, dns:, email: or uri: followed by a name", identity)
+		}
+		if kind != dnsIdentity && strings.HasPrefix(name, "*.") {
+			return fmt.Errorf("the certificate identity %q cannot have a wildcard, since it is not a DNS name", identity)
+		}
+	}
+
+	return nil
+}
+
+// NewCertificateRoleMap creates a new instance of a CertificateRoleMap.
+// Returns (nil, error) when there is an error, otherwise (CertificateRoleMap, nil).
+func NewCertificateRoleMap(identities map[string][]authorizationrole.AuthorizationRole) (*CertificateRoleMap, error) {
+
+	roleMap := &CertificateRoleMap{
+		Identities: identities,
+	}
+
+	err := roleMap.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return roleMap, nil
+}
+
+// LoadCertificateRoleMap reads a role map from a JSON file, which maps each identity to the names of its roles.
+// For example, {"cn:terminal-01": ["General"], "dns:*.office.example.com": ["Accounting"]}.
+// Returns (CertificateRoleMap, nil) on success, otherwise (nil, error).
+func LoadCertificateRoleMap(path string) (*CertificateRoleMap, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCertificateRoleMap(contents)
+}
+
+// ParseCertificateRoleMap creates a role map from JSON, which maps each identity to the names of its roles.
+// Returns (CertificateRoleMap, nil) on success, otherwise (nil, error).
+func ParseCertificateRoleMap(contents []byte) (*CertificateRoleMap, error) {
+	names := make(map[string][]string)
+	err := json.Unmarshal(contents, &names)
+	if err != nil {
+		return nil, fmt.Errorf("the certificate role map is not valid: %s", err.Error())
+	}
+
+	identities := make(map[string][]authorizationrole.AuthorizationRole)
+	for identity, roleNames := range names {
+		roles := make([]authorizationrole.AuthorizationRole, 0, len(roleNames))
+		for _, roleName := range roleNames {
+			role, err := authorizationrole.Parse(roleName)
+			if err != nil {
+				return nil, err
+			}
+			roles = append(roles, role)
+		}
+		identities[identity] = roles
+	}
+
+	return NewCertificateRoleMap(identities)
+}
+
+// Roles finds the roles that are bound to any of the identities in the certificate.
+// Returns the set of roles, which is empty when none of the certificate's identities are mapped.
+func (roleMap *CertificateRoleMap) Roles(certificate *x509.Certificate) map[authorizationrole.AuthorizationRole]bool {
+	roles := make(map[authorizationrole.AuthorizationRole]bool)
+	for pattern, patternRoles := range roleMap.Identities {
+		for _, identity := range certificateIdentities(certificate) {
+			if matchIdentity(pattern, identity) {
+				for _, role := range patternRoles {
+					roles[role] = true
+				}
+			}
+		}
+	}
+
+	return roles
+}
+
+// CertificateAuthenticator authenticates each HTTP request from the client certificate that was presented, and
+// verified against the trusted certificate authorities, when its TLS connection was established.
+type CertificateAuthenticator struct {
+	RoleMap *CertificateRoleMap
+	Policy  *RolePolicy
+}
+
+// Validate verifies that a CertificateAuthenticator's fields contain valid data.
+// Returns nil if the CertificateAuthenticator contains valid data, otherwise an error.
+func (authenticator CertificateAuthenticator) Validate() error {
+	return validation.ValidateStruct(&authenticator,
+		// RoleMap cannot be nil.
+		validation.Field(&authenticator.RoleMap, validation.NotNil),
+		// Policy cannot be nil.
+		validation.Field(&authenticator.Policy, validation.NotNil),
+	)
+}
+
+// NewCertificateAuthenticator creates a new instance of a CertificateAuthenticator.
+// Returns (nil, error) when there is an error, otherwise (CertificateAuthenticator, nil).
+func NewCertificateAuthenticator(roleMap *CertificateRoleMap, policy *RolePolicy) (*CertificateAuthenticator, error) {
+
+	authenticator := &CertificateAuthenticator{
+		RoleMap: roleMap,
+		Policy:  policy,
+	}
+
+	err := authenticator.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return authenticator, nil
+}
+
+// Authenticate creates the auth service for the client certificate that made the request.
+// A request without a verified client certificate has not been authenticated.  The user is identified by the
+// certificate's common name, or by its first subject alternative name when it does not have one.
+// Returns (auth service, nil) on success, otherwise (nil, error) when the certificate is not mapped to any role.
+func (authenticator *CertificateAuthenticator) Authenticate(r *http.Request) (contract.AuthService, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return NewAuthServiceWithPolicy(false, make(map[authorizationrole.AuthorizationRole]bool), authenticator.Policy)
+	}
+
+	certificate := r.TLS.VerifiedChains[0][0]
+	identities := certificateIdentities(certificate)
+	roles := authenticator.RoleMap.Roles(certificate)
+	if len(identities) == 0 || len(roles) == 0 {
+		return nil, errors.New("the client certificate is not mapped to any authorization role")
+	}
+
+	authService, err := NewAuthServiceWithPolicy(true, roles, authenticator.Policy)
+	if err != nil {
+		return nil, err
+	}
+	_, authService.Subject, _ = splitIdentity(identities[0])
+
+	return authService, nil
+}
+
+// certificateIdentities lists the identities in the certificate, starting with its common name.
+func certificateIdentities(certificate *x509.Certificate) []string {
+	identities := make([]string, 0)
+	if certificate.Subject.CommonName != "" {
+		identities = append(identities, commonNameIdentity+":"+certificate.Subject.CommonName)
+	}
+	for _, name := range certificate.DNSNames {
+		identities = append(identities, dnsIdentity+":"+name)
+	}
+	for _, address := range certificate.EmailAddresses {
+		identities = append(identities, emailIdentity+":"+address)
+	}
+	for _, uri := range certificate.URIs {
+		identities = append(identities, uriIdentity+":"+uri.String())
+	}
+
+	return identities
+}
+
+// splitIdentity separates an identity into its kind and name.
+// Returns (kind, name, true) on success, otherwise ("", "", false) when the kind is not known.
+func splitIdentity(identity string) (string, string, bool) {
+	parts := strings.SplitN(identity, ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(parts[0]))
+	switch kind {
+	case commonNameIdentity, dnsIdentity, emailIdentity, uriIdentity:
+		return kind, parts[1], true
+	default:
+		return "", "", false
+	}
+}
+
+// matchIdentity determines whether an identity in a certificate matches an identity in a role map.
+// DNS names and email addresses are compared without regard to case.
+// Returns true if the identities match, otherwise false.
+func matchIdentity(pattern string, identity string) bool {
+	patternKind, patternName, _ := splitIdentity(pattern)
+	kind, name, _ := splitIdentity(identity)
+	if patternKind != kind {
+		return false
+	}
+
+	switch kind {
+	case dnsIdentity:
+		if strings.HasPrefix(patternName, "*.") {
+			suffix := strings.ToLower(patternName[1:])
+			label := strings.TrimSuffix(strings.ToLower(name), suffix)
+			return label != strings.ToLower(name) && label != "" && !strings.Contains(label, ".")
+		}
+		return strings.EqualFold(patternName, name)
+	case emailIdentity:
+		return strings.EqualFold(patternName, name)
+	default:
+		return patternName == name
+	}
+}
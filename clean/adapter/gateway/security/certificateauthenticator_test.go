@@ -0,0 +1,125 @@
+// Package security implements unit tests for the CertificateAuthenticator.
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCertificateRequest creates a request over a TLS connection on which the certificate was verified, unless it is nil.
+func newCertificateRequest(certificate *x509.Certificate) *http.Request {
+	r, _ := http.NewRequest("GET", "/api/motorcycles", nil)
+	r.TLS = &tls.ConnectionState{}
+	if certificate != nil {
+		r.TLS.VerifiedChains = [][]*x509.Certificate{{certificate}}
+	}
+
+	return r
+}
+
+// newTestCertificateRoleMap creates a role map for a terminal and the machines in an office.
+func newTestCertificateRoleMap() *CertificateRoleMap {
+	roleMap, _ := ParseCertificateRoleMap([]byte(`{"cn:terminal-01": ["General"], "dns:*.office.example.com": ["Accounting"]}`))
+	return roleMap
+}
+
+// TestParseCertificateRoleMap_Invalid verifies that a role map with an unknown kind of identity, an unknown role,
+// or a wildcard that is not in a DNS name is rejected.
+func TestParseCertificateRoleMap_Invalid(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, unknownKind := ParseCertificateRoleMap([]byte(`{"ip:10.0.0.1": ["General"]}`))
+	_, unknownRole := ParseCertificateRoleMap([]byte(`{"cn:terminal-01": ["Mechanic"]}`))
+	_, wildcard := ParseCertificateRoleMap([]byte(`{"cn:*.example.com": ["General"]}`))
+	_, empty := ParseCertificateRoleMap([]byte(`{}`))
+
+	// ASSERT
+	assert.NotNil(t, unknownKind)
+	assert.NotNil(t, unknownRole)
+	assert.NotNil(t, wildcard)
+	assert.NotNil(t, empty)
+}
+
+// TestCertificateRoleMap_Roles verifies that the roles of a certificate are found from its common name and its
+// DNS names, where a wildcard matches exactly one label.
+func TestCertificateRoleMap_Roles(t *testing.T) {
+
+	// ARRANGE
+	roleMap := newTestCertificateRoleMap()
+	terminal := &x509.Certificate{Subject: pkix.Name{CommonName: "terminal-01"}}
+	office := &x509.Certificate{DNSNames: []string{"Desk-3.Office.Example.com"}}
+	nested := &x509.Certificate{DNSNames: []string{"a.desk-3.office.example.com", "office.example.com"}}
+
+	// ACT
+	terminalRoles := roleMap.Roles(terminal)
+	officeRoles := roleMap.Roles(office)
+	nestedRoles := roleMap.Roles(nested)
+
+	// ASSERT
+	assert.Equal(t, map[authorizationrole.AuthorizationRole]bool{authorizationrole.GeneralAuthorizationRole: true}, terminalRoles)
+	assert.Equal(t, map[authorizationrole.AuthorizationRole]bool{authorizationrole.AccountingAuthorizationRole: true}, officeRoles)
+	assert.True(t, len(nestedRoles) == 0)
+}
+
+// TestCertificateAuthenticator_Authenticate verifies that a verified client certificate authenticates the user it names,
+// with the roles that it is mapped to.
+func TestCertificateAuthenticator_Authenticate(t *testing.T) {
+
+	// ARRANGE
+	authenticator, _ := NewCertificateAuthenticator(newTestCertificateRoleMap(), DefaultRolePolicy())
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "terminal-01"}}
+
+	// ACT
+	authService, err := authenticator.Authenticate(newCertificateRequest(certificate))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, authService.IsAuthenticated())
+	assert.True(t, authService.UserID() == "terminal-01")
+	assert.True(t, authService.IsPermitted(permission.InsertMotorcyclePermission))
+	assert.False(t, authService.IsPermitted(permission.DeleteMotorcyclePermission))
+}
+
+// TestCertificateAuthenticator_NoCertificate verifies that a request without a verified client certificate
+// has not been authenticated, so it may be authenticated by other means.
+func TestCertificateAuthenticator_NoCertificate(t *testing.T) {
+
+	// ARRANGE
+	authenticator, _ := NewCertificateAuthenticator(newTestCertificateRoleMap(), DefaultRolePolicy())
+	plain, _ := http.NewRequest("GET", "/api/motorcycles", nil)
+
+	// ACT
+	tlsService, tlsErr := authenticator.Authenticate(newCertificateRequest(nil))
+	plainService, plainErr := authenticator.Authenticate(plain)
+
+	// ASSERT
+	assert.Nil(t, tlsErr)
+	assert.False(t, tlsService.IsAuthenticated())
+	assert.Nil(t, plainErr)
+	assert.False(t, plainService.IsAuthenticated())
+}
+
+// TestCertificateAuthenticator_NotMapped verifies that a verified client certificate that is not mapped to any role
+// is rejected.
+func TestCertificateAuthenticator_NotMapped(t *testing.T) {
+
+	// ARRANGE
+	authenticator, _ := NewCertificateAuthenticator(newTestCertificateRoleMap(), DefaultRolePolicy())
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "terminal-02"}}
+
+	// ACT
+	authService, err := authenticator.Authenticate(newCertificateRequest(certificate))
+
+	// ASSERT
+	assert.NotNil(t, err)
+	assert.Nil(t, authService)
+}
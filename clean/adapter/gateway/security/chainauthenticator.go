@@ -0,0 +1,68 @@
+// Package security contains implementations of interfaces dealing security, authentication, and authorization.
+package security
+
+import (
+	"net/http"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// RequestAuthenticator establishes who made an HTTP request, and what they are authorized to do.
+type RequestAuthenticator interface {
+	Authenticate(r *http.Request) (contract.AuthService, error)
+}
+
+// ChainAuthenticator authenticates a request with the first of its authenticators that finds credentials in it,
+// so a request may be made with either a bearer token or an API key.
+type ChainAuthenticator struct {
+	Authenticators []RequestAuthenticator
+}
+
+// Validate verifies that a ChainAuthenticator's fields contain valid data.
+// Returns nil if the ChainAuthenticator contains valid data, otherwise an error.
+func (authenticator ChainAuthenticator) Validate() error {
+	return validation.ValidateStruct(&authenticator,
+		// Authenticators cannot be empty.
+		validation.Field(&authenticator.Authenticators, validation.Required),
+	)
+}
+
+// NewChainAuthenticator creates a new instance of a ChainAuthenticator, which tries the authenticators in order.
+// Returns (nil, error) when there is an error, otherwise (ChainAuthenticator, nil).
+func NewChainAuthenticator(authenticators ...RequestAuthenticator) (*ChainAuthenticator, error) {
+
+	authenticator := &ChainAuthenticator{
+		Authenticators: authenticators,
+	}
+
+	err := authenticator.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return authenticator, nil
+}
+
+// Authenticate creates the auth service for the user who made the request.
+// Invalid credentials are rejected immediately, rather than trying the next authenticator.
+// Returns (auth service, nil) on success, otherwise (nil, error).
+func (authenticator *ChainAuthenticator) Authenticate(r *http.Request) (contract.AuthService, error) {
+	var authService contract.AuthService
+
+	for _, next := range authenticator.Authenticators {
+		var err error
+		authService, err = next.Authenticate(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if authService.IsAuthenticated() {
+			return authService, nil
+		}
+	}
+
+	// None of the authenticators found credentials, so the last one's unauthenticated service is used.
+	return authService, nil
+}
@@ -0,0 +1,182 @@
+// Package security contains implementations of interfaces dealing security, authentication, and authorization.
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/golang-jwt/jwt"
+)
+
+// bearerPrefix is the authentication scheme at the start of an Authorization header carrying a token.
+const bearerPrefix = "Bearer "
+
+// jwtClaims are the claims in a token that are used to authenticate and authorize a user.
+// The subject identifies the user, and the roles are the names of the user's authorization roles.
+type jwtClaims struct {
+	Roles []string `json:"roles"`
+	jwt.StandardClaims
+}
+
+// JwtAuthenticator authenticates each HTTP request from the JSON Web Token in its Authorization header.
+type JwtAuthenticator struct {
+	// Algorithm is the only signing algorithm that is accepted, such as HS256, RS256 or ES256.
+	Algorithm string
+	// Key verifies the signature of a token.  It is a []byte for HMAC, otherwise an *rsa.PublicKey or *ecdsa.PublicKey.
+	Key interface{}
+	// Policy grants permissions to the roles in a token.
+	Policy *RolePolicy
+}
+
+// Validate verifies that a JwtAuthenticator's fields contain valid data.
+// Returns nil if the JwtAuthenticator contains valid data, otherwise an error.
+func (authenticator JwtAuthenticator) Validate() error {
+	err := validation.ValidateStruct(&authenticator,
+		// Algorithm is required.
+		validation.Field(&authenticator.Algorithm, validation.Required),
+		// Key cannot be nil.
+		validation.Field(&authenticator.Key, validation.NotNil),
+		// Policy cannot be nil.
+		validation.Field(&authenticator.Policy, validation.NotNil),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return verifyJwtKey(authenticator.Algorithm, authenticator.Key)
+}
+
+// NewJwtAuthenticator creates a new instance of a JwtAuthenticator.
+// Returns (nil, error) when there is an error, otherwise (JwtAuthenticator, nil).
+func NewJwtAuthenticator(algorithm string, key interface{}, policy *RolePolicy) (*JwtAuthenticator, error) {
+
+	authenticator := &JwtAuthenticator{
+		Algorithm: algorithm,
+		Key:       key,
+		Policy:    policy,
+	}
+
+	err := authenticator.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return authenticator, nil
+}
+
+// LoadJwtKey reads the key that verifies tokens signed with the algorithm from the file at path.
+// The file contains the shared secret for HMAC, otherwise a PEM encoded RSA or ECDSA public key.
+// Returns (key, nil) on success, otherwise (nil, error).
+func LoadJwtKey(algorithm string, path string) (interface{}, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch jwt.GetSigningMethod(algorithm).(type) {
+	case *jwt.SigningMethodHMAC:
+		secret := []byte(strings.TrimSpace(string(contents)))
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("the secret in %s is empty", path)
+		}
+		return secret, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		return jwt.ParseRSAPublicKeyFromPEM(contents)
+	case *jwt.SigningMethodECDSA:
+		return jwt.ParseECPublicKeyFromPEM(contents)
+	default:
+		return nil, fmt.Errorf("the signing algorithm %q is not supported", algorithm)
+	}
+}
+
+// verifyJwtKey checks that the key can verify tokens signed with the algorithm.
+// Returns nil on success, otherwise an error.
+func verifyJwtKey(algorithm string, key interface{}) error {
+	var ok bool
+
+	switch jwt.GetSigningMethod(algorithm).(type) {
+	case *jwt.SigningMethodHMAC:
+		_, ok = key.([]byte)
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, ok = key.(*rsa.PublicKey)
+	case *jwt.SigningMethodECDSA:
+		_, ok = key.(*ecdsa.PublicKey)
+	default:
+		return fmt.Errorf("the signing algorithm %q is not supported", algorithm)
+	}
+
+	if !ok {
+		return fmt.Errorf("the key cannot verify tokens signed with %s", algorithm)
+	}
+
+	return nil
+}
+
+// Authenticate creates the auth service for the user who made the request.
+// A request without an Authorization header is from a user who has not been authenticated.
+// Returns (auth service, nil) on success, otherwise (nil, error) when the token is not valid.
+func (authenticator *JwtAuthenticator) Authenticate(r *http.Request) (contract.AuthService, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return NewAuthServiceWithPolicy(false, make(map[authorizationrole.AuthorizationRole]bool), authenticator.Policy)
+	}
+
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return nil, errors.New("the Authorization header does not contain a bearer token")
+	}
+
+	claims := &jwtClaims{}
+	_, err := jwt.ParseWithClaims(strings.TrimSpace(header[len(bearerPrefix):]), claims, authenticator.keyFor)
+	if err != nil {
+		return nil, fmt.Errorf("the bearer token is not valid: %s", err.Error())
+	}
+
+	// A token without an expiry would be accepted forever, so one is required.
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("the bearer token does not have an expiry")
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("the bearer token does not have a subject")
+	}
+
+	// Roles that are not known to this application are ignored.
+	roles := make(map[authorizationrole.AuthorizationRole]bool)
+	for _, name := range claims.Roles {
+		role, err := authorizationrole.Parse(name)
+		if err == nil {
+			roles[role] = true
+		}
+	}
+
+	authService, err := NewAuthServiceWithPolicy(true, roles, authenticator.Policy)
+	if err != nil {
+		return nil, err
+	}
+	authService.Subject = claims.Subject
+
+	return authService, nil
+}
+
+// keyFor provides the key that verifies the token's signature.
+// A token signed with any algorithm other than the configured one is rejected,
+// so that a public key cannot be used as an HMAC secret.
+// Returns (key, nil) on success, otherwise (nil, error).
+func (authenticator *JwtAuthenticator) keyFor(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != authenticator.Algorithm {
+		return nil, fmt.Errorf("the signing algorithm %q is not accepted", token.Method.Alg())
+	}
+
+	return authenticator.Key, nil
+}
@@ -0,0 +1,148 @@
+// Package security contains implementations of interfaces dealing security, authentication, and authorization.
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// RolePolicy is the mapping of authorization roles to the permissions that they grant.
+type RolePolicy struct {
+	Grants map[authorizationrole.AuthorizationRole]map[permission.Permission]bool
+}
+
+// Validate verifies that a RolePolicy's fields contain valid data.
+// Returns nil if the RolePolicy contains valid data, otherwise an error.
+func (policy RolePolicy) Validate() error {
+	return validation.ValidateStruct(&policy,
+		// Grants cannot be nil.
+		validation.Field(&policy.Grants, validation.NotNil))
+}
+
+// NewRolePolicy creates a new instance of a RolePolicy.
+// Returns (nil, error) when there is an error, otherwise (RolePolicy, nil).
+func NewRolePolicy(grants map[authorizationrole.AuthorizationRole]map[permission.Permission]bool) (*RolePolicy, error) {
+
+	policy := &RolePolicy{
+		Grants: grants,
+	}
+
+	err := policy.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return policy, nil
+}
+
+// DefaultRolePolicy creates the policy that is used when one has not been configured.
+// Admin may do everything, General may do everything except delete, and Accounting may only read.
+func DefaultRolePolicy() *RolePolicy {
+	return &RolePolicy{
+		Grants: map[authorizationrole.AuthorizationRole]map[permission.Permission]bool{
+			authorizationrole.AdminAuthorizationRole: {
+				permission.ListMotorcyclesPermission:       true,
+				permission.GetMotorcyclePermission:         true,
+				permission.InsertMotorcyclePermission:      true,
+				permission.UpdateMotorcyclePermission:      true,
+				permission.DeleteMotorcyclePermission:      true,
+				permission.AccessAllMotorcyclesPermission:  true,
+				permission.ListOdometerReadingsPermission:  true,
+				permission.InsertOdometerReadingPermission: true,
+				permission.DeleteOdometerReadingPermission: true,
+				permission.ListServiceRecordsPermission:    true,
+				permission.GetServiceRecordPermission:      true,
+				permission.InsertServiceRecordPermission:   true,
+				permission.UpdateServiceRecordPermission:   true,
+				permission.DeleteServiceRecordPermission:   true,
+				permission.GetMaintenanceDuePermission:     true,
+				permission.ListRemindersPermission:         true,
+				permission.SnoozeReminderPermission:        true,
+				permission.AcknowledgeReminderPermission:   true,
+				permission.DecodeVinPermission:             true,
+			},
+			authorizationrole.GeneralAuthorizationRole: {
+				permission.ListMotorcyclesPermission:       true,
+				permission.GetMotorcyclePermission:         true,
+				permission.InsertMotorcyclePermission:      true,
+				permission.UpdateMotorcyclePermission:      true,
+				permission.ListOdometerReadingsPermission:  true,
+				permission.InsertOdometerReadingPermission: true,
+				permission.DeleteOdometerReadingPermission: true,
+				permission.ListServiceRecordsPermission:    true,
+				permission.GetServiceRecordPermission:      true,
+				permission.InsertServiceRecordPermission:   true,
+				permission.UpdateServiceRecordPermission:   true,
+				permission.DeleteServiceRecordPermission:   true,
+				permission.GetMaintenanceDuePermission:     true,
+				permission.ListRemindersPermission:         true,
+				permission.SnoozeReminderPermission:        true,
+				permission.AcknowledgeReminderPermission:   true,
+				permission.DecodeVinPermission:             true,
+			},
+			authorizationrole.AccountingAuthorizationRole: {
+				permission.ListMotorcyclesPermission:      true,
+				permission.GetMotorcyclePermission:        true,
+				permission.ListOdometerReadingsPermission: true,
+				permission.ListServiceRecordsPermission:   true,
+				permission.GetServiceRecordPermission:     true,
+				permission.GetMaintenanceDuePermission:    true,
+				permission.ListRemindersPermission:        true,
+				permission.DecodeVinPermission:            true,
+			},
+		},
+	}
+}
+
+// LoadRolePolicy reads a policy from a JSON file, which maps the name of each role to the names of its permissions.
+// For example, {"Admin": ["ListMotorcycles", "DeleteMotorcycle"], "General": ["ListMotorcycles"]}.
+// Returns (RolePolicy, nil) on success, otherwise (nil, error).
+func LoadRolePolicy(path string) (*RolePolicy, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRolePolicy(contents)
+}
+
+// ParseRolePolicy creates a policy from JSON, which maps the name of each role to the names of its permissions.
+// Returns (RolePolicy, nil) on success, otherwise (nil, error).
+func ParseRolePolicy(contents []byte) (*RolePolicy, error) {
+	names := make(map[string][]string)
+	err := json.Unmarshal(contents, &names)
+	if err != nil {
+		return nil, fmt.Errorf("the role policy is not valid: %s", err.Error())
+	}
+
+	grants := make(map[authorizationrole.AuthorizationRole]map[permission.Permission]bool)
+	for roleName, permissionNames := range names {
+		role, err := authorizationrole.Parse(roleName)
+		if err != nil {
+			return nil, err
+		}
+
+		grants[role] = make(map[permission.Permission]bool)
+		for _, permissionName := range permissionNames {
+			granted, err := permission.Parse(permissionName)
+			if err != nil {
+				return nil, err
+			}
+			grants[role][granted] = true
+		}
+	}
+
+	return NewRolePolicy(grants)
+}
+
+// IsGranted determines whether the role grants the permission.
+// Returns true if the permission is granted, otherwise false.
+func (policy *RolePolicy) IsGranted(role authorizationrole.AuthorizationRole, required permission.Permission) bool {
+	return policy.Grants[role][required]
+}
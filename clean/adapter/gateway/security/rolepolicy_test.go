@@ -0,0 +1,102 @@
+// Package security implements unit tests for the RolePolicy.
+package security
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRolePolicy_GrantsIsNil verifies that a policy requires grants.
+func TestRolePolicy_GrantsIsNil(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewRolePolicy(nil)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestRolePolicy_Default verifies that the default policy only grants deletion to the admin role.
+func TestRolePolicy_Default(t *testing.T) {
+
+	// ARRANGE
+	policy := DefaultRolePolicy()
+
+	// ACT
+	admin := policy.IsGranted(authorizationrole.AdminAuthorizationRole, permission.DeleteMotorcyclePermission)
+	general := policy.IsGranted(authorizationrole.GeneralAuthorizationRole, permission.DeleteMotorcyclePermission)
+	accounting := policy.IsGranted(authorizationrole.AccountingAuthorizationRole, permission.DeleteMotorcyclePermission)
+
+	// ASSERT
+	assert.True(t, admin)
+	assert.False(t, general)
+	assert.False(t, accounting)
+}
+
+// TestRolePolicy_Parse verifies that role and permission names are matched regardless of case.
+func TestRolePolicy_Parse(t *testing.T) {
+
+	// ARRANGE
+	contents := []byte(`{"general": ["listMotorcycles", "GETMOTORCYCLE"]}`)
+
+	// ACT
+	policy, err := ParseRolePolicy(contents)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, policy.IsGranted(authorizationrole.GeneralAuthorizationRole, permission.ListMotorcyclesPermission))
+	assert.True(t, policy.IsGranted(authorizationrole.GeneralAuthorizationRole, permission.GetMotorcyclePermission))
+	assert.False(t, policy.IsGranted(authorizationrole.GeneralAuthorizationRole, permission.InsertMotorcyclePermission))
+	assert.False(t, policy.IsGranted(authorizationrole.AdminAuthorizationRole, permission.ListMotorcyclesPermission))
+}
+
+// TestRolePolicy_Parse_InvalidRole verifies that an unknown role is rejected.
+func TestRolePolicy_Parse_InvalidRole(t *testing.T) {
+
+	// ARRANGE
+	contents := []byte(`{"Mechanic": ["ListMotorcycles"]}`)
+
+	// ACT
+	_, err := ParseRolePolicy(contents)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestRolePolicy_Parse_InvalidPermission verifies that an unknown permission is rejected.
+func TestRolePolicy_Parse_InvalidPermission(t *testing.T) {
+
+	// ARRANGE
+	contents := []byte(`{"Admin": ["RepaintMotorcycle"]}`)
+
+	// ACT
+	_, err := ParseRolePolicy(contents)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestRolePolicy_Load verifies that a policy is read from a file.
+func TestRolePolicy_Load(t *testing.T) {
+
+	// ARRANGE
+	dir, _ := ioutil.TempDir("", "rolepolicy")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "policy.json")
+	ioutil.WriteFile(path, []byte(`{"Accounting": ["ListMotorcycles"]}`), 0600)
+
+	// ACT
+	policy, err := LoadRolePolicy(path)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, policy.IsGranted(authorizationrole.AccountingAuthorizationRole, permission.ListMotorcyclesPermission))
+}
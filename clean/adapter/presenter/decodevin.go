@@ -0,0 +1,46 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"fmt"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// DecodeVinPresenter translates the response message from the DecodeVinInteractor to a view model.
+type DecodeVinPresenter struct {
+}
+
+// NewDecodeVinPresenter creates a new instance of a DecodeVinPresenter.
+// Returns (instance of DecodeVinPresenter, nil) on success, otherwise (nil, error).
+func NewDecodeVinPresenter() (*DecodeVinPresenter, error) {
+
+	presenter := &DecodeVinPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of DecodeVinPresenter, nil) on success, otherwise (nil, error)
+func (presenter *DecodeVinPresenter) Handle(responseMessage *response.DecodeVinResponse) (*viewmodel.DecodeVinViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewDecodeVinViewModel(nil, "Failed to decode the VIN.", responseMessage.Error)
+	}
+
+	decodedDto, err := dto.NewDecodedVinDto(*responseMessage.DecodedVin)
+	if err != nil {
+		return viewmodel.NewDecodeVinViewModel(nil, "Failed to create an immutable decoded VIN.", err)
+	}
+
+	return viewmodel.NewDecodeVinViewModel(decodedDto, fmt.Sprintf("Successfully decoded the VIN %s.", decodedDto.Vin), responseMessage.Error)
+}
+
+// Validate verifies that a DecodeVinPresenter's fields contain valid data.
+// Returns (an instance of DecodeVinPresenter, nil) on success, otherwise (nil, error)
+func (presenter DecodeVinPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,40 @@
+// Package presenter implements unit tests for DecodeVinResponseMessagePresentation.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// newManufacturerRepository creates the default table of manufacturers for decoding VINs.
+func newManufacturerRepository() *repository.ManufacturerRepository {
+	repo, _ := repository.DefaultManufacturerRepository()
+	return repo
+}
+
+// TestDecodeVinPresenter_Handle verifies that a response messages is translated into a proper view model.
+func TestDecodeVinPresenter_Handle(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.GeneralAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	vinRequest, _ := request.NewDecodeVinRequest("JH2PC35051M200020")
+	vinInteractor, _ := interactor.NewDecodeVinInteractor(newManufacturerRepository(), authService)
+	vinResponse, _ := vinInteractor.Handle(vinRequest)
+	vinPresenter, _ := NewDecodeVinPresenter()
+
+	// ACT
+	viewModel, _ := vinPresenter.Handle(vinResponse)
+
+	// ASSERT
+	assert.Nil(t, viewModel.Error)
+	assert.True(t, viewModel.DecodedVin.Manufacturer == "Honda")
+	assert.True(t, viewModel.DecodedVin.ModelYear == 2001)
+}
@@ -4,7 +4,9 @@ package presenter
 import (
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
@@ -22,11 +24,11 @@ func TestDeleteMotorcyclePresenter_Handle(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 
 	// Insert a motorcycle so we can delete it.
-	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
 	insertResponse, _ := insertInteractor.Handle(insertRequest)
 
-	deleteRequest, _ := request.NewDeleteMotorcycleRequest(insertResponse.ID)
+	deleteRequest, _ := request.NewDeleteMotorcycleRequest(insertResponse.ID, constant.AnyRowVersion)
 	deleteInteractor, _ := interactor.NewDeleteMotorcycleInteractor(repo, authService)
 	deleteResponse, _ := deleteInteractor.Handle(deleteRequest)
 	deletePresenter, _ := NewDeleteMotorcyclePresenter()
@@ -0,0 +1,38 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// DeleteOdometerReadingPresenter translates the response message from the DeleteOdometerReadingInteractor to a view model.
+type DeleteOdometerReadingPresenter struct {
+}
+
+// NewDeleteOdometerReadingPresenter creates a new instance of a DeleteOdometerReadingPresenter.
+// Returns (instance of DeleteOdometerReadingPresenter, nil) on success, otherwise (nil, error).
+func NewDeleteOdometerReadingPresenter() (*DeleteOdometerReadingPresenter, error) {
+
+	presenter := &DeleteOdometerReadingPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of DeleteOdometerReadingViewModel, nil) on success, otherwise (nil, error)
+func (presenter *DeleteOdometerReadingPresenter) Handle(responseMessage *response.DeleteOdometerReadingResponse) (*viewmodel.DeleteOdometerReadingViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewDeleteOdometerReadingViewModel(responseMessage.ID, "Failed to delete the odometer reading.", responseMessage.Error)
+	}
+
+	return viewmodel.NewDeleteOdometerReadingViewModel(responseMessage.ID, "Successfully deleted the odometer reading.", responseMessage.Error)
+}
+
+// Validate verifies that a DeleteOdometerReadingPresenter's fields contain valid data.
+// Returns (an instance of DeleteOdometerReadingPresenter, nil) on success, otherwise (nil, error)
+func (presenter DeleteOdometerReadingPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,38 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// DeleteServiceRecordPresenter translates the response message from the DeleteServiceRecordInteractor to a view model.
+type DeleteServiceRecordPresenter struct {
+}
+
+// NewDeleteServiceRecordPresenter creates a new instance of a DeleteServiceRecordPresenter.
+// Returns (instance of DeleteServiceRecordPresenter, nil) on success, otherwise (nil, error).
+func NewDeleteServiceRecordPresenter() (*DeleteServiceRecordPresenter, error) {
+
+	presenter := &DeleteServiceRecordPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of DeleteServiceRecordViewModel, nil) on success, otherwise (nil, error)
+func (presenter *DeleteServiceRecordPresenter) Handle(responseMessage *response.DeleteServiceRecordResponse) (*viewmodel.DeleteServiceRecordViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewDeleteServiceRecordViewModel(responseMessage.ID, "Failed to delete the service record.", responseMessage.Error)
+	}
+
+	return viewmodel.NewDeleteServiceRecordViewModel(responseMessage.ID, "Successfully deleted the service record.", responseMessage.Error)
+}
+
+// Validate verifies that a DeleteServiceRecordPresenter's fields contain valid data.
+// Returns (an instance of DeleteServiceRecordPresenter, nil) on success, otherwise (nil, error)
+func (presenter DeleteServiceRecordPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,40 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// GetMaintenanceDuePresenter translates the response message from the GetMaintenanceDueInteractor to a view model.
+type GetMaintenanceDuePresenter struct {
+}
+
+// NewGetMaintenanceDuePresenter creates a new instance of a GetMaintenanceDuePresenter.
+// Returns (instance of GetMaintenanceDuePresenter, nil) on success, otherwise (nil, error).
+func NewGetMaintenanceDuePresenter() (*GetMaintenanceDuePresenter, error) {
+
+	presenter := &GetMaintenanceDuePresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of GetMaintenanceDueViewModel, nil) on success, otherwise (nil, error)
+func (presenter *GetMaintenanceDuePresenter) Handle(responseMessage *response.GetMaintenanceDueResponse) (*viewmodel.GetMaintenanceDueViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewGetMaintenanceDueViewModel(responseMessage.MotorcycleID, 0, responseMessage.Unit, responseMessage.AsOfUtc, nil,
+			"Failed to get the maintenance that is due.", responseMessage.Error)
+	}
+
+	return viewmodel.NewGetMaintenanceDueViewModel(responseMessage.MotorcycleID, responseMessage.Odometer, responseMessage.Unit, responseMessage.AsOfUtc,
+		responseMessage.Items, "Successfully retrieved the maintenance that is due.", responseMessage.Error)
+}
+
+// Validate verifies that a GetMaintenanceDuePresenter's fields contain valid data.
+// Returns (an instance of GetMaintenanceDuePresenter, nil) on success, otherwise (nil, error)
+func (presenter GetMaintenanceDuePresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,52 @@
+// Package presenter implements unit tests for GetMaintenanceDuePresenter.
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetMaintenanceDuePresenter_Handle verifies that a response messages is translated into a proper view model.
+func TestGetMaintenanceDuePresenter_Handle(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	schedule, _ := repository.DefaultMaintenanceScheduleRepository()
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	motorcycleInteractor, _ := interactor.NewInsertMotorcycleInteractor(motorcycles, newManufacturerRepository(), authService)
+	motorcycleResponse, _ := motorcycleInteractor.Handle(motorcycleRequest)
+
+	dueRequest, _ := request.NewGetMaintenanceDueRequest(motorcycleResponse.ID, time.Time{})
+	dueInteractor, _ := interactor.NewGetMaintenanceDueInteractor(motorcycles, readings, records, schedule, authService)
+	dueResponse, _ := dueInteractor.Handle(dueRequest)
+	duePresenter, _ := NewGetMaintenanceDuePresenter()
+
+	// ACT
+	viewModel, _ := duePresenter.Handle(dueResponse)
+
+	// ASSERT
+	assert.Nil(t, viewModel.Error)
+	assert.True(t, len(viewModel.Items) == len(schedule.Rules))
+	for _, item := range viewModel.Items {
+		assert.Nil(t, item.LastPerformedUtc)
+		if item.Type == servicetype.InspectionServiceType {
+			assert.Nil(t, item.DueOdometer)
+			assert.NotNil(t, item.DueUtc)
+		}
+	}
+}
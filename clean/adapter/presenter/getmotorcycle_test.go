@@ -5,6 +5,7 @@ import (
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
@@ -22,8 +23,8 @@ func TestGetMotorcyclePresenter_Handle(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 
 	// Insert a motorcycle so we can get it.
-	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
 	insertResponse, _ := insertInteractor.Handle(insertRequest)
 
 	getRequest, _ := request.NewGetMotorcycleRequest(insertResponse.ID)
@@ -0,0 +1,46 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"fmt"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// GetServiceRecordPresenter translates the response message from the GetServiceRecordInteractor to a view model.
+type GetServiceRecordPresenter struct {
+}
+
+// NewGetServiceRecordPresenter creates a new instance of a GetServiceRecordPresenter.
+// Returns (instance of GetServiceRecordPresenter, nil) on success, otherwise (nil, error).
+func NewGetServiceRecordPresenter() (*GetServiceRecordPresenter, error) {
+
+	presenter := &GetServiceRecordPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of GetServiceRecordViewModel, nil) on success, otherwise (nil, error)
+func (presenter *GetServiceRecordPresenter) Handle(responseMessage *response.GetServiceRecordResponse) (*viewmodel.GetServiceRecordViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewGetServiceRecordViewModel(nil, "Failed to get the service record.", responseMessage.Error)
+	}
+
+	recordDto, err := dto.NewServiceRecordDto(*responseMessage.ServiceRecord)
+	if err != nil {
+		return viewmodel.NewGetServiceRecordViewModel(nil, "Failed to create an immutable service record.", err)
+	}
+
+	return viewmodel.NewGetServiceRecordViewModel(recordDto, fmt.Sprintf("Successfully retrieved the service record with ID %d.", recordDto.ID), responseMessage.Error)
+}
+
+// Validate verifies that a GetServiceRecordPresenter's fields contain valid data.
+// Returns (an instance of GetServiceRecordPresenter, nil) on success, otherwise (nil, error)
+func (presenter GetServiceRecordPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,52 @@
+// Package presenter implements unit tests for GetServiceRecordPresenter.
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetServiceRecordPresenter_Handle verifies that a response messages is translated into a proper view model.
+func TestGetServiceRecordPresenter_Handle(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	motorcycleInteractor, _ := interactor.NewInsertMotorcycleInteractor(motorcycles, newManufacturerRepository(), authService)
+	motorcycleResponse, _ := motorcycleInteractor.Handle(motorcycleRequest)
+
+	record, _ := entity.NewServiceRecord(motorcycleResponse.ID, servicetype.BrakeServiceType, time.Now(), 4000, distanceunit.MilesDistanceUnit,
+		"Front pads", []string{"Brake pads"}, 1, 80, "Dealer")
+	insertRequest, _ := request.NewInsertServiceRecordRequest(motorcycleResponse.ID, record)
+	insertInteractor, _ := interactor.NewInsertServiceRecordInteractor(motorcycles, records, authService)
+	insertResponse, _ := insertInteractor.Handle(insertRequest)
+
+	getRequest, _ := request.NewGetServiceRecordRequest(motorcycleResponse.ID, insertResponse.ID)
+	getInteractor, _ := interactor.NewGetServiceRecordInteractor(motorcycles, records, authService)
+	getResponse, _ := getInteractor.Handle(getRequest)
+	getPresenter, _ := NewGetServiceRecordPresenter()
+
+	// ACT
+	viewModel, _ := getPresenter.Handle(getResponse)
+
+	// ASSERT
+	assert.Nil(t, viewModel.Error)
+	assert.True(t, viewModel.ServiceRecord.ID == insertResponse.ID)
+	assert.True(t, viewModel.ServiceRecord.PerformedBy == "Dealer")
+}
@@ -25,10 +25,10 @@ func NewListMotorcyclesPresenter() (*ListMotorcyclesPresenter, error) {
 // Returns (instance of ListMotorcyclesPresenter, nil) on success, otherwise (nil, error)
 func (presenter *ListMotorcyclesPresenter) Handle(responseMessage *response.ListMotorcyclesResponse) (*viewmodel.ListMotorcyclesViewModel, error) {
 	if responseMessage.Error != nil {
-		return viewmodel.NewListMotorcyclesViewModel(nil, "Failed to get the list of motorcycles.", responseMessage.Error)
+		return viewmodel.NewListMotorcyclesViewModel(nil, nil, "Failed to get the list of motorcycles.", responseMessage.Error)
 	}
 
-	return viewmodel.NewListMotorcyclesViewModel(responseMessage.Motorcycles, "Successfully retrieved the list of motorcycles.", responseMessage.Error)
+	return viewmodel.NewListMotorcyclesViewModel(responseMessage.Motorcycles, responseMessage.Next, "Successfully retrieved the list of motorcycles.", responseMessage.Error)
 }
 
 // Validate verifies that a ListMotorcyclesPresenter's fields contain valid data.
@@ -4,7 +4,9 @@ package presenter
 import (
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
@@ -20,11 +22,11 @@ func TestListMotorcyclesPresenter_Handle(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
 	insertInteractor.Handle(insertRequest)
 
-	listRequest, _ := request.NewListMotorcyclesRequest()
+	listRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{})
 	listInteractor, _ := interactor.NewListMotorcyclesInteractor(repo, authService)
 	listResponse, _ := listInteractor.Handle(listRequest)
 	presenter, _ := NewListMotorcyclesPresenter()
@@ -0,0 +1,38 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ListOdometerReadingsPresenter translates the response message from the ListOdometerReadingsInteractor to a view model.
+type ListOdometerReadingsPresenter struct {
+}
+
+// NewListOdometerReadingsPresenter creates a new instance of a ListOdometerReadingsPresenter.
+// Returns (instance of ListOdometerReadingsPresenter, nil) on success, otherwise (nil, error).
+func NewListOdometerReadingsPresenter() (*ListOdometerReadingsPresenter, error) {
+
+	presenter := &ListOdometerReadingsPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of ListOdometerReadingsViewModel, nil) on success, otherwise (nil, error)
+func (presenter *ListOdometerReadingsPresenter) Handle(responseMessage *response.ListOdometerReadingsResponse) (*viewmodel.ListOdometerReadingsViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewListOdometerReadingsViewModel(responseMessage.MotorcycleID, nil, "Failed to get the list of odometer readings.", responseMessage.Error)
+	}
+
+	return viewmodel.NewListOdometerReadingsViewModel(responseMessage.MotorcycleID, responseMessage.Readings, "Successfully retrieved the list of odometer readings.", responseMessage.Error)
+}
+
+// Validate verifies that a ListOdometerReadingsPresenter's fields contain valid data.
+// Returns (an instance of ListOdometerReadingsPresenter, nil) on success, otherwise (nil, error)
+func (presenter ListOdometerReadingsPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,38 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ListRemindersPresenter translates the response message from the ListRemindersInteractor to a view model.
+type ListRemindersPresenter struct {
+}
+
+// NewListRemindersPresenter creates a new instance of a ListRemindersPresenter.
+// Returns (instance of ListRemindersPresenter, nil) on success, otherwise (nil, error).
+func NewListRemindersPresenter() (*ListRemindersPresenter, error) {
+
+	presenter := &ListRemindersPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of ListRemindersViewModel, nil) on success, otherwise (nil, error)
+func (presenter *ListRemindersPresenter) Handle(responseMessage *response.ListRemindersResponse) (*viewmodel.ListRemindersViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewListRemindersViewModel(responseMessage.MotorcycleID, nil, "Failed to get the reminders.", responseMessage.Error)
+	}
+
+	return viewmodel.NewListRemindersViewModel(responseMessage.MotorcycleID, responseMessage.Reminders, "Successfully retrieved the reminders.", responseMessage.Error)
+}
+
+// Validate verifies that a ListRemindersPresenter's fields contain valid data.
+// Returns (an instance of ListRemindersPresenter, nil) on success, otherwise (nil, error)
+func (presenter ListRemindersPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
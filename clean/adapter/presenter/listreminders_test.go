@@ -0,0 +1,50 @@
+// Package presenter implements unit tests for ListRemindersPresenter.
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestListRemindersPresenter_Handle verifies that a response messages is translated into a proper view model.
+func TestListRemindersPresenter_Handle(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	motorcycleInteractor, _ := interactor.NewInsertMotorcycleInteractor(motorcycles, newManufacturerRepository(), authService)
+	motorcycleResponse, _ := motorcycleInteractor.Handle(motorcycleRequest)
+
+	reminder, _ := entity.NewReminder(motorcycleResponse.ID, "Annual service", time.Now().UTC().AddDate(0, 0, 7))
+	inserted, _, _ := reminders.Insert(reminder)
+
+	listRequest, _ := request.NewListRemindersRequest(motorcycleResponse.ID)
+	listInteractor, _ := interactor.NewListRemindersInteractor(motorcycles, reminders, authService)
+	listResponse, _ := listInteractor.Handle(listRequest)
+	presenter, _ := NewListRemindersPresenter()
+
+	// ACT
+	viewModel, _ := presenter.Handle(listResponse)
+
+	// ASSERT
+	assert.Nil(t, viewModel.Error)
+	assert.True(t, len(viewModel.Reminders) == 1)
+	assert.True(t, viewModel.Reminders[0].ID == inserted.ID)
+	assert.True(t, viewModel.Reminders[0].Status == reminderstatus.PendingReminderStatus)
+	assert.Nil(t, viewModel.Reminders[0].SnoozedUntilUtc)
+}
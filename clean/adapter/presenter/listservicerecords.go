@@ -0,0 +1,38 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ListServiceRecordsPresenter translates the response message from the ListServiceRecordsInteractor to a view model.
+type ListServiceRecordsPresenter struct {
+}
+
+// NewListServiceRecordsPresenter creates a new instance of a ListServiceRecordsPresenter.
+// Returns (instance of ListServiceRecordsPresenter, nil) on success, otherwise (nil, error).
+func NewListServiceRecordsPresenter() (*ListServiceRecordsPresenter, error) {
+
+	presenter := &ListServiceRecordsPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of ListServiceRecordsViewModel, nil) on success, otherwise (nil, error)
+func (presenter *ListServiceRecordsPresenter) Handle(responseMessage *response.ListServiceRecordsResponse) (*viewmodel.ListServiceRecordsViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewListServiceRecordsViewModel(responseMessage.MotorcycleID, nil, "Failed to get the service history.", responseMessage.Error)
+	}
+
+	return viewmodel.NewListServiceRecordsViewModel(responseMessage.MotorcycleID, responseMessage.ServiceRecords, "Successfully retrieved the service history.", responseMessage.Error)
+}
+
+// Validate verifies that a ListServiceRecordsPresenter's fields contain valid data.
+// Returns (an instance of ListServiceRecordsPresenter, nil) on success, otherwise (nil, error)
+func (presenter ListServiceRecordsPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,52 @@
+// Package presenter implements unit tests for ListServiceRecordsPresenter.
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestListServiceRecordsPresenter_Handle verifies that a response messages is translated into a proper view model.
+func TestListServiceRecordsPresenter_Handle(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	motorcycleInteractor, _ := interactor.NewInsertMotorcycleInteractor(motorcycles, newManufacturerRepository(), authService)
+	motorcycleResponse, _ := motorcycleInteractor.Handle(motorcycleRequest)
+
+	record, _ := entity.NewServiceRecord(motorcycleResponse.ID, servicetype.OilChangeServiceType, time.Now(), 4000, distanceunit.MilesDistanceUnit, "", nil, 0, 0, "")
+	insertRequest, _ := request.NewInsertServiceRecordRequest(motorcycleResponse.ID, record)
+	insertInteractor, _ := interactor.NewInsertServiceRecordInteractor(motorcycles, records, authService)
+	insertResponse, _ := insertInteractor.Handle(insertRequest)
+
+	listRequest, _ := request.NewListServiceRecordsRequest(motorcycleResponse.ID)
+	listInteractor, _ := interactor.NewListServiceRecordsInteractor(motorcycles, records, authService)
+	listResponse, _ := listInteractor.Handle(listRequest)
+	presenter, _ := NewListServiceRecordsPresenter()
+
+	// ACT
+	viewModel, _ := presenter.Handle(listResponse)
+
+	// ASSERT
+	assert.Nil(t, viewModel.Error)
+	assert.True(t, len(viewModel.ServiceRecords) == 1)
+	assert.True(t, viewModel.ServiceRecords[0].ID == insertResponse.ID)
+	assert.NotNil(t, viewModel.ServiceRecords[0].Parts)
+}
@@ -0,0 +1,45 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"fmt"
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// PatchMotorcyclePresenter translates the response message from the PatchMotorcycleInteractor to a view model.
+type PatchMotorcyclePresenter struct {
+}
+
+// NewPatchMotorcyclePresenter creates a new instance of a PatchMotorcyclePresenter.
+// Returns (instance of PatchMotorcyclePresenter, nil) on success, otherwise (nil, error).
+func NewPatchMotorcyclePresenter() (*PatchMotorcyclePresenter, error) {
+
+	presenter := &PatchMotorcyclePresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of PatchMotorcyclePresenter, nil) on success, otherwise (nil, error)
+func (presenter *PatchMotorcyclePresenter) Handle(responseMessage *response.PatchMotorcycleResponse) (*viewmodel.PatchMotorcycleViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewPatchMotorcycleViewModel(nil, "Failed to patch the motorcycle.", responseMessage.Error)
+	}
+
+	motorcycleDto, err := dto.NewMotorcycleDto(*responseMessage.Motorcycle)
+	if err != nil {
+		return viewmodel.NewPatchMotorcycleViewModel(nil, "Failed to create an immutable motorcycle.", err)
+	}
+
+	return viewmodel.NewPatchMotorcycleViewModel(motorcycleDto, fmt.Sprintf("Successfully patched the motorcycle with ID %d.", motorcycleDto.ID), responseMessage.Error)
+}
+
+// Validate verifies that a PatchMotorcyclePresenter's fields contain valid data.
+// Returns (an instance of PatchMotorcyclePresenter, nil) on success, otherwise (nil, error)
+func (presenter PatchMotorcyclePresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,43 @@
+// Package presenter implements unit tests for PatchMotorcycleResponseMessagePresentation.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/patchformat"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// TestPatchMotorcyclePresenter_Handle verifies that a response messages is translated into a proper view model.
+func TestPatchMotorcyclePresenter_Handle(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	repo, _ := repository.NewMotorcycleRepository()
+
+	// Insert a motorcycle so we can patch it.
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	insertResponse, _ := insertInteractor.Handle(insertRequest)
+
+	patchRequest, _ := request.NewPatchMotorcycleRequest(insertResponse.ID, constant.AnyRowVersion, patchformat.MergePatchFormat, []byte(`{"model": "Gold Wing"}`))
+	patchInteractor, _ := interactor.NewPatchMotorcycleInteractor(repo, authService)
+	patchResponse, _ := patchInteractor.Handle(patchRequest)
+	patchPresenter, _ := NewPatchMotorcyclePresenter()
+
+	// ACT
+	viewModel, _ := patchPresenter.Handle(patchResponse)
+
+	// ASSERT
+	assert.Nil(t, viewModel.Error)
+	assert.True(t, viewModel.Motorcycle.Model == "Gold Wing")
+}
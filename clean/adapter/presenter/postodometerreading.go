@@ -0,0 +1,38 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// InsertOdometerReadingPresenter translates the response message from the InsertOdometerReadingInteractor to a view model.
+type InsertOdometerReadingPresenter struct {
+}
+
+// NewInsertOdometerReadingPresenter creates a new instance of a InsertOdometerReadingPresenter.
+// Returns (instance of InsertOdometerReadingPresenter, nil) on success, otherwise (nil, error).
+func NewInsertOdometerReadingPresenter() (*InsertOdometerReadingPresenter, error) {
+
+	presenter := &InsertOdometerReadingPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of InsertOdometerReadingViewModel, nil) on success, otherwise (nil, error)
+func (presenter *InsertOdometerReadingPresenter) Handle(responseMessage *response.InsertOdometerReadingResponse) (*viewmodel.InsertOdometerReadingViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewInsertOdometerReadingViewModel(responseMessage.ID, "Failed to record the odometer reading.", responseMessage.Error)
+	}
+
+	return viewmodel.NewInsertOdometerReadingViewModel(responseMessage.ID, "Successfully recorded the odometer reading.", responseMessage.Error)
+}
+
+// Validate verifies that a InsertOdometerReadingPresenter's fields contain valid data.
+// Returns (an instance of InsertOdometerReadingPresenter, nil) on success, otherwise (nil, error)
+func (presenter InsertOdometerReadingPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,44 @@
+// Package presenter implements unit tests for InsertOdometerReadingPresenter.
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInsertOdometerReadingPresenter_Handle verifies that a response messages is translated into a proper view model.
+func TestInsertOdometerReadingPresenter_Handle(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	motorcycleInteractor, _ := interactor.NewInsertMotorcycleInteractor(motorcycles, newManufacturerRepository(), authService)
+	motorcycleResponse, _ := motorcycleInteractor.Handle(motorcycleRequest)
+
+	insertRequest, _ := request.NewInsertOdometerReadingRequest(motorcycleResponse.ID, 1200, distanceunit.MilesDistanceUnit, time.Time{}, readingsource.ManualReadingSource, false)
+	insertInteractor, _ := interactor.NewInsertOdometerReadingInteractor(motorcycles, readings, authService)
+	insertResponse, _ := insertInteractor.Handle(insertRequest)
+	presenter, _ := NewInsertOdometerReadingPresenter()
+
+	// ACT
+	viewModel, _ := presenter.Handle(insertResponse)
+
+	// ASSERT
+	assert.Nil(t, viewModel.Error)
+	assert.True(t, viewModel.ID == insertResponse.ID)
+}
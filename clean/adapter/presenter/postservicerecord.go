@@ -0,0 +1,38 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// InsertServiceRecordPresenter translates the response message from the InsertServiceRecordInteractor to a view model.
+type InsertServiceRecordPresenter struct {
+}
+
+// NewInsertServiceRecordPresenter creates a new instance of a InsertServiceRecordPresenter.
+// Returns (instance of InsertServiceRecordPresenter, nil) on success, otherwise (nil, error).
+func NewInsertServiceRecordPresenter() (*InsertServiceRecordPresenter, error) {
+
+	presenter := &InsertServiceRecordPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of InsertServiceRecordViewModel, nil) on success, otherwise (nil, error)
+func (presenter *InsertServiceRecordPresenter) Handle(responseMessage *response.InsertServiceRecordResponse) (*viewmodel.InsertServiceRecordViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewInsertServiceRecordViewModel(responseMessage.ID, "Failed to record the service.", responseMessage.Error)
+	}
+
+	return viewmodel.NewInsertServiceRecordViewModel(responseMessage.ID, "Successfully recorded the service.", responseMessage.Error)
+}
+
+// Validate verifies that a InsertServiceRecordPresenter's fields contain valid data.
+// Returns (an instance of InsertServiceRecordPresenter, nil) on success, otherwise (nil, error)
+func (presenter InsertServiceRecordPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -4,7 +4,9 @@ package presenter
 import (
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
@@ -22,14 +24,14 @@ func TestUpdateMotorcyclePresenter_Handle(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 
 	// Insert a motorcycle so we can update it.
-	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	insertInteractor, _ := interactor.NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
 	insertResponse, _ := insertInteractor.Handle(insertRequest)
 
 	motorcycle, _, _ := repo.FindByID(insertResponse.ID)
-	motorcycle.Vin = "65432109876543210"
+	motorcycle.Vin = "65432109176543210"
 
-	updateRequest, _ := request.NewUpdateMotorcycleRequest(insertResponse.ID, motorcycle)
+	updateRequest, _ := request.NewUpdateMotorcycleRequest(insertResponse.ID, constant.AnyRowVersion, motorcycle)
 	updateInteractor, _ := interactor.NewUpdateMotorcycleInteractor(repo, authService)
 	updateResponse, _ := updateInteractor.Handle(updateRequest)
 	updatePresenter, _ := NewUpdateMotorcyclePresenter()
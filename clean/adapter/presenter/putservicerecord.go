@@ -0,0 +1,38 @@
+// Package presenter performs the translation of a response message into a view model.
+package presenter
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/viewmodel"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// UpdateServiceRecordPresenter translates the response message from the UpdateServiceRecordInteractor to a view model.
+type UpdateServiceRecordPresenter struct {
+}
+
+// NewUpdateServiceRecordPresenter creates a new instance of a UpdateServiceRecordPresenter.
+// Returns (instance of UpdateServiceRecordPresenter, nil) on success, otherwise (nil, error).
+func NewUpdateServiceRecordPresenter() (*UpdateServiceRecordPresenter, error) {
+
+	presenter := &UpdateServiceRecordPresenter{}
+
+	// All okay
+	return presenter, nil
+}
+
+// Handle performs the translation of the response message into a view model.
+// Returns (instance of UpdateServiceRecordViewModel, nil) on success, otherwise (nil, error)
+func (presenter *UpdateServiceRecordPresenter) Handle(responseMessage *response.UpdateServiceRecordResponse) (*viewmodel.UpdateServiceRecordViewModel, error) {
+	if responseMessage.Error != nil {
+		return viewmodel.NewUpdateServiceRecordViewModel(responseMessage.ID, "Failed to update the service record.", responseMessage.Error)
+	}
+
+	return viewmodel.NewUpdateServiceRecordViewModel(responseMessage.ID, "Successfully updated the service record.", responseMessage.Error)
+}
+
+// Validate verifies that a UpdateServiceRecordPresenter's fields contain valid data.
+// Returns (an instance of UpdateServiceRecordPresenter, nil) on success, otherwise (nil, error)
+func (presenter UpdateServiceRecordPresenter) Validate() error {
+	return validation.ValidateStruct(&presenter)
+}
@@ -0,0 +1,58 @@
+// Package viewmodel translates a response message into a view model.
+package viewmodel
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// DecodeVinViewModel translates a DecodeVinResponse to a DecodeVinViewModel.
+// by the Configuration ring.
+type DecodeVinViewModel struct {
+	DecodedVin *dto.DecodedVinDto `json:"decodedVin"`
+	Message    string             `json:"message"`
+	Error      error              `json:"-"`
+}
+
+// NewDecodeVinViewModel creates a new instance of a DecodeVinViewModel.
+// Returns an (instance of DecodeVinViewModel, nil) on success, otherwise (nil, error)
+func NewDecodeVinViewModel(decodedVin *dto.DecodedVinDto, message string, err error) (*DecodeVinViewModel, error) {
+
+	viewModel := &DecodeVinViewModel{
+		DecodedVin: decodedVin,
+		Message:    message,
+		Error:      err,
+	}
+
+	msgErr := viewModel.Validate()
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if viewModel.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(viewModel.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if viewModel.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if viewModel.Error != nil && msgErr == nil {
+		return viewModel, nil
+	}
+
+	// Otherwise, all okay
+	return viewModel, nil
+}
+
+// Validate verifies that a DecodeVinViewModel's fields contain valid data.
+// Returns (an instance of DecodeVinViewModel, nil) on success, otherwise (nil, error).
+func (viewmodel DecodeVinViewModel) Validate() error {
+	return validation.ValidateStruct(&viewmodel,
+		// DecodedVin can be empty, but not nil
+		validation.Field(&viewmodel.DecodedVin, validation.NotNil),
+
+		// Message is required and it cannot be empty or nil.
+		validation.Field(&viewmodel.Message, validation.NilOrNotEmpty),
+	)
+}
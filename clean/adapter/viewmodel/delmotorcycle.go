@@ -14,7 +14,7 @@ import (
 type DeleteMotorcycleViewModel struct {
 	ID      typedef.ID `json:"id"`
 	Message string     `json:"message"`
-	Error   error      `json:"error"`
+	Error   error      `json:"-"`
 }
 
 // NewDeleteMotorcycleViewModel creates a new instance of a DeleteMotorcycleViewModel.
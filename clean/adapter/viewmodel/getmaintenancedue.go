@@ -0,0 +1,83 @@
+// Package viewmodel translates a response message into a view model.
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// GetMaintenanceDueViewModel translates a GetMaintenanceDueResponse to a GetMaintenanceDueViewModel.
+// by the Configuration ring.
+type GetMaintenanceDueViewModel struct {
+	MotorcycleID typedef.ID                `json:"motorcycleId"`
+	Odometer     float64                   `json:"odometer"`
+	Unit         distanceunit.DistanceUnit `json:"unit"`
+	AsOfUtc      time.Time                 `json:"asOfUtc"`
+	Items        []dto.MaintenanceDueDto   `json:"items"`
+	Message      string                    `json:"message"`
+	Error        error                     `json:"-"`
+}
+
+// NewGetMaintenanceDueViewModel creates a new instance of a GetMaintenanceDueViewModel.
+// Returns an (instance of GetMaintenanceDueViewModel, nil) on success, otherwise (nil, error)
+func NewGetMaintenanceDueViewModel(motorcycleID typedef.ID, odometer float64, unit distanceunit.DistanceUnit, asOfUtc time.Time,
+	items []entity.MaintenanceDue, message string, err error) (*GetMaintenanceDueViewModel, error) {
+	// Ensure that we create an empty slice rather than the default for []entity.MaintenanceDue, which is a null pointer.
+	itemDtos := make([]dto.MaintenanceDueDto, 0)
+
+	for i := 0; i < len(items); i++ {
+		itemDto, dtoErr := dto.NewMaintenanceDueDto(items[i])
+		if dtoErr != nil {
+			return nil, dtoErr
+		}
+
+		itemDtos = append(itemDtos, *itemDto)
+	}
+
+	viewModel := &GetMaintenanceDueViewModel{
+		MotorcycleID: motorcycleID,
+		Odometer:     odometer,
+		Unit:         unit,
+		AsOfUtc:      asOfUtc,
+		Items:        itemDtos,
+		Message:      message,
+		Error:        err,
+	}
+
+	msgErr := viewModel.Validate()
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if viewModel.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(viewModel.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if viewModel.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if viewModel.Error != nil && msgErr == nil {
+		return viewModel, nil
+	}
+
+	// Otherwise, all okay
+	return viewModel, nil
+}
+
+// Validate verifies that a GetMaintenanceDueViewModel's fields contain valid data.
+// Returns (an instance of GetMaintenanceDueViewModel, nil) on success, otherwise (nil, error).
+func (viewmodel GetMaintenanceDueViewModel) Validate() error {
+	return validation.ValidateStruct(&viewmodel,
+		// Items can be empty, but not nil
+		validation.Field(&viewmodel.Items, validation.NotNil),
+
+		// Message is required and it cannot be empty or nil.
+		validation.Field(&viewmodel.Message, validation.NilOrNotEmpty),
+	)
+}
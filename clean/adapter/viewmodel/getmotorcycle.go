@@ -12,7 +12,7 @@ import (
 type GetMotorcycleViewModel struct {
 	Motorcycle *dto.MotorcycleDto `json:"motorcycle"`
 	Message    string             `json:"message"`
-	Error      error              `json:"error"`
+	Error      error              `json:"-"`
 }
 
 // NewGetMotorcycleViewModel creates a new instance of a GetMotorcycleViewModel.
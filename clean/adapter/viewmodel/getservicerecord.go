@@ -0,0 +1,58 @@
+// Package viewmodel translates a response message into a view model.
+package viewmodel
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// GetServiceRecordViewModel translates a GetServiceRecordResponse to a GetServiceRecordViewModel.
+// by the Configuration ring.
+type GetServiceRecordViewModel struct {
+	ServiceRecord *dto.ServiceRecordDto `json:"serviceRecord"`
+	Message       string                `json:"message"`
+	Error         error                 `json:"-"`
+}
+
+// NewGetServiceRecordViewModel creates a new instance of a GetServiceRecordViewModel.
+// Returns an (instance of GetServiceRecordViewModel, nil) on success, otherwise (nil, error)
+func NewGetServiceRecordViewModel(serviceRecord *dto.ServiceRecordDto, message string, err error) (*GetServiceRecordViewModel, error) {
+
+	viewModel := &GetServiceRecordViewModel{
+		ServiceRecord: serviceRecord,
+		Message:       message,
+		Error:         err,
+	}
+
+	msgErr := viewModel.Validate()
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if viewModel.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(viewModel.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if viewModel.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if viewModel.Error != nil && msgErr == nil {
+		return viewModel, nil
+	}
+
+	// Otherwise, all okay
+	return viewModel, nil
+}
+
+// Validate verifies that a GetServiceRecordViewModel's fields contain valid data.
+// Returns (an instance of GetServiceRecordViewModel, nil) on success, otherwise (nil, error).
+func (viewmodel GetServiceRecordViewModel) Validate() error {
+	return validation.ValidateStruct(&viewmodel,
+		// ServiceRecord can be empty, but not nil
+		validation.Field(&viewmodel.ServiceRecord, validation.NotNil),
+
+		// Message is required and it cannot be empty or nil.
+		validation.Field(&viewmodel.Message, validation.NilOrNotEmpty),
+	)
+}
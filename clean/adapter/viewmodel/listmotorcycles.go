@@ -12,13 +12,15 @@ import (
 // by the Configuration ring.
 type ListMotorcyclesViewModel struct {
 	Motorcycles []dto.MotorcycleDto `json:"motorcycles"`
+	Page        dto.PageDto         `json:"page"`
 	Message     string              `json:"message"`
-	Error       error               `json:"error"`
+	Error       error               `json:"-"`
 }
 
 // NewListMotorcyclesViewModel creates a new instance of a ListMotorcyclesViewModel.
+// The next cursor is the position of the last motorcycle in the page, or nil when there are no more motorcycles.
 // Returns an (instance of ListMotorcyclesViewModel, nil) on success, otherwise (nil, error)
-func NewListMotorcyclesViewModel(motorcycles []entity.Motorcycle, message string, err error) (*ListMotorcyclesViewModel, error) {
+func NewListMotorcyclesViewModel(motorcycles []entity.Motorcycle, next *entity.MotorcycleCursor, message string, err error) (*ListMotorcyclesViewModel, error) {
 	// Ensure that we create an empty slice rather than the default for []entity.Motorcycle, which is a null pointer.
 	motorcycleDtos := make([]dto.MotorcycleDto, 0)
 
@@ -31,6 +33,8 @@ func NewListMotorcyclesViewModel(motorcycles []entity.Motorcycle, message string
 			Vin:         motorcycles[i].Vin,
 			CreatedUtc:  motorcycles[i].CreatedUtc,
 			ModifiedUtc: motorcycles[i].ModifiedUtc,
+			RowVersion:  motorcycles[i].RowVersion,
+			OwnerID:     motorcycles[i].OwnerID,
 		}
 
 		motorcycleDtos = append(motorcycleDtos, *motorcycle)
@@ -39,8 +43,15 @@ func NewListMotorcyclesViewModel(motorcycles []entity.Motorcycle, message string
 
 	viewModel := &ListMotorcyclesViewModel{
 		Motorcycles: motorcycleDtos,
-		Message:     message,
-		Error:       err,
+		Page: dto.PageDto{
+			Count: len(motorcycleDtos),
+		},
+		Message: message,
+		Error:   err,
+	}
+
+	if next != nil {
+		viewModel.Page.NextCursor = next.Encode()
 	}
 
 	msgErr := viewModel.Validate()
@@ -0,0 +1,78 @@
+// Package viewmodel translates a response message into a view model.
+package viewmodel
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// ListOdometerReadingsViewModel translates a ListOdometerReadingsResponse to a ListOdometerReadingsViewModel.
+// by the Configuration ring.
+type ListOdometerReadingsViewModel struct {
+	MotorcycleID typedef.ID               `json:"motorcycleId"`
+	Readings     []dto.OdometerReadingDto `json:"readings"`
+	Message      string                   `json:"message"`
+	Error        error                    `json:"-"`
+}
+
+// NewListOdometerReadingsViewModel creates a new instance of a ListOdometerReadingsViewModel.
+// Returns an (instance of ListOdometerReadingsViewModel, nil) on success, otherwise (nil, error)
+func NewListOdometerReadingsViewModel(motorcycleID typedef.ID, readings []entity.OdometerReading, message string, err error) (*ListOdometerReadingsViewModel, error) {
+	// Ensure that we create an empty slice rather than the default for []entity.OdometerReading, which is a null pointer.
+	readingDtos := make([]dto.OdometerReadingDto, 0)
+
+	for i := 0; i < len(readings); i++ {
+		readingDtos = append(readingDtos, dto.OdometerReadingDto{
+			ID:           readings[i].ID,
+			MotorcycleID: readings[i].MotorcycleID,
+			Value:        readings[i].Value,
+			Unit:         readings[i].Unit,
+			ReadingUtc:   readings[i].ReadingUtc,
+			Source:       readings[i].Source,
+			Rollover:     readings[i].Rollover,
+			Distance:     readings[i].Distance,
+			CreatedUtc:   readings[i].CreatedUtc,
+		})
+	}
+
+	viewModel := &ListOdometerReadingsViewModel{
+		MotorcycleID: motorcycleID,
+		Readings:     readingDtos,
+		Message:      message,
+		Error:        err,
+	}
+
+	msgErr := viewModel.Validate()
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if viewModel.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(viewModel.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if viewModel.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if viewModel.Error != nil && msgErr == nil {
+		return viewModel, nil
+	}
+
+	// Otherwise, all okay
+	return viewModel, nil
+}
+
+// Validate verifies that a ListOdometerReadingsViewModel's fields contain valid data.
+// Returns (an instance of ListOdometerReadingsViewModel, nil) on success, otherwise (nil, error).
+func (viewmodel ListOdometerReadingsViewModel) Validate() error {
+	return validation.ValidateStruct(&viewmodel,
+		// Readings can be empty, but not nil
+		validation.Field(&viewmodel.Readings, validation.NotNil),
+
+		// Message is required and it cannot be empty or nil.
+		validation.Field(&viewmodel.Message, validation.NilOrNotEmpty),
+	)
+}
@@ -0,0 +1,73 @@
+// Package viewmodel translates a response message into a view model.
+package viewmodel
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// ListRemindersViewModel translates a ListRemindersResponse to a ListRemindersViewModel.
+// by the Configuration ring.
+type ListRemindersViewModel struct {
+	MotorcycleID typedef.ID        `json:"motorcycleId"`
+	Reminders    []dto.ReminderDto `json:"reminders"`
+	Message      string            `json:"message"`
+	Error        error             `json:"-"`
+}
+
+// NewListRemindersViewModel creates a new instance of a ListRemindersViewModel.
+// Returns an (instance of ListRemindersViewModel, nil) on success, otherwise (nil, error)
+func NewListRemindersViewModel(motorcycleID typedef.ID, reminders []entity.Reminder, message string, err error) (*ListRemindersViewModel, error) {
+	// Ensure that we create an empty slice rather than the default for []entity.Reminder, which is a null pointer.
+	reminderDtos := make([]dto.ReminderDto, 0)
+
+	for i := 0; i < len(reminders); i++ {
+		reminderDto, dtoErr := dto.NewReminderDto(reminders[i])
+		if dtoErr != nil {
+			return nil, dtoErr
+		}
+
+		reminderDtos = append(reminderDtos, *reminderDto)
+	}
+
+	viewModel := &ListRemindersViewModel{
+		MotorcycleID: motorcycleID,
+		Reminders:    reminderDtos,
+		Message:      message,
+		Error:        err,
+	}
+
+	msgErr := viewModel.Validate()
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if viewModel.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(viewModel.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if viewModel.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if viewModel.Error != nil && msgErr == nil {
+		return viewModel, nil
+	}
+
+	// Otherwise, all okay
+	return viewModel, nil
+}
+
+// Validate verifies that a ListRemindersViewModel's fields contain valid data.
+// Returns (an instance of ListRemindersViewModel, nil) on success, otherwise (nil, error).
+func (viewmodel ListRemindersViewModel) Validate() error {
+	return validation.ValidateStruct(&viewmodel,
+		// Reminders can be empty, but not nil
+		validation.Field(&viewmodel.Reminders, validation.NotNil),
+
+		// Message is required and it cannot be empty or nil.
+		validation.Field(&viewmodel.Message, validation.NilOrNotEmpty),
+	)
+}
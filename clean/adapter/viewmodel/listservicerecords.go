@@ -0,0 +1,73 @@
+// Package viewmodel translates a response message into a view model.
+package viewmodel
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/adapter/dto"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// ListServiceRecordsViewModel translates a ListServiceRecordsResponse to a ListServiceRecordsViewModel.
+// by the Configuration ring.
+type ListServiceRecordsViewModel struct {
+	MotorcycleID   typedef.ID             `json:"motorcycleId"`
+	ServiceRecords []dto.ServiceRecordDto `json:"serviceRecords"`
+	Message        string                 `json:"message"`
+	Error          error                  `json:"-"`
+}
+
+// NewListServiceRecordsViewModel creates a new instance of a ListServiceRecordsViewModel.
+// Returns an (instance of ListServiceRecordsViewModel, nil) on success, otherwise (nil, error)
+func NewListServiceRecordsViewModel(motorcycleID typedef.ID, serviceRecords []entity.ServiceRecord, message string, err error) (*ListServiceRecordsViewModel, error) {
+	// Ensure that we create an empty slice rather than the default for []entity.ServiceRecord, which is a null pointer.
+	recordDtos := make([]dto.ServiceRecordDto, 0)
+
+	for i := 0; i < len(serviceRecords); i++ {
+		recordDto, dtoErr := dto.NewServiceRecordDto(serviceRecords[i])
+		if dtoErr != nil {
+			return nil, dtoErr
+		}
+
+		recordDtos = append(recordDtos, *recordDto)
+	}
+
+	viewModel := &ListServiceRecordsViewModel{
+		MotorcycleID:   motorcycleID,
+		ServiceRecords: recordDtos,
+		Message:        message,
+		Error:          err,
+	}
+
+	msgErr := viewModel.Validate()
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if viewModel.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(viewModel.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if viewModel.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if viewModel.Error != nil && msgErr == nil {
+		return viewModel, nil
+	}
+
+	// Otherwise, all okay
+	return viewModel, nil
+}
+
+// Validate verifies that a ListServiceRecordsViewModel's fields contain valid data.
+// Returns (an instance of ListServiceRecordsViewModel, nil) on success, otherwise (nil, error).
+func (viewmodel ListServiceRecordsViewModel) Validate() error {
+	return validation.ValidateStruct(&viewmodel,
+		// ServiceRecords can be empty, but not nil
+		validation.Field(&viewmodel.ServiceRecords, validation.NotNil),
+
+		// Message is required and it cannot be empty or nil.
+		validation.Field(&viewmodel.Message, validation.NilOrNotEmpty),
+	)
+}
@@ -13,7 +13,7 @@ import (
 type InsertMotorcycleViewModel struct {
 	ID      typedef.ID `json:"id"`
 	Message string     `json:"message"`
-	Error   error      `json:"error"`
+	Error   error      `json:"-"`
 }
 
 // NewInsertMotorcycleViewModel creates a new instance of a InsertMotorcycleViewModel.
@@ -0,0 +1,58 @@
+// Package viewmodel translates a response message into a view model.
+package viewmodel
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// InsertServiceRecordViewModel translates a InsertServiceRecordResponse to a InsertServiceRecordViewModel.
+// by the Configuration ring.
+type InsertServiceRecordViewModel struct {
+	ID      typedef.ID `json:"id"`
+	Message string     `json:"message"`
+	Error   error      `json:"-"`
+}
+
+// NewInsertServiceRecordViewModel creates a new instance of a InsertServiceRecordViewModel.
+// Returns an (instance of InsertServiceRecordViewModel, nil) on success, otherwise (nil, error)
+func NewInsertServiceRecordViewModel(id typedef.ID, message string, err error) (*InsertServiceRecordViewModel, error) {
+
+	viewModel := &InsertServiceRecordViewModel{
+		ID:      id,
+		Message: message,
+		Error:   err,
+	}
+
+	msgErr := viewModel.Validate()
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if viewModel.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(viewModel.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if viewModel.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if viewModel.Error != nil && msgErr == nil {
+		return viewModel, nil
+	}
+
+	// Otherwise, all okay
+	return viewModel, nil
+}
+
+// Validate verifies that a InsertServiceRecordViewModel's fields contain valid data.
+// Returns (an instance of InsertServiceRecordViewModel, nil) on success, otherwise (nil, error).
+func (viewmodel InsertServiceRecordViewModel) Validate() error {
+	return validation.ValidateStruct(&viewmodel,
+		// ID is required and it must be non-zero
+		validation.Field(&viewmodel.ID, validation.Required, validation.Min(constant.MinEntityID)),
+		// Message is required and it cannot be empty or nil.
+		validation.Field(&viewmodel.Message, validation.Required, validation.NilOrNotEmpty),
+	)
+}
@@ -14,7 +14,7 @@ import (
 type UpdateMotorcycleViewModel struct {
 	ID      typedef.ID `json:"id"`
 	Message string     `json:"message"`
-	Error   error      `json:"error"`
+	Error   error      `json:"-"`
 }
 
 // NewUpdateMotorcycleViewModel creates a new instance of a UpdateMotorcycleViewModel.
@@ -0,0 +1,352 @@
+// Package config merges the settings of the API web service from its defaults, a configuration file, environment
+// variables and command line flags, in increasing order of precedence.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/api"
+	"github.com/go-ozzo/ozzo-validation"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// The ways in which a request can be authenticated.
+const (
+	// JwtAuthMode authenticates a request from the bearer token in its Authorization header.
+	JwtAuthMode = "jwt"
+	// ApiKeyAuthMode authenticates a request from the API key in its X-API-Key header.
+	ApiKeyAuthMode = "apikey"
+	// CertificateAuthMode authenticates a request from the client certificate of its TLS connection.
+	CertificateAuthMode = "certificate"
+)
+
+// Config contains all of the settings of the API web service.
+type Config struct {
+	Server     ServerSettings     `yaml:"server" json:"server" toml:"server"`
+	Limits     LimitSettings      `yaml:"limits" json:"limits" toml:"limits"`
+	Cors       CorsSettings       `yaml:"cors" json:"cors" toml:"cors"`
+	Repository RepositorySettings `yaml:"repository" json:"repository" toml:"repository"`
+	Auth       AuthSettings       `yaml:"auth" json:"auth" toml:"auth"`
+	Policies   PolicySettings     `yaml:"policies" json:"policies" toml:"policies"`
+	Reminders  ReminderSettings   `yaml:"reminders" json:"reminders" toml:"reminders"`
+	Log        LogSettings        `yaml:"log" json:"log" toml:"log"`
+}
+
+// ServerSettings are where the HTTP server listens, and how long it waits for its clients.
+type ServerSettings struct {
+	Addr              string        `yaml:"addr" json:"addr" toml:"addr"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" json:"readHeaderTimeout" toml:"readHeaderTimeout"`
+	ReadTimeout       time.Duration `yaml:"readTimeout" json:"readTimeout" toml:"readTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout" json:"writeTimeout" toml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout" json:"idleTimeout" toml:"idleTimeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdownTimeout" json:"shutdownTimeout" toml:"shutdownTimeout"`
+	MetricsAddr       string        `yaml:"metricsAddr" json:"metricsAddr" toml:"metricsAddr"`
+	Tls               TlsSettings   `yaml:"tls" json:"tls" toml:"tls"`
+}
+
+// TlsSettings are the certificates with which the HTTP server serves HTTPS, and accepts client certificates.
+type TlsSettings struct {
+	CertFile           string        `yaml:"certFile" json:"certFile" toml:"certFile"`
+	KeyFile            string        `yaml:"keyFile" json:"keyFile" toml:"keyFile"`
+	ReloadInterval     time.Duration `yaml:"reloadInterval" json:"reloadInterval" toml:"reloadInterval"`
+	ClientCAFile       string        `yaml:"clientCAFile" json:"clientCAFile" toml:"clientCAFile"`
+	ClientCertRequired bool          `yaml:"clientCertRequired" json:"clientCertRequired" toml:"clientCertRequired"`
+	RedirectAddr       string        `yaml:"redirectAddr" json:"redirectAddr" toml:"redirectAddr"`
+}
+
+// LimitSettings are the maximum sizes of a request.
+type LimitSettings struct {
+	MaxHeaderBytes int   `yaml:"maxHeaderBytes" json:"maxHeaderBytes" toml:"maxHeaderBytes"`
+	MaxBodyBytes   int64 `yaml:"maxBodyBytes" json:"maxBodyBytes" toml:"maxBodyBytes"`
+}
+
+// CorsSettings are which scripts from other origins may call the API web service.
+type CorsSettings struct {
+	AllowedOrigins []string      `yaml:"allowedOrigins" json:"allowedOrigins" toml:"allowedOrigins"`
+	AllowedMethods []string      `yaml:"allowedMethods" json:"allowedMethods" toml:"allowedMethods"`
+	AllowedHeaders []string      `yaml:"allowedHeaders" json:"allowedHeaders" toml:"allowedHeaders"`
+	MaxAge         time.Duration `yaml:"maxAge" json:"maxAge" toml:"maxAge"`
+}
+
+// RepositorySettings are the kind of repositories, and where they are persisted.
+type RepositorySettings struct {
+	// Backend is either "file" or "sql".
+	Backend string `yaml:"backend" json:"backend" toml:"backend"`
+	// Path is the file of the motorcycles, or the SQLite database of every repository.
+	Path string `yaml:"path" json:"path" toml:"path"`
+	// OdometerPath, ServicePath and ReminderPath are the files of the other repositories of the file backend.
+	OdometerPath string `yaml:"odometerPath" json:"odometerPath" toml:"odometerPath"`
+	ServicePath  string `yaml:"servicePath" json:"servicePath" toml:"servicePath"`
+	ReminderPath string `yaml:"reminderPath" json:"reminderPath" toml:"reminderPath"`
+}
+
+// AuthSettings are how requests are authenticated, and what their roles permit.
+type AuthSettings struct {
+	// Modes are the enabled ways of authenticating a request, or empty for every way whose credentials are configured.
+	Modes           []string `yaml:"modes" json:"modes" toml:"modes"`
+	JwtAlgorithm    string   `yaml:"jwtAlgorithm" json:"jwtAlgorithm" toml:"jwtAlgorithm"`
+	JwtKey          string   `yaml:"jwtKey" json:"jwtKey" toml:"jwtKey"`
+	ApiKeys         string   `yaml:"apiKeys" json:"apiKeys" toml:"apiKeys"`
+	ClientCertRoles string   `yaml:"clientCertRoles" json:"clientCertRoles" toml:"clientCertRoles"`
+	RolePolicy      string   `yaml:"rolePolicy" json:"rolePolicy" toml:"rolePolicy"`
+}
+
+// PolicySettings are the files of the business rules, each of which has a default when it is empty.
+type PolicySettings struct {
+	Validation    string `yaml:"validation" json:"validation" toml:"validation"`
+	Schedule      string `yaml:"schedule" json:"schedule" toml:"schedule"`
+	Manufacturers string `yaml:"manufacturers" json:"manufacturers" toml:"manufacturers"`
+}
+
+// ReminderSettings are when reminders are sent, and how.
+type ReminderSettings struct {
+	Interval   time.Duration `yaml:"interval" json:"interval" toml:"interval"`
+	Rules      string        `yaml:"rules" json:"rules" toml:"rules"`
+	Notifier   string        `yaml:"notifier" json:"notifier" toml:"notifier"`
+	WebhookURL string        `yaml:"webhookURL" json:"webhookURL" toml:"webhookURL"`
+	SmtpAddr   string        `yaml:"smtpAddr" json:"smtpAddr" toml:"smtpAddr"`
+	SmtpFrom   string        `yaml:"smtpFrom" json:"smtpFrom" toml:"smtpFrom"`
+	SmtpTo     []string      `yaml:"smtpTo" json:"smtpTo" toml:"smtpTo"`
+}
+
+// LogSettings are which messages are logged, and how they are formatted.
+type LogSettings struct {
+	Level  string `yaml:"level" json:"level" toml:"level"`
+	Format string `yaml:"format" json:"format" toml:"format"`
+}
+
+// Default creates the settings that are used when none are configured.
+func Default() Config {
+	server := api.DefaultServerConfig()
+
+	return Config{
+		Server: ServerSettings{
+			Addr:              server.Addr,
+			ReadHeaderTimeout: server.ReadHeaderTimeout,
+			ReadTimeout:       server.ReadTimeout,
+			WriteTimeout:      server.WriteTimeout,
+			IdleTimeout:       server.IdleTimeout,
+			ShutdownTimeout:   server.ShutdownTimeout,
+			MetricsAddr:       server.MetricsAddr,
+			Tls: TlsSettings{
+				ReloadInterval: server.CertReloadInterval,
+			},
+		},
+		Limits: LimitSettings{
+			MaxHeaderBytes: server.MaxHeaderBytes,
+			MaxBodyBytes:   server.MaxBodyBytes,
+		},
+		Cors: CorsSettings{
+			AllowedOrigins: server.Cors.AllowedOrigins,
+			AllowedMethods: server.Cors.AllowedMethods,
+			AllowedHeaders: server.Cors.AllowedHeaders,
+			MaxAge:         server.Cors.MaxAge,
+		},
+		Repository: RepositorySettings{
+			Backend:      "file",
+			Path:         "motorcycles.json",
+			OdometerPath: "odometer.json",
+			ServicePath:  "services.json",
+			ReminderPath: "reminders.json",
+		},
+		Auth: AuthSettings{
+			Modes:        []string{},
+			JwtAlgorithm: "HS256",
+		},
+		Reminders: ReminderSettings{
+			Interval: time.Hour,
+			Notifier: "log",
+			SmtpAddr: "localhost:1025",
+			SmtpFrom: "motominder@localhost",
+			SmtpTo:   []string{},
+		},
+		Log: LogSettings{
+			Level:  "info",
+			Format: "json",
+		},
+	}
+}
+
+// ServerConfig converts the settings of the HTTP server to the ones used by the API web service.
+func (config Config) ServerConfig() api.ServerConfig {
+	return api.ServerConfig{
+		Addr:               config.Server.Addr,
+		ReadHeaderTimeout:  config.Server.ReadHeaderTimeout,
+		ReadTimeout:        config.Server.ReadTimeout,
+		WriteTimeout:       config.Server.WriteTimeout,
+		IdleTimeout:        config.Server.IdleTimeout,
+		MaxHeaderBytes:     config.Limits.MaxHeaderBytes,
+		MaxBodyBytes:       config.Limits.MaxBodyBytes,
+		ShutdownTimeout:    config.Server.ShutdownTimeout,
+		TLSCertFile:        config.Server.Tls.CertFile,
+		TLSKeyFile:         config.Server.Tls.KeyFile,
+		CertReloadInterval: config.Server.Tls.ReloadInterval,
+		ClientCAFile:       config.Server.Tls.ClientCAFile,
+		ClientCertRequired: config.Server.Tls.ClientCertRequired,
+		RedirectAddr:       config.Server.Tls.RedirectAddr,
+		MetricsAddr:        config.Server.MetricsAddr,
+		Cors: api.CorsConfig{
+			AllowedOrigins: config.Cors.AllowedOrigins,
+			AllowedMethods: config.Cors.AllowedMethods,
+			AllowedHeaders: config.Cors.AllowedHeaders,
+			MaxAge:         config.Cors.MaxAge,
+		},
+	}
+}
+
+// AuthModes lists the enabled ways of authenticating a request.  When none are listed, every way whose credentials
+// are configured is enabled.
+func (config Config) AuthModes() []string {
+	if len(config.Auth.Modes) > 0 {
+		return config.Auth.Modes
+	}
+
+	modes := make([]string, 0)
+	if config.Auth.ClientCertRoles != "" {
+		modes = append(modes, CertificateAuthMode)
+	}
+	if config.Auth.JwtKey != "" {
+		modes = append(modes, JwtAuthMode)
+	}
+	if config.Auth.ApiKeys != "" {
+		modes = append(modes, ApiKeyAuthMode)
+	}
+
+	return modes
+}
+
+// IsAuthModeEnabled determines whether requests may be authenticated in the way.
+func (config Config) IsAuthModeEnabled(mode string) bool {
+	for _, enabled := range config.AuthModes() {
+		if enabled == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate verifies that a Config's settings are valid, and consistent with each other.
+// Returns nil if the Config contains valid settings, otherwise an error naming the section that is not valid.
+func (config Config) Validate() error {
+	sections := []struct {
+		name     string
+		validate func() error
+	}{
+		{"server", config.ServerConfig().Validate},
+		{"repository", config.Repository.Validate},
+		{"auth", config.validateAuth},
+		{"reminders", config.Reminders.Validate},
+		{"log", config.Log.Validate},
+	}
+
+	for _, section := range sections {
+		err := section.validate()
+		if err != nil {
+			return fmt.Errorf("the %s settings are not valid: %s", section.name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Validate verifies that a RepositorySettings' fields contain valid data.
+// Returns nil if the RepositorySettings contains valid data, otherwise an error.
+func (settings RepositorySettings) Validate() error {
+	// The other files are required by the file backend.
+	fileRules := []validation.Rule{}
+	if settings.Backend == "file" {
+		fileRules = append(fileRules, validation.Required)
+	}
+
+	return validation.ValidateStruct(&settings,
+		// Backend is either "file" or "sql".
+		validation.Field(&settings.Backend, validation.Required, validation.In("file", "sql")),
+		// Path is required.
+		validation.Field(&settings.Path, validation.Required),
+		validation.Field(&settings.OdometerPath, fileRules...),
+		validation.Field(&settings.ServicePath, fileRules...),
+		validation.Field(&settings.ReminderPath, fileRules...))
+}
+
+// validateAuth verifies that at least one way of authenticating requests is enabled, and that each of them has the
+// credentials and settings that it requires.
+// Returns nil if the auth settings are valid, otherwise an error.
+func (config Config) validateAuth() error {
+	modes := config.AuthModes()
+	if len(modes) == 0 {
+		return errors.New("one of jwtKey, apiKeys or clientCertRoles is required to authenticate requests")
+	}
+
+	for _, mode := range modes {
+		switch {
+		case mode == JwtAuthMode && config.Auth.JwtKey == "":
+			return fmt.Errorf("jwtKey is required by the %s mode", mode)
+		case mode == JwtAuthMode && config.Auth.JwtAlgorithm == "":
+			return fmt.Errorf("jwtAlgorithm is required by the %s mode", mode)
+		case mode == ApiKeyAuthMode && config.Auth.ApiKeys == "":
+			return fmt.Errorf("apiKeys is required by the %s mode", mode)
+		case mode == CertificateAuthMode && config.Auth.ClientCertRoles == "":
+			return fmt.Errorf("clientCertRoles is required by the %s mode", mode)
+		case mode == CertificateAuthMode && config.Server.Tls.ClientCAFile == "":
+			return fmt.Errorf("server.tls.clientCAFile is required by the %s mode", mode)
+		case mode != JwtAuthMode && mode != ApiKeyAuthMode && mode != CertificateAuthMode:
+			return fmt.Errorf("the mode %q is not one of %s, %s or %s", mode, JwtAuthMode, ApiKeyAuthMode, CertificateAuthMode)
+		}
+	}
+
+	return nil
+}
+
+// Validate verifies that a ReminderSettings' fields contain valid data.
+// Returns nil if the ReminderSettings contains valid data, otherwise an error.
+func (settings ReminderSettings) Validate() error {
+	// WebhookURL is required by the webhook notifier, and SmtpAddr and SmtpFrom are required by the SMTP notifier.
+	webhookRules := []validation.Rule{}
+	if settings.Notifier == "webhook" {
+		webhookRules = append(webhookRules, validation.Required)
+	}
+	smtpRules := []validation.Rule{}
+	if settings.Notifier == "smtp" {
+		smtpRules = append(smtpRules, validation.Required)
+	}
+
+	return validation.ValidateStruct(&settings,
+		// Interval cannot be negative, and zero disables the reminder scheduler.
+		validation.Field(&settings.Interval, validation.Min(time.Duration(0))),
+		// Notifier is either "log", "webhook" or "smtp".
+		validation.Field(&settings.Notifier, validation.Required, validation.In("log", "webhook", "smtp")),
+		validation.Field(&settings.WebhookURL, webhookRules...),
+		validation.Field(&settings.SmtpAddr, smtpRules...),
+		validation.Field(&settings.SmtpFrom, smtpRules...))
+}
+
+// Validate verifies that a LogSettings' fields contain valid data.
+// Returns nil if the LogSettings contains valid data, otherwise an error.
+func (settings LogSettings) Validate() error {
+	_, err := log.ParseLevel(settings.Level)
+	if err != nil {
+		return fmt.Errorf("level: %s", err.Error())
+	}
+
+	return validation.ValidateStruct(&settings,
+		// Format is either "json" or "text".
+		validation.Field(&settings.Format, validation.Required, validation.In("json", "text")))
+}
+
+// Write prints the settings as YAML, which can be used as a configuration file.
+// Returns nil on success, otherwise error.
+func (config Config) Write(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	err := encoder.Encode(config)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
@@ -0,0 +1,89 @@
+// Package config implements unit tests for the Config.
+package config
+
+import (
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfig_Validate_Auth verifies that each enabled way of authenticating requests has what it requires.
+func TestConfig_Validate_Auth(t *testing.T) {
+
+	// ARRANGE
+	none := Default()
+	unknownMode := Default()
+	unknownMode.Auth.Modes = []string{"password"}
+	jwtWithoutKey := Default()
+	jwtWithoutKey.Auth.Modes = []string{JwtAuthMode}
+	jwtWithoutKey.Auth.ApiKeys = "keys.json"
+	certificateWithoutCA := Default()
+	certificateWithoutCA.Auth.ClientCertRoles = "roles.json"
+	certificate := certificateWithoutCA
+	certificate.Server.Tls = TlsSettings{CertFile: "server.pem", KeyFile: "server.key", ClientCAFile: "ca.pem"}
+
+	// ACT
+	noneErr := none.Validate()
+	unknownModeErr := unknownMode.Validate()
+	jwtWithoutKeyErr := jwtWithoutKey.Validate()
+	certificateWithoutCAErr := certificateWithoutCA.Validate()
+	certificateErr := certificate.Validate()
+
+	// ASSERT
+	assert.NotNil(t, noneErr)
+	assert.True(t, unknownModeErr != nil && strings.Contains(unknownModeErr.Error(), "password"))
+	assert.True(t, jwtWithoutKeyErr != nil && strings.Contains(jwtWithoutKeyErr.Error(), "jwtKey"))
+	assert.True(t, certificateWithoutCAErr != nil && strings.Contains(certificateWithoutCAErr.Error(), "clientCAFile"))
+	assert.Nil(t, certificateErr)
+	assert.Equal(t, []string{CertificateAuthMode}, certificate.AuthModes())
+}
+
+// TestConfig_Validate_Sections verifies that an invalid setting is reported with the section that contains it.
+func TestConfig_Validate_Sections(t *testing.T) {
+
+	// ARRANGE
+	valid := Default()
+	valid.Auth.JwtKey = "secret.txt"
+	server := valid
+	server.Server.Tls.KeyFile = "server.key"
+	cors := valid
+	cors.Cors.AllowedOrigins = []string{"app.example.com"}
+	repository := valid
+	repository.Repository.OdometerPath = ""
+	reminders := valid
+	reminders.Reminders.Notifier = "webhook"
+	logging := valid
+	logging.Log.Level = "chatty"
+
+	// ACT
+	validErr := valid.Validate()
+	serverErr := server.Validate()
+	corsErr := cors.Validate()
+	repositoryErr := repository.Validate()
+	remindersErr := reminders.Validate()
+	loggingErr := logging.Validate()
+
+	// ASSERT
+	assert.Nil(t, validErr)
+	assert.True(t, serverErr != nil && strings.Contains(serverErr.Error(), "server"))
+	assert.True(t, corsErr != nil && strings.Contains(corsErr.Error(), "app.example.com"))
+	assert.True(t, repositoryErr != nil && strings.Contains(repositoryErr.Error(), "odometerPath"))
+	assert.True(t, remindersErr != nil && strings.Contains(remindersErr.Error(), "webhookURL"))
+	assert.True(t, loggingErr != nil && strings.Contains(loggingErr.Error(), "log"))
+}
+
+// TestDefault_LogLevel verifies that the default level lets the access entry of each request be logged.
+func TestDefault_LogLevel(t *testing.T) {
+
+	// ARRANGE
+	settings := Default()
+
+	// ACT
+	level, err := log.ParseLevel(settings.Log.Level)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, level >= log.InfoLevel)
+}
@@ -0,0 +1,315 @@
+// Package config merges the settings of the API web service from its defaults, a configuration file, environment
+// variables and command line flags, in increasing order of precedence.
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is at the start of the name of the environment variable of every setting.  The rest of the name is the
+// name of the setting's flag in upper case, with underscores instead of hyphens, such as MOTOMINDER_READ_TIMEOUT.
+const EnvPrefix = "MOTOMINDER_"
+
+// configFlag is the flag, and the suffix of the environment variable, with the path of the configuration file.
+const configFlag = "config"
+
+// Loader defines a command line flag for each setting, and merges the layers of settings once the flags are parsed.
+type Loader struct {
+	flags *flag.FlagSet
+	// config is where the flags store their values.
+	config *Config
+	// names are the names of the flags of the settings, in the order they were defined.
+	names []string
+	// kinds describe the values of the settings that are not strings, which explains why a value is not valid.
+	kinds map[string]string
+	path  *string
+	print *bool
+}
+
+// NewLoader creates a new instance of a Loader, which defines the flags of the settings in the flag set, as well as
+// the -config flag with the path of the configuration file, and the -print-config flag.
+func NewLoader(flags *flag.FlagSet) *Loader {
+	config := Default()
+	loader := &Loader{
+		flags:  flags,
+		config: &config,
+		names:  make([]string, 0),
+		kinds:  make(map[string]string),
+	}
+
+	loader.path = flags.String(configFlag, "", "The path of a YAML, JSON or TOML file containing the settings.  The "+EnvPrefix+"* environment variables and the flags override its settings.")
+	loader.print = flags.Bool("print-config", false, "Print the settings, after merging the file, the environment variables and the flags, as YAML, and exit.")
+
+	// Server
+	loader.stringVar(&config.Server.Addr, "addr", "The TCP address on which the API web service listens.")
+	loader.durationVar(&config.Server.ReadHeaderTimeout, "read-header-timeout", "How long the server waits to read the headers of a request.")
+	loader.durationVar(&config.Server.ReadTimeout, "read-timeout", "How long the server waits to read a whole request, including its body.  There is no timeout when it is zero.")
+	loader.durationVar(&config.Server.WriteTimeout, "write-timeout", "How long the server waits to write a response.  There is no timeout when it is zero.")
+	loader.durationVar(&config.Server.IdleTimeout, "idle-timeout", "How long the server keeps an idle keep-alive connection open.")
+	loader.durationVar(&config.Server.ShutdownTimeout, "shutdown-timeout", "How long the server waits for the requests in progress to finish when it is interrupted or terminated.")
+	loader.stringVar(&config.Server.MetricsAddr, "metrics-addr", "The TCP address on which the metrics are exposed at /metrics, without authentication.  The metrics are not exposed when it is empty.")
+	loader.stringVar(&config.Server.Tls.CertFile, "tls-cert", "The path of the PEM encoded certificate of the server, which serves HTTPS when it is provided.")
+	loader.stringVar(&config.Server.Tls.KeyFile, "tls-key", "The path of the PEM encoded private key of the server's certificate.")
+	loader.durationVar(&config.Server.Tls.ReloadInterval, "cert-reload-interval", "How often the server's certificate and private key are checked for changes, and reloaded.")
+	loader.stringVar(&config.Server.Tls.ClientCAFile, "client-ca", "The path of the PEM encoded bundle of certificate authorities that issue client certificates.  Client certificates are not accepted when it is empty.")
+	loader.boolVar(&config.Server.Tls.ClientCertRequired, "client-cert-required", "Whether a client certificate is required to connect to the server, rather than being one of the ways to authenticate a request.")
+	loader.stringVar(&config.Server.Tls.RedirectAddr, "redirect-addr", "The TCP address on which HTTP requests are redirected to HTTPS.  Requests are not redirected when it is empty.")
+
+	// Limits
+	loader.intVar(&config.Limits.MaxHeaderBytes, "max-header-bytes", "The maximum size of the headers of a request.")
+	loader.int64Var(&config.Limits.MaxBodyBytes, "max-body-bytes", "The maximum size of the body of a request.")
+
+	// CORS
+	loader.listVar(&config.Cors.AllowedOrigins, "cors-origins", "The comma separated origins, such as https://app.example.com, whose scripts may call the API web service, or * for any origin.  Cross-origin requests are not allowed when it is empty.")
+	loader.listVar(&config.Cors.AllowedMethods, "cors-methods", "The comma separated HTTP methods that a script from another origin may use.")
+	loader.listVar(&config.Cors.AllowedHeaders, "cors-headers", "The comma separated request headers that a script from another origin may send.")
+	loader.durationVar(&config.Cors.MaxAge, "cors-max-age", "How long a browser may cache the response to a preflight request.")
+
+	// Repository
+	loader.stringVar(&config.Repository.Backend, "backend", "The kind of motorcycle repository, which is either \"file\" or \"sql\".")
+	loader.stringVar(&config.Repository.Path, "repository", "The path of the file or SQLite database that persists the motorcycle repository.")
+	loader.stringVar(&config.Repository.OdometerPath, "odometer-repository", "The path of the file that persists the odometer reading repository.  The SQL backend keeps the readings in its database.")
+	loader.stringVar(&config.Repository.ServicePath, "service-repository", "The path of the file that persists the service record repository.  The SQL backend keeps the service records in its database.")
+	loader.stringVar(&config.Repository.ReminderPath, "reminder-repository", "The path of the file that persists the reminder repository.  The SQL backend keeps the reminders in its database.")
+
+	// Auth
+	loader.listVar(&config.Auth.Modes, "auth-modes", "The comma separated ways of authenticating a request, which are \"jwt\", \"apikey\" and \"certificate\".  Every way whose credentials are configured is enabled when it is empty.")
+	loader.stringVar(&config.Auth.JwtAlgorithm, "jwt-algorithm", "The algorithm that signs bearer tokens, such as HS256, RS256 or ES256.")
+	loader.stringVar(&config.Auth.JwtKey, "jwt-key", "The path of the file containing the HMAC secret, or the PEM encoded RSA or ECDSA public key, that verifies bearer tokens.")
+	loader.stringVar(&config.Auth.ApiKeys, "api-keys", "The path of the file that persists the hashed API keys.  API keys are not accepted when it is empty.")
+	loader.stringVar(&config.Auth.ClientCertRoles, "client-cert-roles", "The path of a JSON file that maps the identities in client certificates to authorization roles.  Client certificates do not authenticate requests when it is empty.")
+	loader.stringVar(&config.Auth.RolePolicy, "policy", "The path of a JSON file that maps authorization roles to permissions.  The default policy is used when it is empty.")
+
+	// Policies
+	loader.stringVar(&config.Policies.Validation, "validation-policy", "The path of a JSON file containing the rules for the make, model, year and VIN of a motorcycle.  The default policy is used when it is empty.")
+	loader.stringVar(&config.Policies.Schedule, "schedule", "The path of a JSON file that lists the maintenance rules.  The default schedule is used when it is empty.")
+	loader.stringVar(&config.Policies.Manufacturers, "manufacturers", "The path of a JSON file that lists the manufacturers used to decode VINs.  The default table is used when it is empty.")
+
+	// Reminders
+	loader.durationVar(&config.Reminders.Interval, "reminder-interval", "How often the reminder scheduler sends the reminders that are due.  The scheduler is disabled when it is zero.")
+	loader.stringVar(&config.Reminders.Rules, "reminder-rules", "The path of a JSON file that lists the reminder rules.  The default rules are used when it is empty.")
+	loader.stringVar(&config.Reminders.Notifier, "notifier", "How reminders are sent, which is either \"log\", \"webhook\" or \"smtp\".")
+	loader.stringVar(&config.Reminders.WebhookURL, "webhook-url", "The URL to which the webhook notifier posts reminders.")
+	loader.stringVar(&config.Reminders.SmtpAddr, "smtp-addr", "The host and port of the SMTP server through which the SMTP notifier sends reminders.")
+	loader.stringVar(&config.Reminders.SmtpFrom, "smtp-from", "The address from which the SMTP notifier sends reminders.")
+	loader.listVar(&config.Reminders.SmtpTo, "smtp-to", "A comma separated list of addresses to which the SMTP notifier sends every reminder, in addition to the owner of the motorcycle.")
+
+	// Log
+	loader.stringVar(&config.Log.Level, "log-level", "The least severe level of the messages that are logged, such as \"debug\", \"info\", \"warning\" or \"error\".")
+	loader.stringVar(&config.Log.Format, "log-format", "How messages are logged, which is either \"json\" or \"text\".")
+
+	return loader
+}
+
+// IsPrintRequested determines whether the -print-config flag was given, once the flags have been parsed.
+func (loader *Loader) IsPrintRequested() bool {
+	return *loader.print
+}
+
+// Load merges the defaults, the configuration file, the environment variables and the flags, which must have been
+// parsed, and validates the result.  The configuration file is named by the -config flag, or by the MOTOMINDER_CONFIG
+// environment variable.  lookupEnv finds an environment variable, such as os.LookupEnv.
+// Returns (settings, nil) on success, otherwise (nil, error) describing the setting that is not valid, and where it came from.
+func (loader *Loader) Load(lookupEnv func(string) (string, bool)) (*Config, error) {
+	// The flags have already stored their values, so remember them before the layers beneath them are applied.
+	commandLine := make(map[string]string)
+	loader.flags.Visit(func(f *flag.Flag) {
+		if loader.isSetting(f.Name) {
+			commandLine[f.Name] = f.Value.String()
+		}
+	})
+
+	*loader.config = Default()
+
+	path := *loader.path
+	if path == "" {
+		path, _ = lookupEnv(EnvName(configFlag))
+	}
+	if path != "" {
+		err := loader.readFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range loader.names {
+		value, ok := lookupEnv(EnvName(name))
+		if !ok {
+			continue
+		}
+
+		err := loader.flags.Set(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("the environment variable %s is not valid, because %q is not %s", EnvName(name), value, loader.kinds[name])
+		}
+	}
+
+	for _, name := range loader.names {
+		value, ok := commandLine[name]
+		if !ok {
+			continue
+		}
+
+		err := loader.flags.Set(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("the flag -%s is not valid, because %q is not %s", name, value, loader.kinds[name])
+		}
+	}
+
+	err := loader.config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	config := *loader.config
+	return &config, nil
+}
+
+// EnvName converts the name of a setting's flag to the name of its environment variable.
+func EnvName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.Replace(flagName, "-", "_", -1))
+}
+
+// readFile merges the settings in the configuration file, which is YAML, JSON or TOML, into the defaults.  A setting
+// that is not known is a mistake, rather than something to ignore.
+// Returns nil on success, otherwise error.
+func (loader *Loader) readFile(path string) error {
+	extension := strings.ToLower(filepath.Ext(path))
+	switch extension {
+	case ".yaml", ".yml", ".json", ".toml":
+	default:
+		return fmt.Errorf("the configuration file %s must be YAML, JSON or TOML, with a .yaml, .yml, .json or .toml extension", path)
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read the configuration file: %s", err.Error())
+	}
+
+	if extension == ".toml" {
+		err = loader.decodeToml(contents)
+	} else {
+		err = loader.decodeYaml(contents)
+	}
+	if err != nil {
+		return fmt.Errorf("the configuration file %s is not valid: %s", path, err.Error())
+	}
+
+	return nil
+}
+
+// decodeYaml merges the settings in the YAML or JSON contents of a configuration file into the defaults.
+// Returns nil on success, otherwise error.
+func (loader *Loader) decodeYaml(contents []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+	err := decoder.Decode(loader.config)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// decodeToml merges the settings in the TOML contents of a configuration file into the defaults.
+// Returns nil on success, otherwise error.
+func (loader *Loader) decodeToml(contents []byte) error {
+	metadata, err := toml.Decode(string(contents), loader.config)
+	if err != nil {
+		return err
+	}
+
+	undecoded := metadata.Undecoded()
+	if len(undecoded) > 0 {
+		return fmt.Errorf("the setting %s is not known", undecoded[0].String())
+	}
+
+	return nil
+}
+
+// isSetting determines whether the flag is one of the settings.
+func (loader *Loader) isSetting(name string) bool {
+	for _, setting := range loader.names {
+		if setting == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringVar defines the flag of a string setting.
+func (loader *Loader) stringVar(p *string, name string, usage string) {
+	loader.flags.StringVar(p, name, *p, usage)
+	loader.names = append(loader.names, name)
+}
+
+// boolVar defines the flag of a boolean setting.
+func (loader *Loader) boolVar(p *bool, name string, usage string) {
+	loader.flags.BoolVar(p, name, *p, usage)
+	loader.kinds[name] = "a boolean, such as true or false"
+	loader.names = append(loader.names, name)
+}
+
+// intVar defines the flag of an integer setting.
+func (loader *Loader) intVar(p *int, name string, usage string) {
+	loader.flags.IntVar(p, name, *p, usage)
+	loader.kinds[name] = "an integer"
+	loader.names = append(loader.names, name)
+}
+
+// int64Var defines the flag of a 64-bit integer setting.
+func (loader *Loader) int64Var(p *int64, name string, usage string) {
+	loader.flags.Int64Var(p, name, *p, usage)
+	loader.kinds[name] = "an integer"
+	loader.names = append(loader.names, name)
+}
+
+// durationVar defines the flag of a duration setting, such as "30s".
+func (loader *Loader) durationVar(p *time.Duration, name string, usage string) {
+	loader.flags.DurationVar(p, name, *p, usage)
+	loader.kinds[name] = "a duration, such as 30s or 5m"
+	loader.names = append(loader.names, name)
+}
+
+// listVar defines the flag of a list setting, which is given as a comma separated list.
+func (loader *Loader) listVar(p *[]string, name string, usage string) {
+	loader.flags.Var((*listValue)(p), name, usage)
+	loader.names = append(loader.names, name)
+}
+
+// listValue is a flag.Value of a list of strings, which replaces the list with the comma separated items.
+type listValue []string
+
+// String implements flag.Value.String().
+func (list *listValue) String() string {
+	if list == nil {
+		return ""
+	}
+
+	return strings.Join(*list, ",")
+}
+
+// Set implements flag.Value.Set().
+func (list *listValue) Set(value string) error {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) != "" {
+			items = append(items, strings.TrimSpace(item))
+		}
+	}
+
+	*list = items
+	return nil
+}
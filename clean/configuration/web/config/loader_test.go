@@ -0,0 +1,207 @@
+// Package config implements unit tests for the Loader.
+package config
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLoader creates a loader whose flags are parsed from the arguments.
+func newTestLoader(t *testing.T, args ...string) *Loader {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	loader := NewLoader(flags)
+	err := flags.Parse(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return loader
+}
+
+// lookupIn creates a function that finds the environment variables in the map, rather than in the process.
+func lookupIn(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+// writeConfigFile writes the contents to a configuration file with the name in a temporary directory.
+// Returns (path of the file, function that removes it).
+func writeConfigFile(t *testing.T, name string, contents string) (string, func()) {
+	dir, err := ioutil.TempDir("", "motominder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, name)
+	err = ioutil.WriteFile(path, []byte(contents), 0600)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+// TestLoader_Defaults verifies that a setting that is not configured has its default value.
+func TestLoader_Defaults(t *testing.T) {
+
+	// ARRANGE
+	loader := newTestLoader(t, "-jwt-key", "secret.txt")
+	expected := Default()
+	expected.Auth.JwtKey = "secret.txt"
+
+	// ACT
+	config, err := loader.Load(lookupIn(map[string]string{}))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Equal(t, expected, *config)
+	assert.Equal(t, []string{JwtAuthMode}, config.AuthModes())
+}
+
+// TestLoader_Precedence verifies that a flag overrides an environment variable, which overrides the configuration file,
+// which overrides the default.
+func TestLoader_Precedence(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := writeConfigFile(t, "motominder.yaml", `
+server:
+  addr: ":9000"
+  readTimeout: 20s
+repository:
+  backend: sql
+  path: motominder.db
+auth:
+  jwtKey: secret.txt
+cors:
+  allowedOrigins: ["https://app.example.com"]
+`)
+	defer cleanup()
+	loader := newTestLoader(t, "-config", path, "-addr", ":9100", "-smtp-to", "a@example.com, b@example.com")
+	env := map[string]string{
+		"MOTOMINDER_ADDR":         ":9050",
+		"MOTOMINDER_READ_TIMEOUT": "25s",
+		"MOTOMINDER_LOG_LEVEL":    "debug",
+	}
+
+	// ACT
+	config, err := loader.Load(lookupIn(env))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Equal(t, ":9100", config.Server.Addr)
+	assert.Equal(t, 25*time.Second, config.Server.ReadTimeout)
+	assert.Equal(t, "debug", config.Log.Level)
+	assert.Equal(t, "sql", config.Repository.Backend)
+	assert.Equal(t, "motominder.db", config.Repository.Path)
+	assert.Equal(t, []string{"https://app.example.com"}, config.Cors.AllowedOrigins)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, config.Reminders.SmtpTo)
+	assert.Equal(t, Default().Server.WriteTimeout, config.Server.WriteTimeout)
+	assert.Equal(t, config.Cors.AllowedOrigins, config.ServerConfig().Cors.AllowedOrigins)
+}
+
+// TestLoader_JsonFileFromEnv verifies that a JSON configuration file can be named by an environment variable.
+func TestLoader_JsonFileFromEnv(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := writeConfigFile(t, "motominder.json", `{"auth": {"apiKeys": "keys.json"}, "limits": {"maxBodyBytes": 1024}}`)
+	defer cleanup()
+	loader := newTestLoader(t)
+
+	// ACT
+	config, err := loader.Load(lookupIn(map[string]string{"MOTOMINDER_CONFIG": path}))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Equal(t, "keys.json", config.Auth.ApiKeys)
+	assert.True(t, config.Limits.MaxBodyBytes == 1024)
+}
+
+// TestLoader_TomlFile verifies that the settings can be read from a TOML configuration file.
+func TestLoader_TomlFile(t *testing.T) {
+
+	// ARRANGE
+	path, cleanup := writeConfigFile(t, "motominder.toml", `
+[server]
+addr = ":9100"
+readTimeout = "25s"
+
+[auth]
+apiKeys = "keys.json"
+
+[reminders]
+smtpTo = ["a@example.com", "b@example.com"]
+`)
+	defer cleanup()
+	loader := newTestLoader(t, "-config", path)
+
+	// ACT
+	config, err := loader.Load(lookupIn(map[string]string{}))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Equal(t, ":9100", config.Server.Addr)
+	assert.Equal(t, 25*time.Second, config.Server.ReadTimeout)
+	assert.Equal(t, "keys.json", config.Auth.ApiKeys)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, config.Reminders.SmtpTo)
+	assert.Equal(t, Default().Server.WriteTimeout, config.Server.WriteTimeout)
+}
+
+// TestLoader_Invalid verifies that a setting that is not valid is reported with where it came from.
+func TestLoader_Invalid(t *testing.T) {
+
+	// ARRANGE
+	unknownPath, cleanupUnknown := writeConfigFile(t, "unknown.yaml", "server:\n  adr: \":9000\"\n")
+	defer cleanupUnknown()
+	unknownTomlPath, cleanupUnknownToml := writeConfigFile(t, "unknown.toml", "[server]\nadr = \":9000\"\n")
+	defer cleanupUnknownToml()
+	iniPath, cleanupIni := writeConfigFile(t, "motominder.ini", "[server]\naddr = :9000\n")
+	defer cleanupIni()
+	jwt := map[string]string{"MOTOMINDER_JWT_KEY": "secret.txt"}
+
+	// ACT
+	_, unknownErr := newTestLoader(t, "-config", unknownPath).Load(lookupIn(jwt))
+	_, unknownTomlErr := newTestLoader(t, "-config", unknownTomlPath).Load(lookupIn(jwt))
+	_, iniErr := newTestLoader(t, "-config", iniPath).Load(lookupIn(jwt))
+	_, missingErr := newTestLoader(t, "-config", "missing.yaml").Load(lookupIn(jwt))
+	_, envErr := newTestLoader(t).Load(lookupIn(map[string]string{"MOTOMINDER_JWT_KEY": "secret.txt", "MOTOMINDER_IDLE_TIMEOUT": "forever"}))
+	_, settingErr := newTestLoader(t, "-backend", "mongo").Load(lookupIn(jwt))
+
+	// ASSERT
+	assert.True(t, unknownErr != nil && strings.Contains(unknownErr.Error(), "adr"))
+	assert.True(t, unknownTomlErr != nil && strings.Contains(unknownTomlErr.Error(), "server.adr"))
+	assert.True(t, iniErr != nil && strings.Contains(iniErr.Error(), "YAML, JSON or TOML"))
+	assert.NotNil(t, missingErr)
+	assert.True(t, envErr != nil && strings.Contains(envErr.Error(), "MOTOMINDER_IDLE_TIMEOUT"))
+	assert.True(t, settingErr != nil && strings.Contains(settingErr.Error(), "repository"))
+}
+
+// TestConfig_Write verifies that the printed settings can be loaded as a configuration file, with the same result.
+func TestConfig_Write(t *testing.T) {
+
+	// ARRANGE
+	config, _ := newTestLoader(t, "-jwt-key", "secret.txt", "-cors-origins", "*", "-shutdown-timeout", "5s").Load(lookupIn(map[string]string{}))
+	var printed bytes.Buffer
+
+	// ACT
+	err := config.Write(&printed)
+	path, cleanup := writeConfigFile(t, "printed.yaml", printed.String())
+	defer cleanup()
+	loaded, loadErr := newTestLoader(t, "-config", path).Load(lookupIn(map[string]string{}))
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Nil(t, loadErr)
+	assert.Equal(t, *config, *loaded)
+}
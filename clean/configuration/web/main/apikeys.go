@@ -0,0 +1,133 @@
+// Package main is the entry point for the API web service.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// apiKeyCommands are the command line flags that manage the API keys, rather than starting the API web service.
+type apiKeyCommands struct {
+	list     *bool
+	issue    *string
+	subject  *string
+	roles    *string
+	lifetime *time.Duration
+	revoke   *int64
+	rotate   *int64
+	overlap  *time.Duration
+}
+
+// parseApiKeyCommands defines the command line flags that manage the API keys.
+// Returns the commands, which are populated by flag.Parse().
+func parseApiKeyCommands() *apiKeyCommands {
+	return &apiKeyCommands{
+		list:     flag.Bool("list-api-keys", false, "List the API keys, and exit."),
+		issue:    flag.String("issue-api-key", "", "Issue an API key with this name, print it, and exit."),
+		subject:  flag.String("api-key-subject", "", "The user on whose behalf an issued API key makes requests.  It is the key's name when it is empty."),
+		roles:    flag.String("api-key-roles", "General", "The comma separated authorization roles that are bound to an issued API key."),
+		lifetime: flag.Duration("api-key-lifetime", 0, "How long an issued or rotated API key is accepted.  Zero never expires."),
+		revoke:   flag.Int64("revoke-api-key", 0, "Revoke the API key with this ID, and exit."),
+		rotate:   flag.Int64("rotate-api-key", 0, "Replace the API key with this ID by a new key, print it, and exit."),
+		overlap:  flag.Duration("api-key-overlap", 24*time.Hour, "How long a rotated API key continues to be accepted."),
+	}
+}
+
+// isRequested determines whether any of the commands were given on the command line.
+// Returns true if an API key should be managed, otherwise false.
+func (commands *apiKeyCommands) isRequested() bool {
+	return *commands.list || *commands.issue != "" || *commands.revoke != 0 || *commands.rotate != 0
+}
+
+// run performs the command that was given on the command line.
+// Returns nil on success, otherwise an error.
+func (commands *apiKeyCommands) run(apiKeys contract.ApiKeyRepository) error {
+	switch {
+	case *commands.issue != "":
+		roles, err := parseRoles(*commands.roles)
+		if err != nil {
+			return err
+		}
+
+		key, apiKey, err := security.IssueApiKey(apiKeys, *commands.issue, *commands.subject, roles, *commands.lifetime)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Issued the API key with ID %d.  It will not be shown again:\n%s\n", apiKey.ID, key)
+		return nil
+
+	case *commands.revoke != 0:
+		err := security.RevokeApiKey(apiKeys, typedef.ID(*commands.revoke))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Revoked the API key with ID %d.\n", *commands.revoke)
+		return nil
+
+	case *commands.rotate != 0:
+		key, apiKey, err := security.RotateApiKey(apiKeys, typedef.ID(*commands.rotate), *commands.overlap, *commands.lifetime)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Replaced the API key with ID %d by the API key with ID %d.  It will not be shown again:\n%s\n", *commands.rotate, apiKey.ID, key)
+		return nil
+
+	default:
+		list, _, err := apiKeys.List()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		for _, apiKey := range list {
+			names := make([]string, 0, len(apiKey.Roles))
+			for _, role := range apiKey.Roles {
+				names = append(names, role.ToString())
+			}
+
+			fmt.Printf("%d\t%s\t%s\t%s\tactive=%t\tcreated=%s\texpires=%s\trevoked=%s\tlastUsed=%s\n",
+				apiKey.ID, apiKey.Name, apiKey.Subject, strings.Join(names, ","), apiKey.IsActive(now),
+				formatTime(apiKey.CreatedUtc), formatTime(apiKey.ExpiresUtc), formatTime(apiKey.RevokedUtc), formatTime(apiKey.LastUsedUtc))
+		}
+		return nil
+	}
+}
+
+// parseRoles converts comma separated names into authorization roles.
+// Returns (roles, nil) on success, otherwise (nil, error).
+func parseRoles(names string) ([]authorizationrole.AuthorizationRole, error) {
+	roles := make([]authorizationrole.AuthorizationRole, 0)
+	for _, name := range strings.Split(names, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+
+		role, err := authorizationrole.Parse(name)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// formatTime formats a time for the list of API keys.
+// Returns "-" for a zero time, otherwise the time in RFC 3339 format.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -2,39 +2,361 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/api"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
-	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/configuration/web/config"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	_ "github.com/glebarez/go-sqlite"
 	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
 )
 
 // Main is the entry point for the API web service.
+// It exits with status 2 when it is used incorrectly, and with status 1 when it fails.
 func main() {
 
+	// Merge the settings from the defaults, the configuration file, the environment variables and the command line.
+	loader := config.NewLoader(flag.CommandLine)
+	commands := parseApiKeyCommands()
+	flag.Parse()
+
+	settings, err := loader.Load(os.LookupEnv)
+	if err != nil {
+		println("The configuration is not valid:", err.Error())
+		os.Exit(2)
+	}
+
+	if loader.IsPrintRequested() {
+		err = settings.Write(os.Stdout)
+		if err != nil {
+			println("Failed to print the configuration:", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Every layer validates motorcycles with the same policy, such as whether the check digit of a VIN is required,
+	// which it is not in markets outside North America.
+	validationPolicy, err := newValidationPolicy(settings.Policies.Validation)
+	if err != nil {
+		println("Failed to load the validation policy:", err.Error())
+		os.Exit(1)
+	}
+
+	err = entity.SetValidationPolicy(validationPolicy)
+	if err != nil {
+		println("Failed to configure the validation policy:", err.Error())
+		os.Exit(1)
+	}
+
 	// Configure the application...
-	roles := map[authorizationrole.AuthorizationRole]bool{
-		authorizationrole.AdminAuthorizationRole: true,
+	policy, err := newRolePolicy(settings.Auth.RolePolicy)
+	if err != nil {
+		println("Failed to load the role policy:", err.Error())
+		os.Exit(1)
+	}
+
+	// Each request is authenticated from its client certificate, bearer token or API key.
+	authenticators := make([]security.RequestAuthenticator, 0)
+
+	if settings.IsAuthModeEnabled(config.CertificateAuthMode) {
+		certificateAuthenticator, err := newCertificateAuthenticator(settings.Auth.ClientCertRoles, policy)
+		if err != nil {
+			println("Failed to configure the authentication of client certificates:", err.Error())
+			os.Exit(1)
+		}
+		authenticators = append(authenticators, certificateAuthenticator)
+	}
+
+	if settings.IsAuthModeEnabled(config.JwtAuthMode) {
+		jwtAuthenticator, err := newJwtAuthenticator(settings.Auth.JwtAlgorithm, settings.Auth.JwtKey, policy)
+		if err != nil {
+			println("Failed to configure the authentication of bearer tokens:", err.Error())
+			os.Exit(1)
+		}
+		authenticators = append(authenticators, jwtAuthenticator)
+	}
+
+	if settings.Auth.ApiKeys != "" {
+		apiKeys, err := repository.NewFileApiKeyRepository(settings.Auth.ApiKeys)
+		if err != nil {
+			println("Failed to load the API keys:", err.Error())
+			os.Exit(1)
+		}
+
+		// Manage the API keys instead of starting the API web service, when asked to.
+		if commands.isRequested() {
+			err = commands.run(apiKeys)
+			if err != nil {
+				println("Failed to manage the API keys:", err.Error())
+				os.Exit(1)
+			}
+			return
+		}
+
+		if settings.IsAuthModeEnabled(config.ApiKeyAuthMode) {
+			apiKeyAuthenticator, err := security.NewApiKeyAuthenticator(apiKeys, policy)
+			if err != nil {
+				println("Failed to configure the authentication of API keys:", err.Error())
+				os.Exit(1)
+			}
+			authenticators = append(authenticators, apiKeyAuthenticator)
+		}
+	}
+
+	if commands.isRequested() {
+		println("The -api-keys flag is required to manage API keys.")
+		os.Exit(2)
+	}
+
+	authenticator, err := security.NewChainAuthenticator(authenticators...)
+	if err != nil {
+		println("Failed to configure the authentication of requests:", err.Error())
+		os.Exit(1)
 	}
 
-	authService, _ := security.NewAuthService(true, roles)
-	motorcycleRepository, _ := repository.NewMotorcycleRepository()
 	router := httprouter.New()
 
+	// Load the motorcycles, odometer readings, service records and reminders that were saved by a previous run of the API web service.
+	repos, err := newRepositories(settings.Repository.Backend, settings.Repository.Path, settings.Repository.OdometerPath, settings.Repository.ServicePath, settings.Repository.ReminderPath)
+	if err != nil {
+		println("Failed to load the repositories:", err.Error())
+		os.Exit(1)
+	}
+
+	schedule, err := newMaintenanceSchedule(settings.Policies.Schedule)
+	if err != nil {
+		println("Failed to load the maintenance schedule:", err.Error())
+		os.Exit(1)
+	}
+
+	manufacturers, err := newManufacturers(settings.Policies.Manufacturers)
+	if err != nil {
+		println("Failed to load the table of manufacturers:", err.Error())
+		os.Exit(1)
+	}
+
+	reminderRules, err := newReminderRules(settings.Reminders.Rules)
+	if err != nil {
+		println("Failed to load the reminder rules:", err.Error())
+		os.Exit(1)
+	}
+
+	reminderNotifier, err := newNotifier(settings.Reminders.Notifier, settings.Reminders.WebhookURL, settings.Reminders.SmtpAddr, settings.Reminders.SmtpFrom, settings.Reminders.SmtpTo)
+	if err != nil {
+		println("Failed to configure the notifier:", err.Error())
+		os.Exit(1)
+	}
+
 	// Create an instance of the API web service.
-	ourApi, err := api.NewApi(roles, authService, motorcycleRepository, router)
+	ourApi, err := api.NewApi(authenticator, repos.motorcycles, router,
+		api.WithOdometerReadingRepository(repos.odometerReadings),
+		api.WithServiceRecordRepository(repos.serviceRecords),
+		api.WithMaintenanceScheduleRepository(schedule),
+		api.WithReminderRepository(repos.reminders),
+		api.WithManufacturerRepository(manufacturers))
 	if err != nil {
-		println("Failed to create an instance of the API web service: &s", err.Error())
-		return
+		println("Failed to create an instance of the API web service:", err.Error())
+		os.Exit(1)
 	}
 
-	// Start the API web service.
-	err = ourApi.Start()
+	// Creating the API web service configures logging, so it is configured again with the settings.
+	err = configureLogging(settings.Log)
+	if err != nil {
+		println("Failed to configure logging:", err.Error())
+		os.Exit(1)
+	}
 
+	err = ourApi.Configure(settings.ServerConfig())
 	if err != nil {
-		println("API has had a failure, and is exiting: &s", err.Error())
-		return
+		println("Failed to configure the API web service:", err.Error())
+		os.Exit(1)
+	}
+
+	// Periodically create the reminders that are due, and send them to the owners of the motorcycles.  The API's
+	// repositories are used, so the scheduler's operations are measured with the requests'.  The scheduler is stopped
+	// when the API is, once its pass in progress has finished, so the repositories are not saved while it changes them.
+	if settings.Reminders.Interval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		var scheduler sync.WaitGroup
+		startReminderScheduler(ctx, &scheduler, ourApi, reminderRules, reminderNotifier, settings.Reminders.Interval)
+		ourApi.OnStop(func() {
+			cancel()
+			scheduler.Wait()
+		})
+	}
+
+	// Start the API web service, which runs until it is interrupted or terminated, and then saves the repositories.
+	err = ourApi.Start()
+	if err != nil {
+		println("API has had a failure, and is exiting:", err.Error())
+		os.Exit(1)
 	}
 
 	println("API is exiting after normal processing.")
 }
+
+// configureLogging sets the level and format of the messages that are logged.
+// Returns nil on success, otherwise error.
+func configureLogging(settings config.LogSettings) error {
+	level, err := log.ParseLevel(settings.Level)
+	if err != nil {
+		return err
+	}
+	log.SetLevel(level)
+
+	if settings.Format == "text" {
+		log.SetFormatter(&log.TextFormatter{})
+	} else {
+		log.SetFormatter(&log.JSONFormatter{})
+	}
+
+	return nil
+}
+
+// newRolePolicy loads the role policy from the file at path, or uses the default policy when path is empty.
+// Returns (role policy, nil) on success, otherwise (nil, error).
+func newRolePolicy(path string) (*security.RolePolicy, error) {
+	if path == "" {
+		return security.DefaultRolePolicy(), nil
+	}
+
+	return security.LoadRolePolicy(path)
+}
+
+// newValidationPolicy loads the validation policy from the file at path, or uses the default policy when path is empty.
+// Returns (validation policy, nil) on success, otherwise (nil, error).
+func newValidationPolicy(path string) (*entity.ValidationPolicy, error) {
+	if path == "" {
+		return entity.DefaultValidationPolicy(), nil
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ParseValidationPolicy(contents)
+}
+
+// newMaintenanceSchedule loads the maintenance schedule from the file at path, or uses the default schedule when path is empty.
+// Returns (maintenance schedule, nil) on success, otherwise (nil, error).
+func newMaintenanceSchedule(path string) (*repository.MaintenanceScheduleRepository, error) {
+	if path == "" {
+		return repository.DefaultMaintenanceScheduleRepository()
+	}
+
+	return repository.LoadMaintenanceScheduleRepository(path)
+}
+
+// newManufacturers loads the table of manufacturers from the file at path, or uses the default table when path is empty.
+// Returns (table of manufacturers, nil) on success, otherwise (nil, error).
+func newManufacturers(path string) (*repository.ManufacturerRepository, error) {
+	if path == "" {
+		return repository.DefaultManufacturerRepository()
+	}
+
+	return repository.LoadManufacturerRepository(path)
+}
+
+// newJwtAuthenticator creates an authenticator for bearer tokens signed with the algorithm, which are verified by the key in the file at keyPath.
+// Returns (authenticator, nil) on success, otherwise (nil, error).
+func newJwtAuthenticator(algorithm string, keyPath string, policy *security.RolePolicy) (*security.JwtAuthenticator, error) {
+	key, err := security.LoadJwtKey(algorithm, keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return security.NewJwtAuthenticator(algorithm, key, policy)
+}
+
+// newCertificateAuthenticator creates an authenticator for client certificates, whose identities are mapped to roles by the file at rolesPath.
+// Returns (authenticator, nil) on success, otherwise (nil, error).
+func newCertificateAuthenticator(rolesPath string, policy *security.RolePolicy) (*security.CertificateAuthenticator, error) {
+	roleMap, err := security.LoadCertificateRoleMap(rolesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return security.NewCertificateAuthenticator(roleMap, policy)
+}
+
+// repositories contains the repositories used by the API web service.
+type repositories struct {
+	motorcycles      contract.MotorcycleRepository
+	odometerReadings contract.OdometerReadingRepository
+	serviceRecords   contract.ServiceRecordRepository
+	reminders        contract.ReminderRepository
+}
+
+// newRepositories creates the kind of repositories selected by backend.  The file backend persists the motorcycles at path,
+// the odometer readings at odometerPath, the service records at servicePath, and the reminders at reminderPath.  The SQL backend
+// persists all of them in the SQLite database at path.
+// Returns (repositories, nil) on success, otherwise (nil, error).
+func newRepositories(backend string, path string, odometerPath string, servicePath string, reminderPath string) (*repositories, error) {
+	switch backend {
+	case "file":
+		motorcycles, err := repository.NewFileMotorcycleRepository(path)
+		if err != nil {
+			return nil, err
+		}
+
+		odometerReadings, err := repository.NewFileOdometerReadingRepository(odometerPath)
+		if err != nil {
+			return nil, err
+		}
+
+		serviceRecords, err := repository.NewFileServiceRecordRepository(servicePath)
+		if err != nil {
+			return nil, err
+		}
+
+		reminders, err := repository.NewFileReminderRepository(reminderPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return &repositories{motorcycles: motorcycles, odometerReadings: odometerReadings, serviceRecords: serviceRecords, reminders: reminders}, nil
+	case "sql":
+		// Each request has its own transaction, so a writer waits for another's to finish, rather than failing, and
+		// readers are not blocked by writers.
+		db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+		if err != nil {
+			return nil, err
+		}
+
+		motorcycles, err := repository.NewSqlMotorcycleRepository(db)
+		if err != nil {
+			return nil, err
+		}
+
+		odometerReadings, err := repository.NewSqlOdometerReadingRepository(db)
+		if err != nil {
+			return nil, err
+		}
+
+		serviceRecords, err := repository.NewSqlServiceRecordRepository(db)
+		if err != nil {
+			return nil, err
+		}
+
+		reminders, err := repository.NewSqlReminderRepository(db)
+		if err != nil {
+			return nil, err
+		}
+
+		return &repositories{motorcycles: motorcycles, odometerReadings: odometerReadings, serviceRecords: serviceRecords, reminders: reminders}, nil
+	default:
+		return nil, fmt.Errorf("the repository backend %q is not supported", backend)
+	}
+}
@@ -0,0 +1,105 @@
+// Package main is the entry point for the API web service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/api"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/logger"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/notifier"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/usecase/interactor"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	log "github.com/sirupsen/logrus"
+)
+
+// newReminderRules loads the reminder rules from the file at path, or uses the default rules when path is empty.
+// Returns (reminder rules, nil) on success, otherwise (nil, error).
+func newReminderRules(path string) (*repository.ReminderRuleRepository, error) {
+	if path == "" {
+		return repository.DefaultReminderRuleRepository()
+	}
+
+	return repository.LoadReminderRuleRepository(path)
+}
+
+// newNotifier creates the kind of notifier selected by kind, which is either "log", "webhook" or "smtp".
+// The webhook notifier posts to webhookURL, and the SMTP notifier sends mail from smtpFrom through the server at smtpAddr
+// to the addresses in smtpTo, as well as to the owner of the motorcycle.
+// Returns (notifier, nil) on success, otherwise (nil, error).
+func newNotifier(kind string, webhookURL string, smtpAddr string, smtpFrom string, smtpTo []string) (contract.Notifier, error) {
+	switch kind {
+	case "log":
+		return notifier.NewLogNotifier()
+	case "webhook":
+		return notifier.NewWebhookNotifier(webhookURL)
+	case "smtp":
+		return notifier.NewSmtpNotifier(smtpAddr, smtpFrom, smtpTo)
+	default:
+		return nil, fmt.Errorf("the notifier %q is not supported", kind)
+	}
+}
+
+// startReminderScheduler sends the reminders that are due now, and then again each time the interval elapses.
+// The scheduler runs in the background until the context is done, and the wait group is done once it has stopped,
+// which is after the pass in progress has finished.
+func startReminderScheduler(ctx context.Context, wg *sync.WaitGroup, ourApi *api.Api, reminderRules contract.ReminderRuleRepository, reminderNotifier contract.Notifier, interval time.Duration) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			sendReminders(ourApi, reminderRules, reminderNotifier)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// sendReminders performs one pass of the reminder scheduler, and logs its outcome.
+// Each pass has a session of the API's repositories, so its changes are not saved with those of a request.
+func sendReminders(ourApi *api.Api, reminderRules contract.ReminderRuleRepository, reminderNotifier contract.Notifier) {
+	session := ourApi.NewSession()
+	defer session.Close()
+
+	sendInteractor, err := interactor.NewSendRemindersInteractor(session.MotorcycleRepository, reminderRules, session.ReminderRepository, reminderNotifier)
+	if err != nil {
+		log.WithError(err).Error("Failed to create the interactor to send reminders.")
+		return
+	}
+	sendInteractor.Logger, _ = logger.NewLogrusLogger(log.WithField("task", "sendReminders"))
+
+	sendRequest, err := request.NewSendRemindersRequest(time.Time{})
+	if err != nil {
+		log.WithError(err).Error("Failed to create the request to send reminders.")
+		return
+	}
+
+	sendResponse, err := sendInteractor.Handle(sendRequest)
+	if err != nil {
+		log.WithError(err).Error("Failed to send reminders.")
+		return
+	}
+
+	if sendResponse.Error != nil {
+		log.WithError(sendResponse.Error).Error("Failed to send reminders.")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"created":  sendResponse.Created,
+		"notified": sendResponse.Notified,
+		"failed":   sendResponse.Failed,
+	}).Info("Sent the reminders that are due.")
+}
@@ -1,29 +1,93 @@
 // Package constant contains values for the domain.
 package constant
 
-// MinMakeLength is the minimum length string for a make.
-const MinMakeLength = 1
+import "time"
 
-// MinModelLength is the minimum length string for a model.
-const MinModelLength = 1
+// VinLength is the length of a VIN.
+const VinLength = 17
 
-// MaxMakeLength is the maximum length string for a make.
-const MaxMakeLength = 20
+// VinCheckDigitPosition is the position of a VIN's check digit, counting from one.
+const VinCheckDigitPosition = 9
 
-// MaxModelLength is the maximum length string for a model.
-const MaxModelLength = 20
+// VinModelYearPosition is the position of the code for a motorcycle's model year in its VIN, counting from one.
+const VinModelYearPosition = 10
 
-// MaxYear is the maximum year.
-const MaxYear = 2020
+// VinPlantCodePosition is the position of the code for the plant where a motorcycle was assembled in its VIN, counting from one.
+const VinPlantCodePosition = 11
 
-// MinYear is the minimum year.
-const MinYear = 1999
-
-// VinLength is the length of a VIN.
-const VinLength = 17
+// VinModelYearCycle is the number of years after which the model year codes of VINs repeat.
+const VinModelYearCycle = 30
 
 // InvalidEntityID is used when there is an invalid ID for an entity.
 const InvalidEntityID = -1
 
 // MinEntityID is the minimum ID value.
 const MinEntityID = 1
+
+// AnyRowVersion is used when a change should be made regardless of an entity's row version.
+const AnyRowVersion = 0
+
+// InitialRowVersion is the row version of an entity when it is inserted into a repository.
+const InitialRowVersion = 1
+
+// MinApiKeyNameLength is the minimum length string for the name of an API key.
+const MinApiKeyNameLength = 1
+
+// MaxApiKeyNameLength is the maximum length string for the name of an API key.
+const MaxApiKeyNameLength = 50
+
+// ApiKeyHashLength is the length of the hex encoded SHA-256 hash of an API key.
+const ApiKeyHashLength = 64
+
+// MaxOdometerValue is the maximum value that an odometer can display.
+const MaxOdometerValue = 10000000
+
+// MaxReadingClockSkew is how far in the future an odometer reading may be, to allow for clocks that are not synchronized.
+const MaxReadingClockSkew = 5 * time.Minute
+
+// MaxServiceNotesLength is the maximum length string for the notes of a service record.
+const MaxServiceNotesLength = 2000
+
+// MaxServiceParts is the maximum number of parts in a service record.
+const MaxServiceParts = 50
+
+// MaxServicePartLength is the maximum length string for a part in a service record.
+const MaxServicePartLength = 100
+
+// MaxPerformedByLength is the maximum length string for who performed a service.
+const MaxPerformedByLength = 100
+
+// MaxLaborHours is the maximum number of hours of labor in a service record.
+const MaxLaborHours = 1000
+
+// MaxServiceCost is the maximum cost of a service record.
+const MaxServiceCost = 1000000
+
+// MaxServiceClockSkew is how far in the future a service record may be, since a service is often recorded by its date
+// in the rider's time zone.
+const MaxServiceClockSkew = 24 * time.Hour
+
+// MaxMaintenanceMonths is the maximum number of months between performances of a maintenance task.
+const MaxMaintenanceMonths = 120
+
+// UpcomingMaintenanceFraction is the fraction of a maintenance task's interval before it is due, when it is upcoming,
+// and the fraction after it is due, when it becomes overdue.
+const UpcomingMaintenanceFraction = 0.1
+
+// MaxReminderNameLength is the maximum length string for the name of a reminder.
+const MaxReminderNameLength = 50
+
+// MaxReminderLeadDays is the maximum number of days before a reminder is due, when its owner is notified.
+const MaxReminderLeadDays = 365
+
+// MaxReminderMonths is the maximum number of months between the occurrences of a recurring reminder.
+const MaxReminderMonths = 120
+
+// MaxReminderSnooze is how far in the future a reminder may be snoozed.
+const MaxReminderSnooze = 90 * 24 * time.Hour
+
+// DefaultMotorcyclePageSize is the number of motorcycles in a page of a list, when the client does not choose one.
+const DefaultMotorcyclePageSize = 25
+
+// MaxMotorcyclePageSize is the maximum number of motorcycles in a page of a list.
+const MaxMotorcyclePageSize = 100
@@ -0,0 +1,23 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// ApiKeyRepository defines the contract for a store of API keys.
+// Implementations must be safe for concurrent use by multiple goroutines, and the API keys that
+// they return must be copies that are not affected by subsequent changes to the repository.
+// Keys are found by the hash of the key, because the keys themselves are never stored.
+// Update cannot undo a revocation or postpone an expiry, since it may be given a stale copy of the key.
+type ApiKeyRepository interface {
+	List() ([]entity.ApiKey, operationstatus.OperationStatus, error)
+	FindByID(id typedef.ID) (*entity.ApiKey, operationstatus.OperationStatus, error)
+	FindByHash(hash string) (*entity.ApiKey, operationstatus.OperationStatus, error)
+	Insert(apiKey *entity.ApiKey) (*entity.ApiKey, operationstatus.OperationStatus, error)
+	Update(id typedef.ID, apiKey *entity.ApiKey) (*entity.ApiKey, operationstatus.OperationStatus, error)
+	Save() (operationstatus.OperationStatus, error)
+	Validate() error
+}
@@ -3,11 +3,15 @@ package contract
 
 import (
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
 )
 
 // AuthService is a contract that provides authentication and authorization services.
+// UserID identifies the authenticated user, who owns the motorcycles that they insert.  It is empty when the user is not known.
 type AuthService interface {
 	IsAuthenticated() bool
+	UserID() string
 	IsAuthorized(role authorizationrole.AuthorizationRole) bool
+	IsPermitted(required permission.Permission) bool
 	Validate() error
 }
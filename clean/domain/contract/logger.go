@@ -0,0 +1,19 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+// Logger records what happened while a use case was performed, such as an error that is not reported to the user.
+// The fields that it was given, such as the ID of the request being processed, are recorded with every entry.
+// Implementations must be safe for concurrent use by multiple goroutines.
+type Logger interface {
+	// WithField creates a logger that records the field with every entry, as well as the fields of this logger.
+	WithField(key string, value interface{}) Logger
+
+	// Info records something that happened as expected.
+	Info(message string)
+
+	// Warn records an error from which the use case recovered.
+	Warn(err error, message string)
+
+	// Error records an error that prevented the use case from completing.
+	Error(err error, message string)
+}
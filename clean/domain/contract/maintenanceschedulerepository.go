@@ -0,0 +1,17 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+)
+
+// MaintenanceScheduleRepository defines the contract for its actions.
+// Implementations must be safe for concurrent use by multiple goroutines, and the rules that they
+// return must be copies that are not affected by subsequent changes to the repository.
+// ListByMotorcycle chooses the most specific rule for each kind of service, as entity.SelectMaintenanceRules does.
+type MaintenanceScheduleRepository interface {
+	List() ([]entity.MaintenanceRule, operationstatus.OperationStatus, error)
+	ListByMotorcycle(motorcycle entity.Motorcycle) ([]entity.MaintenanceRule, operationstatus.OperationStatus, error)
+	Validate() error
+}
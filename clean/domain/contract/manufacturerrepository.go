@@ -0,0 +1,17 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+)
+
+// ManufacturerRepository defines the contract for its actions.
+// Implementations must be safe for concurrent use by multiple goroutines, and the manufacturers that they
+// return must be copies that are not affected by subsequent changes to the repository.
+// FindByWmi returns (nil, NotFound, nil) for a WMI that is not in the repository.
+type ManufacturerRepository interface {
+	List() ([]entity.Manufacturer, operationstatus.OperationStatus, error)
+	FindByWmi(wmi string) (*entity.Manufacturer, operationstatus.OperationStatus, error)
+	Validate() error
+}
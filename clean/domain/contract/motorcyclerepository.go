@@ -8,15 +8,23 @@ import (
 )
 
 // MotorcycleRepository defines the contract for its actions.
+// Implementations must be safe for concurrent use by multiple goroutines, and the motorcycles that
+// they return must be copies that are not affected by subsequent changes to the repository.
+// Update and Delete only succeed when the row version matches the motorcycle's current row version,
+// unless it is constant.AnyRowVersion, otherwise they return a Conflict status.
+// Insert and Update return a DuplicateKey status when another motorcycle has the VIN, regardless of its case.
+// The owner of a motorcycle is set when it is inserted, and is not changed by Update.
+// Query sorts the motorcycles as entity.MotorcycleQuery.Compare() does, and ignores the case of their makes, models and VINs.
 type MotorcycleRepository interface {
 	FindByVin(vin string) (*entity.Motorcycle, operationstatus.OperationStatus, error)
 	ExistsByVin(vin string) (bool, operationstatus.OperationStatus, error)
 	ExistsByID(id typedef.ID) (bool, operationstatus.OperationStatus, error)
 
 	List() ([]entity.Motorcycle, operationstatus.OperationStatus, error)
+	Query(query entity.MotorcycleQuery) (*entity.MotorcyclePage, operationstatus.OperationStatus, error)
 	Insert(motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error)
 	Update(id typedef.ID, motorcycle *entity.Motorcycle) (*entity.Motorcycle, operationstatus.OperationStatus, error)
-	Delete(id typedef.ID) (operationstatus.OperationStatus, error)
+	Delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error)
 	FindByID(id typedef.ID) (*entity.Motorcycle, operationstatus.OperationStatus, error)
 	Save() (operationstatus.OperationStatus, error)
 	Validate() error
@@ -0,0 +1,14 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+)
+
+// Notifier delivers reminders to the owners of motorcycles, such as by email.
+// Implementations must be safe for concurrent use by multiple goroutines.
+type Notifier interface {
+	// Notify tells the owner of the motorcycle about the reminder.
+	// Returns nil on success, otherwise an error, in which case the owner will be notified again later.
+	Notify(reminder entity.Reminder, motorcycle entity.Motorcycle) error
+}
@@ -0,0 +1,27 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// OdometerReadingRepository defines the contract for its actions.
+// Implementations must be safe for concurrent use by multiple goroutines, and the readings that
+// they return must be copies that are not affected by subsequent changes to the repository.
+// A motorcycle's readings are kept in chronological order.  Insert atomically verifies that the reading
+// follows the motorcycle's latest reading, otherwise it returns a BadRequest status.  Only the latest
+// reading of a motorcycle can be deleted, so the distances of later readings remain correct, otherwise
+// Delete returns a Conflict status.
+// DeleteByMotorcycle removes all of a motorcycle's readings, so none are left behind when it is deleted.
+type OdometerReadingRepository interface {
+	ListByMotorcycle(motorcycleID typedef.ID) ([]entity.OdometerReading, operationstatus.OperationStatus, error)
+	Latest(motorcycleID typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error)
+	FindByID(id typedef.ID) (*entity.OdometerReading, operationstatus.OperationStatus, error)
+	Insert(reading *entity.OdometerReading) (*entity.OdometerReading, operationstatus.OperationStatus, error)
+	Delete(id typedef.ID) (operationstatus.OperationStatus, error)
+	DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error)
+	Save() (operationstatus.OperationStatus, error)
+	Validate() error
+}
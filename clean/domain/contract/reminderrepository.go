@@ -0,0 +1,29 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// ReminderRepository defines the contract for its actions.
+// Implementations must be safe for concurrent use by multiple goroutines, and the reminders that
+// they return must be copies that are not affected by subsequent changes to the repository.
+// A motorcycle's reminders are listed in the order that they are due.
+// Insert must fail with a Conflict status when the motorcycle already has a reminder with the name and due date.
+// Update cannot change a reminder's motorcycle, name or due date, and it must fail with a Conflict status when the
+// row version is not current, unless it is AnyRowVersion.
+// DeleteByMotorcycle removes all of a motorcycle's reminders, so none are left behind when it is deleted.
+type ReminderRepository interface {
+	ListByMotorcycle(motorcycleID typedef.ID) ([]entity.Reminder, operationstatus.OperationStatus, error)
+	FindByID(id typedef.ID) (*entity.Reminder, operationstatus.OperationStatus, error)
+	FindByDue(motorcycleID typedef.ID, name string, dueUtc time.Time) (*entity.Reminder, operationstatus.OperationStatus, error)
+	Insert(reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error)
+	Update(id typedef.ID, reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error)
+	DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error)
+	Save() (operationstatus.OperationStatus, error)
+	Validate() error
+}
@@ -0,0 +1,17 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+)
+
+// ReminderRuleRepository defines the contract for its actions.
+// Implementations must be safe for concurrent use by multiple goroutines, and the rules that they
+// return must be copies that are not affected by subsequent changes to the repository.
+// ListByMotorcycle chooses the most specific rule for each name, as entity.SelectReminderRules does.
+type ReminderRuleRepository interface {
+	List() ([]entity.ReminderRule, operationstatus.OperationStatus, error)
+	ListByMotorcycle(motorcycle entity.Motorcycle) ([]entity.ReminderRule, operationstatus.OperationStatus, error)
+	Validate() error
+}
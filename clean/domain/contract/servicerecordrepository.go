@@ -0,0 +1,25 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// ServiceRecordRepository defines the contract for its actions.
+// Implementations must be safe for concurrent use by multiple goroutines, and the service records that
+// they return must be copies that are not affected by subsequent changes to the repository.
+// A motorcycle's service records are listed in the order that the services were performed.
+// Update and Delete must fail with a Conflict status when the row version is not current, unless it is AnyRowVersion.
+// DeleteByMotorcycle removes all of a motorcycle's service records, so none are left behind when it is deleted.
+type ServiceRecordRepository interface {
+	ListByMotorcycle(motorcycleID typedef.ID) ([]entity.ServiceRecord, operationstatus.OperationStatus, error)
+	FindByID(id typedef.ID) (*entity.ServiceRecord, operationstatus.OperationStatus, error)
+	Insert(record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error)
+	Update(id typedef.ID, record *entity.ServiceRecord) (*entity.ServiceRecord, operationstatus.OperationStatus, error)
+	Delete(id typedef.ID, rowVersion typedef.RowVersion) (operationstatus.OperationStatus, error)
+	DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error)
+	Save() (operationstatus.OperationStatus, error)
+	Validate() error
+}
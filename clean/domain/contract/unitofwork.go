@@ -0,0 +1,35 @@
+// Package contract contains contracts for entities and other objects.
+package contract
+
+// UnitOfWork is implemented by a repository that keeps its changes in a unit of work until they are saved, such as a
+// transaction.  Since the changes of all of its callers would be saved together, each caller that changes the repository
+// uses a session of its own, which it discards when it has finished.
+type UnitOfWork interface {
+	// Rollback discards the changes that have not been saved.
+	// Returns nil on success, otherwise an error.
+	Rollback() error
+}
+
+// MotorcycleRepositorySessions is implemented by a motorcycle repository that gives each caller a session of its own.
+type MotorcycleRepositorySessions interface {
+	// Session creates a repository of the same motorcycles, which has a unit of work of its own.
+	Session() MotorcycleRepository
+}
+
+// OdometerReadingRepositorySessions is implemented by an odometer reading repository that gives each caller a session of its own.
+type OdometerReadingRepositorySessions interface {
+	// Session creates a repository of the same readings, which has a unit of work of its own.
+	Session() OdometerReadingRepository
+}
+
+// ServiceRecordRepositorySessions is implemented by a service record repository that gives each caller a session of its own.
+type ServiceRecordRepositorySessions interface {
+	// Session creates a repository of the same service records, which has a unit of work of its own.
+	Session() ServiceRecordRepository
+}
+
+// ReminderRepositorySessions is implemented by a reminder repository that gives each caller a session of its own.
+type ReminderRepositorySessions interface {
+	// Session creates a repository of the same reminders, which has a unit of work of its own.
+	Session() ReminderRepository
+}
@@ -0,0 +1,82 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ApiKey is an entity, which authenticates a script or integration rather than a user.
+// Only the hash of the key is kept, so the key itself cannot be recovered from the repository.
+type ApiKey struct {
+	ID    typedef.ID                            `json:"id"`
+	Name  string                                `json:"name"`
+	Hash  string                                `json:"hash"`
+	Roles []authorizationrole.AuthorizationRole `json:"roles"`
+	// Subject identifies the user on whose behalf the key makes requests.
+	Subject string `json:"subject"`
+	// CreatedUtc is when the key was issued.
+	CreatedUtc time.Time `json:"createdUtc"`
+	// ExpiresUtc is when the key stops being accepted.  A zero time never expires.
+	ExpiresUtc time.Time `json:"expiresUtc"`
+	// RevokedUtc is when the key was revoked.  A zero time has not been revoked.
+	RevokedUtc time.Time `json:"revokedUtc"`
+	// LastUsedUtc is approximately when the key last authenticated a request.
+	LastUsedUtc time.Time `json:"lastUsedUtc"`
+}
+
+// Validate implemented Entity.Validate().  It verifies that an API key's fields contain valid data that satisfies enterprise's common business rules.
+// Returns nil if the API key contains valid data, otherwise an error.
+func (k ApiKey) Validate() error {
+	return validation.ValidateStruct(&k,
+		// Name cannot be nil, cannot be empty, and max length of 50
+		validation.Field(&k.Name, validation.Required, validation.Length(constant.MinApiKeyNameLength, constant.MaxApiKeyNameLength)),
+		// Hash cannot be nil, and is the hex encoded SHA-256 hash of the key.
+		validation.Field(&k.Hash, validation.Required, validation.Length(constant.ApiKeyHashLength, constant.ApiKeyHashLength)),
+		// Roles can be empty, but not nil.
+		validation.Field(&k.Roles, validation.NotNil),
+	)
+}
+
+// IsActive determines whether the API key is accepted at the time.
+// Returns true if the key has not been revoked and has not expired, otherwise false.
+func (k ApiKey) IsActive(now time.Time) bool {
+	if !k.RevokedUtc.IsZero() && !now.Before(k.RevokedUtc) {
+		return false
+	}
+
+	if !k.ExpiresUtc.IsZero() && !now.Before(k.ExpiresUtc) {
+		return false
+	}
+
+	return true
+}
+
+// NewApiKey creates a new instance of an ApiKey.
+// Returns (nil, error) when there is an error, otherwise (API key, nil).
+func NewApiKey(name string, subject string, hash string, roles []authorizationrole.AuthorizationRole, expiresUtc time.Time) (*ApiKey, error) {
+
+	apiKey := &ApiKey{
+		ID:         constant.InvalidEntityID,
+		Name:       name,
+		Subject:    subject,
+		Hash:       hash,
+		Roles:      roles,
+		ExpiresUtc: expiresUtc,
+		// CreatedUtc: Set when an instance is created in the repository.
+		// RevokedUtc: Set when an instance is revoked.
+		// LastUsedUtc: Set when an instance authenticates a request.
+	}
+
+	err := apiKey.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return apiKey, nil
+}
@@ -0,0 +1,59 @@
+// Package entity implements unit tests for the ApiKey entity.
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/stretchr/testify/assert"
+)
+
+// testApiKeyHash is a well formed hash of an API key.
+var testApiKeyHash = strings.Repeat("a", 64)
+
+// TestApiKey_NameIsEmpty verifies that an API key requires a name.
+func TestApiKey_NameIsEmpty(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewApiKey("", "rider", testApiKeyHash, []authorizationrole.AuthorizationRole{}, time.Time{})
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestApiKey_HashIsMalformed verifies that an API key requires a SHA-256 hash.
+func TestApiKey_HashIsMalformed(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewApiKey("Backup", "rider", "mmk_plaintext", []authorizationrole.AuthorizationRole{}, time.Time{})
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestApiKey_IsActive verifies when an API key is accepted.
+func TestApiKey_IsActive(t *testing.T) {
+
+	// ARRANGE
+	now := time.Now().UTC()
+	apiKey, _ := NewApiKey("Backup", "rider", testApiKeyHash, []authorizationrole.AuthorizationRole{authorizationrole.GeneralAuthorizationRole}, time.Time{})
+	expiring := *apiKey
+	expiring.ExpiresUtc = now.Add(time.Hour)
+	expired := *apiKey
+	expired.ExpiresUtc = now.Add(-time.Hour)
+	revoked := *apiKey
+	revoked.RevokedUtc = now.Add(-time.Second)
+
+	// ACT
+	// ASSERT
+	assert.True(t, apiKey.IsActive(now))
+	assert.True(t, expiring.IsActive(now))
+	assert.False(t, expired.IsActive(now))
+	assert.False(t, revoked.IsActive(now))
+}
@@ -0,0 +1,70 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+)
+
+// DecodedVin is the information about a motorcycle that is encoded in its VIN.
+type DecodedVin struct {
+	Vin string `json:"vin"`
+	Wmi string `json:"wmi"`
+	// Manufacturer is the make of the motorcycle.  It is empty when the WMI is not in the table of manufacturers.
+	Manufacturer string `json:"manufacturer"`
+	// ModelYear is zero when the VIN does not contain a model year code.
+	ModelYear int    `json:"modelYear"`
+	PlantCode string `json:"plantCode"`
+	// Plant is where the motorcycle was assembled.  It is empty when the plant code is unknown.
+	Plant string `json:"plant"`
+}
+
+// DecodeVin decodes a VIN.  The manufacturer is the one identified by the VIN's WMI, or nil when it is unknown.
+// A model year code repeats every 30 years, so the latest model year that is not after latestYear is chosen.
+// Returns (decoded VIN, nil) on success, otherwise (nil, error) when the VIN is invalid.
+func DecodeVin(vin string, manufacturer *Manufacturer, latestYear int) (*DecodedVin, error) {
+	err := IsVin(vin)
+	if err != nil {
+		return nil, fmt.Errorf("the VIN %s %s", vin, err.Error())
+	}
+
+	decoded := &DecodedVin{
+		Vin:       strings.ToUpper(vin),
+		Wmi:       VinWmi(vin),
+		PlantCode: VinPlantCode(vin),
+	}
+
+	decoded.ModelYear, _ = VinModelYear(vin, latestYear)
+
+	if manufacturer != nil {
+		decoded.Manufacturer = manufacturer.Name
+		decoded.Plant = manufacturer.Plants[decoded.PlantCode]
+	}
+
+	// All okay
+	return decoded, nil
+}
+
+// Verify compares the make and year of a motorcycle with those decoded from its VIN.  A make is not compared when the
+// manufacturer is unknown, and a year is not compared when the VIN does not contain a model year code.  Since a code
+// repeats every 30 years, a year matches any of the years with the same code.
+// Returns nil when they match, otherwise an error describing each mismatch.
+func (decoded DecodedVin) Verify(make string, year int) error {
+	mismatches := []string{}
+
+	if decoded.Manufacturer != "" && !strings.EqualFold(strings.TrimSpace(make), decoded.Manufacturer) {
+		mismatches = append(mismatches, fmt.Sprintf("the make %q does not match the manufacturer %q", make, decoded.Manufacturer))
+	}
+
+	if decoded.ModelYear != 0 && (year-decoded.ModelYear)%constant.VinModelYearCycle != 0 {
+		mismatches = append(mismatches, fmt.Sprintf("the year %d does not match the model year %d", year, decoded.ModelYear))
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%s of the VIN %s", strings.Join(mismatches, ", and "), decoded.Vin)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package entity implements unit tests for decoding VINs.
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVinWmi verifies that the WMI of a small manufacturer includes characters 12-14 of the VIN.
+func TestVinWmi(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	large := VinWmi("jh2pc35051m200020")
+	small := VinWmi("1A9AB12345CDE6789")
+
+	// ASSERT
+	assert.True(t, large == "JH2")
+	assert.True(t, small == "1A9DE6")
+}
+
+// TestVinModelYear verifies that the latest model year with the code is chosen, since the codes repeat every 30 years.
+func TestVinModelYear(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	y2001, _ := VinModelYear("JH2PC35051M200020", 2027)
+	y2031, _ := VinModelYear("JH2PC35051M200020", 2031)
+	y2018, _ := VinModelYear("JH2PC3505JM200020", 2027)
+	_, ok := VinModelYear("JH2PC35070M200020", 2027)
+
+	// ASSERT
+	assert.True(t, y2001 == 2001)
+	assert.True(t, y2031 == 2031)
+	assert.True(t, y2018 == 2018)
+	assert.False(t, ok)
+}
+
+// TestDecodeVin verifies that the manufacturer, model year and plant are decoded from a VIN.
+func TestDecodeVin(t *testing.T) {
+
+	// ARRANGE
+	manufacturer, _ := NewManufacturer("1hd", "Harley-Davidson", map[string]string{"Y": "York, Pennsylvania"})
+
+	// ACT
+	decoded, err := DecodeVin("1HD1KB4147Y700000", manufacturer, 2027)
+	unknown, _ := DecodeVin("1HD1KB4147Y700000", nil, 2027)
+	_, invalidErr := DecodeVin("1HD1KB4197Y700000", manufacturer, 2027)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, decoded.Wmi == "1HD")
+	assert.True(t, decoded.Manufacturer == "Harley-Davidson")
+	assert.True(t, decoded.ModelYear == 2007)
+	assert.True(t, decoded.PlantCode == "Y")
+	assert.True(t, decoded.Plant == "York, Pennsylvania")
+	assert.True(t, unknown.Manufacturer == "")
+	assert.True(t, unknown.ModelYear == 2007)
+	assert.NotNil(t, invalidErr)
+}
+
+// TestDecodedVin_Verify verifies that a make and year are compared with those decoded, ignoring the case of the make.
+func TestDecodedVin_Verify(t *testing.T) {
+
+	// ARRANGE
+	decoded := DecodedVin{Vin: "1HD1KB4147Y700000", Manufacturer: "Harley-Davidson", ModelYear: 2007}
+	unknown := DecodedVin{Vin: "1HD1KB4147Y700000", ModelYear: 2007}
+
+	// ACT
+	match := decoded.Verify("harley-davidson", 2007)
+	earlierCycle := decoded.Verify("Harley-Davidson", 1977)
+	wrongMake := decoded.Verify("Honda", 2007)
+	wrongBoth := decoded.Verify("Honda", 2008)
+	unknownMake := unknown.Verify("Honda", 2007)
+
+	// ASSERT
+	assert.Nil(t, match)
+	assert.Nil(t, earlierCycle)
+	assert.Contains(t, wrongMake.Error(), "make")
+	assert.Contains(t, wrongBoth.Error(), "year 2008")
+	assert.Nil(t, unknownMake)
+}
+
+// TestManufacturer_InvalidWmi verifies that a WMI has three characters, or six when the third one is 9.
+func TestManufacturer_InvalidWmi(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, short := NewManufacturer("JH", "Honda", nil)
+	_, smallWithoutSuffix := NewManufacturer("1A9", "Boutique", nil)
+	_, small := NewManufacturer("1A9DE6", "Boutique", nil)
+	_, forbidden := NewManufacturer("JO2", "Honda", nil)
+	_, plant := NewManufacturer("JH2", "Honda", map[string]string{"MM": "Kumamoto"})
+
+	// ASSERT
+	assert.NotNil(t, short)
+	assert.NotNil(t, smallWithoutSuffix)
+	assert.Nil(t, small)
+	assert.NotNil(t, forbidden)
+	assert.NotNil(t, plant)
+}
@@ -0,0 +1,28 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/maintenancestatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+)
+
+// MaintenanceDue describes when a maintenance task is next due on a motorcycle, and how urgently it needs to be performed.
+// It is calculated from a maintenance rule, rather than being stored in a repository.
+type MaintenanceDue struct {
+	Type   servicetype.ServiceType             `json:"type"`
+	Status maintenancestatus.MaintenanceStatus `json:"status"`
+	// LastServiceID identifies the latest performance of the task.  It is zero when the task has never been performed.
+	LastServiceID    typedef.ID `json:"lastServiceId"`
+	LastPerformedUtc time.Time  `json:"lastPerformedUtc"`
+	// DueOdometer is the distance at which the task is due, in the unit.  It is zero when the task is only due by time.
+	DueOdometer float64 `json:"dueOdometer"`
+	// RemainingDistance is the distance until the task is due, in the unit.  It is negative once the task is past due.
+	RemainingDistance float64                   `json:"remainingDistance"`
+	Unit              distanceunit.DistanceUnit `json:"unit"`
+	// DueUtc is when the task is due.  It is zero when the task is only due by distance.
+	DueUtc time.Time `json:"dueUtc"`
+}
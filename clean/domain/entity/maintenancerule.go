@@ -0,0 +1,206 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/maintenancestatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// MaintenanceRule is an entity, which defines how often a kind of service must be performed on the motorcycles that it applies to.
+// A task is due after the distance or the number of months since it was last performed, whichever comes first.
+// A rule applies to a particular motorcycle, to the motorcycles of a make and model, to the motorcycles of a make, or to every motorcycle.
+type MaintenanceRule struct {
+	// MotorcycleID limits the rule to one motorcycle.  It is zero for a rule that does not apply to a particular motorcycle.
+	MotorcycleID typedef.ID `json:"motorcycleId,omitempty"`
+	// Make limits the rule to motorcycles of the make, ignoring case.  It is empty for a rule that applies to every make.
+	Make string `json:"make,omitempty"`
+	// Model limits the rule to motorcycles of the make's model, ignoring case.  It is empty for a rule that applies to every model.
+	Model string                  `json:"model,omitempty"`
+	Type  servicetype.ServiceType `json:"type"`
+	// Distance is the distance between performances of the task, in the unit.  It is zero when the task is only due by time.
+	Distance float64                   `json:"distance,omitempty"`
+	Unit     distanceunit.DistanceUnit `json:"unit,omitempty"`
+	// Months is the number of months between performances of the task.  It is zero when the task is only due by distance.
+	Months int `json:"months,omitempty"`
+}
+
+// Validate implemented Entity.Validate().  It verifies that a maintenance rule's fields contain valid data that satisfies enterprise's common business rules.
+// Returns nil if the maintenance rule contains valid data, otherwise an error.
+func (rule MaintenanceRule) Validate() error {
+	policy := CurrentValidationPolicy()
+
+	err := validation.ValidateStruct(&rule,
+		// MotorcycleID is optional, but it must refer to a motorcycle when it is present.
+		validation.Field(&rule.MotorcycleID, validation.Min(0)),
+		// Make is optional, and is limited in length by the validation policy.
+		validation.Field(&rule.Make, validation.Length(0, policy.MaxMakeLength)),
+		// Model is optional, and is limited in length by the validation policy.
+		validation.Field(&rule.Model, validation.Length(0, policy.MaxModelLength)),
+		// Type is required, and must be known.
+		validation.Field(&rule.Type, validation.Required, validation.In(servicetype.All...)),
+		// Distance cannot be negative, or greater than an odometer can display.
+		validation.Field(&rule.Distance, validation.Min(0.0), validation.Max(float64(constant.MaxOdometerValue))),
+		// Unit is optional, but must be kilometers or miles when it is present.
+		validation.Field(&rule.Unit, validation.In(distanceunit.KilometersDistanceUnit, distanceunit.MilesDistanceUnit)),
+		// Months cannot be negative, and has a max of 120.
+		validation.Field(&rule.Months, validation.Min(0), validation.Max(constant.MaxMaintenanceMonths)),
+	)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case rule.Distance == 0 && rule.Months == 0:
+		return errors.New("a maintenance rule requires a distance or a number of months between performances of the task")
+	case rule.Distance > 0 && rule.Unit == distanceunit.UndefinedDistanceUnit:
+		return errors.New("a maintenance rule with a distance requires a unit")
+	case rule.Model != "" && rule.Make == "":
+		return errors.New("a maintenance rule with a model requires a make")
+	case rule.MotorcycleID != 0 && (rule.Make != "" || rule.Model != ""):
+		return errors.New("a maintenance rule for a particular motorcycle cannot also have a make or model")
+	}
+
+	return nil
+}
+
+// NewMaintenanceRule creates a new instance of a MaintenanceRule.
+// Returns (nil, error) when there is an error, otherwise (maintenance rule, nil).
+func NewMaintenanceRule(motorcycleID typedef.ID, make string, model string, serviceType servicetype.ServiceType, distance float64,
+	unit distanceunit.DistanceUnit, months int) (*MaintenanceRule, error) {
+
+	rule := &MaintenanceRule{
+		MotorcycleID: motorcycleID,
+		Make:         strings.TrimSpace(make),
+		Model:        strings.TrimSpace(model),
+		Type:         serviceType,
+		Distance:     distance,
+		Unit:         unit,
+		Months:       months,
+	}
+
+	err := rule.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return rule, nil
+}
+
+// Specificity determines whether the rule applies to the motorcycle, and how specifically.  A rule for the motorcycle is
+// more specific than one for its make and model, which is more specific than one for its make, which is more specific
+// than one for every motorcycle.
+// Returns (specificity, true) if the rule applies to the motorcycle, otherwise (0, false).
+func (rule MaintenanceRule) Specificity(motorcycle Motorcycle) (int, bool) {
+	return scopeSpecificity(rule.MotorcycleID, rule.Make, rule.Model, motorcycle)
+}
+
+// scopeSpecificity determines whether a rule limited to the motorcycle ID, make and model applies to the motorcycle, and how
+// specifically.  An empty scope applies to every motorcycle.
+// Returns (specificity, true) if the scope includes the motorcycle, otherwise (0, false).
+func scopeSpecificity(motorcycleID typedef.ID, make string, model string, motorcycle Motorcycle) (int, bool) {
+	switch {
+	case motorcycleID != 0:
+		return 3, motorcycleID == motorcycle.ID
+	case model != "":
+		return 2, strings.EqualFold(make, motorcycle.Make) && strings.EqualFold(model, motorcycle.Model)
+	case make != "":
+		return 1, strings.EqualFold(make, motorcycle.Make)
+	default:
+		return 0, true
+	}
+}
+
+// SelectMaintenanceRules chooses the rules in the schedule that apply to the motorcycle.  When several rules for the same
+// kind of service apply, the most specific one is chosen, so a rule for a motorcycle overrides the one for its make and model.
+// Returns the rules that apply to the motorcycle, ordered by the kind of service.
+func SelectMaintenanceRules(schedule []MaintenanceRule, motorcycle Motorcycle) []MaintenanceRule {
+	chosen := make(map[servicetype.ServiceType]int)
+	specificities := make(map[servicetype.ServiceType]int)
+
+	for i, rule := range schedule {
+		specificity, ok := rule.Specificity(motorcycle)
+		if !ok {
+			continue
+		}
+
+		if _, found := chosen[rule.Type]; !found || specificity > specificities[rule.Type] {
+			chosen[rule.Type] = i
+			specificities[rule.Type] = specificity
+		}
+	}
+
+	rules := make([]MaintenanceRule, 0, len(chosen))
+	for _, i := range chosen {
+		rules = append(rules, schedule[i])
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Type < rules[j].Type
+	})
+
+	return rules
+}
+
+// Evaluate determines when the task is due, and how urgently it needs to be performed.  The task is measured from its latest
+// performance, or from when the motorcycle was added with an odometer of zero when the task has never been performed.  The
+// odometer is the current distance travelled by the motorcycle in its unit, and asOfUtc is the current time.
+// Returns the maintenance that is due, whose status is the more urgent of the distance and the time.
+func (rule MaintenanceRule) Evaluate(last *ServiceRecord, addedUtc time.Time, odometer float64, unit distanceunit.DistanceUnit, asOfUtc time.Time) MaintenanceDue {
+	due := MaintenanceDue{
+		Type:   rule.Type,
+		Status: maintenancestatus.OkMaintenanceStatus,
+		Unit:   rule.Unit,
+	}
+
+	baseOdometer := 0.0
+	baseUtc := addedUtc
+	if last != nil {
+		due.LastServiceID = last.ID
+		due.LastPerformedUtc = last.PerformedUtc
+		baseOdometer = last.Unit.Convert(last.Odometer, rule.Unit)
+		baseUtc = last.PerformedUtc
+	}
+
+	if rule.Distance > 0 {
+		due.DueOdometer = baseOdometer + rule.Distance
+		due.RemainingDistance = due.DueOdometer - unit.Convert(odometer, rule.Unit)
+		due.Status = urgency(due.RemainingDistance, rule.Distance)
+	}
+
+	if rule.Months > 0 {
+		due.DueUtc = baseUtc.AddDate(0, rule.Months, 0)
+		status := urgency(due.DueUtc.Sub(asOfUtc).Hours(), due.DueUtc.Sub(baseUtc).Hours())
+		if status > due.Status {
+			due.Status = status
+		}
+	}
+
+	return due
+}
+
+// urgency classifies how urgent a task is from how much of its interval remains until it is due.
+// Returns the maintenance status.
+func urgency(remaining float64, interval float64) maintenancestatus.MaintenanceStatus {
+	window := interval * constant.UpcomingMaintenanceFraction
+
+	switch {
+	case remaining > window:
+		return maintenancestatus.OkMaintenanceStatus
+	case remaining > 0:
+		return maintenancestatus.UpcomingMaintenanceStatus
+	case remaining >= -window:
+		return maintenancestatus.DueMaintenanceStatus
+	default:
+		return maintenancestatus.OverdueMaintenanceStatus
+	}
+}
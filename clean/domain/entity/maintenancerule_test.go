@@ -0,0 +1,128 @@
+// Package entity implements unit tests for the MaintenanceRule entity.
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/maintenancestatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMaintenanceRule_NoInterval verifies that a rule requires a distance or a number of months.
+func TestMaintenanceRule_NoInterval(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewMaintenanceRule(0, "", "", servicetype.OilChangeServiceType, 0, distanceunit.KilometersDistanceUnit, 0)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestMaintenanceRule_DistanceWithoutUnit verifies that a rule with a distance requires a unit.
+func TestMaintenanceRule_DistanceWithoutUnit(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewMaintenanceRule(0, "", "", servicetype.OilChangeServiceType, 5000, distanceunit.UndefinedDistanceUnit, 12)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestMaintenanceRule_ModelWithoutMake verifies that a rule for a model requires its make.
+func TestMaintenanceRule_ModelWithoutMake(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewMaintenanceRule(0, "", "Shadow", servicetype.ValveCheckServiceType, 16000, distanceunit.MilesDistanceUnit, 0)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestMaintenanceRule_MotorcycleWithMake verifies that a rule for a motorcycle cannot also be for a make.
+func TestMaintenanceRule_MotorcycleWithMake(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewMaintenanceRule(1, "Honda", "", servicetype.ValveCheckServiceType, 16000, distanceunit.MilesDistanceUnit, 0)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestSelectMaintenanceRules verifies that the most specific rule for each kind of service applies to a motorcycle.
+func TestSelectMaintenanceRules(t *testing.T) {
+
+	// ARRANGE
+	motorcycle := Motorcycle{ID: 7, Make: "Honda", Model: "Shadow"}
+	schedule := []MaintenanceRule{
+		{Type: servicetype.OilChangeServiceType, Distance: 6000, Unit: distanceunit.KilometersDistanceUnit, Months: 12},
+		{Make: "honda", Model: "SHADOW", Type: servicetype.OilChangeServiceType, Distance: 8000, Unit: distanceunit.KilometersDistanceUnit},
+		{MotorcycleID: 7, Type: servicetype.OilChangeServiceType, Distance: 3000, Unit: distanceunit.MilesDistanceUnit},
+		{Make: "Honda", Type: servicetype.ValveCheckServiceType, Distance: 16000, Unit: distanceunit.MilesDistanceUnit},
+		{Make: "Yamaha", Type: servicetype.InspectionServiceType, Months: 12},
+		{MotorcycleID: 8, Type: servicetype.BrakeServiceType, Months: 12},
+	}
+
+	// ACT
+	rules := SelectMaintenanceRules(schedule, motorcycle)
+
+	// ASSERT
+	assert.True(t, len(rules) == 2)
+	assert.True(t, rules[0].Type == servicetype.OilChangeServiceType)
+	assert.True(t, rules[0].Distance == 3000)
+	assert.True(t, rules[1].Type == servicetype.ValveCheckServiceType)
+}
+
+// TestMaintenanceRule_Evaluate_Distance verifies the status as the motorcycle travels towards and past the due distance.
+func TestMaintenanceRule_Evaluate_Distance(t *testing.T) {
+
+	// ARRANGE
+	rule, _ := NewMaintenanceRule(0, "", "", servicetype.OilChangeServiceType, 5000, distanceunit.KilometersDistanceUnit, 0)
+	last := &ServiceRecord{ID: 4, Type: servicetype.OilChangeServiceType, PerformedUtc: testReadingUtc, Odometer: 10000, Unit: distanceunit.KilometersDistanceUnit}
+
+	// ACT
+	ok := rule.Evaluate(last, testReadingUtc, 14000, distanceunit.KilometersDistanceUnit, testReadingUtc)
+	upcoming := rule.Evaluate(last, testReadingUtc, 14600, distanceunit.KilometersDistanceUnit, testReadingUtc)
+	due := rule.Evaluate(last, testReadingUtc, 15400, distanceunit.KilometersDistanceUnit, testReadingUtc)
+	overdue := rule.Evaluate(last, testReadingUtc, 15600, distanceunit.KilometersDistanceUnit, testReadingUtc)
+	miles := rule.Evaluate(last, testReadingUtc, 10000, distanceunit.MilesDistanceUnit, testReadingUtc)
+
+	// ASSERT
+	assert.True(t, ok.Status == maintenancestatus.OkMaintenanceStatus)
+	assert.True(t, ok.DueOdometer == 15000)
+	assert.True(t, ok.RemainingDistance == 1000)
+	assert.True(t, ok.LastServiceID == 4)
+	assert.True(t, ok.DueUtc.IsZero())
+	assert.True(t, upcoming.Status == maintenancestatus.UpcomingMaintenanceStatus)
+	assert.True(t, due.Status == maintenancestatus.DueMaintenanceStatus)
+	assert.True(t, overdue.Status == maintenancestatus.OverdueMaintenanceStatus)
+	assert.True(t, miles.Status == maintenancestatus.OverdueMaintenanceStatus)
+}
+
+// TestMaintenanceRule_Evaluate_WhicheverComesFirst verifies that the time makes a task due even though the distance does not.
+func TestMaintenanceRule_Evaluate_WhicheverComesFirst(t *testing.T) {
+
+	// ARRANGE
+	rule, _ := NewMaintenanceRule(0, "", "", servicetype.OilChangeServiceType, 5000, distanceunit.KilometersDistanceUnit, 12)
+	asOfUtc := testReadingUtc.AddDate(1, 0, 1)
+
+	// ACT
+	due := rule.Evaluate(nil, testReadingUtc, 100, distanceunit.KilometersDistanceUnit, asOfUtc)
+
+	// ASSERT
+	assert.True(t, due.Status == maintenancestatus.DueMaintenanceStatus)
+	assert.True(t, due.LastServiceID == 0)
+	assert.True(t, due.DueOdometer == 5000)
+	assert.True(t, due.DueUtc.Equal(testReadingUtc.AddDate(1, 0, 0)))
+	assert.True(t, due.DueUtc.Sub(asOfUtc) == -24*time.Hour)
+}
@@ -0,0 +1,86 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// Manufacturer is an entity, which is a maker of motorcycles that is identified by the world manufacturer identifier (WMI)
+// at the start of their VINs.
+type Manufacturer struct {
+	// Wmi is the WMI, which has six characters for a manufacturer that builds fewer than 500 vehicles a year, as VinWmi explains.
+	Wmi string `json:"wmi"`
+	// Name is the make of the manufacturer's motorcycles, such as "Honda".
+	Name string `json:"name"`
+	// Plants maps the plant codes of the manufacturer's VINs to the plants where its motorcycles are assembled.
+	// A plant code that is missing is unknown.
+	Plants map[string]string `json:"plants,omitempty"`
+}
+
+// Validate implemented Entity.Validate().  It verifies that a manufacturer's fields contain valid data that satisfies enterprise's common business rules.
+// Returns nil if the manufacturer contains valid data, otherwise an error.
+func (m Manufacturer) Validate() error {
+	policy := CurrentValidationPolicy()
+
+	err := validation.ValidateStruct(&m,
+		// Wmi cannot be empty, and has three or six characters.
+		validation.Field(&m.Wmi, validation.Required, validation.Length(3, 6)),
+		// Name cannot be empty, and is limited in length by the validation policy, since it is a make.
+		validation.Field(&m.Name, validation.Required, validation.Length(policy.MinMakeLength, policy.MaxMakeLength)),
+	)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Wmi); i++ {
+		if _, ok := vinValue(m.Wmi[i]); !ok {
+			return fmt.Errorf("the WMI %s cannot contain %q", m.Wmi, m.Wmi[i])
+		}
+	}
+
+	switch {
+	case len(m.Wmi) != 3 && len(m.Wmi) != 6:
+		return errors.New("a WMI has either three or six characters")
+	case len(m.Wmi) == 3 && m.Wmi[2] == '9':
+		return fmt.Errorf("the WMI %s of a small manufacturer requires characters 12-14 of its VINs", m.Wmi)
+	case len(m.Wmi) == 6 && m.Wmi[2] != '9':
+		return fmt.Errorf("the WMI %s can only have six characters when the third one is 9", m.Wmi)
+	}
+
+	for code, plant := range m.Plants {
+		if len(code) != 1 {
+			return fmt.Errorf("the plant code %q of the manufacturer %s is not a character of a VIN", code, m.Name)
+		}
+		if _, ok := vinValue(code[0]); !ok {
+			return fmt.Errorf("the plant code %q of the manufacturer %s is not a character of a VIN", code, m.Name)
+		}
+		if strings.TrimSpace(plant) == "" {
+			return fmt.Errorf("the plant with code %s of the manufacturer %s requires a name", code, m.Name)
+		}
+	}
+
+	return nil
+}
+
+// NewManufacturer creates a new instance of a Manufacturer.
+// Returns (nil, error) when there is an error, otherwise (manufacturer, nil).
+func NewManufacturer(wmi string, name string, plants map[string]string) (*Manufacturer, error) {
+
+	manufacturer := &Manufacturer{
+		Wmi:    strings.ToUpper(strings.TrimSpace(wmi)),
+		Name:   strings.TrimSpace(name),
+		Plants: plants,
+	}
+
+	err := manufacturer.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return manufacturer, nil
+}
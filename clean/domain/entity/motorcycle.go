@@ -2,8 +2,6 @@
 package entity
 
 import (
-	"strings"
-
 	"errors"
 
 	"time"
@@ -15,43 +13,37 @@ import (
 
 // Motorcycle is an entity
 type Motorcycle struct {
-	ID          typedef.ID `json:"id"`
-	Make        string     `json:"make"`
-	Model       string     `json:"model"`
-	Year        int        `json:"year"`
-	Vin         string     `json:"vin"`
-	CreatedUtc  time.Time  `json:"createdUtc"`
-	ModifiedUtc time.Time  `json:"modifiedUtc"`
-	//rowVersion  []byte    `json:"rowVersion"`
+	ID          typedef.ID         `json:"id"`
+	Make        string             `json:"make"`
+	Model       string             `json:"model"`
+	Year        int                `json:"year"`
+	Vin         string             `json:"vin"`
+	CreatedUtc  time.Time          `json:"createdUtc"`
+	ModifiedUtc time.Time          `json:"modifiedUtc"`
+	RowVersion  typedef.RowVersion `json:"rowVersion"`
+	// OwnerID identifies the user who owns the motorcycle.  It is empty for a motorcycle that was inserted before
+	// motorcycles had owners, which only users with access to all motorcycles can see.
+	OwnerID string `json:"ownerId"`
 }
 
 // Validate implemented Entity.Validate().  It verifies that a motorcycle's fields contain valid data that satisfies enterprise's common business rules.
 // Returns nil if the motorcycle contains valid data, otherwise an error.
 func (m Motorcycle) Validate() error {
+	policy := CurrentValidationPolicy()
+	minYear, maxYear := policy.YearRange(time.Now())
+
 	return validation.ValidateStruct(&m,
-		// Make cannot be nil, cannot be empty, max length of 20, and not Ford (case insensitive)
-		validation.Field(&m.Make, validation.Required, validation.Length(constant.MinMakeLength, constant.MaxMakeLength), validation.By(IsInvalidManufacturer)),
-		// Model cannot be nil, cannot be empty, and max length of 20
-		validation.Field(&m.Model, validation.Required, validation.Length(constant.MinModelLength, constant.MaxModelLength)),
-		// Year must be between 1999 and 2020, inclusive.
-		validation.Field(&m.Year, validation.Required, validation.Min(constant.MinYear), validation.Max(constant.MaxYear)),
-		// Vin cannot be nil, cannot be empty, and has a length of 17
-		validation.Field(&m.Vin, validation.Required, validation.By(Is17Characters)),
+		// Make cannot be nil, cannot be empty, is limited in length, and must be permitted by the validation policy (case insensitive)
+		validation.Field(&m.Make, validation.Required, validation.Length(policy.MinMakeLength, policy.MaxMakeLength), validation.By(policy.IsValidMake)),
+		// Model cannot be nil, cannot be empty, and is limited in length
+		validation.Field(&m.Model, validation.Required, validation.Length(policy.MinModelLength, policy.MaxModelLength)),
+		// Year must be within the range of the validation policy, inclusive.
+		validation.Field(&m.Year, validation.Required, validation.Min(minYear), validation.Max(maxYear)),
+		// Vin cannot be nil, cannot be empty, and must be a valid VIN
+		validation.Field(&m.Vin, validation.Required, validation.By(IsVin)),
 	)
 }
 
-// IsInvalidManufacturer verifies that a motorcycle's make is not an invalid manufacturer.
-// Returns nil if the manufacturer is valid, otherwise an error.
-func IsInvalidManufacturer(value interface{}) error {
-	s, _ := value.(string)
-
-	// Test for invalid manufacturers
-	if strings.EqualFold(s, "Ford") {
-		return errors.New("cannot be Ford")
-	}
-	return nil
-}
-
 // Is17Characters verifies that a string has 17 characters.
 // Returns nil if the string does not contain 17 characters, otherwise an error.
 func Is17Characters(value interface{}) error {
@@ -76,6 +68,7 @@ func NewMotorcycle(make string, model string, year int, vin string) (*Motorcycle
 		Vin:   vin,
 		// CreatedUtc: Set when an instance is created in the repository.
 		// ModifiedUtc: Set when an instance is updated in the repository.
+		// RowVersion: Set when an instance is created or updated in the repository.
 	}
 
 	err := motorcycle.Validate()
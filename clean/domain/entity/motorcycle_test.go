@@ -3,6 +3,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -103,25 +104,25 @@ func TestMotorcycleYear_1999(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-// TestMotorcycleYear_2020 verifies that 2020 is a valid year.
-func TestMotorcycleYear_2020(t *testing.T) {
+// TestMotorcycleYear_NextYear verifies that next year is a valid year, since a model year can be ahead of the calendar year.
+func TestMotorcycleYear_NextYear(t *testing.T) {
 
 	// ARRANGE
 
 	// ACT
-	_, err := NewMotorcycle("Honda", "Shadow", 2020, "01234567890123456")
+	_, err := NewMotorcycle("Honda", "Shadow", time.Now().Year()+1, "01234567890123456")
 
 	// ASSERT
 	assert.Nil(t, err)
 }
 
-// TestMotorcycleYear_GT2020 verifies that the year cannot be greater than 2020.
-func TestMotorcycleYear_GT2020(t *testing.T) {
+// TestMotorcycleYear_GTNextYear verifies that the year cannot be greater than next year.
+func TestMotorcycleYear_GTNextYear(t *testing.T) {
 
 	// ARRANGE
 
 	// ACT
-	_, err := NewMotorcycle("Honda", "Shadow", 2021, "01234567890123456")
+	_, err := NewMotorcycle("Honda", "Shadow", time.Now().Year()+2, "01234567890123456")
 
 	// ASSERT
 	assert.NotNil(t, err)
@@ -187,8 +188,8 @@ func TestMotorcycleVin_17Characters(t *testing.T) {
 	// ARRANGE
 
 	// ACT
-	_, err := NewMotorcycle("Honda", "Shadow", 2021, "01234567890123456")
+	_, err := NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
 
 	// ASSERT
-	assert.NotNil(t, err)
+	assert.Nil(t, err)
 }
@@ -0,0 +1,309 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/motorcyclefield"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// MotorcycleSort is one of the fields by which a list of motorcycles is sorted.
+type MotorcycleSort struct {
+	Field      motorcyclefield.MotorcycleField
+	Descending bool
+}
+
+// MotorcycleQuery selects a page of motorcycles from a repository.  A filter that is empty or zero is not applied.
+// Motorcycles are sorted by the fields in Sort, and then by their ID, so the order is always the same.
+type MotorcycleQuery struct {
+	// OwnerID restricts the motorcycles to those owned by the user.
+	OwnerID string
+	// Make is the make of the motorcycles, ignoring case.
+	Make string
+	// Model is the model of the motorcycles, ignoring case.
+	Model string
+	// MinYear is the earliest year of the motorcycles, inclusive.
+	MinYear int
+	// MaxYear is the latest year of the motorcycles, inclusive.
+	MaxYear int
+	// VinPrefix is the beginning of the VINs of the motorcycles, ignoring case.
+	VinPrefix string
+	// Sort is the list of fields by which the motorcycles are sorted, in order of precedence.
+	Sort []MotorcycleSort
+	// After is the position of the last motorcycle of the previous page, or nil for the first page.
+	After *MotorcycleCursor
+	// Limit is the maximum number of motorcycles in the page, or zero for constant.DefaultMotorcyclePageSize.
+	Limit int
+}
+
+// MotorcycleCursor is the position of a motorcycle in a sorted list, which is given to a client as an opaque string,
+// so it can ask for the page that follows it.  Its position does not change when motorcycles are inserted or deleted.
+type MotorcycleCursor struct {
+	// Sort is the sort order in which the position was found, formatted by FormatMotorcycleSort.
+	Sort  string     `json:"s"`
+	ID    typedef.ID `json:"i"`
+	Make  string     `json:"ma"`
+	Model string     `json:"mo"`
+	Year  int        `json:"y"`
+	Vin   string     `json:"v"`
+}
+
+// MotorcyclePage is a page of a sorted list of motorcycles.
+type MotorcyclePage struct {
+	Motorcycles []Motorcycle
+	// Next is the position of the last motorcycle in the page, or nil when there are no more motorcycles.
+	Next *MotorcycleCursor
+}
+
+// ParseMotorcycleSort decodes a sort order, which is a comma separated list of field names.
+// A name that begins with a minus sign is sorted in descending order, such as "make,-year".
+// Returns (sort order, nil) on success, otherwise (nil, error).
+func ParseMotorcycleSort(text string) ([]MotorcycleSort, error) {
+	sorts := make([]MotorcycleSort, 0)
+	if strings.TrimSpace(text) == "" {
+		return sorts, nil
+	}
+
+	for _, name := range strings.Split(text, ",") {
+		name = strings.TrimSpace(name)
+		descending := strings.HasPrefix(name, "-")
+
+		field, err := motorcyclefield.Parse(strings.TrimPrefix(name, "-"))
+		if err != nil {
+			return nil, err
+		}
+
+		sorts = append(sorts, MotorcycleSort{Field: field, Descending: descending})
+	}
+
+	return sorts, nil
+}
+
+// FormatMotorcycleSort encodes a sort order, so it can be parsed by ParseMotorcycleSort.
+func FormatMotorcycleSort(sorts []MotorcycleSort) string {
+	names := make([]string, 0, len(sorts))
+	for _, s := range sorts {
+		name := s.Field.ToString()
+		if s.Descending {
+			name = "-" + name
+		}
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// ParseMotorcycleCursor decodes a cursor that was encoded by MotorcycleCursor.Encode().
+// Returns (cursor, nil) on success, otherwise (nil, error).
+func ParseMotorcycleCursor(text string) (*MotorcycleCursor, error) {
+	contents, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil {
+		return nil, errors.New("the cursor is not valid")
+	}
+
+	cursor := &MotorcycleCursor{}
+	err = json.Unmarshal(contents, cursor)
+	if err != nil || cursor.ID < constant.MinEntityID {
+		return nil, errors.New("the cursor is not valid")
+	}
+
+	// All okay
+	return cursor, nil
+}
+
+// Encode converts the cursor to an opaque string, which can be used in a URL.
+func (cursor MotorcycleCursor) Encode() string {
+	contents, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(contents)
+}
+
+// Value gets the cursor's value of a field, as it is compared when motorcycles are sorted.
+func (cursor MotorcycleCursor) Value(field motorcyclefield.MotorcycleField) interface{} {
+	switch field {
+	case motorcyclefield.MakeMotorcycleField:
+		return cursor.Make
+	case motorcyclefield.ModelMotorcycleField:
+		return cursor.Model
+	case motorcyclefield.YearMotorcycleField:
+		return cursor.Year
+	case motorcyclefield.VinMotorcycleField:
+		return cursor.Vin
+	default:
+		return cursor.ID
+	}
+}
+
+// Validate verifies that a MotorcycleQuery's fields contain valid data.
+// Returns nil if the MotorcycleQuery contains valid data, otherwise an error.
+func (query MotorcycleQuery) Validate() error {
+	err := validation.ValidateStruct(&query,
+		// MinYear cannot be negative.
+		validation.Field(&query.MinYear, validation.Min(0)),
+		// MaxYear cannot be negative.
+		validation.Field(&query.MaxYear, validation.Min(0)),
+		// VinPrefix cannot be longer than a VIN.
+		validation.Field(&query.VinPrefix, validation.Length(0, constant.VinLength)),
+		// Limit cannot be negative, or more than the maximum page size.
+		validation.Field(&query.Limit, validation.Min(0), validation.Max(constant.MaxMotorcyclePageSize)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if query.MinYear > 0 && query.MaxYear > 0 && query.MinYear > query.MaxYear {
+		return errors.New("the minimum year cannot be greater than the maximum year")
+	}
+
+	for i := 0; i < len(query.VinPrefix); i++ {
+		if _, ok := vinValue(query.VinPrefix[i]); !ok {
+			return fmt.Errorf("the VIN prefix cannot contain %q", query.VinPrefix[i])
+		}
+	}
+
+	fields := make(map[motorcyclefield.MotorcycleField]bool)
+	for _, s := range query.Sort {
+		if s.Field == motorcyclefield.UndefinedMotorcycleField {
+			return errors.New("the sort field cannot be undefined")
+		}
+		if fields[s.Field] {
+			return fmt.Errorf("motorcycles cannot be sorted by %s more than once", s.Field.ToString())
+		}
+		fields[s.Field] = true
+	}
+
+	if query.After != nil && query.After.Sort != FormatMotorcycleSort(query.Sort) {
+		return errors.New("the cursor belongs to a list with a different sort order")
+	}
+
+	return nil
+}
+
+// PageSize is the maximum number of motorcycles in the page.
+func (query MotorcycleQuery) PageSize() int {
+	if query.Limit == 0 {
+		return constant.DefaultMotorcyclePageSize
+	}
+
+	return query.Limit
+}
+
+// SortOrder is the complete list of fields by which the motorcycles are sorted, which ends with their ID, so that
+// no two motorcycles have the same position.
+func (query MotorcycleQuery) SortOrder() []MotorcycleSort {
+	order := make([]MotorcycleSort, 0, len(query.Sort)+1)
+	for _, s := range query.Sort {
+		order = append(order, s)
+		if s.Field == motorcyclefield.IDMotorcycleField {
+			return order
+		}
+	}
+
+	return append(order, MotorcycleSort{Field: motorcyclefield.IDMotorcycleField})
+}
+
+// Cursor creates the position of the motorcycle in the list.
+func (query MotorcycleQuery) Cursor(motorcycle Motorcycle) *MotorcycleCursor {
+	return &MotorcycleCursor{
+		Sort:  FormatMotorcycleSort(query.Sort),
+		ID:    motorcycle.ID,
+		Make:  motorcycle.Make,
+		Model: motorcycle.Model,
+		Year:  motorcycle.Year,
+		Vin:   motorcycle.Vin,
+	}
+}
+
+// Matches determines whether the motorcycle satisfies all of the filters.
+func (query MotorcycleQuery) Matches(motorcycle Motorcycle) bool {
+	switch {
+	case query.OwnerID != "" && motorcycle.OwnerID != query.OwnerID:
+		return false
+	case query.Make != "" && !strings.EqualFold(strings.TrimSpace(query.Make), strings.TrimSpace(motorcycle.Make)):
+		return false
+	case query.Model != "" && !strings.EqualFold(strings.TrimSpace(query.Model), strings.TrimSpace(motorcycle.Model)):
+		return false
+	case query.MinYear > 0 && motorcycle.Year < query.MinYear:
+		return false
+	case query.MaxYear > 0 && motorcycle.Year > query.MaxYear:
+		return false
+	case !strings.HasPrefix(strings.ToUpper(motorcycle.Vin), strings.ToUpper(query.VinPrefix)):
+		return false
+	default:
+		return true
+	}
+}
+
+// Compare determines the order of two positions in the list.
+// Returns a negative number when a is before b, zero when they are the same, otherwise a positive number.
+func (query MotorcycleQuery) Compare(a *MotorcycleCursor, b *MotorcycleCursor) int {
+	for _, s := range query.SortOrder() {
+		result := compareValues(a.Value(s.Field), b.Value(s.Field))
+		if s.Descending {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// Apply selects the page of motorcycles from a list, which is the one that a repository without an index does.
+// Returns the page.
+func (query MotorcycleQuery) Apply(motorcycles []Motorcycle) *MotorcyclePage {
+	// Ensure that we create an empty slice rather than the default for []Motorcycle, which is a null pointer.
+	matches := make([]Motorcycle, 0)
+	for _, motorcycle := range motorcycles {
+		if !query.Matches(motorcycle) {
+			continue
+		}
+		if query.After != nil && query.Compare(query.Cursor(motorcycle), query.After) <= 0 {
+			continue
+		}
+		matches = append(matches, motorcycle)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return query.Compare(query.Cursor(matches[i]), query.Cursor(matches[j])) < 0
+	})
+
+	page := &MotorcyclePage{Motorcycles: matches}
+	if len(matches) > query.PageSize() {
+		page.Motorcycles = matches[:query.PageSize()]
+		page.Next = query.Cursor(page.Motorcycles[len(page.Motorcycles)-1])
+	}
+
+	return page
+}
+
+// compareValues compares two values of a field, where strings are compared without regard to case.
+// Returns a negative number when a is less than b, zero when they are equal, otherwise a positive number.
+func compareValues(a interface{}, b interface{}) int {
+	switch x := a.(type) {
+	case string:
+		return strings.Compare(strings.ToLower(x), strings.ToLower(b.(string)))
+	case int:
+		return x - b.(int)
+	case typedef.ID:
+		y := b.(typedef.ID)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	default:
+		return 0
+	}
+}
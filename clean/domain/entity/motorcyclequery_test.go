@@ -0,0 +1,164 @@
+// Package entity implements unit tests for the MotorcycleQuery.
+package entity
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/motorcyclefield"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/stretchr/testify/assert"
+)
+
+// newQueryMotorcycles creates a list of motorcycles with IDs, in no particular order.
+func newQueryMotorcycles() []Motorcycle {
+	return []Motorcycle{
+		{ID: 3, Make: "Honda", Model: "Shadow", Year: 2006, Vin: "JH2PC35051M200020"},
+		{ID: 1, Make: "Yamaha", Model: "Bolt", Year: 2015, Vin: "JYAVP27E4FA000001"},
+		{ID: 4, Make: "honda", Model: "Gold Wing", Year: 2018, Vin: "JH2SC79A9JK000001"},
+		{ID: 2, Make: "Harley-Davidson", Model: "Sportster", Year: 2007, Vin: "1HD1KB4147Y700000"},
+		{ID: 5, Make: "Honda", Model: "Rebel", Year: 2018, Vin: "JH2PC56A2JK000002"},
+	}
+}
+
+// ids gets the IDs of the motorcycles, in order.
+func ids(motorcycles []Motorcycle) []typedef.ID {
+	result := make([]typedef.ID, 0, len(motorcycles))
+	for _, motorcycle := range motorcycles {
+		result = append(result, motorcycle.ID)
+	}
+
+	return result
+}
+
+// TestParseMotorcycleSort verifies that a sort order is parsed with its directions, and can be formatted again.
+func TestParseMotorcycleSort(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	sorts, err := ParseMotorcycleSort("Make, -year")
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, len(sorts) == 2)
+	assert.True(t, sorts[0] == MotorcycleSort{Field: motorcyclefield.MakeMotorcycleField})
+	assert.True(t, sorts[1] == MotorcycleSort{Field: motorcyclefield.YearMotorcycleField, Descending: true})
+	assert.True(t, FormatMotorcycleSort(sorts) == "make,-year")
+}
+
+// TestParseMotorcycleSort_UnknownField verifies that motorcycles cannot be sorted by a field that they do not have.
+func TestParseMotorcycleSort_UnknownField(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := ParseMotorcycleSort("make,color")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestParseMotorcycleCursor verifies that an encoded cursor is decoded to the same position.
+func TestParseMotorcycleCursor(t *testing.T) {
+
+	// ARRANGE
+	cursor := MotorcycleCursor{Sort: "-year", ID: 4, Make: "Honda", Model: "Gold Wing", Year: 2018, Vin: "JH2SC79A9JK000001"}
+
+	// ACT
+	parsed, err := ParseMotorcycleCursor(cursor.Encode())
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, *parsed == cursor)
+}
+
+// TestParseMotorcycleCursor_Invalid verifies that a cursor that was not encoded by the service is rejected.
+func TestParseMotorcycleCursor_Invalid(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, notBase64 := ParseMotorcycleCursor("not a cursor!")
+	_, notJson := ParseMotorcycleCursor("bm90IGpzb24")
+
+	// ASSERT
+	assert.NotNil(t, notBase64)
+	assert.NotNil(t, notJson)
+}
+
+// TestMotorcycleQuery_Validate verifies the filters, sort order and page size of a query.
+func TestMotorcycleQuery_Validate(t *testing.T) {
+
+	// ARRANGE
+	sorts := []MotorcycleSort{{Field: motorcyclefield.YearMotorcycleField}}
+
+	// ACT
+	valid := MotorcycleQuery{MinYear: 2000, MaxYear: 2010, VinPrefix: "jh2", Sort: sorts, Limit: constant.MaxMotorcyclePageSize}.Validate()
+	yearRange := MotorcycleQuery{MinYear: 2010, MaxYear: 2000}.Validate()
+	vinPrefix := MotorcycleQuery{VinPrefix: "JH%"}.Validate()
+	limit := MotorcycleQuery{Limit: constant.MaxMotorcyclePageSize + 1}.Validate()
+	duplicateSort := MotorcycleQuery{Sort: append(sorts, MotorcycleSort{Field: motorcyclefield.YearMotorcycleField, Descending: true})}.Validate()
+	otherSort := MotorcycleQuery{Sort: sorts, After: &MotorcycleCursor{Sort: "make", ID: 1}}.Validate()
+
+	// ASSERT
+	assert.Nil(t, valid)
+	assert.NotNil(t, yearRange)
+	assert.NotNil(t, vinPrefix)
+	assert.NotNil(t, limit)
+	assert.NotNil(t, duplicateSort)
+	assert.NotNil(t, otherSort)
+}
+
+// TestMotorcycleQuery_Apply_Filters verifies that only the motorcycles satisfying every filter are selected, ignoring case.
+func TestMotorcycleQuery_Apply_Filters(t *testing.T) {
+
+	// ARRANGE
+	query := MotorcycleQuery{Make: "HONDA", MinYear: 2010, VinPrefix: "jh2"}
+
+	// ACT
+	page := query.Apply(newQueryMotorcycles())
+
+	// ASSERT
+	assert.Equal(t, []typedef.ID{4, 5}, ids(page.Motorcycles))
+	assert.Nil(t, page.Next)
+}
+
+// TestMotorcycleQuery_Apply_Sort verifies that the motorcycles are sorted by each field in turn, and then by their ID.
+func TestMotorcycleQuery_Apply_Sort(t *testing.T) {
+
+	// ARRANGE
+	sorts, _ := ParseMotorcycleSort("-year,make")
+	query := MotorcycleQuery{Sort: sorts}
+
+	// ACT
+	page := query.Apply(newQueryMotorcycles())
+
+	// ASSERT
+	assert.Equal(t, []typedef.ID{4, 5, 1, 2, 3}, ids(page.Motorcycles))
+}
+
+// TestMotorcycleQuery_Apply_Pages verifies that following the cursors visits every motorcycle once, in order.
+func TestMotorcycleQuery_Apply_Pages(t *testing.T) {
+
+	// ARRANGE
+	sorts, _ := ParseMotorcycleSort("-year")
+	query := MotorcycleQuery{Sort: sorts, Limit: 2}
+	visited := make([]typedef.ID, 0)
+	pages := 0
+
+	// ACT
+	for {
+		page := query.Apply(newQueryMotorcycles())
+		visited = append(visited, ids(page.Motorcycles)...)
+		pages++
+		if page.Next == nil {
+			break
+		}
+		query.After = page.Next
+	}
+
+	// ASSERT
+	assert.True(t, pages == 3)
+	assert.Equal(t, []typedef.ID{4, 5, 1, 2, 3}, visited)
+}
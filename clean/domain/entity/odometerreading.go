@@ -0,0 +1,133 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// odometerTolerance is how much a reading may appear to decrease when it is converted between units, due to rounding.
+const odometerTolerance = 0.05
+
+// OdometerReading is an entity, which records the distance displayed by a motorcycle's odometer at a point in time.
+type OdometerReading struct {
+	ID           typedef.ID                  `json:"id"`
+	MotorcycleID typedef.ID                  `json:"motorcycleId"`
+	Value        float64                     `json:"value"`
+	Unit         distanceunit.DistanceUnit   `json:"unit"`
+	ReadingUtc   time.Time                   `json:"readingUtc"`
+	Source       readingsource.ReadingSource `json:"source"`
+	// Rollover is true when the odometer wrapped around to zero since the previous reading.
+	Rollover bool `json:"rollover"`
+	// Distance is the total distance travelled by the motorcycle when it was read, in the reading's unit.
+	// It is greater than the value once the odometer has rolled over.
+	Distance   float64   `json:"distance"`
+	CreatedUtc time.Time `json:"createdUtc"`
+}
+
+// Validate implemented Entity.Validate().  It verifies that an odometer reading's fields contain valid data that satisfies enterprise's common business rules.
+// Returns nil if the odometer reading contains valid data, otherwise an error.
+func (r OdometerReading) Validate() error {
+	return validation.ValidateStruct(&r,
+		// MotorcycleID must refer to a motorcycle.
+		validation.Field(&r.MotorcycleID, validation.Required, validation.Min(constant.MinEntityID)),
+		// Value cannot be negative, or greater than an odometer can display.
+		validation.Field(&r.Value, validation.Min(0.0), validation.Max(float64(constant.MaxOdometerValue))),
+		// Unit is required, and must be kilometers or miles.
+		validation.Field(&r.Unit, validation.Required, validation.In(distanceunit.KilometersDistanceUnit, distanceunit.MilesDistanceUnit)),
+		// ReadingUtc cannot be empty.
+		validation.Field(&r.ReadingUtc, validation.Required),
+		// Source is required, and must be known.
+		validation.Field(&r.Source, validation.Required, validation.In(readingsource.ManualReadingSource, readingsource.ServiceReadingSource,
+			readingsource.TelematicsReadingSource, readingsource.ImportReadingSource)),
+	)
+}
+
+// Follow verifies that the reading can follow the previous reading of the same motorcycle, and calculates its distance.
+// Readings must be in chronological order, and the odometer's value cannot decrease unless it has rolled over.
+// An odometer with n digits rolls over at 10^n, so it is assumed to have rolled over at the smallest power of ten
+// that is greater than the previous value.  The previous reading is nil for the first reading of a motorcycle.
+// Returns nil on success, otherwise an error.
+func (r *OdometerReading) Follow(previous *OdometerReading) error {
+	if previous == nil {
+		if r.Rollover {
+			return errors.New("the first odometer reading of a motorcycle cannot roll over")
+		}
+
+		r.Distance = r.Value
+		return nil
+	}
+
+	if r.ReadingUtc.Before(previous.ReadingUtc) {
+		return fmt.Errorf("the odometer reading cannot be earlier than the previous reading at %s", previous.ReadingUtc.Format(time.RFC3339))
+	}
+
+	previousValue := previous.Unit.Convert(previous.Value, r.Unit)
+	previousDistance := previous.Unit.Convert(previous.Distance, r.Unit)
+
+	if !r.Rollover {
+		if r.Value < previousValue-odometerTolerance {
+			return fmt.Errorf("the odometer reading cannot be less than the previous reading of %.1f %s, unless the odometer has rolled over",
+				previousValue, r.Unit.ToString())
+		}
+
+		r.Distance = previousDistance + math.Max(r.Value-previousValue, 0)
+		return nil
+	}
+
+	if r.Unit != previous.Unit {
+		return errors.New("the odometer cannot roll over when the reading is in a different unit than the previous reading")
+	}
+
+	if r.Value >= previous.Value {
+		return fmt.Errorf("the odometer cannot have rolled over because the reading is not less than the previous reading of %.1f %s",
+			previous.Value, r.Unit.ToString())
+	}
+
+	r.Distance = previous.Distance + (rolloverLimit(previous.Value) - previous.Value) + r.Value
+	return nil
+}
+
+// rolloverLimit calculates the value at which an odometer that displayed the value would roll over to zero.
+// Returns the smallest power of ten that is greater than the value.
+func rolloverLimit(value float64) float64 {
+	limit := 10.0
+	for limit <= value {
+		limit *= 10
+	}
+
+	return limit
+}
+
+// NewOdometerReading creates a new instance of an OdometerReading.
+// Returns (nil, error) when there is an error, otherwise (odometer reading, nil).
+func NewOdometerReading(motorcycleID typedef.ID, value float64, unit distanceunit.DistanceUnit, readingUtc time.Time, source readingsource.ReadingSource, rollover bool) (*OdometerReading, error) {
+
+	reading := &OdometerReading{
+		ID:           constant.InvalidEntityID,
+		MotorcycleID: motorcycleID,
+		Value:        value,
+		Unit:         unit,
+		ReadingUtc:   readingUtc,
+		Source:       source,
+		Rollover:     rollover,
+		// Distance: Set when an instance follows the previous reading in the repository.
+		// CreatedUtc: Set when an instance is created in the repository.
+	}
+
+	err := reading.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return reading, nil
+}
@@ -0,0 +1,133 @@
+// Package entity implements unit tests for the OdometerReading entity.
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testReadingUtc is the time of the first odometer reading in a test.
+var testReadingUtc = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// TestOdometerReading_UnitIsUndefined verifies that a reading requires a unit.
+func TestOdometerReading_UnitIsUndefined(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewOdometerReading(1, 1000, distanceunit.UndefinedDistanceUnit, testReadingUtc, readingsource.ManualReadingSource, false)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestOdometerReading_ValueIsNegative verifies that a reading cannot be negative.
+func TestOdometerReading_ValueIsNegative(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewOdometerReading(1, -1, distanceunit.MilesDistanceUnit, testReadingUtc, readingsource.ManualReadingSource, false)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestOdometerReading_Follow_First verifies that the distance of a motorcycle's first reading is its value.
+func TestOdometerReading_Follow_First(t *testing.T) {
+
+	// ARRANGE
+	reading, _ := NewOdometerReading(1, 1200, distanceunit.MilesDistanceUnit, testReadingUtc, readingsource.ManualReadingSource, false)
+	rollover, _ := NewOdometerReading(1, 1200, distanceunit.MilesDistanceUnit, testReadingUtc, readingsource.ManualReadingSource, true)
+
+	// ACT
+	err := reading.Follow(nil)
+	rolloverErr := rollover.Follow(nil)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, reading.Distance == 1200)
+	assert.NotNil(t, rolloverErr)
+}
+
+// TestOdometerReading_Follow_Monotonic verifies that a reading cannot decrease unless the odometer rolled over.
+func TestOdometerReading_Follow_Monotonic(t *testing.T) {
+
+	// ARRANGE
+	previous, _ := NewOdometerReading(1, 1200, distanceunit.MilesDistanceUnit, testReadingUtc, readingsource.ManualReadingSource, false)
+	previous.Follow(nil)
+	same, _ := NewOdometerReading(1, 1200, distanceunit.MilesDistanceUnit, testReadingUtc.Add(time.Hour), readingsource.ManualReadingSource, false)
+	decreased, _ := NewOdometerReading(1, 1100, distanceunit.MilesDistanceUnit, testReadingUtc.Add(time.Hour), readingsource.ManualReadingSource, false)
+	increased, _ := NewOdometerReading(1, 1300, distanceunit.MilesDistanceUnit, testReadingUtc.Add(time.Hour), readingsource.ManualReadingSource, false)
+
+	// ACT
+	sameErr := same.Follow(previous)
+	decreasedErr := decreased.Follow(previous)
+	increasedErr := increased.Follow(previous)
+
+	// ASSERT
+	assert.Nil(t, sameErr)
+	assert.NotNil(t, decreasedErr)
+	assert.Nil(t, increasedErr)
+	assert.True(t, increased.Distance == 1300)
+}
+
+// TestOdometerReading_Follow_Chronological verifies that a reading cannot be earlier than the previous reading.
+func TestOdometerReading_Follow_Chronological(t *testing.T) {
+
+	// ARRANGE
+	previous, _ := NewOdometerReading(1, 1200, distanceunit.MilesDistanceUnit, testReadingUtc, readingsource.ManualReadingSource, false)
+	previous.Follow(nil)
+	reading, _ := NewOdometerReading(1, 1300, distanceunit.MilesDistanceUnit, testReadingUtc.Add(-time.Hour), readingsource.ManualReadingSource, false)
+
+	// ACT
+	err := reading.Follow(previous)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestOdometerReading_Follow_Rollover verifies that the distance continues to accumulate after the odometer rolls over.
+func TestOdometerReading_Follow_Rollover(t *testing.T) {
+
+	// ARRANGE
+	previous, _ := NewOdometerReading(1, 99900, distanceunit.MilesDistanceUnit, testReadingUtc, readingsource.ManualReadingSource, false)
+	previous.Follow(nil)
+	reading, _ := NewOdometerReading(1, 150, distanceunit.MilesDistanceUnit, testReadingUtc.Add(time.Hour), readingsource.ManualReadingSource, true)
+	notLess, _ := NewOdometerReading(1, 99950, distanceunit.MilesDistanceUnit, testReadingUtc.Add(time.Hour), readingsource.ManualReadingSource, true)
+	otherUnit, _ := NewOdometerReading(1, 150, distanceunit.KilometersDistanceUnit, testReadingUtc.Add(time.Hour), readingsource.ManualReadingSource, true)
+
+	// ACT
+	err := reading.Follow(previous)
+	notLessErr := notLess.Follow(previous)
+	otherUnitErr := otherUnit.Follow(previous)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, reading.Distance == 100150)
+	assert.NotNil(t, notLessErr)
+	assert.NotNil(t, otherUnitErr)
+}
+
+// TestOdometerReading_Follow_UnitConversion verifies that a reading is compared with a previous reading in another unit.
+func TestOdometerReading_Follow_UnitConversion(t *testing.T) {
+
+	// ARRANGE
+	previous, _ := NewOdometerReading(1, 1000, distanceunit.MilesDistanceUnit, testReadingUtc, readingsource.ManualReadingSource, false)
+	previous.Follow(nil)
+	less, _ := NewOdometerReading(1, 1500, distanceunit.KilometersDistanceUnit, testReadingUtc.Add(time.Hour), readingsource.ManualReadingSource, false)
+	more, _ := NewOdometerReading(1, 1700, distanceunit.KilometersDistanceUnit, testReadingUtc.Add(time.Hour), readingsource.ManualReadingSource, false)
+
+	// ACT
+	lessErr := less.Follow(previous)
+	moreErr := more.Follow(previous)
+
+	// ASSERT
+	assert.NotNil(t, lessErr)
+	assert.Nil(t, moreErr)
+	assert.True(t, more.Distance == 1700)
+}
@@ -0,0 +1,111 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// Reminder is an entity, which records that the owner of a motorcycle needs to be reminded of a reminder rule's date.
+// There is at most one reminder for each motorcycle, name and due date, so the owner is not reminded twice.
+type Reminder struct {
+	ID           typedef.ID                    `json:"id"`
+	MotorcycleID typedef.ID                    `json:"motorcycleId"`
+	Name         string                        `json:"name"`
+	DueUtc       time.Time                     `json:"dueUtc"`
+	Status       reminderstatus.ReminderStatus `json:"status"`
+	// SnoozedUntilUtc is when the owner is notified again, after the reminder has been snoozed.
+	SnoozedUntilUtc time.Time `json:"snoozedUntilUtc"`
+	// NotifiedUtc is when the owner was last notified of the reminder.
+	NotifiedUtc     time.Time          `json:"notifiedUtc"`
+	AcknowledgedUtc time.Time          `json:"acknowledgedUtc"`
+	CreatedUtc      time.Time          `json:"createdUtc"`
+	ModifiedUtc     time.Time          `json:"modifiedUtc"`
+	RowVersion      typedef.RowVersion `json:"rowVersion"`
+}
+
+// Validate implemented Entity.Validate().  It verifies that a reminder's fields contain valid data that satisfies enterprise's common business rules.
+// Returns nil if the reminder contains valid data, otherwise an error.
+func (r Reminder) Validate() error {
+	err := validation.ValidateStruct(&r,
+		// MotorcycleID must refer to a motorcycle.
+		validation.Field(&r.MotorcycleID, validation.Required, validation.Min(constant.MinEntityID)),
+		// Name is required, and has a max length of 50.
+		validation.Field(&r.Name, validation.Required, validation.Length(1, constant.MaxReminderNameLength)),
+		// DueUtc cannot be empty.
+		validation.Field(&r.DueUtc, validation.Required),
+		// Status is required, and must be known.
+		validation.Field(&r.Status, validation.Required, validation.In(reminderstatus.All...)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if r.Status == reminderstatus.SnoozedReminderStatus && r.SnoozedUntilUtc.IsZero() {
+		return errors.New("a snoozed reminder requires the time until which it is snoozed")
+	}
+
+	return nil
+}
+
+// NewReminder creates a new instance of a Reminder, which is pending until its owner is notified.
+// Returns (nil, error) when there is an error, otherwise (reminder, nil).
+func NewReminder(motorcycleID typedef.ID, name string, dueUtc time.Time) (*Reminder, error) {
+
+	reminder := &Reminder{
+		ID:           constant.InvalidEntityID,
+		MotorcycleID: motorcycleID,
+		Name:         strings.TrimSpace(name),
+		DueUtc:       dueUtc.UTC(),
+		Status:       reminderstatus.PendingReminderStatus,
+		// CreatedUtc: Set when an instance is created in the repository.
+		// ModifiedUtc: Set when an instance is modified in the repository.
+		// RowVersion: Set when an instance is created or modified in the repository.
+	}
+
+	err := reminder.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return reminder, nil
+}
+
+// IsDispatchable determines whether the owner needs to be notified of the reminder at asOfUtc, which is when it is pending,
+// or when it was snoozed until then.
+// Returns true if the owner needs to be notified, otherwise false.
+func (r Reminder) IsDispatchable(asOfUtc time.Time) bool {
+	switch r.Status {
+	case reminderstatus.PendingReminderStatus:
+		return true
+	case reminderstatus.SnoozedReminderStatus:
+		return !r.SnoozedUntilUtc.After(asOfUtc)
+	default:
+		return false
+	}
+}
+
+// Snooze postpones notifying the owner until the time.
+func (r *Reminder) Snooze(untilUtc time.Time) {
+	r.Status = reminderstatus.SnoozedReminderStatus
+	r.SnoozedUntilUtc = untilUtc.UTC()
+}
+
+// Acknowledge records that the owner has acknowledged the reminder at the time, so they are not notified again.
+func (r *Reminder) Acknowledge(acknowledgedUtc time.Time) {
+	r.Status = reminderstatus.AcknowledgedReminderStatus
+	r.AcknowledgedUtc = acknowledgedUtc.UTC()
+}
+
+// Notified records that the owner was notified of the reminder at the time.
+func (r *Reminder) Notified(notifiedUtc time.Time) {
+	r.Status = reminderstatus.NotifiedReminderStatus
+	r.NotifiedUtc = notifiedUtc.UTC()
+}
@@ -0,0 +1,60 @@
+// Package entity implements unit tests for the Reminder entity.
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReminder_IsDispatchable verifies that a pending reminder is delivered, and a snoozed one only once its snooze has elapsed.
+func TestReminder_IsDispatchable(t *testing.T) {
+
+	// ARRANGE
+	reminder, _ := NewReminder(1, "Annual service", testReadingUtc)
+	snoozed := *reminder
+	snoozed.Snooze(testReadingUtc.AddDate(0, 0, 3))
+	acknowledged := *reminder
+	acknowledged.Acknowledge(testReadingUtc)
+
+	// ACT
+	pending := reminder.IsDispatchable(testReadingUtc)
+	asleep := snoozed.IsDispatchable(testReadingUtc.AddDate(0, 0, 2))
+	awake := snoozed.IsDispatchable(testReadingUtc.AddDate(0, 0, 3))
+	done := acknowledged.IsDispatchable(testReadingUtc)
+
+	// ASSERT
+	assert.True(t, reminder.Status == reminderstatus.PendingReminderStatus)
+	assert.True(t, pending)
+	assert.False(t, asleep)
+	assert.True(t, awake)
+	assert.False(t, done)
+}
+
+// TestReminder_SnoozedWithoutTime verifies that a snoozed reminder requires the time until which it is snoozed.
+func TestReminder_SnoozedWithoutTime(t *testing.T) {
+
+	// ARRANGE
+	reminder, _ := NewReminder(1, "Annual service", testReadingUtc)
+	reminder.Status = reminderstatus.SnoozedReminderStatus
+
+	// ACT
+	err := reminder.Validate()
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestReminder_DueUtcIsZero verifies that a reminder requires the date that it is due.
+func TestReminder_DueUtcIsZero(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewReminder(1, "Annual service", time.Time{})
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,193 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ReminderRule is an entity, which defines a calendar date when the owners of the motorcycles that it applies to are reminded
+// of something, such as renewing the registration.  The date is either the same day each year, or it recurs a number of months
+// after the motorcycle was added, such as an annual service.  A rule applies to a particular motorcycle, to the motorcycles of
+// a make and model, to the motorcycles of a make, or to every motorcycle.
+type ReminderRule struct {
+	// MotorcycleID limits the rule to one motorcycle.  It is zero for a rule that does not apply to a particular motorcycle.
+	MotorcycleID typedef.ID `json:"motorcycleId,omitempty"`
+	// Make limits the rule to motorcycles of the make, ignoring case.  It is empty for a rule that applies to every make.
+	Make string `json:"make,omitempty"`
+	// Model limits the rule to motorcycles of the make's model, ignoring case.  It is empty for a rule that applies to every model.
+	Model string `json:"model,omitempty"`
+	// Name describes the reminder, such as "Registration renewal".  A more specific rule overrides one with the same name.
+	Name string `json:"name"`
+	// Month and Day are the date of a reminder that recurs each year.  They are zero for a reminder that recurs by months.
+	Month time.Month `json:"month,omitempty"`
+	Day   int        `json:"day,omitempty"`
+	// Months is the number of months between the occurrences of the reminder, starting when the motorcycle was added.
+	Months int `json:"months,omitempty"`
+	// LeadDays is the number of days before the date, when the owner is reminded.
+	LeadDays int `json:"leadDays,omitempty"`
+}
+
+// Validate implemented Entity.Validate().  It verifies that a reminder rule's fields contain valid data that satisfies enterprise's common business rules.
+// Returns nil if the reminder rule contains valid data, otherwise an error.
+func (rule ReminderRule) Validate() error {
+	policy := CurrentValidationPolicy()
+
+	err := validation.ValidateStruct(&rule,
+		// MotorcycleID is optional, but it must refer to a motorcycle when it is present.
+		validation.Field(&rule.MotorcycleID, validation.Min(0)),
+		// Make is optional, and is limited in length by the validation policy.
+		validation.Field(&rule.Make, validation.Length(0, policy.MaxMakeLength)),
+		// Model is optional, and is limited in length by the validation policy.
+		validation.Field(&rule.Model, validation.Length(0, policy.MaxModelLength)),
+		// Name is required, and has a max length of 50.
+		validation.Field(&rule.Name, validation.Required, validation.Length(1, constant.MaxReminderNameLength)),
+		// Month is optional, but it must be a month of the year when it is present.
+		validation.Field(&rule.Month, validation.Min(time.Month(0)), validation.Max(time.December)),
+		// Day is optional, but it must be a day of the month when it is present.
+		validation.Field(&rule.Day, validation.Min(0), validation.Max(31)),
+		// Months cannot be negative, and has a max of 120.
+		validation.Field(&rule.Months, validation.Min(0), validation.Max(constant.MaxReminderMonths)),
+		// LeadDays cannot be negative, and has a max of 365.
+		validation.Field(&rule.LeadDays, validation.Min(0), validation.Max(constant.MaxReminderLeadDays)),
+	)
+	if err != nil {
+		return err
+	}
+
+	annual := rule.Month != 0 || rule.Day != 0
+
+	switch {
+	case annual == (rule.Months > 0):
+		return errors.New("a reminder rule requires either a month and day, or a number of months between occurrences")
+	case annual && (rule.Month == 0 || rule.Day == 0):
+		return errors.New("a reminder rule for a date each year requires both a month and a day")
+	case annual && rule.Day > daysIn(rule.Month):
+		return fmt.Errorf("a reminder rule cannot be for day %d of %s", rule.Day, rule.Month)
+	case rule.Model != "" && rule.Make == "":
+		return errors.New("a reminder rule with a model requires a make")
+	case rule.MotorcycleID != 0 && (rule.Make != "" || rule.Model != ""):
+		return errors.New("a reminder rule for a particular motorcycle cannot also have a make or model")
+	}
+
+	return nil
+}
+
+// NewReminderRule creates a new instance of a ReminderRule.
+// Returns (nil, error) when there is an error, otherwise (reminder rule, nil).
+func NewReminderRule(motorcycleID typedef.ID, make string, model string, name string, month time.Month, day int, months int, leadDays int) (*ReminderRule, error) {
+
+	rule := &ReminderRule{
+		MotorcycleID: motorcycleID,
+		Make:         strings.TrimSpace(make),
+		Model:        strings.TrimSpace(model),
+		Name:         strings.TrimSpace(name),
+		Month:        month,
+		Day:          day,
+		Months:       months,
+		LeadDays:     leadDays,
+	}
+
+	err := rule.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return rule, nil
+}
+
+// Specificity determines whether the rule applies to the motorcycle, and how specifically, as MaintenanceRule.Specificity does.
+// Returns (specificity, true) if the rule applies to the motorcycle, otherwise (0, false).
+func (rule ReminderRule) Specificity(motorcycle Motorcycle) (int, bool) {
+	return scopeSpecificity(rule.MotorcycleID, rule.Make, rule.Model, motorcycle)
+}
+
+// SelectReminderRules chooses the rules that apply to the motorcycle.  When several rules with the same name apply, ignoring
+// case, the most specific one is chosen, so a rule for a motorcycle overrides the one for its make and model.
+// Returns the rules that apply to the motorcycle, ordered by their name.
+func SelectReminderRules(rules []ReminderRule, motorcycle Motorcycle) []ReminderRule {
+	chosen := make(map[string]int)
+	specificities := make(map[string]int)
+
+	for i, rule := range rules {
+		specificity, ok := rule.Specificity(motorcycle)
+		if !ok {
+			continue
+		}
+
+		name := strings.ToLower(rule.Name)
+		if _, found := chosen[name]; !found || specificity > specificities[name] {
+			chosen[name] = i
+			specificities[name] = specificity
+		}
+	}
+
+	selected := make([]ReminderRule, 0, len(chosen))
+	for _, i := range chosen {
+		selected = append(selected, rules[i])
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return strings.ToLower(selected[i].Name) < strings.ToLower(selected[j].Name)
+	})
+
+	return selected
+}
+
+// NextDueUtc finds the next date of the reminder, which is the first one on or after the day of asOfUtc.  A reminder that recurs
+// by months is counted from the day that the motorcycle was added, so it cannot be evaluated when addedUtc is unknown.  Dates
+// are midnight UTC.
+// Returns (due date, true) on success, otherwise (zero time, false).
+func (rule ReminderRule) NextDueUtc(addedUtc time.Time, asOfUtc time.Time) (time.Time, bool) {
+	today := dateOf(asOfUtc)
+
+	if rule.Months > 0 {
+		if addedUtc.IsZero() {
+			return time.Time{}, false
+		}
+
+		// Each occurrence is counted from the day that the motorcycle was added, so the end of a month does not drift.
+		added := dateOf(addedUtc)
+		for n := 1; ; n++ {
+			due := added.AddDate(0, n*rule.Months, 0)
+			if !due.Before(today) {
+				return due, true
+			}
+		}
+	}
+
+	due := time.Date(today.Year(), rule.Month, rule.Day, 0, 0, 0, 0, time.UTC)
+	if due.Before(today) {
+		due = time.Date(today.Year()+1, rule.Month, rule.Day, 0, 0, 0, 0, time.UTC)
+	}
+
+	return due, true
+}
+
+// RemindUtc determines when the owner is reminded of the date, which is the rule's number of lead days before it.
+// Returns the time to remind the owner.
+func (rule ReminderRule) RemindUtc(dueUtc time.Time) time.Time {
+	return dueUtc.AddDate(0, 0, -rule.LeadDays)
+}
+
+// dateOf gets the date of a time in UTC, at midnight.
+// Returns the date.
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// daysIn gets the greatest number of days in a month, which includes the 29th of February.
+// Returns the number of days.
+func daysIn(month time.Month) int {
+	// The day before the first of the next month, in a leap year.
+	return time.Date(2000, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
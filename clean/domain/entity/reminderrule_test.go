@@ -0,0 +1,115 @@
+// Package entity implements unit tests for the ReminderRule entity.
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReminderRule_NoDate verifies that a rule requires a date each year or a number of months.
+func TestReminderRule_NoDate(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewReminderRule(0, "", "", "Registration renewal", 0, 0, 0, 30)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestReminderRule_DateAndMonths verifies that a rule cannot have both a date each year and a number of months.
+func TestReminderRule_DateAndMonths(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewReminderRule(0, "", "", "Registration renewal", time.March, 31, 12, 30)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestReminderRule_InvalidDay verifies that the day of a rule must exist in its month.
+func TestReminderRule_InvalidDay(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, leapErr := NewReminderRule(0, "", "", "Registration renewal", time.February, 29, 0, 30)
+	_, err := NewReminderRule(0, "", "", "Registration renewal", time.April, 31, 0, 30)
+
+	// ASSERT
+	assert.Nil(t, leapErr)
+	assert.NotNil(t, err)
+}
+
+// TestReminderRule_NameIsEmpty verifies that a rule requires a name.
+func TestReminderRule_NameIsEmpty(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewReminderRule(0, "", "", " ", 0, 0, 12, 14)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestReminderRule_NextDueUtc_Annual verifies that a date each year is due this year until it has passed, and then next year.
+func TestReminderRule_NextDueUtc_Annual(t *testing.T) {
+
+	// ARRANGE
+	rule, _ := NewReminderRule(0, "", "", "Registration renewal", time.March, 31, 0, 30)
+
+	// ACT
+	before, _ := rule.NextDueUtc(time.Time{}, time.Date(2018, 3, 1, 9, 0, 0, 0, time.UTC))
+	onDay, _ := rule.NextDueUtc(time.Time{}, time.Date(2018, 3, 31, 23, 0, 0, 0, time.UTC))
+	after, _ := rule.NextDueUtc(time.Time{}, time.Date(2018, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	// ASSERT
+	assert.True(t, before.Equal(time.Date(2018, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, onDay.Equal(before))
+	assert.True(t, after.Equal(time.Date(2019, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, rule.RemindUtc(before).Equal(time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+// TestReminderRule_NextDueUtc_Months verifies that a rule recurring by months is counted from the day the motorcycle was added.
+func TestReminderRule_NextDueUtc_Months(t *testing.T) {
+
+	// ARRANGE
+	rule, _ := NewReminderRule(0, "", "", "Annual service", 0, 0, 12, 14)
+	addedUtc := time.Date(2016, 5, 10, 15, 30, 0, 0, time.UTC)
+
+	// ACT
+	due, ok := rule.NextDueUtc(addedUtc, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	_, unknown := rule.NextDueUtc(time.Time{}, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// ASSERT
+	assert.True(t, ok)
+	assert.True(t, due.Equal(time.Date(2018, 5, 10, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, unknown)
+}
+
+// TestSelectReminderRules verifies that the most specific rule with each name is chosen, ignoring case, and ordered by name.
+func TestSelectReminderRules(t *testing.T) {
+
+	// ARRANGE
+	motorcycle := Motorcycle{ID: 1, Make: "Honda", Model: "Shadow", Year: 2006}
+	rules := []ReminderRule{
+		{Name: "Annual service", Months: 12},
+		{Make: "Honda", Model: "Shadow", Name: "annual SERVICE", Months: 6},
+		{MotorcycleID: 2, Name: "Registration renewal", Month: time.March, Day: 31},
+		{Make: "Honda", Name: "Insurance renewal", Month: time.June, Day: 1},
+	}
+
+	// ACT
+	selected := SelectReminderRules(rules, motorcycle)
+
+	// ASSERT
+	assert.True(t, len(selected) == 2)
+	assert.True(t, selected[0].Months == 6)
+	assert.True(t, selected[1].Name == "Insurance renewal")
+}
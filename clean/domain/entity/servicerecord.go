@@ -0,0 +1,115 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ServiceRecord is an entity, which records service or maintenance that was performed on a motorcycle.
+type ServiceRecord struct {
+	ID           typedef.ID              `json:"id"`
+	MotorcycleID typedef.ID              `json:"motorcycleId"`
+	Type         servicetype.ServiceType `json:"type"`
+	PerformedUtc time.Time               `json:"performedUtc"`
+	// Odometer is the distance displayed by the motorcycle's odometer when it was serviced, in the unit.
+	Odometer    float64                   `json:"odometer"`
+	Unit        distanceunit.DistanceUnit `json:"unit"`
+	Notes       string                    `json:"notes"`
+	Parts       []string                  `json:"parts"`
+	LaborHours  float64                   `json:"laborHours"`
+	Cost        float64                   `json:"cost"`
+	PerformedBy string                    `json:"performedBy"`
+	CreatedUtc  time.Time                 `json:"createdUtc"`
+	ModifiedUtc time.Time                 `json:"modifiedUtc"`
+	RowVersion  typedef.RowVersion        `json:"rowVersion"`
+}
+
+// Validate implemented Entity.Validate().  It verifies that a service record's fields contain valid data that satisfies enterprise's common business rules.
+// Returns nil if the service record contains valid data, otherwise an error.
+func (s ServiceRecord) Validate() error {
+	return validation.ValidateStruct(&s,
+		// MotorcycleID must refer to a motorcycle.
+		validation.Field(&s.MotorcycleID, validation.Required, validation.Min(constant.MinEntityID)),
+		// Type is required, and must be known.
+		validation.Field(&s.Type, validation.Required, validation.In(servicetype.All...)),
+		// PerformedUtc cannot be empty.
+		validation.Field(&s.PerformedUtc, validation.Required),
+		// Odometer cannot be negative, or greater than an odometer can display.
+		validation.Field(&s.Odometer, validation.Min(0.0), validation.Max(float64(constant.MaxOdometerValue))),
+		// Unit is required, and must be kilometers or miles.
+		validation.Field(&s.Unit, validation.Required, validation.In(distanceunit.KilometersDistanceUnit, distanceunit.MilesDistanceUnit)),
+		// Notes can be empty, and has a max length of 2000.
+		validation.Field(&s.Notes, validation.Length(0, constant.MaxServiceNotesLength)),
+		// Parts can be empty, but each part must be named.
+		validation.Field(&s.Parts, validation.Length(0, constant.MaxServiceParts), validation.By(AreValidParts)),
+		// LaborHours cannot be negative.
+		validation.Field(&s.LaborHours, validation.Min(0.0), validation.Max(float64(constant.MaxLaborHours))),
+		// Cost cannot be negative.
+		validation.Field(&s.Cost, validation.Min(0.0), validation.Max(float64(constant.MaxServiceCost))),
+		// PerformedBy can be empty, and has a max length of 100.
+		validation.Field(&s.PerformedBy, validation.Length(0, constant.MaxPerformedByLength)),
+	)
+}
+
+// AreValidParts verifies that each part in a list of parts is named, and that its name is not too long.
+// Returns nil if the parts are valid, otherwise an error.
+func AreValidParts(value interface{}) error {
+	parts, _ := value.([]string)
+
+	for i, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return errors.New("cannot contain an empty part")
+		}
+
+		if len(part) > constant.MaxServicePartLength {
+			return fmt.Errorf("part %d cannot be longer than %d characters", i+1, constant.MaxServicePartLength)
+		}
+	}
+
+	return nil
+}
+
+// NewServiceRecord creates a new instance of a ServiceRecord.
+// Returns (nil, error) when there is an error, otherwise (service record, nil).
+func NewServiceRecord(motorcycleID typedef.ID, serviceType servicetype.ServiceType, performedUtc time.Time, odometer float64, unit distanceunit.DistanceUnit,
+	notes string, parts []string, laborHours float64, cost float64, performedBy string) (*ServiceRecord, error) {
+
+	// Ensure that we create an empty slice rather than the default for []string, which is a null pointer.
+	if parts == nil {
+		parts = make([]string, 0)
+	}
+
+	record := &ServiceRecord{
+		ID:           constant.InvalidEntityID,
+		MotorcycleID: motorcycleID,
+		Type:         serviceType,
+		PerformedUtc: performedUtc,
+		Odometer:     odometer,
+		Unit:         unit,
+		Notes:        notes,
+		Parts:        parts,
+		LaborHours:   laborHours,
+		Cost:         cost,
+		PerformedBy:  performedBy,
+		// CreatedUtc: Set when an instance is created in the repository.
+		// ModifiedUtc: Set when an instance is modified in the repository.
+		// RowVersion: Set when an instance is created or modified in the repository.
+	}
+
+	err := record.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return record, nil
+}
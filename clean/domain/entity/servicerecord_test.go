@@ -0,0 +1,88 @@
+// Package entity implements unit tests for the ServiceRecord entity.
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestServiceRecord_Valid verifies that a service record with only the required fields is valid, and that it has no parts.
+func TestServiceRecord_Valid(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	record, err := NewServiceRecord(1, servicetype.ValveCheckServiceType, testReadingUtc, 16000, distanceunit.MilesDistanceUnit, "", nil, 0, 0, "")
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.NotNil(t, record.Parts)
+	assert.True(t, len(record.Parts) == 0)
+}
+
+// TestServiceRecord_TypeIsUndefined verifies that a service record requires a type.
+func TestServiceRecord_TypeIsUndefined(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewServiceRecord(1, servicetype.UndefinedServiceType, testReadingUtc, 4000, distanceunit.MilesDistanceUnit, "", nil, 0, 0, "")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestServiceRecord_PerformedUtcIsZero verifies that a service record requires the time that the service was performed.
+func TestServiceRecord_PerformedUtcIsZero(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewServiceRecord(1, servicetype.OilChangeServiceType, time.Time{}, 4000, distanceunit.MilesDistanceUnit, "", nil, 0, 0, "")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestServiceRecord_CostIsNegative verifies that a service cannot have a negative cost.
+func TestServiceRecord_CostIsNegative(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewServiceRecord(1, servicetype.OilChangeServiceType, testReadingUtc, 4000, distanceunit.MilesDistanceUnit, "", nil, 0, -1, "")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestServiceRecord_PartIsEmpty verifies that each part must be named.
+func TestServiceRecord_PartIsEmpty(t *testing.T) {
+
+	// ARRANGE
+	parts := []string{"Oil filter", " "}
+
+	// ACT
+	_, err := NewServiceRecord(1, servicetype.OilChangeServiceType, testReadingUtc, 4000, distanceunit.MilesDistanceUnit, "", parts, 0, 0, "")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestServiceRecord_NotesAreTooLong verifies that the notes have a maximum length.
+func TestServiceRecord_NotesAreTooLong(t *testing.T) {
+
+	// ARRANGE
+	notes := strings.Repeat("x", 2001)
+
+	// ACT
+	_, err := NewServiceRecord(1, servicetype.OtherServiceType, testReadingUtc, 4000, distanceunit.MilesDistanceUnit, notes, nil, 0, 0, "")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,199 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ValidationPolicy contains the business rules for the make, model, year and VIN of a motorcycle, which can be
+// configured without rebuilding the API web service.  The entities, requests and dtos all validate with the current
+// policy, so every layer agrees on them.
+type ValidationPolicy struct {
+	// MinYear is the earliest year of a motorcycle.
+	MinYear int `json:"minYear"`
+	// MaxAge is how many years before the current year a motorcycle may have been made, when it is later than MinYear.
+	// There is no limit when it is zero.
+	MaxAge int `json:"maxAge"`
+	// MaxYearLead is how many years a motorcycle's model year can be ahead of the current year.
+	MaxYearLead int `json:"maxYearLead"`
+	// MinMakeLength is the minimum length string for a make.
+	MinMakeLength int `json:"minMakeLength"`
+	// MaxMakeLength is the maximum length string for a make.
+	MaxMakeLength int `json:"maxMakeLength"`
+	// MinModelLength is the minimum length string for a model.
+	MinModelLength int `json:"minModelLength"`
+	// MaxModelLength is the maximum length string for a model.
+	MaxModelLength int `json:"maxModelLength"`
+	// AllowedMakes are the only makes that are valid, ignoring case.  Any make is valid when it is empty.
+	AllowedMakes []string `json:"allowedMakes"`
+	// BlockedMakes are the makes that are not valid, ignoring case.
+	BlockedMakes []string `json:"blockedMakes"`
+	// VinCheckDigitRequired determines whether the ninth character of a VIN must be its check digit, as it is in North America.
+	// Markets that do not use check digits can turn it off, so only a VIN's length and characters are verified.
+	VinCheckDigitRequired bool `json:"vinCheckDigitRequired"`
+}
+
+// currentValidationPolicy is the policy that motorcycles are validated with.  It is shared by the whole process, since
+// an entity's Validate method has nowhere to be given a policy, so it is set once, when the API web service is
+// configured, before any requests are processed.  It is guarded by currentValidationPolicyLock, since it is read by
+// every request.  A test that replaces it must restore it with ResetValidationPolicy, and cannot run in parallel with
+// the tests that validate motorcycles.
+var currentValidationPolicy = DefaultValidationPolicy()
+var currentValidationPolicyLock sync.RWMutex
+
+// DefaultValidationPolicy creates the policy that is used when one has not been configured.
+// A motorcycle must have been made since 1999, but not after next year, and Ford is not a motorcycle manufacturer.
+func DefaultValidationPolicy() *ValidationPolicy {
+	return &ValidationPolicy{
+		MinYear:               1999,
+		MaxYearLead:           1,
+		MinMakeLength:         1,
+		MaxMakeLength:         20,
+		MinModelLength:        1,
+		MaxModelLength:        20,
+		AllowedMakes:          []string{},
+		BlockedMakes:          []string{"Ford"},
+		VinCheckDigitRequired: true,
+	}
+}
+
+// ParseValidationPolicy decodes a policy from JSON.  A field that is missing keeps its value from the default policy.
+// For example, {"minYear": 1950, "blockedMakes": []}.
+// Returns (policy, nil) on success, otherwise (nil, error).
+func ParseValidationPolicy(contents []byte) (*ValidationPolicy, error) {
+	policy := DefaultValidationPolicy()
+	err := json.Unmarshal(contents, policy)
+	if err != nil {
+		return nil, fmt.Errorf("the validation policy is not valid: %s", err.Error())
+	}
+
+	err = policy.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return policy, nil
+}
+
+// Validate verifies that a ValidationPolicy's fields contain valid data.
+// Returns nil if the ValidationPolicy contains valid data, otherwise an error.
+func (policy ValidationPolicy) Validate() error {
+	err := validation.ValidateStruct(&policy,
+		// MinYear is required, since a motorcycle cannot be made before motorcycles were invented.
+		validation.Field(&policy.MinYear, validation.Required, validation.Min(1885)),
+		// MaxAge cannot be negative.
+		validation.Field(&policy.MaxAge, validation.Min(0)),
+		// MaxYearLead cannot be negative.
+		validation.Field(&policy.MaxYearLead, validation.Min(0)),
+		// MinMakeLength must be at least 1.
+		validation.Field(&policy.MinMakeLength, validation.Required, validation.Min(1)),
+		// MaxMakeLength must be at least 1.
+		validation.Field(&policy.MaxMakeLength, validation.Required, validation.Min(1)),
+		// MinModelLength must be at least 1.
+		validation.Field(&policy.MinModelLength, validation.Required, validation.Min(1)),
+		// MaxModelLength must be at least 1.
+		validation.Field(&policy.MaxModelLength, validation.Required, validation.Min(1)),
+		// AllowedMakes can be empty, but not nil.
+		validation.Field(&policy.AllowedMakes, validation.NotNil),
+		// BlockedMakes can be empty, but not nil.
+		validation.Field(&policy.BlockedMakes, validation.NotNil),
+	)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case policy.MinMakeLength > policy.MaxMakeLength:
+		return errors.New("the minimum length of a make cannot be greater than its maximum length")
+	case policy.MinModelLength > policy.MaxModelLength:
+		return errors.New("the minimum length of a model cannot be greater than its maximum length")
+	}
+
+	return nil
+}
+
+// YearRange determines the earliest and latest years of a motorcycle, as of the time now.
+// Returns (earliest year, latest year).
+func (policy ValidationPolicy) YearRange(now time.Time) (int, int) {
+	minYear := policy.MinYear
+	if policy.MaxAge > 0 && now.Year()-policy.MaxAge > minYear {
+		minYear = now.Year() - policy.MaxAge
+	}
+
+	return minYear, now.Year() + policy.MaxYearLead
+}
+
+// IsValidMake verifies that a motorcycle's make is allowed and is not blocked, ignoring case.
+// Returns nil if the make is valid, otherwise an error.
+func (policy ValidationPolicy) IsValidMake(value interface{}) error {
+	s, _ := value.(string)
+	s = strings.TrimSpace(s)
+
+	for _, blocked := range policy.BlockedMakes {
+		if strings.EqualFold(s, blocked) {
+			return fmt.Errorf("cannot be %s", blocked)
+		}
+	}
+
+	if len(policy.AllowedMakes) == 0 {
+		return nil
+	}
+
+	for _, allowed := range policy.AllowedMakes {
+		if strings.EqualFold(s, allowed) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must be one of %s", strings.Join(policy.AllowedMakes, ", "))
+}
+
+// CurrentValidationPolicy gets the policy that motorcycles are validated with.
+// Returns a copy of the current policy.
+func CurrentValidationPolicy() ValidationPolicy {
+	currentValidationPolicyLock.RLock()
+	defer currentValidationPolicyLock.RUnlock()
+
+	return *currentValidationPolicy
+}
+
+// SetValidationPolicy replaces the policy that motorcycles are validated with, throughout the process.  It is meant to
+// be called once, when the API web service is configured, since a request in progress may validate with either policy.
+// Returns nil on success, otherwise an error when the policy is not valid.
+func SetValidationPolicy(policy *ValidationPolicy) error {
+	if policy == nil {
+		return errors.New("the validation policy cannot be nil")
+	}
+
+	err := policy.Validate()
+	if err != nil {
+		return err
+	}
+
+	currentValidationPolicyLock.Lock()
+	defer currentValidationPolicyLock.Unlock()
+
+	replacement := *policy
+	replacement.AllowedMakes = append([]string{}, policy.AllowedMakes...)
+	replacement.BlockedMakes = append([]string{}, policy.BlockedMakes...)
+	currentValidationPolicy = &replacement
+
+	// All okay
+	return nil
+}
+
+// ResetValidationPolicy restores the default policy, such as after a test that has replaced it.
+func ResetValidationPolicy() {
+	currentValidationPolicyLock.Lock()
+	defer currentValidationPolicyLock.Unlock()
+
+	currentValidationPolicy = DefaultValidationPolicy()
+}
@@ -0,0 +1,108 @@
+// Package entity implements unit tests for the ValidationPolicy.
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidationPolicy_YearRange verifies that the earliest year is the later of the minimum year and the maximum age.
+func TestValidationPolicy_YearRange(t *testing.T) {
+
+	// ARRANGE
+	now := time.Date(2030, time.June, 1, 0, 0, 0, 0, time.UTC)
+	policy := DefaultValidationPolicy()
+
+	// ACT
+	minYear, maxYear := policy.YearRange(now)
+	policy.MaxAge = 25
+	agedMinYear, _ := policy.YearRange(now)
+
+	// ASSERT
+	assert.True(t, minYear == 1999)
+	assert.True(t, maxYear == 2031)
+	assert.True(t, agedMinYear == 2005)
+}
+
+// TestValidationPolicy_IsValidMake verifies that a make must be allowed, and cannot be blocked, ignoring case.
+func TestValidationPolicy_IsValidMake(t *testing.T) {
+
+	// ARRANGE
+	policy := DefaultValidationPolicy()
+	policy.AllowedMakes = []string{"Honda", "Ford"}
+
+	// ACT
+	honda := policy.IsValidMake("honda")
+	ford := policy.IsValidMake("FORD")
+	yamaha := policy.IsValidMake("Yamaha")
+
+	// ASSERT
+	assert.Nil(t, honda)
+	assert.NotNil(t, ford)
+	assert.NotNil(t, yamaha)
+}
+
+// TestParseValidationPolicy verifies that a field that is missing from a policy keeps its default value.
+func TestParseValidationPolicy(t *testing.T) {
+
+	// ARRANGE
+	contents := []byte(`{"minYear": 1950, "blockedMakes": []}`)
+
+	// ACT
+	policy, err := ParseValidationPolicy(contents)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.True(t, policy.MinYear == 1950)
+	assert.True(t, len(policy.BlockedMakes) == 0)
+	assert.True(t, policy.MaxMakeLength == 20)
+	assert.True(t, policy.VinCheckDigitRequired)
+}
+
+// TestParseValidationPolicy_Invalid verifies that a policy cannot have a minimum length greater than its maximum length.
+func TestParseValidationPolicy_Invalid(t *testing.T) {
+
+	// ARRANGE
+	contents := []byte(`{"minModelLength": 30}`)
+
+	// ACT
+	_, err := ParseValidationPolicy(contents)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestSetValidationPolicy verifies that motorcycles are validated with the current policy.
+func TestSetValidationPolicy(t *testing.T) {
+
+	// ARRANGE
+	policy := DefaultValidationPolicy()
+	policy.MinYear = 1950
+	policy.BlockedMakes = []string{}
+	defer ResetValidationPolicy()
+
+	// ACT
+	err := SetValidationPolicy(policy)
+	_, motorcycleErr := NewMotorcycle("Ford", "Model T", 1951, "01234567890123456")
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Nil(t, motorcycleErr)
+}
+
+// TestResetValidationPolicy verifies that the default policy is restored after it has been replaced.
+func TestResetValidationPolicy(t *testing.T) {
+
+	// ARRANGE
+	policy := DefaultValidationPolicy()
+	policy.MinYear = 1950
+	SetValidationPolicy(policy)
+
+	// ACT
+	ResetValidationPolicy()
+
+	// ASSERT
+	assert.Equal(t, *DefaultValidationPolicy(), CurrentValidationPolicy())
+}
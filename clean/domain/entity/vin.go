@@ -0,0 +1,147 @@
+// Package entity contains the domain entities.
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+)
+
+// vinWeights are the weights of a VIN's characters when its check digit is calculated.  The check digit itself has no weight.
+var vinWeights = [constant.VinLength]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// IsVin verifies that a string is a VIN, as defined by ISO 3779.  It has 17 characters, which are digits and letters
+// other than I, O and Q.  When the validation policy requires it, its ninth character must also be its check digit.
+// Returns nil if the string is a VIN, otherwise an error.
+func IsVin(value interface{}) error {
+	s, _ := value.(string)
+
+	err := Is17Characters(s)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(s); i++ {
+		if _, ok := vinValue(s[i]); !ok {
+			return fmt.Errorf("must only contain the digits 0-9 and the letters A-Z other than I, O and Q, but position %d is %q", i+1, s[i])
+		}
+	}
+
+	if !CurrentValidationPolicy().VinCheckDigitRequired {
+		return nil
+	}
+
+	checkDigit, err := VinCheckDigit(s)
+	if err != nil {
+		return err
+	}
+
+	if unicode.ToUpper(rune(s[constant.VinCheckDigitPosition-1])) != rune(checkDigit) {
+		return fmt.Errorf("has an invalid check digit %q at position %d, which should be %q", s[constant.VinCheckDigitPosition-1], constant.VinCheckDigitPosition, checkDigit)
+	}
+
+	return nil
+}
+
+// VinCheckDigit calculates the check digit of a VIN.  Each character is transliterated to a number, and the weighted sum
+// of the numbers is divided by 11.  The remainder is the check digit, which is X when the remainder is 10.
+// Returns (check digit, nil) on success, otherwise (0, error).
+func VinCheckDigit(vin string) (byte, error) {
+	err := Is17Characters(vin)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0
+	for i := 0; i < len(vin); i++ {
+		value, ok := vinValue(vin[i])
+		if !ok {
+			return 0, fmt.Errorf("position %d is %q, which cannot be in a VIN", i+1, vin[i])
+		}
+		sum += value * vinWeights[i]
+	}
+
+	remainder := sum % 11
+	if remainder == 10 {
+		return 'X', nil
+	}
+
+	return byte('0' + remainder), nil
+}
+
+// vinModelYearCodes are the codes for the model years from 1980 to 2009, which are repeated from 2010 to 2039.
+const vinModelYearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// vinFirstModelYear is the first model year with a code.
+const vinFirstModelYear = 1980
+
+// VinWmi gets the world manufacturer identifier (WMI) from a VIN, in capital letters.  It is the first three characters,
+// unless the third one is 9, which identifies a manufacturer that builds fewer than 500 vehicles a year.  Such a manufacturer
+// is identified by characters 12-14 as well, so its WMI has six characters.
+// Returns the WMI, or an empty string if the VIN is too short to have one.
+func VinWmi(vin string) string {
+	vin = strings.ToUpper(vin)
+
+	switch {
+	case len(vin) != constant.VinLength:
+		return ""
+	case vin[2] == '9':
+		return vin[:3] + vin[11:14]
+	default:
+		return vin[:3]
+	}
+}
+
+// VinPlantCode gets the code for the plant where the motorcycle was assembled from its VIN, in capital letters.
+// Returns the plant code, or an empty string if the VIN is too short to have one.
+func VinPlantCode(vin string) string {
+	if len(vin) != constant.VinLength {
+		return ""
+	}
+
+	return strings.ToUpper(vin[constant.VinPlantCodePosition-1 : constant.VinPlantCodePosition])
+}
+
+// VinModelYear decodes the model year of a motorcycle from its VIN.  The codes repeat every 30 years, so the latest
+// model year that is not after latestYear is chosen.
+// Returns (model year, true) on success, otherwise (0, false) when the VIN does not contain a model year code.
+func VinModelYear(vin string, latestYear int) (int, bool) {
+	if len(vin) != constant.VinLength {
+		return 0, false
+	}
+
+	index := strings.IndexByte(vinModelYearCodes, strings.ToUpper(vin)[constant.VinModelYearPosition-1])
+	if index < 0 {
+		return 0, false
+	}
+
+	year := vinFirstModelYear + index
+	for year+constant.VinModelYearCycle <= latestYear {
+		year += constant.VinModelYearCycle
+	}
+
+	return year, true
+}
+
+// vinLetterValues are the numbers that the letters A-Z are transliterated to, where a period is a letter that cannot be in a VIN.
+const vinLetterValues = "12345678.12345.7.923456789"
+
+// vinValue transliterates a character of a VIN to the number used to calculate its check digit.
+// Lower case letters are the same as capital ones, since VINs are compared without regard to case.
+// Returns (number, true) if the character can be in a VIN, otherwise (0, false).
+func vinValue(c byte) (int, bool) {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'Z' && vinLetterValues[c-'A'] != '.':
+		return int(vinLetterValues[c-'A'] - '0'), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,103 @@
+// Package entity implements unit tests for the validation of VINs.
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsVin_Valid verifies that VINs with the correct check digit are valid, including one whose check digit is X.
+func TestIsVin_Valid(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	honda := IsVin("JH2PC35051M200020")
+	checkX := IsVin("1M8GDM9AXKP042788")
+	lowerCase := IsVin("1m8gdm9axkp042788")
+
+	// ASSERT
+	assert.Nil(t, honda)
+	assert.Nil(t, checkX)
+	assert.Nil(t, lowerCase)
+}
+
+// TestIsVin_WrongLength verifies that a VIN must have 17 characters.
+func TestIsVin_WrongLength(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	err := IsVin("JH2PC35051M20002")
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestIsVin_ForbiddenLetters verifies that a VIN cannot contain I, O or Q, which are easily mistaken for 1 and 0.
+func TestIsVin_ForbiddenLetters(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	i := IsVin("JH2PC35051M2I0020")
+	o := IsVin("JH2PC35051M2O0020")
+	q := IsVin("JH2PC35051M2Q0020")
+	symbol := IsVin("JH2PC35051M2-0020")
+
+	// ASSERT
+	assert.NotNil(t, i)
+	assert.NotNil(t, o)
+	assert.NotNil(t, q)
+	assert.Contains(t, symbol.Error(), "position 13")
+}
+
+// TestIsVin_WrongCheckDigit verifies that a VIN whose ninth character is not its check digit is invalid, such as one
+// made of a single letter.
+func TestIsVin_WrongCheckDigit(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	typo := IsVin("JH2PC35051M200021")
+	garbage := IsVin("AAAAAAAAAAAAAAAAA")
+
+	// ASSERT
+	assert.Contains(t, typo.Error(), "check digit")
+	assert.NotNil(t, garbage)
+}
+
+// TestIsVin_CheckDigitNotRequired verifies that the check digit is ignored in markets that do not use it, but the characters are not.
+func TestIsVin_CheckDigitNotRequired(t *testing.T) {
+
+	// ARRANGE
+	policy := DefaultValidationPolicy()
+	policy.VinCheckDigitRequired = false
+	SetValidationPolicy(policy)
+	defer ResetValidationPolicy()
+
+	// ACT
+	typo := IsVin("JH2PC35051M200021")
+	forbidden := IsVin("JH2PC35051M2O0020")
+
+	// ASSERT
+	assert.Nil(t, typo)
+	assert.NotNil(t, forbidden)
+}
+
+// TestVinCheckDigit verifies the transliteration and weighting of a VIN's characters.
+func TestVinCheckDigit(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	honda, _ := VinCheckDigit("JH2PC35051M200020")
+	checkX, _ := VinCheckDigit("1M8GDM9AXKP042788")
+	_, err := VinCheckDigit("JH2PC35051M2O0020")
+
+	// ASSERT
+	assert.True(t, honda == '5')
+	assert.True(t, checkX == 'X')
+	assert.NotNil(t, err)
+}
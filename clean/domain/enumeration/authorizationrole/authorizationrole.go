@@ -1,6 +1,11 @@
 // Package authorizationrole defines authorization roles for the application.
 package authorizationrole
 
+import (
+	"fmt"
+	"strings"
+)
+
 // AuthorizationRole is an authorization given to an authenticated user to access a resource.
 type AuthorizationRole int
 
@@ -19,15 +24,32 @@ const (
 )
 
 // descriptions are the textual message for each authorization role value.
-var descriptions = [...]string{
-	"Undefined",
-	"None",
-	"Admin",
-	"Accounting",
-	"General",
+var descriptions = map[AuthorizationRole]string{
+	UndefinedAuthorizationRole:  "Undefined",
+	NoAuthorizationRole:         "None",
+	AdminAuthorizationRole:      "Admin",
+	AccountingAuthorizationRole: "Accounting",
+	GeneralAuthorizationRole:    "General",
 }
 
 // ToString provides a description for the authorization role value.
 func (role AuthorizationRole) ToString() string {
-	return descriptions[role-1]
+	description, ok := descriptions[role]
+	if !ok {
+		return descriptions[UndefinedAuthorizationRole]
+	}
+
+	return description
+}
+
+// Parse finds the authorization role with the description, ignoring case.
+// Returns (authorization role, nil) on success, otherwise (UndefinedAuthorizationRole, error).
+func Parse(description string) (AuthorizationRole, error) {
+	for role, text := range descriptions {
+		if role != UndefinedAuthorizationRole && strings.EqualFold(text, strings.TrimSpace(description)) {
+			return role, nil
+		}
+	}
+
+	return UndefinedAuthorizationRole, fmt.Errorf("the authorization role %q is not valid", description)
 }
@@ -0,0 +1,93 @@
+// Package distanceunit defines the units in which an odometer measures distance.
+package distanceunit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DistanceUnit is the unit in which a distance is measured.
+type DistanceUnit int
+
+// The list of valid distance unit values.
+const (
+	// UndefinedDistanceUnit is when a distance unit has not been assigned.
+	UndefinedDistanceUnit DistanceUnit = iota
+	// KilometersDistanceUnit measures distance in kilometers.
+	KilometersDistanceUnit
+	// MilesDistanceUnit measures distance in statute miles.
+	MilesDistanceUnit
+)
+
+// KilometersPerMile is the number of kilometers in a statute mile.
+const KilometersPerMile = 1.609344
+
+// descriptions are the textual message for each distance unit value.
+var descriptions = map[DistanceUnit]string{
+	UndefinedDistanceUnit:  "Undefined",
+	KilometersDistanceUnit: "km",
+	MilesDistanceUnit:      "mi",
+}
+
+// ToString provides a description for the distance unit value.
+func (unit DistanceUnit) ToString() string {
+	description, ok := descriptions[unit]
+	if !ok {
+		return descriptions[UndefinedDistanceUnit]
+	}
+
+	return description
+}
+
+// Parse finds the distance unit with the description, ignoring case.
+// Returns (distance unit, nil) on success, otherwise (UndefinedDistanceUnit, error).
+func Parse(description string) (DistanceUnit, error) {
+	for unit, text := range descriptions {
+		if unit != UndefinedDistanceUnit && strings.EqualFold(text, strings.TrimSpace(description)) {
+			return unit, nil
+		}
+	}
+
+	return UndefinedDistanceUnit, fmt.Errorf("the distance unit %q is not valid", description)
+}
+
+// Convert expresses a distance in this unit as a distance in another unit.
+// Returns the converted distance.
+func (unit DistanceUnit) Convert(distance float64, to DistanceUnit) float64 {
+	switch {
+	case unit == to:
+		return distance
+	case unit == MilesDistanceUnit && to == KilometersDistanceUnit:
+		return distance * KilometersPerMile
+	case unit == KilometersDistanceUnit && to == MilesDistanceUnit:
+		return distance / KilometersPerMile
+	default:
+		return distance
+	}
+}
+
+// MarshalText encodes the distance unit as its description, such as "km".
+// An undefined distance unit is empty, so it can be decoded again.
+func (unit DistanceUnit) MarshalText() ([]byte, error) {
+	if unit == UndefinedDistanceUnit {
+		return []byte{}, nil
+	}
+
+	return []byte(unit.ToString()), nil
+}
+
+// UnmarshalText decodes the distance unit from its description.  An empty description is undefined.
+func (unit *DistanceUnit) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*unit = UndefinedDistanceUnit
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*unit = parsed
+	return nil
+}
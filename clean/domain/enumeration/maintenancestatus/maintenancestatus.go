@@ -0,0 +1,82 @@
+// Package maintenancestatus defines how urgently a motorcycle's maintenance task needs to be performed.
+package maintenancestatus
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaintenanceStatus is how urgently a maintenance task needs to be performed.
+// The values are ordered from the least to the most urgent.
+type MaintenanceStatus int
+
+// The list of valid maintenance status values.
+const (
+	// UndefinedMaintenanceStatus is when a maintenance status has not been assigned.
+	UndefinedMaintenanceStatus MaintenanceStatus = iota
+	// OkMaintenanceStatus is when the task is not due for a while.
+	OkMaintenanceStatus
+	// UpcomingMaintenanceStatus is when the task will soon be due.
+	UpcomingMaintenanceStatus
+	// DueMaintenanceStatus is when the task has recently become due.
+	DueMaintenanceStatus
+	// OverdueMaintenanceStatus is when the task became due a while ago.
+	OverdueMaintenanceStatus
+)
+
+// descriptions are the textual message for each maintenance status value.
+var descriptions = map[MaintenanceStatus]string{
+	UndefinedMaintenanceStatus: "Undefined",
+	OkMaintenanceStatus:        "Ok",
+	UpcomingMaintenanceStatus:  "Upcoming",
+	DueMaintenanceStatus:       "Due",
+	OverdueMaintenanceStatus:   "Overdue",
+}
+
+// ToString provides a description for the maintenance status value.
+func (status MaintenanceStatus) ToString() string {
+	description, ok := descriptions[status]
+	if !ok {
+		return descriptions[UndefinedMaintenanceStatus]
+	}
+
+	return description
+}
+
+// Parse finds the maintenance status with the description, ignoring case.
+// Returns (maintenance status, nil) on success, otherwise (UndefinedMaintenanceStatus, error).
+func Parse(description string) (MaintenanceStatus, error) {
+	for status, text := range descriptions {
+		if status != UndefinedMaintenanceStatus && strings.EqualFold(text, strings.TrimSpace(description)) {
+			return status, nil
+		}
+	}
+
+	return UndefinedMaintenanceStatus, fmt.Errorf("the maintenance status %q is not valid", description)
+}
+
+// MarshalText encodes the maintenance status as its description, such as "Overdue".
+// An undefined maintenance status is empty, so it can be decoded again.
+func (status MaintenanceStatus) MarshalText() ([]byte, error) {
+	if status == UndefinedMaintenanceStatus {
+		return []byte{}, nil
+	}
+
+	return []byte(status.ToString()), nil
+}
+
+// UnmarshalText decodes the maintenance status from its description.  An empty description is undefined.
+func (status *MaintenanceStatus) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*status = UndefinedMaintenanceStatus
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*status = parsed
+	return nil
+}
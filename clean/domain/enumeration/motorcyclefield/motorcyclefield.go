@@ -0,0 +1,67 @@
+// Package motorcyclefield defines the fields by which a list of motorcycles can be sorted.
+package motorcyclefield
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MotorcycleField is a field of a motorcycle by which a list of motorcycles can be sorted.
+type MotorcycleField int
+
+// The list of valid motorcycle field values.
+const (
+	// UndefinedMotorcycleField is when a motorcycle field has not been assigned.
+	UndefinedMotorcycleField MotorcycleField = iota
+	// IDMotorcycleField is the motorcycle's ID.
+	IDMotorcycleField
+	// MakeMotorcycleField is the motorcycle's make, ignoring case.
+	MakeMotorcycleField
+	// ModelMotorcycleField is the motorcycle's model, ignoring case.
+	ModelMotorcycleField
+	// YearMotorcycleField is the motorcycle's year.
+	YearMotorcycleField
+	// VinMotorcycleField is the motorcycle's VIN, ignoring case.
+	VinMotorcycleField
+)
+
+// descriptions are the names of each motorcycle field value, as they are known to a client.
+var descriptions = map[MotorcycleField]string{
+	UndefinedMotorcycleField: "Undefined",
+	IDMotorcycleField:        "id",
+	MakeMotorcycleField:      "make",
+	ModelMotorcycleField:     "model",
+	YearMotorcycleField:      "year",
+	VinMotorcycleField:       "vin",
+}
+
+// All is the list of fields by which motorcycles can be sorted.
+var All = []interface{}{
+	IDMotorcycleField,
+	MakeMotorcycleField,
+	ModelMotorcycleField,
+	YearMotorcycleField,
+	VinMotorcycleField,
+}
+
+// ToString provides the name of the motorcycle field value.
+func (field MotorcycleField) ToString() string {
+	description, ok := descriptions[field]
+	if !ok {
+		return descriptions[UndefinedMotorcycleField]
+	}
+
+	return description
+}
+
+// Parse finds the motorcycle field with the name, ignoring case.
+// Returns (motorcycle field, nil) on success, otherwise (UndefinedMotorcycleField, error).
+func Parse(name string) (MotorcycleField, error) {
+	for field, text := range descriptions {
+		if field != UndefinedMotorcycleField && strings.EqualFold(text, strings.TrimSpace(name)) {
+			return field, nil
+		}
+	}
+
+	return UndefinedMotorcycleField, fmt.Errorf("motorcycles cannot be sorted by %q", name)
+}
@@ -15,24 +15,41 @@ const (
 	NotAuthenticated = 401
 	NotAuthorized    = 403
 	NotFound         = 404
-	InternalError    = 500
+	// Conflict is when a change was based on an out of date row version of an entity.
+	Conflict           = 409
+	PreconditionFailed = 412
+	// UnprocessableEntity is when a well formed change cannot be made to the current state of an entity.
+	UnprocessableEntity = 422
+	InternalError       = 500
+	// DuplicateKey is when a change would give an entity the unique key of another, such as its VIN.  It is a conflict
+	// with the other entity, so unlike Conflict, it is not a failed precondition when the change required a row version.
+	DuplicateKey = 1409
 )
 
 // descriptions are the textual message for each operation status value.
-var descriptions = [...]string{
-	"Undefined",
-	"Ok",
-	"Created",
-	"No Content",
-	"Found",
-	"Bad Request",
-	"Not Authenticated",
-	"Not Authorized",
-	"Not Found",
-	"Internal Error",
+var descriptions = map[OperationStatus]string{
+	Undefined:           "Undefined",
+	Ok:                  "Ok",
+	Created:             "Created",
+	NoContent:           "No Content",
+	Found:               "Found",
+	BadRequest:          "Bad Request",
+	NotAuthenticated:    "Not Authenticated",
+	NotAuthorized:       "Not Authorized",
+	NotFound:            "Not Found",
+	Conflict:            "Conflict",
+	PreconditionFailed:  "Precondition Failed",
+	UnprocessableEntity: "Unprocessable Entity",
+	InternalError:       "Internal Error",
+	DuplicateKey:        "Duplicate Key",
 }
 
 // ToString provides a description for the operation status value.
 func (status OperationStatus) ToString() string {
-	return descriptions[status-1]
+	description, ok := descriptions[status]
+	if !ok {
+		return descriptions[Undefined]
+	}
+
+	return description
 }
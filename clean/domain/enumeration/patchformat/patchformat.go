@@ -0,0 +1,55 @@
+// Package patchformat defines the formats of a patch that partially updates an entity.
+package patchformat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PatchFormat is the format of a patch, which is identified by its media type.
+type PatchFormat int
+
+// The list of valid patch format values.
+const (
+	// UndefinedPatchFormat is when a patch format has not been assigned.
+	UndefinedPatchFormat PatchFormat = iota
+	// MergePatchFormat is a JSON merge patch, as defined by RFC 7396.
+	MergePatchFormat
+	// JsonPatchFormat is a JSON patch, as defined by RFC 6902.
+	JsonPatchFormat
+)
+
+// All is the list of patch formats that are supported.
+var All = []interface{}{
+	MergePatchFormat,
+	JsonPatchFormat,
+}
+
+// descriptions are the media types of each patch format value.
+var descriptions = map[PatchFormat]string{
+	UndefinedPatchFormat: "Undefined",
+	MergePatchFormat:     "application/merge-patch+json",
+	JsonPatchFormat:      "application/json-patch+json",
+}
+
+// ToString provides the media type of the patch format value.
+func (format PatchFormat) ToString() string {
+	description, ok := descriptions[format]
+	if !ok {
+		return descriptions[UndefinedPatchFormat]
+	}
+
+	return description
+}
+
+// Parse finds the patch format with the media type, ignoring case.
+// Returns (patch format, nil) on success, otherwise (UndefinedPatchFormat, error).
+func Parse(mediaType string) (PatchFormat, error) {
+	for format, text := range descriptions {
+		if format != UndefinedPatchFormat && strings.EqualFold(text, strings.TrimSpace(mediaType)) {
+			return format, nil
+		}
+	}
+
+	return UndefinedPatchFormat, fmt.Errorf("the patch format %q is not supported", mediaType)
+}
@@ -0,0 +1,100 @@
+// Package permission defines the permissions that are required to perform the use cases of the application.
+package permission
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Permission is the right to perform a use case, which is granted to authorization roles.
+type Permission int
+
+// The list of valid permission values.
+const (
+	// UndefinedPermission is when a permission has not been assigned.
+	UndefinedPermission Permission = iota
+	// ListMotorcyclesPermission permits getting the list of motorcycles.
+	ListMotorcyclesPermission
+	// GetMotorcyclePermission permits getting a particular motorcycle.
+	GetMotorcyclePermission
+	// InsertMotorcyclePermission permits adding a new motorcycle.
+	InsertMotorcyclePermission
+	// UpdateMotorcyclePermission permits changing an existing motorcycle.
+	UpdateMotorcyclePermission
+	// DeleteMotorcyclePermission permits removing an existing motorcycle.
+	DeleteMotorcyclePermission
+	// AccessAllMotorcyclesPermission permits access to every user's motorcycles, rather than only the user's own.
+	AccessAllMotorcyclesPermission
+	// ListOdometerReadingsPermission permits getting the list of a motorcycle's odometer readings.
+	ListOdometerReadingsPermission
+	// InsertOdometerReadingPermission permits adding a new odometer reading to a motorcycle.
+	InsertOdometerReadingPermission
+	// DeleteOdometerReadingPermission permits removing a motorcycle's latest odometer reading.
+	DeleteOdometerReadingPermission
+	// ListServiceRecordsPermission permits getting the list of a motorcycle's service records.
+	ListServiceRecordsPermission
+	// GetServiceRecordPermission permits getting a particular service record.
+	GetServiceRecordPermission
+	// InsertServiceRecordPermission permits adding a new service record to a motorcycle.
+	InsertServiceRecordPermission
+	// UpdateServiceRecordPermission permits changing an existing service record.
+	UpdateServiceRecordPermission
+	// DeleteServiceRecordPermission permits removing a service record.
+	DeleteServiceRecordPermission
+	// GetMaintenanceDuePermission permits getting the maintenance that is due on a motorcycle.
+	GetMaintenanceDuePermission
+	// ListRemindersPermission permits getting the list of a motorcycle's reminders.
+	ListRemindersPermission
+	// SnoozeReminderPermission permits postponing a reminder.
+	SnoozeReminderPermission
+	// AcknowledgeReminderPermission permits acknowledging a reminder, so its owner is not notified again.
+	AcknowledgeReminderPermission
+	// DecodeVinPermission permits decoding the manufacturer, model year and plant from a VIN.
+	DecodeVinPermission
+)
+
+// descriptions are the textual message for each permission value.
+var descriptions = map[Permission]string{
+	UndefinedPermission:             "Undefined",
+	ListMotorcyclesPermission:       "ListMotorcycles",
+	GetMotorcyclePermission:         "GetMotorcycle",
+	InsertMotorcyclePermission:      "InsertMotorcycle",
+	UpdateMotorcyclePermission:      "UpdateMotorcycle",
+	DeleteMotorcyclePermission:      "DeleteMotorcycle",
+	AccessAllMotorcyclesPermission:  "AccessAllMotorcycles",
+	ListOdometerReadingsPermission:  "ListOdometerReadings",
+	InsertOdometerReadingPermission: "InsertOdometerReading",
+	DeleteOdometerReadingPermission: "DeleteOdometerReading",
+	ListServiceRecordsPermission:    "ListServiceRecords",
+	GetServiceRecordPermission:      "GetServiceRecord",
+	InsertServiceRecordPermission:   "InsertServiceRecord",
+	UpdateServiceRecordPermission:   "UpdateServiceRecord",
+	DeleteServiceRecordPermission:   "DeleteServiceRecord",
+	GetMaintenanceDuePermission:     "GetMaintenanceDue",
+	ListRemindersPermission:         "ListReminders",
+	SnoozeReminderPermission:        "SnoozeReminder",
+	AcknowledgeReminderPermission:   "AcknowledgeReminder",
+	DecodeVinPermission:             "DecodeVin",
+}
+
+// ToString provides a description for the permission value.
+func (permission Permission) ToString() string {
+	description, ok := descriptions[permission]
+	if !ok {
+		return descriptions[UndefinedPermission]
+	}
+
+	return description
+}
+
+// Parse finds the permission with the description, ignoring case.
+// Returns (permission, nil) on success, otherwise (UndefinedPermission, error).
+func Parse(description string) (Permission, error) {
+	for permission, text := range descriptions {
+		if permission != UndefinedPermission && strings.EqualFold(text, strings.TrimSpace(description)) {
+			return permission, nil
+		}
+	}
+
+	return UndefinedPermission, fmt.Errorf("the permission %q is not valid", description)
+}
@@ -0,0 +1,81 @@
+// Package readingsource defines where an odometer reading came from.
+package readingsource
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReadingSource is where an odometer reading came from.
+type ReadingSource int
+
+// The list of valid reading source values.
+const (
+	// UndefinedReadingSource is when a reading source has not been assigned.
+	UndefinedReadingSource ReadingSource = iota
+	// ManualReadingSource is a reading that was entered by the rider.
+	ManualReadingSource
+	// ServiceReadingSource is a reading that was recorded when the motorcycle was serviced.
+	ServiceReadingSource
+	// TelematicsReadingSource is a reading that was reported by a device on the motorcycle.
+	TelematicsReadingSource
+	// ImportReadingSource is a reading that was imported from another system.
+	ImportReadingSource
+)
+
+// descriptions are the textual message for each reading source value.
+var descriptions = map[ReadingSource]string{
+	UndefinedReadingSource:  "Undefined",
+	ManualReadingSource:     "Manual",
+	ServiceReadingSource:    "Service",
+	TelematicsReadingSource: "Telematics",
+	ImportReadingSource:     "Import",
+}
+
+// ToString provides a description for the reading source value.
+func (source ReadingSource) ToString() string {
+	description, ok := descriptions[source]
+	if !ok {
+		return descriptions[UndefinedReadingSource]
+	}
+
+	return description
+}
+
+// Parse finds the reading source with the description, ignoring case.
+// Returns (reading source, nil) on success, otherwise (UndefinedReadingSource, error).
+func Parse(description string) (ReadingSource, error) {
+	for source, text := range descriptions {
+		if source != UndefinedReadingSource && strings.EqualFold(text, strings.TrimSpace(description)) {
+			return source, nil
+		}
+	}
+
+	return UndefinedReadingSource, fmt.Errorf("the reading source %q is not valid", description)
+}
+
+// MarshalText encodes the reading source as its description, such as "Manual".
+// An undefined reading source is empty, so it can be decoded again.
+func (source ReadingSource) MarshalText() ([]byte, error) {
+	if source == UndefinedReadingSource {
+		return []byte{}, nil
+	}
+
+	return []byte(source.ToString()), nil
+}
+
+// UnmarshalText decodes the reading source from its description.  An empty description is undefined.
+func (source *ReadingSource) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*source = UndefinedReadingSource
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*source = parsed
+	return nil
+}
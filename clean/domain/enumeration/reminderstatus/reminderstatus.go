@@ -0,0 +1,89 @@
+// Package reminderstatus defines the stages in the life of a reminder.
+package reminderstatus
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReminderStatus is the stage that a reminder has reached.
+type ReminderStatus int
+
+// The list of valid reminder status values.
+const (
+	// UndefinedReminderStatus is when a reminder status has not been assigned.
+	UndefinedReminderStatus ReminderStatus = iota
+	// PendingReminderStatus is when the reminder has been created, but the owner has not been notified yet.
+	PendingReminderStatus
+	// NotifiedReminderStatus is when the owner has been notified of the reminder.
+	NotifiedReminderStatus
+	// SnoozedReminderStatus is when the owner has asked to be notified again later.
+	SnoozedReminderStatus
+	// AcknowledgedReminderStatus is when the owner has acknowledged the reminder, so they are not notified again.
+	AcknowledgedReminderStatus
+)
+
+// All is the list of reminder status values that can be assigned to a reminder.
+var All = []interface{}{
+	PendingReminderStatus,
+	NotifiedReminderStatus,
+	SnoozedReminderStatus,
+	AcknowledgedReminderStatus,
+}
+
+// descriptions are the textual message for each reminder status value.
+var descriptions = map[ReminderStatus]string{
+	UndefinedReminderStatus:    "Undefined",
+	PendingReminderStatus:      "Pending",
+	NotifiedReminderStatus:     "Notified",
+	SnoozedReminderStatus:      "Snoozed",
+	AcknowledgedReminderStatus: "Acknowledged",
+}
+
+// ToString provides a description for the reminder status value.
+func (status ReminderStatus) ToString() string {
+	description, ok := descriptions[status]
+	if !ok {
+		return descriptions[UndefinedReminderStatus]
+	}
+
+	return description
+}
+
+// Parse finds the reminder status with the description, ignoring case.
+// Returns (reminder status, nil) on success, otherwise (UndefinedReminderStatus, error).
+func Parse(description string) (ReminderStatus, error) {
+	for status, text := range descriptions {
+		if status != UndefinedReminderStatus && strings.EqualFold(text, strings.TrimSpace(description)) {
+			return status, nil
+		}
+	}
+
+	return UndefinedReminderStatus, fmt.Errorf("the reminder status %q is not valid", description)
+}
+
+// MarshalText encodes the reminder status as its description, such as "Snoozed".
+// An undefined reminder status is empty, so it can be decoded again.
+func (status ReminderStatus) MarshalText() ([]byte, error) {
+	if status == UndefinedReminderStatus {
+		return []byte{}, nil
+	}
+
+	return []byte(status.ToString()), nil
+}
+
+// UnmarshalText decodes the reminder status from its description.  An empty description is undefined.
+func (status *ReminderStatus) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*status = UndefinedReminderStatus
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*status = parsed
+	return nil
+}
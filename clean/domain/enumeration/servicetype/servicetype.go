@@ -0,0 +1,101 @@
+// Package servicetype defines the kinds of service and maintenance that are performed on a motorcycle.
+package servicetype
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ServiceType is a kind of service or maintenance that is performed on a motorcycle.
+type ServiceType int
+
+// The list of valid service type values.
+const (
+	// UndefinedServiceType is when a service type has not been assigned.
+	UndefinedServiceType ServiceType = iota
+	// OilChangeServiceType is a change of the engine oil and its filter.
+	OilChangeServiceType
+	// ChainAdjustmentServiceType is an adjustment and lubrication of the drive chain.
+	ChainAdjustmentServiceType
+	// ValveCheckServiceType is an inspection and adjustment of the valve clearances.
+	ValveCheckServiceType
+	// TireReplacementServiceType is a replacement of one or both tires.
+	TireReplacementServiceType
+	// BrakeServiceType is a replacement of the brake pads or fluid.
+	BrakeServiceType
+	// InspectionServiceType is a general inspection, such as a safety or annual inspection.
+	InspectionServiceType
+	// OtherServiceType is any other service or repair.
+	OtherServiceType
+)
+
+// descriptions are the textual message for each service type value.
+var descriptions = map[ServiceType]string{
+	UndefinedServiceType:       "Undefined",
+	OilChangeServiceType:       "OilChange",
+	ChainAdjustmentServiceType: "ChainAdjustment",
+	ValveCheckServiceType:      "ValveCheck",
+	TireReplacementServiceType: "TireReplacement",
+	BrakeServiceType:           "Brake",
+	InspectionServiceType:      "Inspection",
+	OtherServiceType:           "Other",
+}
+
+// All is the list of defined service types.
+var All = []interface{}{
+	OilChangeServiceType,
+	ChainAdjustmentServiceType,
+	ValveCheckServiceType,
+	TireReplacementServiceType,
+	BrakeServiceType,
+	InspectionServiceType,
+	OtherServiceType,
+}
+
+// ToString provides a description for the service type value.
+func (serviceType ServiceType) ToString() string {
+	description, ok := descriptions[serviceType]
+	if !ok {
+		return descriptions[UndefinedServiceType]
+	}
+
+	return description
+}
+
+// Parse finds the service type with the description, ignoring case.
+// Returns (service type, nil) on success, otherwise (UndefinedServiceType, error).
+func Parse(description string) (ServiceType, error) {
+	for serviceType, text := range descriptions {
+		if serviceType != UndefinedServiceType && strings.EqualFold(text, strings.TrimSpace(description)) {
+			return serviceType, nil
+		}
+	}
+
+	return UndefinedServiceType, fmt.Errorf("the service type %q is not valid", description)
+}
+
+// MarshalText encodes the service type as its description, such as "OilChange".
+// An undefined service type is empty, so it can be decoded again.
+func (serviceType ServiceType) MarshalText() ([]byte, error) {
+	if serviceType == UndefinedServiceType {
+		return []byte{}, nil
+	}
+
+	return []byte(serviceType.ToString()), nil
+}
+
+// UnmarshalText decodes the service type from its description.  An empty description is undefined.
+func (serviceType *ServiceType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*serviceType = UndefinedServiceType
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*serviceType = parsed
+	return nil
+}
@@ -0,0 +1,81 @@
+// Package vincheck defines how the make and year of a new motorcycle are compared with those decoded from its VIN.
+package vincheck
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VinCheck is how the make and year of a new motorcycle are compared with those decoded from its VIN.
+type VinCheck int
+
+// The list of valid VIN check values.
+const (
+	// UndefinedVinCheck is when a VIN check has not been assigned, so the VIN is not decoded.
+	UndefinedVinCheck VinCheck = iota
+	// FillVinCheck fills in a make or year that is missing from those decoded from the VIN, and verifies the ones that are present.
+	FillVinCheck
+	// VerifyVinCheck verifies that the make and year match those decoded from the VIN.
+	VerifyVinCheck
+)
+
+// All is the list of VIN check values that can be requested.
+var All = []interface{}{
+	FillVinCheck,
+	VerifyVinCheck,
+}
+
+// descriptions are the textual message for each VIN check value.
+var descriptions = map[VinCheck]string{
+	UndefinedVinCheck: "Undefined",
+	FillVinCheck:      "Fill",
+	VerifyVinCheck:    "Verify",
+}
+
+// ToString provides a description for the VIN check value.
+func (check VinCheck) ToString() string {
+	description, ok := descriptions[check]
+	if !ok {
+		return descriptions[UndefinedVinCheck]
+	}
+
+	return description
+}
+
+// Parse finds the VIN check with the description, ignoring case.
+// Returns (VIN check, nil) on success, otherwise (UndefinedVinCheck, error).
+func Parse(description string) (VinCheck, error) {
+	for check, text := range descriptions {
+		if check != UndefinedVinCheck && strings.EqualFold(text, strings.TrimSpace(description)) {
+			return check, nil
+		}
+	}
+
+	return UndefinedVinCheck, fmt.Errorf("the VIN check %q is not valid", description)
+}
+
+// MarshalText encodes the VIN check as its description, such as "Fill".
+// An undefined VIN check is empty, so it can be decoded again.
+func (check VinCheck) MarshalText() ([]byte, error) {
+	if check == UndefinedVinCheck {
+		return []byte{}, nil
+	}
+
+	return []byte(check.ToString()), nil
+}
+
+// UnmarshalText decodes the VIN check from its description.  An empty description is undefined.
+func (check *VinCheck) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*check = UndefinedVinCheck
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*check = parsed
+	return nil
+}
@@ -0,0 +1,331 @@
+// Package patch applies a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) to a JSON document.
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The operations of a JSON patch.
+const (
+	addOperation     = "add"
+	removeOperation  = "remove"
+	replaceOperation = "replace"
+	moveOperation    = "move"
+	copyOperation    = "copy"
+	testOperation    = "test"
+)
+
+// Operation is one of the changes in a JSON patch.  Its locations are JSON pointers, as defined by RFC 6901.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JsonPatch is a JSON patch, which is a list of operations that are applied in order.  When one of them fails,
+// none of the changes are made.
+type JsonPatch struct {
+	Operations []Operation
+}
+
+// NewJsonPatch creates a new instance of a JsonPatch from its JSON document, which is an array of operations.
+// Returns (JsonPatch, nil) on success, otherwise (nil, error).
+func NewJsonPatch(contents []byte) (*JsonPatch, error) {
+	operations := make([]Operation, 0)
+	err := json.Unmarshal(contents, &operations)
+	if err != nil {
+		return nil, fmt.Errorf("the JSON patch is not an array of operations: %s", err.Error())
+	}
+
+	for i, operation := range operations {
+		err = operation.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("operation %d of the JSON patch is not valid: %s", i+1, err.Error())
+		}
+	}
+
+	// All okay
+	return &JsonPatch{Operations: operations}, nil
+}
+
+// Validate verifies that an operation is known, and has the members that it requires.
+// Returns nil if the operation is valid, otherwise an error.
+func (operation Operation) Validate() error {
+	_, err := parsePointer(operation.Path)
+	if err != nil {
+		return err
+	}
+
+	switch operation.Op {
+	case addOperation, replaceOperation, testOperation:
+		if len(operation.Value) == 0 {
+			return fmt.Errorf("the %s operation requires a value", operation.Op)
+		}
+		var value interface{}
+		return json.Unmarshal(operation.Value, &value)
+	case moveOperation, copyOperation:
+		_, err = parsePointer(operation.From)
+		if err != nil {
+			return err
+		}
+		if operation.Op == moveOperation && strings.HasPrefix(operation.Path, operation.From+"/") {
+			return fmt.Errorf("%q cannot be moved into one of its children", operation.From)
+		}
+		return nil
+	case removeOperation:
+		return nil
+	default:
+		return fmt.Errorf("the operation %q is not supported", operation.Op)
+	}
+}
+
+// Apply implements Patch.Apply().
+func (patch *JsonPatch) Apply(document []byte) ([]byte, error) {
+	var target interface{}
+	err := json.Unmarshal(document, &target)
+	if err != nil {
+		return nil, fmt.Errorf("the document is not valid JSON: %s", err.Error())
+	}
+
+	for i, operation := range patch.Operations {
+		target, err = operation.apply(target)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d of the JSON patch failed: %s", i+1, err.Error())
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+// apply performs the operation on the document.
+// Returns (changed document, nil) on success, otherwise (nil, error).
+func (operation Operation) apply(document interface{}) (interface{}, error) {
+	path, _ := parsePointer(operation.Path)
+
+	switch operation.Op {
+	case addOperation:
+		var value interface{}
+		json.Unmarshal(operation.Value, &value)
+		return add(document, path, value)
+	case removeOperation:
+		document, _, err := remove(document, path)
+		return document, err
+	case replaceOperation:
+		var value interface{}
+		json.Unmarshal(operation.Value, &value)
+		document, _, err := remove(document, path)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	case moveOperation:
+		from, _ := parsePointer(operation.From)
+		document, value, err := remove(document, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	case copyOperation:
+		from, _ := parsePointer(operation.From)
+		value, err := get(document, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, path, deepCopy(value))
+	default:
+		var expected interface{}
+		json.Unmarshal(operation.Value, &expected)
+		actual, err := get(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			return nil, fmt.Errorf("the value at %q is not %s", operation.Path, string(operation.Value))
+		}
+		return document, nil
+	}
+}
+
+// parsePointer splits a JSON pointer into the names of the members or the indexes of the elements that it refers to.
+// The empty pointer refers to the whole document.
+// Returns (reference tokens, nil) on success, otherwise (nil, error).
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("the path %q is not a JSON pointer", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+
+	return tokens, nil
+}
+
+// get finds the value at the path in the document.
+// Returns (value, nil) on success, otherwise (nil, error) when the path does not exist.
+func get(document interface{}, path []string) (interface{}, error) {
+	value := document
+	for i, token := range path {
+		switch container := value.(type) {
+		case map[string]interface{}:
+			member, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("the path %q does not exist", formatPointer(path[:i+1]))
+			}
+			value = member
+		case []interface{}:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			value = container[index]
+		default:
+			return nil, fmt.Errorf("the path %q does not exist", formatPointer(path[:i+1]))
+		}
+	}
+
+	return value, nil
+}
+
+// add inserts the value at the path in the document.  A member of an object is replaced, and an element of an array is
+// inserted before the one at the index, or at the end of the array when the index is "-".
+// Returns (changed document, nil) on success, otherwise (nil, error) when the parent of the path does not exist.
+func add(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(document, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[token] = value
+		return document, nil
+	case []interface{}:
+		index := len(container)
+		if token != "-" {
+			index, err = arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+		}
+		elements := append(container[:index:index], append([]interface{}{value}, container[index:]...)...)
+		return replaceParent(document, path[:len(path)-1], elements)
+	default:
+		return nil, fmt.Errorf("the path %q does not exist", formatPointer(path[:len(path)-1]))
+	}
+}
+
+// remove deletes the value at the path in the document.
+// Returns (changed document, removed value, nil) on success, otherwise (nil, nil, error) when the path does not exist.
+func remove(document interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, document, nil
+	}
+
+	parent, err := get(document, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		value, ok := container[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("the path %q does not exist", formatPointer(path))
+		}
+		delete(container, token)
+		return document, value, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(container)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := container[index]
+		elements := append(container[:index:index], container[index+1:]...)
+		document, err = replaceParent(document, path[:len(path)-1], elements)
+		return document, value, err
+	default:
+		return nil, nil, fmt.Errorf("the path %q does not exist", formatPointer(path))
+	}
+}
+
+// replaceParent replaces the array at the path in the document, since an array cannot grow or shrink in place.
+// Returns (changed document, nil) on success, otherwise (nil, error).
+func replaceParent(document interface{}, path []string, elements []interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return elements, nil
+	}
+
+	grandparent, err := get(document, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+	switch container := grandparent.(type) {
+	case map[string]interface{}:
+		container[token] = elements
+	case []interface{}:
+		index, _ := arrayIndex(token, len(container)-1)
+		container[index] = elements
+	}
+
+	return document, nil
+}
+
+// arrayIndex parses the index of an element of an array, which cannot be greater than max.
+// Returns (index, nil) on success, otherwise (0, error).
+func arrayIndex(token string, max int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("the array index %q does not exist", token)
+	}
+
+	return index, nil
+}
+
+// formatPointer joins reference tokens into a JSON pointer.
+func formatPointer(path []string) string {
+	pointer := ""
+	for _, token := range path {
+		pointer += "/" + strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+	}
+
+	return pointer
+}
+
+// deepCopy copies a value decoded from JSON, so a copied object or array is not shared with the original.
+func deepCopy(value interface{}) interface{} {
+	switch original := value.(type) {
+	case map[string]interface{}:
+		members := make(map[string]interface{}, len(original))
+		for name, member := range original {
+			members[name] = deepCopy(member)
+		}
+		return members
+	case []interface{}:
+		elements := make([]interface{}, len(original))
+		for i, element := range original {
+			elements[i] = deepCopy(element)
+		}
+		return elements
+	default:
+		return value
+	}
+}
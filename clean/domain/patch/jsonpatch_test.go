@@ -0,0 +1,90 @@
+// Package patch implements unit tests for the JsonPatch.
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestJsonPatch_Apply verifies each of the operations of a JSON patch, which are applied in order.
+func TestJsonPatch_Apply(t *testing.T) {
+
+	// ARRANGE
+	document := []byte(`{"make": "Honda", "model": "Shadow", "parts": ["filter", "plug"], "a/b": 1}`)
+	patch, err := NewJsonPatch([]byte(`[
+		{"op": "test", "path": "/make", "value": "Honda"},
+		{"op": "replace", "path": "/model", "value": "Gold Wing"},
+		{"op": "add", "path": "/parts/1", "value": "oil"},
+		{"op": "add", "path": "/parts/-", "value": "chain"},
+		{"op": "remove", "path": "/parts/0"},
+		{"op": "copy", "from": "/parts", "path": "/spares"},
+		{"op": "move", "from": "/a~1b", "path": "/count"}]`))
+
+	// ACT
+	patched, applyErr := patch.Apply(document)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.Nil(t, applyErr)
+	assert.JSONEq(t, `{"make": "Honda", "model": "Gold Wing", "parts": ["oil", "plug", "chain"], "spares": ["oil", "plug", "chain"], "count": 1}`, string(patched))
+}
+
+// TestJsonPatch_FailedTest verifies that none of the changes are made when a test fails.
+func TestJsonPatch_FailedTest(t *testing.T) {
+
+	// ARRANGE
+	document := []byte(`{"make": "Honda", "model": "Shadow"}`)
+	patch, _ := NewJsonPatch([]byte(`[
+		{"op": "replace", "path": "/model", "value": "Gold Wing"},
+		{"op": "test", "path": "/make", "value": "Yamaha"}]`))
+
+	// ACT
+	patched, err := patch.Apply(document)
+
+	// ASSERT
+	assert.Nil(t, patched)
+	assert.Contains(t, err.Error(), "operation 2")
+}
+
+// TestJsonPatch_MissingPath verifies that a value that does not exist cannot be removed or replaced.
+func TestJsonPatch_MissingPath(t *testing.T) {
+
+	// ARRANGE
+	document := []byte(`{"make": "Honda", "parts": []}`)
+	remove, _ := NewJsonPatch([]byte(`[{"op": "remove", "path": "/model"}]`))
+	replace, _ := NewJsonPatch([]byte(`[{"op": "replace", "path": "/parts/0", "value": "oil"}]`))
+	add, _ := NewJsonPatch([]byte(`[{"op": "add", "path": "/tags/color", "value": "red"}]`))
+
+	// ACT
+	_, removeErr := remove.Apply(document)
+	_, replaceErr := replace.Apply(document)
+	_, addErr := add.Apply(document)
+
+	// ASSERT
+	assert.NotNil(t, removeErr)
+	assert.NotNil(t, replaceErr)
+	assert.NotNil(t, addErr)
+}
+
+// TestNewJsonPatch_Invalid verifies that a JSON patch must be an array of known operations with the members that they require.
+func TestNewJsonPatch_Invalid(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, notArray := NewJsonPatch([]byte(`{"op": "remove", "path": "/model"}`))
+	_, unknown := NewJsonPatch([]byte(`[{"op": "delete", "path": "/model"}]`))
+	_, noValue := NewJsonPatch([]byte(`[{"op": "add", "path": "/model"}]`))
+	_, nullValue := NewJsonPatch([]byte(`[{"op": "add", "path": "/model", "value": null}]`))
+	_, badPointer := NewJsonPatch([]byte(`[{"op": "remove", "path": "model"}]`))
+	_, intoChild := NewJsonPatch([]byte(`[{"op": "move", "from": "/parts", "path": "/parts/0"}]`))
+
+	// ASSERT
+	assert.NotNil(t, notArray)
+	assert.NotNil(t, unknown)
+	assert.NotNil(t, noValue)
+	assert.Nil(t, nullValue)
+	assert.NotNil(t, badPointer)
+	assert.NotNil(t, intoChild)
+}
@@ -0,0 +1,83 @@
+// Package patch applies a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) to a JSON document.
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/patchformat"
+)
+
+// Patch is a set of changes to a JSON document.
+type Patch interface {
+	// Apply makes the changes to the document.
+	// Returns (changed document, nil) on success, otherwise (nil, error) when the changes cannot be made to the document.
+	Apply(document []byte) ([]byte, error)
+}
+
+// Parse decodes a patch in the format.
+// Returns (patch, nil) on success, otherwise (nil, error) when the patch is not a valid document in the format.
+func Parse(format patchformat.PatchFormat, contents []byte) (Patch, error) {
+	switch format {
+	case patchformat.MergePatchFormat:
+		return NewMergePatch(contents)
+	case patchformat.JsonPatchFormat:
+		return NewJsonPatch(contents)
+	default:
+		return nil, fmt.Errorf("the patch format %q is not supported", format.ToString())
+	}
+}
+
+// MergePatch is a JSON merge patch, which describes the changes with a document that looks like the one being changed.
+// A member with a null value is removed, and any other member replaces the one with the same name.
+type MergePatch struct {
+	changes interface{}
+}
+
+// NewMergePatch creates a new instance of a MergePatch from its JSON document.
+// Returns (MergePatch, nil) on success, otherwise (nil, error).
+func NewMergePatch(contents []byte) (*MergePatch, error) {
+	var changes interface{}
+	err := json.Unmarshal(contents, &changes)
+	if err != nil {
+		return nil, fmt.Errorf("the merge patch is not valid JSON: %s", err.Error())
+	}
+
+	// All okay
+	return &MergePatch{changes: changes}, nil
+}
+
+// Apply implements Patch.Apply().
+func (patch *MergePatch) Apply(document []byte) ([]byte, error) {
+	var target interface{}
+	err := json.Unmarshal(document, &target)
+	if err != nil {
+		return nil, fmt.Errorf("the document is not valid JSON: %s", err.Error())
+	}
+
+	return json.Marshal(mergeValue(target, patch.changes))
+}
+
+// mergeValue merges the changes into the target, as defined by RFC 7396.
+// Returns the merged value.
+func mergeValue(target interface{}, changes interface{}) interface{} {
+	changedMembers, ok := changes.(map[string]interface{})
+	if !ok {
+		return changes
+	}
+
+	members, ok := target.(map[string]interface{})
+	if !ok {
+		members = make(map[string]interface{})
+	}
+
+	for name, value := range changedMembers {
+		if value == nil {
+			delete(members, name)
+			continue
+		}
+		members[name] = mergeValue(members[name], value)
+	}
+
+	return members
+}
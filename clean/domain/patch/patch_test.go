@@ -0,0 +1,54 @@
+// Package patch implements unit tests for the MergePatch.
+package patch
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/patchformat"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMergePatch_Apply verifies that a member is replaced, a null member is removed, and an object is merged recursively.
+func TestMergePatch_Apply(t *testing.T) {
+
+	// ARRANGE
+	document := []byte(`{"make": "Honda", "model": "Shadow", "year": 2006, "tags": {"color": "red", "style": "cruiser"}}`)
+	patch, _ := NewMergePatch([]byte(`{"model": "Gold Wing", "year": null, "tags": {"color": "black"}}`))
+
+	// ACT
+	patched, err := patch.Apply(document)
+
+	// ASSERT
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"make": "Honda", "model": "Gold Wing", "tags": {"color": "black", "style": "cruiser"}}`, string(patched))
+}
+
+// TestMergePatch_NotJson verifies that a merge patch must be valid JSON.
+func TestMergePatch_NotJson(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := NewMergePatch([]byte(`{"model": `))
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestParse verifies that a patch is decoded in the format that was requested.
+func TestParse(t *testing.T) {
+
+	// ARRANGE
+	contents := []byte(`[{"op": "remove", "path": "/model"}]`)
+
+	// ACT
+	jsonPatch, jsonErr := Parse(patchformat.JsonPatchFormat, contents)
+	_, mergeErr := Parse(patchformat.MergePatchFormat, contents)
+	_, undefinedErr := Parse(patchformat.UndefinedPatchFormat, contents)
+
+	// ASSERT
+	assert.Nil(t, jsonErr)
+	assert.IsType(t, &JsonPatch{}, jsonPatch)
+	assert.Nil(t, mergeErr)
+	assert.NotNil(t, undefinedErr)
+}
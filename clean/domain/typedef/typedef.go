@@ -3,3 +3,7 @@ package typedef
 
 // ID is the primary key for entities.
 type ID int64
+
+// RowVersion is the version of an entity, which is incremented each time that the entity is updated.
+// It is used to detect conflicting changes (optimistic concurrency).
+type RowVersion int64
@@ -0,0 +1,155 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Acknowledge a reminder of a motorcycle.
+
+DESCRIPTION
+User accesses the system to acknowledge a reminder, so they are not notified of it again.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to acknowledge a reminder.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+A Reminder with the ID exists for the motorcycle.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User will not be notified of the reminder again.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Reminders..." from the menu.
+2. System displays a view in which the user selects a reminder.
+3. User click the "Acknowledge" button.
+4. System updates the reminder in the reminder repository, and displays a confirmation message.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to acknowledge a reminder.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to acknowledge a reminder.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle or reminder with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that the reminder does not exist.
+	   The User clicks the "OK" button, and returns to the primary view.
+
+(3d) The reminder has already been acknowledged.
+       System displays a confirmation message, without changing the reminder.
+*/
+
+// AcknowledgeReminderInteractor is a use case for acknowledging a reminder of a motorcycle.
+type AcknowledgeReminderInteractor struct {
+	MotorcycleRepository contract.MotorcycleRepository
+	ReminderRepository   contract.ReminderRepository
+	AuthService          contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
+}
+
+// NewAcknowledgeReminderInteractor creates a new instance of a AcknowledgeReminderInteractor.
+// Returns (nil, error) when there is an error, otherwise (AcknowledgeReminderInteractor, nil).
+func NewAcknowledgeReminderInteractor(motorcycleRepository contract.MotorcycleRepository, reminderRepository contract.ReminderRepository, authService contract.AuthService) (*AcknowledgeReminderInteractor, error) {
+
+	interactor := &AcknowledgeReminderInteractor{
+		MotorcycleRepository: motorcycleRepository,
+		ReminderRepository:   reminderRepository,
+		AuthService:          authService,
+		Logger:               nopLogger{},
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a AcknowledgeReminderInteractor's fields contain valid data.
+// Returns nil if the AcknowledgeReminderInteractor contains valid data, otherwise an error.
+func (interactor AcknowledgeReminderInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ReminderRepository is required and cannot be null.
+		validation.Field(&interactor.ReminderRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *AcknowledgeReminderInteractor) Handle(requestMessage *request.AcknowledgeReminderRequest) (*response.AcknowledgeReminderResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewAcknowledgeReminderResponse(requestMessage.ID, constant.AnyRowVersion, operationstatus.NotAuthenticated, errors.New("acknowledge operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.AcknowledgeReminderPermission) {
+		return response.NewAcknowledgeReminderResponse(requestMessage.ID, constant.AnyRowVersion, operationstatus.NotAuthorized, errors.New("acknowledge operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Get the reminder, which must belong to a motorcycle that the user can access.
+	reminder, status, err := findAccessibleReminder(interactor.MotorcycleRepository, interactor.ReminderRepository, interactor.AuthService,
+		requestMessage.MotorcycleID, requestMessage.ID)
+	if err != nil {
+		return response.NewAcknowledgeReminderResponse(requestMessage.ID, constant.AnyRowVersion, status, err)
+	}
+
+	// Acknowledging a reminder again does not change it.
+	if reminder.Status == reminderstatus.AcknowledgedReminderStatus {
+		return response.NewAcknowledgeReminderResponse(requestMessage.ID, reminder.RowVersion, operationstatus.Ok, nil)
+	}
+
+	// Update the reminder in the repository, as long as nobody else has changed it since it was read.
+	reminder.Acknowledge(time.Now())
+	updated, status, err := interactor.ReminderRepository.Update(requestMessage.ID, reminder)
+	if err != nil {
+		return response.NewAcknowledgeReminderResponse(requestMessage.ID, constant.AnyRowVersion, status, err)
+	}
+
+	// Save the changes.
+	status, err = interactor.ReminderRepository.Save()
+	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("reminderId", requestMessage.ID).Error(err, "Failed to save the acknowledged reminder.")
+		return response.NewAcknowledgeReminderResponse(requestMessage.ID, constant.AnyRowVersion, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewAcknowledgeReminderResponse(requestMessage.ID, updated.RowVersion, operationstatus.Ok, nil)
+}
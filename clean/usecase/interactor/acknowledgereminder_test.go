@@ -0,0 +1,62 @@
+// Package interactor implements unit tests for the AcknowledgeReminderInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAcknowledgeReminderInteractor_Handle verifies that an acknowledged reminder cannot be snoozed, and acknowledging it again is harmless.
+func TestAcknowledgeReminderInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	id := insertReminder(reminders, motorcycleID, "Annual service")
+	interactor, _ := NewAcknowledgeReminderInteractor(motorcycles, reminders, alice)
+	snoozeInteractor, _ := NewSnoozeReminderInteractor(motorcycles, reminders, alice)
+	acknowledgeRequest, _ := request.NewAcknowledgeReminderRequest(motorcycleID, id)
+	snoozeRequest, _ := request.NewSnoozeReminderRequest(motorcycleID, id, time.Now().UTC().AddDate(0, 0, 3))
+
+	// ACT
+	acknowledgeResponse, _ := interactor.Handle(acknowledgeRequest)
+	repeatResponse, _ := interactor.Handle(acknowledgeRequest)
+	snoozeResponse, _ := snoozeInteractor.Handle(snoozeRequest)
+	reminder, _, _ := reminders.FindByID(id)
+
+	// ASSERT
+	assert.True(t, acknowledgeResponse.Status == operationstatus.Ok)
+	assert.True(t, repeatResponse.Status == operationstatus.Ok)
+	assert.True(t, snoozeResponse.Status == operationstatus.BadRequest)
+	assert.True(t, reminder.Status == reminderstatus.AcknowledgedReminderStatus)
+	assert.False(t, reminder.AcknowledgedUtc.IsZero())
+}
+
+// TestAcknowledgeReminderInteractor_OtherOwner verifies that a user cannot acknowledge a reminder of another user's motorcycle.
+func TestAcknowledgeReminderInteractor_OtherOwner(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	id := insertReminder(reminders, motorcycleID, "Annual service")
+	interactor, _ := NewAcknowledgeReminderInteractor(motorcycles, reminders, bob)
+	acknowledgeRequest, _ := request.NewAcknowledgeReminderRequest(motorcycleID, id)
+
+	// ACT
+	acknowledgeResponse, _ := interactor.Handle(acknowledgeRequest)
+
+	// ASSERT
+	assert.True(t, acknowledgeResponse.Status == operationstatus.NotFound)
+}
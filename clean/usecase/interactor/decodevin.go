@@ -0,0 +1,139 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Decode a VIN.
+
+DESCRIPTION
+User accesses the system to find out the manufacturer, model year and assembly plant of a motorcycle from its VIN.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to decode a VIN.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has viewed the information that is encoded in the VIN.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Decode VIN..." from the menu.
+2. System displays a view in which the user enters a VIN.
+3. User click the "Submit" button.
+4. System decodes the VIN using its table of manufacturers, without contacting any other system,
+   and displays the manufacturer, model year and plant.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to decode a VIN.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to decode a VIN.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) The manufacturer of the VIN is not in the table of manufacturers.
+       System displays the model year and plant code without the manufacturer.  The User
+	   clicks the "OK" button, and returns to the primary view.
+*/
+
+// DecodeVinInteractor is a use case for decoding a VIN.
+type DecodeVinInteractor struct {
+	ManufacturerRepository contract.ManufacturerRepository
+	AuthService            contract.AuthService
+}
+
+// NewDecodeVinInteractor creates a new instance of a DecodeVinInteractor.
+// Returns (nil, error) when there is an error, otherwise (DecodeVinInteractor, nil).
+func NewDecodeVinInteractor(manufacturerRepository contract.ManufacturerRepository, authService contract.AuthService) (*DecodeVinInteractor, error) {
+
+	interactor := &DecodeVinInteractor{
+		ManufacturerRepository: manufacturerRepository,
+		AuthService:            authService,
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a DecodeVinInteractor's fields contain valid data.
+// Returns nil if the DecodeVinInteractor contains valid data, otherwise an error.
+func (interactor DecodeVinInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// ManufacturerRepository is required and cannot be null.
+		validation.Field(&interactor.ManufacturerRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *DecodeVinInteractor) Handle(requestMessage *request.DecodeVinRequest) (*response.DecodeVinResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewDecodeVinResponse(nil, operationstatus.NotAuthenticated, errors.New("decode operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.DecodeVinPermission) {
+		return response.NewDecodeVinResponse(nil, operationstatus.NotAuthorized, errors.New("decode operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	decoded, status, err := decodeVin(interactor.ManufacturerRepository, requestMessage.Vin)
+	if err != nil {
+		return response.NewDecodeVinResponse(nil, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewDecodeVinResponse(decoded, operationstatus.Ok, nil)
+}
+
+// decodeVin decodes a VIN using the table of manufacturers in the repository.  A model year can be ahead of the
+// calendar year, so the latest model year that a code can be decoded to is the latest year in the validation policy.
+// Returns (decoded VIN, Ok, nil) on success, otherwise (nil, operationStatus, error).
+func decodeVin(manufacturerRepository contract.ManufacturerRepository, vin string) (*entity.DecodedVin, operationstatus.OperationStatus, error) {
+	manufacturer, status, err := manufacturerRepository.FindByWmi(entity.VinWmi(vin))
+	if err != nil {
+		return nil, status, err
+	}
+
+	_, latestYear := entity.CurrentValidationPolicy().YearRange(time.Now())
+	decoded, err := entity.DecodeVin(vin, manufacturer, latestYear)
+	if err != nil {
+		return nil, operationstatus.BadRequest, err
+	}
+
+	return decoded, operationstatus.Ok, nil
+}
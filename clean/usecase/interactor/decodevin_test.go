@@ -0,0 +1,126 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// newManufacturerRepository creates the default table of manufacturers for decoding VINs.
+func newManufacturerRepository() *repository.ManufacturerRepository {
+	repo, _ := repository.DefaultManufacturerRepository()
+	return repo
+}
+
+// TestDecodeVinInteractor_ManufacturerRepositoryIsNil verifies that a nil manufacturer repository fails properly.
+func TestDecodeVinInteractor_ManufacturerRepositoryIsNil(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{})
+
+	// ACT
+	_, err := NewDecodeVinInteractor(nil, authService)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestDecodeVinInteractor_NotAuthenticated verifies that a non-authenticated user fails properly.
+func TestDecodeVinInteractor_NotAuthenticated(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(false, map[authorizationrole.AuthorizationRole]bool{authorizationrole.GeneralAuthorizationRole: true})
+	vinRequest, _ := request.NewDecodeVinRequest("JH2PC35051M200020")
+	interactor, _ := NewDecodeVinInteractor(newManufacturerRepository(), authService)
+
+	// ACT
+	response, _ := interactor.Handle(vinRequest)
+
+	// ASSERT
+	assert.True(t, response.Status == operationstatus.NotAuthenticated)
+}
+
+// TestDecodeVinInteractor_Decode verifies that the manufacturer and model year of a VIN are decoded.
+func TestDecodeVinInteractor_Decode(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.GeneralAuthorizationRole: true})
+	vinRequest, _ := request.NewDecodeVinRequest("JH2PC35051M200020")
+	interactor, _ := NewDecodeVinInteractor(newManufacturerRepository(), authService)
+
+	// ACT
+	response, _ := interactor.Handle(vinRequest)
+
+	// ASSERT
+	assert.Nil(t, response.Error)
+	assert.True(t, response.DecodedVin.Manufacturer == "Honda")
+	assert.True(t, response.DecodedVin.ModelYear == 2001)
+	assert.True(t, response.DecodedVin.PlantCode == "M")
+}
+
+// TestDecodeVinInteractor_UnknownManufacturer verifies that a VIN is decoded without a manufacturer that is not in the table.
+func TestDecodeVinInteractor_UnknownManufacturer(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.GeneralAuthorizationRole: true})
+	vinRequest, _ := request.NewDecodeVinRequest("11234567590123456")
+	interactor, _ := NewDecodeVinInteractor(newManufacturerRepository(), authService)
+
+	// ACT
+	response, _ := interactor.Handle(vinRequest)
+
+	// ASSERT
+	assert.Nil(t, response.Error)
+	assert.True(t, response.DecodedVin.Manufacturer == "")
+	assert.True(t, response.DecodedVin.Wmi == "112")
+}
+
+// TestInsertMotorcycleInteractor_FillVinCheck verifies that a missing make and year are filled in from the VIN.
+func TestInsertMotorcycleInteractor_FillVinCheck(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.AdminAuthorizationRole: true})
+	repo, _ := repository.NewMotorcycleRepository()
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("", "Shadow", 0, "JH2PC35051M200020", vincheck.FillVinCheck)
+	interactor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+
+	// ACT
+	response, _ := interactor.Handle(motorcycleRequest)
+	motorcycle, _, _ := repo.FindByID(response.ID)
+
+	// ASSERT
+	assert.Nil(t, response.Error)
+	assert.True(t, motorcycle.Make == "Honda")
+	assert.True(t, motorcycle.Year == 2001)
+}
+
+// TestInsertMotorcycleInteractor_VerifyVinCheck_Mismatch verifies that a motorcycle that does not match its VIN is not inserted.
+func TestInsertMotorcycleInteractor_VerifyVinCheck_Mismatch(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.AdminAuthorizationRole: true})
+	repo, _ := repository.NewMotorcycleRepository()
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Yamaha", "Bolt", 2006, "JH2PC35051M200020", vincheck.VerifyVinCheck)
+	interactor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+
+	// ACT
+	response, _ := interactor.Handle(motorcycleRequest)
+	motorcycles, _, _ := repo.List()
+
+	// ASSERT
+	assert.NotNil(t, response.Error)
+	assert.True(t, response.Status == operationstatus.BadRequest)
+	assert.True(t, len(motorcycles) == 0)
+}
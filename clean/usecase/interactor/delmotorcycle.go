@@ -10,8 +10,9 @@ import (
 	"github.com/abitofhelp/motominderapi/clean/domain/contract"
 	"github.com/go-ozzo/ozzo-validation"
 
-	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/abitofhelp/motominderapi/clean/usecase/response"
 	"github.com/pkg/errors"
@@ -40,7 +41,8 @@ MAIN SUCCESS SCENARIO
 1. User selects "Delete Motorcycle..." from the menu.
 2. System displays a view in which the user selects a motorcycle to delete.
 3. User click the "Submit" button.
-4. System deletes the motorcycle from the motorcycle repository, and displays a confirmation message.
+4. System deletes the motorcycle from the motorcycle repository, along with its odometer readings, service records
+   and reminders, and displays a confirmation message.
 5. User clicks the "OK" button, and returns to the primary view.
 
 EXTENSIONS
@@ -55,16 +57,28 @@ EXTENSIONS
 	   System Administrator.  The User clicks the "OK" button, and returns to the
 	   primary view.
 
-(3c) A motorcycle with the ID does not exist in the repository.
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
        System displays an error message indicating that a motorcycle with the
 	   ID does not exist.  The User clicks the "OK" button, and
 	   returns to the primary view.
+
+(3d) The motorcycle has been changed by someone else since the User selected it.
+       System displays an error message indicating that the motorcycle has been
+	   changed.  The User clicks the "OK" button, and returns to the view to
+	   select the motorcycle again.
 */
 
 // DeleteMotorcycleInteractor is a use case for deleting a motorcycle from the motorcycle repository.
 type DeleteMotorcycleInteractor struct {
 	MotorcycleRepository contract.MotorcycleRepository
 	AuthService          contract.AuthService
+	// OdometerReadingRepository, ServiceRecordRepository and ReminderRepository contain the data that belongs to the
+	// motorcycle.  Each one that is set has the motorcycle's data deleted with it, so none of it is left behind.
+	OdometerReadingRepository contract.OdometerReadingRepository
+	ServiceRecordRepository   contract.ServiceRecordRepository
+	ReminderRepository        contract.ReminderRepository
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
 }
 
 // NewDeleteMotorcycleInteractor creates a new instance of a DeleteMotorcycleInteractor.
@@ -74,6 +88,7 @@ func NewDeleteMotorcycleInteractor(motorcycleRepository contract.MotorcycleRepos
 	interactor := &DeleteMotorcycleInteractor{
 		MotorcycleRepository: motorcycleRepository,
 		AuthService:          authService,
+		Logger:               nopLogger{},
 	}
 
 	// Validate the interactor
@@ -105,13 +120,24 @@ func (interactor *DeleteMotorcycleInteractor) Handle(requestMessage *request.Del
 		return response.NewDeleteMotorcycleResponse(requestMessage.ID, operationstatus.NotAuthenticated, errors.New("delete operation failed due to not being authenticated"))
 	}
 
-	// Verify that the user has the necessary authorizations.
-	if !interactor.AuthService.IsAuthorized(authorizationrole.AdminAuthorizationRole) {
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.DeleteMotorcyclePermission) {
 		return response.NewDeleteMotorcycleResponse(requestMessage.ID, operationstatus.NotAuthorized, errors.New("delete operation failed due to not being authorized, so please contact your system administrator"))
 	}
 
+	// Verify that the motorcycle belongs to the user.  One that belongs to another user is reported as not found,
+	// so its existence is not disclosed.
+	existing, status, err := interactor.MotorcycleRepository.FindByID(requestMessage.ID)
+	if err != nil {
+		return response.NewDeleteMotorcycleResponse(requestMessage.ID, status, err)
+	}
+
+	if existing == nil || !canAccessMotorcycle(interactor.AuthService, existing) {
+		return response.NewDeleteMotorcycleResponse(requestMessage.ID, operationstatus.NotFound, errors.Errorf("cannot delete the motorcycle with ID %d because it doesn't exist in the repository", requestMessage.ID))
+	}
+
 	// Delete the motorcycle with ID from the repository.
-	status, err := interactor.MotorcycleRepository.Delete(requestMessage.ID)
+	status, err = interactor.MotorcycleRepository.Delete(requestMessage.ID, requestMessage.RowVersion)
 	if err != nil {
 		return response.NewDeleteMotorcycleResponse(requestMessage.ID, status, err)
 	}
@@ -119,9 +145,57 @@ func (interactor *DeleteMotorcycleInteractor) Handle(requestMessage *request.Del
 	// Save the changes.
 	status, err = interactor.MotorcycleRepository.Save()
 	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("motorcycleId", requestMessage.ID).Error(err, "Failed to save the deleted motorcycle.")
+		return response.NewDeleteMotorcycleResponse(requestMessage.ID, status, err)
+	}
+
+	// Delete the motorcycle's data, which is only done once the motorcycle has been deleted, so it is never lost
+	// while the motorcycle remains.
+	status, err = interactor.deleteMotorcycleData(requestMessage.ID)
+	if err != nil {
+		// The motorcycle has been deleted, so the operator is told which motorcycle's data has been left behind.
+		interactor.Logger.WithField("motorcycleId", requestMessage.ID).Error(err, "Failed to delete the data of the deleted motorcycle.")
 		return response.NewDeleteMotorcycleResponse(requestMessage.ID, status, err)
 	}
 
 	// Return the successful response message.
 	return response.NewDeleteMotorcycleResponse(requestMessage.ID, operationstatus.Ok, nil)
 }
+
+// motorcycleData is a repository of data that belongs to motorcycles.
+type motorcycleData interface {
+	DeleteByMotorcycle(motorcycleID typedef.ID) (operationstatus.OperationStatus, error)
+	Save() (operationstatus.OperationStatus, error)
+}
+
+// deleteMotorcycleData deletes the motorcycle's odometer readings, service records and reminders from the repositories
+// that are set.  Each repository's changes are saved before the next one's are made, since a repository that keeps
+// them in a unit of work might otherwise wait for another's to finish.
+// Returns (Ok, nil) on success, otherwise (operationStatus, error).
+func (interactor *DeleteMotorcycleInteractor) deleteMotorcycleData(motorcycleID typedef.ID) (operationstatus.OperationStatus, error) {
+	repositories := make([]motorcycleData, 0, 3)
+	if interactor.OdometerReadingRepository != nil {
+		repositories = append(repositories, interactor.OdometerReadingRepository)
+	}
+	if interactor.ServiceRecordRepository != nil {
+		repositories = append(repositories, interactor.ServiceRecordRepository)
+	}
+	if interactor.ReminderRepository != nil {
+		repositories = append(repositories, interactor.ReminderRepository)
+	}
+
+	for _, repository := range repositories {
+		status, err := repository.DeleteByMotorcycle(motorcycleID)
+		if err != nil {
+			return status, err
+		}
+
+		status, err = repository.Save()
+		if err != nil {
+			return status, err
+		}
+	}
+
+	return operationstatus.Ok, nil
+}
@@ -9,10 +9,16 @@ package interactor
 import (
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 // TestDeleteMotorcycleInteractor_MotorcycleRepositoryIsNil verifies that a nil motorcycle repository fails properly.
@@ -51,7 +57,7 @@ func TestDeleteMotorcycleInteractor_NotAuthenticated(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(false, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewDeleteMotorcycleRequest(123)
+	motorcycleRequest, _ := request.NewDeleteMotorcycleRequest(123, constant.AnyRowVersion)
 	interactor, _ := NewDeleteMotorcycleInteractor(repo, authService)
 
 	// ACT
@@ -70,7 +76,7 @@ func TestDeleteMotorcycleInteractor_NotAuthorized(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewDeleteMotorcycleRequest(123)
+	motorcycleRequest, _ := request.NewDeleteMotorcycleRequest(123, constant.AnyRowVersion)
 	interactor, _ := NewDeleteMotorcycleInteractor(repo, authService)
 
 	// ACT
@@ -80,6 +86,29 @@ func TestDeleteMotorcycleInteractor_NotAuthorized(t *testing.T) {
 	assert.NotNil(t, response.Error)
 }
 
+// TestDeleteMotorcycleInteractor_GeneralRoleNotPermitted verifies that the general role is not permitted to delete a motorcycle.
+func TestDeleteMotorcycleInteractor_GeneralRoleNotPermitted(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.GeneralAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	repo, _ := repository.NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	deleteRequest, _ := request.NewDeleteMotorcycleRequest(moto.ID, constant.AnyRowVersion)
+	interactor, _ := NewDeleteMotorcycleInteractor(repo, authService)
+
+	// ACT
+	response, _ := interactor.Handle(deleteRequest)
+	exists, _, _ := repo.ExistsByID(moto.ID)
+
+	// ASSERT
+	assert.True(t, response.Status == operationstatus.NotAuthorized)
+	assert.True(t, exists)
+}
+
 // TestDeleteMotorcycleInteractor_Delete deletes a motorcycle from the repository.
 func TestDeleteMotorcycleInteractor_Delete(t *testing.T) {
 
@@ -91,11 +120,11 @@ func TestDeleteMotorcycleInteractor_Delete(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 
 	// Add a motorcycle so we can delete it.
-	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, authService)
-	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
+	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
 	insertResponse, _ := insertInteractor.Handle(insertRequest)
 
-	deleteRequest, _ := request.NewDeleteMotorcycleRequest(insertResponse.ID)
+	deleteRequest, _ := request.NewDeleteMotorcycleRequest(insertResponse.ID, constant.AnyRowVersion)
 	deleteInteractor, _ := NewDeleteMotorcycleInteractor(repo, authService)
 
 	// ACT
@@ -116,7 +145,7 @@ func TestDeleteMotorcycleInteractor_NotExist(t *testing.T) {
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
 
-	deleteRequest, _ := request.NewDeleteMotorcycleRequest(123)
+	deleteRequest, _ := request.NewDeleteMotorcycleRequest(123, constant.AnyRowVersion)
 	deleteInteractor, _ := NewDeleteMotorcycleInteractor(repo, authService)
 
 	// ACT
@@ -126,3 +155,48 @@ func TestDeleteMotorcycleInteractor_NotExist(t *testing.T) {
 	assert.True(t, deleteResponse.ID == 123)
 	assert.NotNil(t, deleteResponse.Error)
 }
+
+// TestDeleteMotorcycleInteractor_DeletesMotorcycleData verifies that the odometer readings, service records and reminders
+// of a deleted motorcycle are deleted with it, and that those of other motorcycles are kept.
+func TestDeleteMotorcycleInteractor_DeletesMotorcycleData(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.AdminAuthorizationRole)
+	readingInteractor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, alice)
+	recordInteractor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+
+	motorcycleIDs := []typedef.ID{
+		insertOwnedMotorcycle(motorcycles, alice, "01234567890123456"),
+		insertOwnedMotorcycle(motorcycles, alice, "11234567590123456"),
+	}
+	for _, motorcycleID := range motorcycleIDs {
+		insertOdometerReading(readingInteractor, motorcycleID, 4000, time.Now(), false)
+		insertServiceRecord(recordInteractor, motorcycleID, time.Now(), 4000)
+		insertReminder(reminders, motorcycleID, "Oil change")
+	}
+
+	deleteRequest, _ := request.NewDeleteMotorcycleRequest(motorcycleIDs[0], constant.AnyRowVersion)
+	deleteInteractor, _ := NewDeleteMotorcycleInteractor(motorcycles, alice)
+	deleteInteractor.OdometerReadingRepository = readings
+	deleteInteractor.ServiceRecordRepository = records
+	deleteInteractor.ReminderRepository = reminders
+
+	// ACT
+	deleteResponse, _ := deleteInteractor.Handle(deleteRequest)
+
+	// ASSERT
+	assert.Nil(t, deleteResponse.Error)
+	for i, motorcycleID := range motorcycleIDs {
+		motorcycleReadings, _, _ := readings.ListByMotorcycle(motorcycleID)
+		motorcycleRecords, _, _ := records.ListByMotorcycle(motorcycleID)
+		motorcycleReminders, _, _ := reminders.ListByMotorcycle(motorcycleID)
+
+		assert.Len(t, motorcycleReadings, i)
+		assert.Len(t, motorcycleRecords, i)
+		assert.Len(t, motorcycleReminders, i)
+	}
+}
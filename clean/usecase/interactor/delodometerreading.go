@@ -0,0 +1,155 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Delete the latest odometer reading of a motorcycle.
+
+DESCRIPTION
+User accesses the system to delete an odometer reading that was recorded by mistake.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to delete an odometer reading.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+The reading is the motorcycle's latest odometer reading.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has deleted the odometer reading from the system.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Odometer Readings..." from the menu.
+2. System displays a view in which the user selects the reading to delete.
+3. User click the "Delete" button.
+4. System deletes the reading from the odometer reading repository, and displays a confirmation message.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to delete an odometer reading.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to delete an odometer reading.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle or reading with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that the reading does not exist.
+	   The User clicks the "OK" button, and returns to the primary view.
+
+(3d) The reading is not the motorcycle's latest reading.
+       System displays an error message indicating that only the latest reading can
+	   be deleted.  The User clicks the "OK" button, and returns to the primary view.
+*/
+
+// DeleteOdometerReadingInteractor is a use case for deleting the latest odometer reading of a motorcycle.
+type DeleteOdometerReadingInteractor struct {
+	MotorcycleRepository      contract.MotorcycleRepository
+	OdometerReadingRepository contract.OdometerReadingRepository
+	AuthService               contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
+}
+
+// NewDeleteOdometerReadingInteractor creates a new instance of a DeleteOdometerReadingInteractor.
+// Returns (nil, error) when there is an error, otherwise (DeleteOdometerReadingInteractor, nil).
+func NewDeleteOdometerReadingInteractor(motorcycleRepository contract.MotorcycleRepository, odometerReadingRepository contract.OdometerReadingRepository, authService contract.AuthService) (*DeleteOdometerReadingInteractor, error) {
+
+	interactor := &DeleteOdometerReadingInteractor{
+		MotorcycleRepository:      motorcycleRepository,
+		OdometerReadingRepository: odometerReadingRepository,
+		AuthService:               authService,
+		Logger:                    nopLogger{},
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a DeleteOdometerReadingInteractor's fields contain valid data.
+// Returns nil if the DeleteOdometerReadingInteractor contains valid data, otherwise an error.
+func (interactor DeleteOdometerReadingInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// OdometerReadingRepository is required and cannot be null.
+		validation.Field(&interactor.OdometerReadingRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *DeleteOdometerReadingInteractor) Handle(requestMessage *request.DeleteOdometerReadingRequest) (*response.DeleteOdometerReadingResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewDeleteOdometerReadingResponse(requestMessage.ID, operationstatus.NotAuthenticated, errors.New("delete operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.DeleteOdometerReadingPermission) {
+		return response.NewDeleteOdometerReadingResponse(requestMessage.ID, operationstatus.NotAuthorized, errors.New("delete operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the motorcycle exists and belongs to the user.
+	_, status, err := findAccessibleMotorcycle(interactor.MotorcycleRepository, interactor.AuthService, requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewDeleteOdometerReadingResponse(requestMessage.ID, status, err)
+	}
+
+	// Verify that the reading belongs to the motorcycle.
+	reading, status, err := interactor.OdometerReadingRepository.FindByID(requestMessage.ID)
+	if err != nil {
+		return response.NewDeleteOdometerReadingResponse(requestMessage.ID, status, err)
+	}
+
+	if reading == nil || reading.MotorcycleID != requestMessage.MotorcycleID {
+		return response.NewDeleteOdometerReadingResponse(requestMessage.ID, operationstatus.NotFound, errors.Errorf("cannot delete the odometer reading with ID %d because it doesn't exist in the repository", requestMessage.ID))
+	}
+
+	// Delete the reading, which must be the motorcycle's latest reading.
+	status, err = interactor.OdometerReadingRepository.Delete(requestMessage.ID)
+	if err != nil {
+		return response.NewDeleteOdometerReadingResponse(requestMessage.ID, status, err)
+	}
+
+	// Save the changes.
+	status, err = interactor.OdometerReadingRepository.Save()
+	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("readingId", requestMessage.ID).Error(err, "Failed to save the deleted odometer reading.")
+		return response.NewDeleteOdometerReadingResponse(requestMessage.ID, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewDeleteOdometerReadingResponse(requestMessage.ID, operationstatus.Ok, nil)
+}
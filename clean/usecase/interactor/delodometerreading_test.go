@@ -0,0 +1,43 @@
+// Package interactor implements unit tests for the DeleteOdometerReadingInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDeleteOdometerReadingInteractor_Handle verifies that only the latest reading of the motorcycle can be deleted.
+func TestDeleteOdometerReadingInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	otherMotorcycleID := insertOwnedMotorcycle(motorcycles, alice, "11234567590123456")
+	insertInteractor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, alice)
+	_, firstID := insertOdometerReading(insertInteractor, motorcycleID, 1200, time.Now().Add(-time.Hour), false)
+	_, latestID := insertOdometerReading(insertInteractor, motorcycleID, 1300, time.Time{}, false)
+	interactor, _ := NewDeleteOdometerReadingInteractor(motorcycles, readings, alice)
+	firstRequest, _ := request.NewDeleteOdometerReadingRequest(motorcycleID, firstID)
+	otherRequest, _ := request.NewDeleteOdometerReadingRequest(otherMotorcycleID, latestID)
+	latestRequest, _ := request.NewDeleteOdometerReadingRequest(motorcycleID, latestID)
+
+	// ACT
+	firstResponse, _ := interactor.Handle(firstRequest)
+	otherResponse, _ := interactor.Handle(otherRequest)
+	latestResponse, _ := interactor.Handle(latestRequest)
+	latest, _, _ := readings.Latest(motorcycleID)
+
+	// ASSERT
+	assert.True(t, firstResponse.Status == operationstatus.Conflict)
+	assert.True(t, otherResponse.Status == operationstatus.NotFound)
+	assert.True(t, latestResponse.Status == operationstatus.Ok)
+	assert.True(t, latest.ID == firstID)
+}
@@ -0,0 +1,147 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Delete a service record of a motorcycle.
+
+DESCRIPTION
+User accesses the system to delete a service record that was recorded by mistake.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to delete a service record.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+A Service record with the ID exists for the motorcycle.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has deleted the service record from the system.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Service History..." from the menu.
+2. System displays a view in which the user selects the service record to delete.
+3. User click the "Delete" button.
+4. System deletes the service record from the service record repository, and displays a confirmation message.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to delete a service record.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to delete a service record.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle or service record with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that the service record does not exist.
+	   The User clicks the "OK" button, and returns to the primary view.
+
+(3d) The service record has been changed by someone else since the User selected it.
+       System displays an error message indicating that the service record has been
+	   changed.  The User clicks the "OK" button, and returns to the view to
+	   select the service record again.
+*/
+
+// DeleteServiceRecordInteractor is a use case for deleting a service record of a motorcycle.
+type DeleteServiceRecordInteractor struct {
+	MotorcycleRepository    contract.MotorcycleRepository
+	ServiceRecordRepository contract.ServiceRecordRepository
+	AuthService             contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
+}
+
+// NewDeleteServiceRecordInteractor creates a new instance of a DeleteServiceRecordInteractor.
+// Returns (nil, error) when there is an error, otherwise (DeleteServiceRecordInteractor, nil).
+func NewDeleteServiceRecordInteractor(motorcycleRepository contract.MotorcycleRepository, serviceRecordRepository contract.ServiceRecordRepository, authService contract.AuthService) (*DeleteServiceRecordInteractor, error) {
+
+	interactor := &DeleteServiceRecordInteractor{
+		MotorcycleRepository:    motorcycleRepository,
+		ServiceRecordRepository: serviceRecordRepository,
+		AuthService:             authService,
+		Logger:                  nopLogger{},
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a DeleteServiceRecordInteractor's fields contain valid data.
+// Returns nil if the DeleteServiceRecordInteractor contains valid data, otherwise an error.
+func (interactor DeleteServiceRecordInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ServiceRecordRepository is required and cannot be null.
+		validation.Field(&interactor.ServiceRecordRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *DeleteServiceRecordInteractor) Handle(requestMessage *request.DeleteServiceRecordRequest) (*response.DeleteServiceRecordResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewDeleteServiceRecordResponse(requestMessage.ID, operationstatus.NotAuthenticated, errors.New("delete operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.DeleteServiceRecordPermission) {
+		return response.NewDeleteServiceRecordResponse(requestMessage.ID, operationstatus.NotAuthorized, errors.New("delete operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the service record belongs to a motorcycle that the user can access.
+	_, status, err := findAccessibleServiceRecord(interactor.MotorcycleRepository, interactor.ServiceRecordRepository, interactor.AuthService,
+		requestMessage.MotorcycleID, requestMessage.ID)
+	if err != nil {
+		return response.NewDeleteServiceRecordResponse(requestMessage.ID, status, err)
+	}
+
+	// Delete the service record, as long as nobody else has changed it since it was read.
+	status, err = interactor.ServiceRecordRepository.Delete(requestMessage.ID, requestMessage.RowVersion)
+	if err != nil {
+		return response.NewDeleteServiceRecordResponse(requestMessage.ID, status, err)
+	}
+
+	// Save the changes.
+	status, err = interactor.ServiceRecordRepository.Save()
+	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("serviceRecordId", requestMessage.ID).Error(err, "Failed to save the deleted service record.")
+		return response.NewDeleteServiceRecordResponse(requestMessage.ID, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewDeleteServiceRecordResponse(requestMessage.ID, operationstatus.Ok, nil)
+}
@@ -0,0 +1,40 @@
+// Package interactor implements unit tests for the DeleteServiceRecordInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDeleteServiceRecordInteractor_Handle verifies that a service record can only be deleted by the owner of its motorcycle.
+func TestDeleteServiceRecordInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	insertInteractor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+	_, id := insertServiceRecord(insertInteractor, motorcycleID, time.Now(), 4000)
+	bobInteractor, _ := NewDeleteServiceRecordInteractor(motorcycles, records, bob)
+	interactor, _ := NewDeleteServiceRecordInteractor(motorcycles, records, alice)
+	deleteRequest, _ := request.NewDeleteServiceRecordRequest(motorcycleID, id, constant.InitialRowVersion)
+
+	// ACT
+	bobResponse, _ := bobInteractor.Handle(deleteRequest)
+	deleteResponse, _ := interactor.Handle(deleteRequest)
+	_, status, _ := records.FindByID(id)
+
+	// ASSERT
+	assert.True(t, bobResponse.Status == operationstatus.NotFound)
+	assert.True(t, deleteResponse.Status == operationstatus.Ok)
+	assert.True(t, status == operationstatus.NotFound)
+}
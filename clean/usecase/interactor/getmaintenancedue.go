@@ -0,0 +1,213 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Get the maintenance that is due on a motorcycle.
+
+DESCRIPTION
+User accesses the system to find out which maintenance tasks are due, overdue, or upcoming for a motorcycle.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to get the maintenance that is due.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has viewed when each maintenance task in the motorcycle's schedule is due, with the most urgent first.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Maintenance Due..." from the menu.
+2. System displays a view in which the user selects a motorcycle.
+3. User click the "Submit" button.
+4. System evaluates the motorcycle's maintenance schedule against its latest odometer reading and service history,
+   and displays the maintenance tasks.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to get the maintenance that is due.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to get the maintenance that is due.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that a motorcycle with the
+	   ID does not exist.  The User clicks the "OK" button, and
+	   returns to the primary view.
+*/
+
+// GetMaintenanceDueInteractor is a use case for getting the maintenance that is due on a motorcycle.
+type GetMaintenanceDueInteractor struct {
+	MotorcycleRepository          contract.MotorcycleRepository
+	OdometerReadingRepository     contract.OdometerReadingRepository
+	ServiceRecordRepository       contract.ServiceRecordRepository
+	MaintenanceScheduleRepository contract.MaintenanceScheduleRepository
+	AuthService                   contract.AuthService
+}
+
+// NewGetMaintenanceDueInteractor creates a new instance of a GetMaintenanceDueInteractor.
+// Returns (nil, error) when there is an error, otherwise (GetMaintenanceDueInteractor, nil).
+func NewGetMaintenanceDueInteractor(motorcycleRepository contract.MotorcycleRepository, odometerReadingRepository contract.OdometerReadingRepository,
+	serviceRecordRepository contract.ServiceRecordRepository, maintenanceScheduleRepository contract.MaintenanceScheduleRepository,
+	authService contract.AuthService) (*GetMaintenanceDueInteractor, error) {
+
+	interactor := &GetMaintenanceDueInteractor{
+		MotorcycleRepository:          motorcycleRepository,
+		OdometerReadingRepository:     odometerReadingRepository,
+		ServiceRecordRepository:       serviceRecordRepository,
+		MaintenanceScheduleRepository: maintenanceScheduleRepository,
+		AuthService:                   authService,
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a GetMaintenanceDueInteractor's fields contain valid data.
+// Returns nil if the GetMaintenanceDueInteractor contains valid data, otherwise an error.
+func (interactor GetMaintenanceDueInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// OdometerReadingRepository is required and cannot be null.
+		validation.Field(&interactor.OdometerReadingRepository, validation.Required),
+		// ServiceRecordRepository is required and cannot be null.
+		validation.Field(&interactor.ServiceRecordRepository, validation.Required),
+		// MaintenanceScheduleRepository is required and cannot be null.
+		validation.Field(&interactor.MaintenanceScheduleRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *GetMaintenanceDueInteractor) Handle(requestMessage *request.GetMaintenanceDueRequest) (*response.GetMaintenanceDueResponse, error) {
+	asOfUtc := requestMessage.AsOfUtc
+	if asOfUtc.IsZero() {
+		asOfUtc = time.Now().UTC()
+	}
+
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewGetMaintenanceDueResponse(requestMessage.MotorcycleID, 0, distanceunit.UndefinedDistanceUnit, asOfUtc, nil,
+			operationstatus.NotAuthenticated, errors.New("get operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.GetMaintenanceDuePermission) {
+		return response.NewGetMaintenanceDueResponse(requestMessage.MotorcycleID, 0, distanceunit.UndefinedDistanceUnit, asOfUtc, nil,
+			operationstatus.NotAuthorized, errors.New("get operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the motorcycle exists and belongs to the user.
+	motorcycle, status, err := findAccessibleMotorcycle(interactor.MotorcycleRepository, interactor.AuthService, requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewGetMaintenanceDueResponse(requestMessage.MotorcycleID, 0, distanceunit.UndefinedDistanceUnit, asOfUtc, nil, status, err)
+	}
+
+	// Get the motorcycle's latest odometer reading, which is nil when it has not been read yet.
+	latest, status, err := interactor.OdometerReadingRepository.Latest(requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewGetMaintenanceDueResponse(requestMessage.MotorcycleID, 0, distanceunit.UndefinedDistanceUnit, asOfUtc, nil, status, err)
+	}
+
+	// Get the motorcycle's service history, in the order that the services were performed.
+	records, status, err := interactor.ServiceRecordRepository.ListByMotorcycle(requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewGetMaintenanceDueResponse(requestMessage.MotorcycleID, 0, distanceunit.UndefinedDistanceUnit, asOfUtc, nil, status, err)
+	}
+
+	// Get the rules in the maintenance schedule that apply to the motorcycle.
+	rules, status, err := interactor.MaintenanceScheduleRepository.ListByMotorcycle(*motorcycle)
+	if err != nil {
+		return response.NewGetMaintenanceDueResponse(requestMessage.MotorcycleID, 0, distanceunit.UndefinedDistanceUnit, asOfUtc, nil, status, err)
+	}
+
+	// Evaluate each rule from the latest performance of its task, with the most urgent tasks first.
+	odometer, unit := currentOdometer(latest, records)
+	lastServices := latestServices(records)
+	items := make([]entity.MaintenanceDue, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, rule.Evaluate(lastServices[rule.Type], motorcycle.CreatedUtc, odometer, unit, asOfUtc))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Status > items[j].Status
+	})
+
+	// Return the successful response message.
+	return response.NewGetMaintenanceDueResponse(requestMessage.MotorcycleID, odometer, unit, asOfUtc, items, operationstatus.Ok, nil)
+}
+
+// currentOdometer determines the distance travelled by a motorcycle from its latest odometer reading, which is nil when
+// it has not been read, and its service records, which may have been recorded at a greater distance than the reading.
+// Returns the (distance, unit), which is zero kilometers when there is neither a reading nor a service record.
+func currentOdometer(latest *entity.OdometerReading, records []entity.ServiceRecord) (float64, distanceunit.DistanceUnit) {
+	odometer := 0.0
+	unit := distanceunit.KilometersDistanceUnit
+
+	switch {
+	case latest != nil:
+		odometer, unit = latest.Distance, latest.Unit
+	case len(records) > 0:
+		unit = records[len(records)-1].Unit
+	}
+
+	for _, record := range records {
+		if distance := record.Unit.Convert(record.Odometer, unit); distance > odometer {
+			odometer = distance
+		}
+	}
+
+	return odometer, unit
+}
+
+// latestServices finds the latest performance of each kind of service in a motorcycle's service history,
+// which is in the order that the services were performed.
+// Returns the latest service record of each kind of service.
+func latestServices(records []entity.ServiceRecord) map[servicetype.ServiceType]*entity.ServiceRecord {
+	latest := make(map[servicetype.ServiceType]*entity.ServiceRecord)
+	for i := range records {
+		latest[records[i].Type] = &records[i]
+	}
+
+	return latest
+}
@@ -0,0 +1,101 @@
+// Package interactor implements unit tests for the GetMaintenanceDueInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/maintenancestatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestMaintenanceSchedule creates a schedule with an oil change every 3,000 miles or 12 months, and an annual inspection.
+func newTestMaintenanceSchedule() *repository.MaintenanceScheduleRepository {
+	schedule, _ := repository.NewMaintenanceScheduleRepository([]entity.MaintenanceRule{
+		{Type: servicetype.OilChangeServiceType, Distance: 3000, Unit: distanceunit.MilesDistanceUnit, Months: 12},
+		{Type: servicetype.InspectionServiceType, Months: 12},
+	})
+	return schedule
+}
+
+// TestGetMaintenanceDueInteractor_Handle verifies that the tasks are evaluated from the latest reading and service, with the most urgent first.
+func TestGetMaintenanceDueInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	now := time.Now()
+	recordInteractor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+	insertServiceRecord(recordInteractor, motorcycleID, now.AddDate(0, -2, 0), 1000)
+	_, lastID := insertServiceRecord(recordInteractor, motorcycleID, now.AddDate(0, -1, 0), 4000)
+	readingInteractor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, alice)
+	insertOdometerReading(readingInteractor, motorcycleID, 6800, time.Time{}, false)
+	interactor, _ := NewGetMaintenanceDueInteractor(motorcycles, readings, records, newTestMaintenanceSchedule(), alice)
+	dueRequest, _ := request.NewGetMaintenanceDueRequest(motorcycleID, time.Time{})
+
+	// ACT
+	dueResponse, _ := interactor.Handle(dueRequest)
+
+	// ASSERT
+	assert.True(t, dueResponse.Status == operationstatus.Ok)
+	assert.True(t, dueResponse.Odometer == 6800)
+	assert.True(t, len(dueResponse.Items) == 2)
+	assert.True(t, dueResponse.Items[0].Type == servicetype.OilChangeServiceType)
+	assert.True(t, dueResponse.Items[0].Status == maintenancestatus.UpcomingMaintenanceStatus)
+	assert.True(t, dueResponse.Items[0].LastServiceID == lastID)
+	assert.True(t, dueResponse.Items[0].DueOdometer == 7000)
+	assert.True(t, dueResponse.Items[1].Status == maintenancestatus.OkMaintenanceStatus)
+}
+
+// TestGetMaintenanceDueInteractor_ServiceBeyondReading verifies that a service recorded at a greater distance than the latest reading is the current distance.
+func TestGetMaintenanceDueInteractor_ServiceBeyondReading(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	recordInteractor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+	insertServiceRecord(recordInteractor, motorcycleID, time.Now(), 4000)
+	interactor, _ := NewGetMaintenanceDueInteractor(motorcycles, readings, records, newTestMaintenanceSchedule(), alice)
+	dueRequest, _ := request.NewGetMaintenanceDueRequest(motorcycleID, time.Time{})
+
+	// ACT
+	dueResponse, _ := interactor.Handle(dueRequest)
+
+	// ASSERT
+	assert.True(t, dueResponse.Status == operationstatus.Ok)
+	assert.True(t, dueResponse.Odometer == 4000)
+	assert.True(t, dueResponse.Unit == distanceunit.MilesDistanceUnit)
+}
+
+// TestGetMaintenanceDueInteractor_OtherOwner verifies that a user cannot get the maintenance of another user's motorcycle.
+func TestGetMaintenanceDueInteractor_OtherOwner(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewGetMaintenanceDueInteractor(motorcycles, readings, records, newTestMaintenanceSchedule(), bob)
+	dueRequest, _ := request.NewGetMaintenanceDueRequest(motorcycleID, time.Time{})
+
+	// ACT
+	dueResponse, _ := interactor.Handle(dueRequest)
+
+	// ASSERT
+	assert.True(t, dueResponse.Status == operationstatus.NotFound)
+}
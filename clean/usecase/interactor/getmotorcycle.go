@@ -10,8 +10,8 @@ import (
 	"github.com/abitofhelp/motominderapi/clean/domain/contract"
 	"github.com/go-ozzo/ozzo-validation"
 
-	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/abitofhelp/motominderapi/clean/usecase/response"
 	"github.com/pkg/errors"
@@ -55,7 +55,7 @@ EXTENSIONS
 	   System Administrator.  The User clicks the "OK" button, and returns to the
 	   primary view.
 
-(3c) A motorcycle with the ID does not exist in the repository.
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
        System displays an error message indicating that a motorcycle with the
 	   ID does not exist.  The User clicks the "OK" button, and
 	   returns to the primary view.
@@ -65,6 +65,8 @@ EXTENSIONS
 type GetMotorcycleInteractor struct {
 	MotorcycleRepository contract.MotorcycleRepository
 	AuthService          contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
 }
 
 // NewGetMotorcycleInteractor creates a new instance of a GetMotorcycleInteractor.
@@ -74,6 +76,7 @@ func NewGetMotorcycleInteractor(motorcycleRepository contract.MotorcycleReposito
 	interactor := &GetMotorcycleInteractor{
 		MotorcycleRepository: motorcycleRepository,
 		AuthService:          authService,
+		Logger:               nopLogger{},
 	}
 
 	// Validate the interactor
@@ -105,14 +108,16 @@ func (interactor *GetMotorcycleInteractor) Handle(requestMessage *request.GetMot
 		return response.NewGetMotorcycleResponse(nil, operationstatus.NotAuthenticated, errors.New("get operation failed due to not being authenticated"))
 	}
 
-	// Verify that the user has the necessary authorizations.
-	if !interactor.AuthService.IsAuthorized(authorizationrole.AdminAuthorizationRole) {
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.GetMotorcyclePermission) {
 		return response.NewGetMotorcycleResponse(nil, operationstatus.NotAuthorized, errors.New("get operation failed due to not being authorized, so please contact your system administrator"))
 	}
 
 	// Get the motorcycle with ID from the repository.
 	motorcycle, status, err := interactor.MotorcycleRepository.FindByID(requestMessage.ID)
 	if err != nil {
+		// The repository has failed, so the operator is told why.
+		interactor.Logger.WithField("motorcycleId", requestMessage.ID).Error(err, "Failed to get the motorcycle.")
 		return response.NewGetMotorcycleResponse(nil, status, err)
 	}
 
@@ -120,6 +125,11 @@ func (interactor *GetMotorcycleInteractor) Handle(requestMessage *request.GetMot
 		return response.NewGetMotorcycleResponse(nil, status, nil)
 	}
 
+	// A motorcycle that belongs to another user is reported as not found, so its existence is not disclosed.
+	if !canAccessMotorcycle(interactor.AuthService, motorcycle) {
+		return response.NewGetMotorcycleResponse(nil, operationstatus.NotFound, nil)
+	}
+
 	// Return the successful response message.
 	return response.NewGetMotorcycleResponse(motorcycle, operationstatus.Ok, nil)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -92,8 +93,8 @@ func TestGetMotorcycleInteractor_Get(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 
 	// Add a motorcycle so we can get it.
-	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, authService)
-	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
+	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
 	insertResponse, _ := insertInteractor.Handle(insertRequest)
 
 	getRequest, _ := request.NewGetMotorcycleRequest(insertResponse.ID)
@@ -0,0 +1,125 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Get a service record of a motorcycle.
+
+DESCRIPTION
+User accesses the system to view the details of a service that was performed on a motorcycle.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to get a service record.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+A Service record with the ID exists for the motorcycle.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has viewed the service record.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Service History..." from the menu.
+2. System displays a view in which the user selects a service record.
+3. User click the "Submit" button.
+4. System displays the service record.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to get a service record.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to get a service record.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle or service record with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that the service record does not exist.
+	   The User clicks the "OK" button, and returns to the primary view.
+*/
+
+// GetServiceRecordInteractor is a use case for getting a service record of a motorcycle.
+type GetServiceRecordInteractor struct {
+	MotorcycleRepository    contract.MotorcycleRepository
+	ServiceRecordRepository contract.ServiceRecordRepository
+	AuthService             contract.AuthService
+}
+
+// NewGetServiceRecordInteractor creates a new instance of a GetServiceRecordInteractor.
+// Returns (nil, error) when there is an error, otherwise (GetServiceRecordInteractor, nil).
+func NewGetServiceRecordInteractor(motorcycleRepository contract.MotorcycleRepository, serviceRecordRepository contract.ServiceRecordRepository, authService contract.AuthService) (*GetServiceRecordInteractor, error) {
+
+	interactor := &GetServiceRecordInteractor{
+		MotorcycleRepository:    motorcycleRepository,
+		ServiceRecordRepository: serviceRecordRepository,
+		AuthService:             authService,
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a GetServiceRecordInteractor's fields contain valid data.
+// Returns nil if the GetServiceRecordInteractor contains valid data, otherwise an error.
+func (interactor GetServiceRecordInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ServiceRecordRepository is required and cannot be null.
+		validation.Field(&interactor.ServiceRecordRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *GetServiceRecordInteractor) Handle(requestMessage *request.GetServiceRecordRequest) (*response.GetServiceRecordResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewGetServiceRecordResponse(nil, operationstatus.NotAuthenticated, errors.New("get operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.GetServiceRecordPermission) {
+		return response.NewGetServiceRecordResponse(nil, operationstatus.NotAuthorized, errors.New("get operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Get the service record, which must belong to a motorcycle that the user can access.
+	record, status, err := findAccessibleServiceRecord(interactor.MotorcycleRepository, interactor.ServiceRecordRepository, interactor.AuthService,
+		requestMessage.MotorcycleID, requestMessage.ID)
+	if err != nil {
+		return response.NewGetServiceRecordResponse(nil, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewGetServiceRecordResponse(record, operationstatus.Ok, nil)
+}
@@ -0,0 +1,38 @@
+// Package interactor implements unit tests for the GetServiceRecordInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetServiceRecordInteractor_Handle verifies that a service record is only found through its own motorcycle.
+func TestGetServiceRecordInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	otherMotorcycleID := insertOwnedMotorcycle(motorcycles, alice, "11234567590123456")
+	insertInteractor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+	_, id := insertServiceRecord(insertInteractor, motorcycleID, time.Now(), 4000)
+	interactor, _ := NewGetServiceRecordInteractor(motorcycles, records, alice)
+	getRequest, _ := request.NewGetServiceRecordRequest(motorcycleID, id)
+	otherRequest, _ := request.NewGetServiceRecordRequest(otherMotorcycleID, id)
+
+	// ACT
+	getResponse, _ := interactor.Handle(getRequest)
+	otherResponse, _ := interactor.Handle(otherRequest)
+
+	// ASSERT
+	assert.True(t, getResponse.Status == operationstatus.Ok)
+	assert.True(t, getResponse.ServiceRecord.ID == id)
+	assert.True(t, otherResponse.Status == operationstatus.NotFound)
+}
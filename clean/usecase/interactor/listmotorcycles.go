@@ -8,22 +8,24 @@ package interactor
 
 import (
 	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 	"github.com/go-ozzo/ozzo-validation"
 
 	"github.com/pkg/errors"
 
-	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/abitofhelp/motominderapi/clean/usecase/response"
 )
 
 /*
 TITLE
-Get an unsorted list of motorcycles from the motorcycle repository.
+Get a page of a filtered and sorted list of motorcycles from the motorcycle repository.
 
 DESCRIPTION
-User accesses the system to get a list of motorcycles.
+User accesses the system to get a list of motorcycles, which can be filtered by make, model, year range and VIN prefix,
+and sorted by several fields.  A long list is divided into pages.
 
 PRIMARY ACTOR
 User
@@ -38,8 +40,10 @@ User has received a list of motorcycles from the system, and the list can be emp
 
 MAIN SUCCESS SCENARIO
 1. User selects "Get Motorcycles" from the menu.
-2. System displays a view showing the unsorted list of motorcycles.
-3. User clicks the "OK" button, and returns to the primary view.
+2. User chooses the filters and the sort order.
+3. System displays a view showing the first page of the sorted list of motorcycles.
+4. User clicks the "Next" button until they have found the motorcycle, and the System displays the following page.
+5. User clicks the "OK" button, and returns to the primary view.
 
 EXTENSIONS
 (3a) The user cannot log into the system.
@@ -52,12 +56,18 @@ EXTENSIONS
 	   security authorizations.  It recommends contacting the
 	   System Administrator.  The User clicks the "OK" button, and returns to the
 	   primary view.
+
+(3c) The filters, sort order or page are not valid.
+       System displays an error message describing the problem.  The User clicks the
+	   "OK" button, and returns to the view to correct them.
 */
 
 // ListMotorcyclesInteractor is a use case for getting a list of motorcycles from the motorcycle repository.
 type ListMotorcyclesInteractor struct {
 	MotorcycleRepository contract.MotorcycleRepository
 	AuthService          contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
 }
 
 // NewListMotorcyclesInteractor creates a new instance of a ListMotorcyclesInteractor.
@@ -67,6 +77,7 @@ func NewListMotorcyclesInteractor(motorcycleRepository contract.MotorcycleReposi
 	interactor := &ListMotorcyclesInteractor{
 		MotorcycleRepository: motorcycleRepository,
 		AuthService:          authService,
+		Logger:               nopLogger{},
 	}
 
 	// Validate the interactor
@@ -95,20 +106,34 @@ func (interactor ListMotorcyclesInteractor) Validate() error {
 func (interactor *ListMotorcyclesInteractor) Handle(requestMessage *request.ListMotorcyclesRequest) (*response.ListMotorcyclesResponse, error) {
 	// Verify that the user has been properly authenticated.
 	if !interactor.AuthService.IsAuthenticated() {
-		return response.NewListMotorcyclesResponse(nil, operationstatus.NotAuthenticated, errors.New("list operation failed due to not being authenticated"))
+		return response.NewListMotorcyclesResponse(nil, nil, operationstatus.NotAuthenticated, errors.New("list operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.ListMotorcyclesPermission) {
+		return response.NewListMotorcyclesResponse(nil, nil, operationstatus.NotAuthorized, errors.New("list operation failed due to not being authorized, so please contact your system administrator"))
 	}
 
-	// Verify that the user has the necessary authorizations.
-	if !interactor.AuthService.IsAuthorized(authorizationrole.AdminAuthorizationRole) {
-		return response.NewListMotorcyclesResponse(nil, operationstatus.NotAuthorized, errors.New("list operation failed due to not being authorized, so please contact your system administrator"))
+	// Get the page of motorcycles from the repository, which only contains the user's own motorcycles
+	// unless they may access all of them.
+	query := requestMessage.Query
+	switch {
+	case canAccessAllMotorcycles(interactor.AuthService):
+		query.OwnerID = ""
+	case interactor.AuthService.UserID() == "":
+		// A user who cannot be identified does not own any motorcycles.
+		return response.NewListMotorcyclesResponse(make([]entity.Motorcycle, 0), nil, operationstatus.Ok, nil)
+	default:
+		query.OwnerID = interactor.AuthService.UserID()
 	}
 
-	// Get the list of motorcycles from the repository.
-	motorcycles, status, err := interactor.MotorcycleRepository.List()
+	page, status, err := interactor.MotorcycleRepository.Query(query)
 	if err != nil {
-		return response.NewListMotorcyclesResponse(nil, status, err)
+		// The repository has failed, so the operator is told why.
+		interactor.Logger.Error(err, "Failed to list the motorcycles.")
+		return response.NewListMotorcyclesResponse(nil, nil, status, err)
 	}
 
 	// Return the successful response message.
-	return response.NewListMotorcyclesResponse(motorcycles, operationstatus.Ok, nil)
+	return response.NewListMotorcyclesResponse(page.Motorcycles, page.Next, operationstatus.Ok, nil)
 }
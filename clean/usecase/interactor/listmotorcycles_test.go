@@ -11,7 +11,9 @@ import (
 
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
 )
@@ -52,7 +54,7 @@ func TestListMotorcyclesInteractor_NotAuthenticated(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(false, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewListMotorcyclesRequest()
+	motorcycleRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{})
 	interactor, _ := NewListMotorcyclesInteractor(repo, authService)
 
 	// ACT
@@ -72,7 +74,7 @@ func TestListMotorcyclesInteractor_NotAuthorized(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewListMotorcyclesRequest()
+	motorcycleRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{})
 	interactor, _ := NewListMotorcyclesInteractor(repo, authService)
 
 	// ACT
@@ -92,7 +94,7 @@ func TestListMotorcyclesInteractor_EmptyList(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewListMotorcyclesRequest()
+	motorcycleRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{})
 	interactor, _ := NewListMotorcyclesInteractor(repo, authService)
 
 	// ACT
@@ -111,12 +113,12 @@ func TestListMotorcyclesInteractor_NotEmptyList(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	listRequest, _ := request.NewListMotorcyclesRequest()
+	listRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{})
 	listInteractor, _ := NewListMotorcyclesInteractor(repo, authService)
 
-	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, authService)
-	insertRequest1, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	insertRequest2, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2009, "01234567999923456")
+	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	insertRequest1, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	insertRequest2, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2009, "01234567499923456", vincheck.UndefinedVinCheck)
 	insertInteractor.Handle(insertRequest1)
 	insertInteractor.Handle(insertRequest2)
 
@@ -126,3 +128,43 @@ func TestListMotorcyclesInteractor_NotEmptyList(t *testing.T) {
 	// ASSERT
 	assert.Len(t, response.Motorcycles, 2)
 }
+
+// TestListMotorcyclesInteractor_Query gets the first page of a filtered and sorted list of motorcycles, with the cursor of the next page.
+func TestListMotorcyclesInteractor_Query(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	repo, _ := repository.NewMotorcycleRepository()
+	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	for year, vin := range map[int]string{2006: "01234567890123456", 2009: "01234567499923456", 2012: "11234567590123456"} {
+		insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", year, vin, vincheck.UndefinedVinCheck)
+		insertInteractor.Handle(insertRequest)
+	}
+	sorts, _ := entity.ParseMotorcycleSort("-year")
+	listRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{MinYear: 2009, Sort: sorts, Limit: 1})
+	listInteractor, _ := NewListMotorcyclesInteractor(repo, authService)
+
+	// ACT
+	response, _ := listInteractor.Handle(listRequest)
+
+	// ASSERT
+	assert.Nil(t, response.Error)
+	assert.Len(t, response.Motorcycles, 1)
+	assert.True(t, response.Motorcycles[0].Year == 2012)
+	assert.True(t, response.Next != nil && response.Next.Year == 2012)
+}
+
+// TestListMotorcyclesInteractor_InvalidQuery verifies that a request with an invalid query cannot be created.
+func TestListMotorcyclesInteractor_InvalidQuery(t *testing.T) {
+
+	// ARRANGE
+
+	// ACT
+	_, err := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{MinYear: 2012, MaxYear: 2009})
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
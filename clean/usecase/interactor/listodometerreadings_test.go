@@ -0,0 +1,44 @@
+// Package interactor implements unit tests for the ListOdometerReadingsInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestListOdometerReadingsInteractor_Handle verifies that the owner and an Admin can list a motorcycle's readings, but other users cannot.
+func TestListOdometerReadingsInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	admin := newRiderAuthService("admin", authorizationrole.AdminAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	insertInteractor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, alice)
+	insertOdometerReading(insertInteractor, motorcycleID, 1200, time.Now().Add(-time.Hour), false)
+	insertOdometerReading(insertInteractor, motorcycleID, 1300, time.Time{}, false)
+	listRequest, _ := request.NewListOdometerReadingsRequest(motorcycleID)
+	aliceInteractor, _ := NewListOdometerReadingsInteractor(motorcycles, readings, alice)
+	bobInteractor, _ := NewListOdometerReadingsInteractor(motorcycles, readings, bob)
+	adminInteractor, _ := NewListOdometerReadingsInteractor(motorcycles, readings, admin)
+
+	// ACT
+	aliceResponse, _ := aliceInteractor.Handle(listRequest)
+	bobResponse, _ := bobInteractor.Handle(listRequest)
+	adminResponse, _ := adminInteractor.Handle(listRequest)
+
+	// ASSERT
+	assert.True(t, aliceResponse.Status == operationstatus.Ok)
+	assert.True(t, len(aliceResponse.Readings) == 2)
+	assert.True(t, aliceResponse.Readings[1].Value == 1300)
+	assert.True(t, bobResponse.Status == operationstatus.NotFound)
+	assert.True(t, len(adminResponse.Readings) == 2)
+}
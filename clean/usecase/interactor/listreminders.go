@@ -0,0 +1,130 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+List the reminders of a motorcycle.
+
+DESCRIPTION
+User accesses the system to view the reminders of a motorcycle, such as a registration renewal.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to list reminders.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has viewed the motorcycle's reminders, in the order that they are due.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Reminders..." from the menu.
+2. System displays a view in which the user selects a motorcycle.
+3. User click the "Submit" button.
+4. System displays the motorcycle's reminders.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to list reminders.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to list reminders.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that a motorcycle with the
+	   ID does not exist.  The User clicks the "OK" button, and
+	   returns to the primary view.
+*/
+
+// ListRemindersInteractor is a use case for listing the reminders of a motorcycle.
+type ListRemindersInteractor struct {
+	MotorcycleRepository contract.MotorcycleRepository
+	ReminderRepository   contract.ReminderRepository
+	AuthService          contract.AuthService
+}
+
+// NewListRemindersInteractor creates a new instance of a ListRemindersInteractor.
+// Returns (nil, error) when there is an error, otherwise (ListRemindersInteractor, nil).
+func NewListRemindersInteractor(motorcycleRepository contract.MotorcycleRepository, reminderRepository contract.ReminderRepository, authService contract.AuthService) (*ListRemindersInteractor, error) {
+
+	interactor := &ListRemindersInteractor{
+		MotorcycleRepository: motorcycleRepository,
+		ReminderRepository:   reminderRepository,
+		AuthService:          authService,
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a ListRemindersInteractor's fields contain valid data.
+// Returns nil if the ListRemindersInteractor contains valid data, otherwise an error.
+func (interactor ListRemindersInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ReminderRepository is required and cannot be null.
+		validation.Field(&interactor.ReminderRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *ListRemindersInteractor) Handle(requestMessage *request.ListRemindersRequest) (*response.ListRemindersResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewListRemindersResponse(requestMessage.MotorcycleID, nil, operationstatus.NotAuthenticated, errors.New("list operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.ListRemindersPermission) {
+		return response.NewListRemindersResponse(requestMessage.MotorcycleID, nil, operationstatus.NotAuthorized, errors.New("list operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the motorcycle exists and belongs to the user.
+	_, status, err := findAccessibleMotorcycle(interactor.MotorcycleRepository, interactor.AuthService, requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewListRemindersResponse(requestMessage.MotorcycleID, nil, status, err)
+	}
+
+	// Get the motorcycle's reminders from the repository.
+	reminders, status, err := interactor.ReminderRepository.ListByMotorcycle(requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewListRemindersResponse(requestMessage.MotorcycleID, nil, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewListRemindersResponse(requestMessage.MotorcycleID, reminders, operationstatus.Ok, nil)
+}
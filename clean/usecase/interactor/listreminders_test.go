@@ -0,0 +1,67 @@
+// Package interactor implements unit tests for the ListRemindersInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// insertReminder inserts a reminder for the motorcycle that is due in a week.
+// Returns the ID of the new reminder.
+func insertReminder(repo contract.ReminderRepository, motorcycleID typedef.ID, name string) typedef.ID {
+	reminder, _ := entity.NewReminder(motorcycleID, name, time.Now().UTC().AddDate(0, 0, 7))
+	inserted, _, _ := repo.Insert(reminder)
+	return inserted.ID
+}
+
+// TestListRemindersInteractor_Handle verifies that a motorcycle's reminders are listed.
+func TestListRemindersInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	otherID := insertOwnedMotorcycle(motorcycles, alice, "11234567590123456")
+	insertReminder(reminders, motorcycleID, "Annual service")
+	insertReminder(reminders, motorcycleID, "Registration renewal")
+	insertReminder(reminders, otherID, "Annual service")
+	interactor, _ := NewListRemindersInteractor(motorcycles, reminders, alice)
+	listRequest, _ := request.NewListRemindersRequest(motorcycleID)
+
+	// ACT
+	listResponse, _ := interactor.Handle(listRequest)
+
+	// ASSERT
+	assert.True(t, listResponse.Status == operationstatus.Ok)
+	assert.True(t, len(listResponse.Reminders) == 2)
+}
+
+// TestListRemindersInteractor_OtherOwner verifies that a user cannot list the reminders of another user's motorcycle.
+func TestListRemindersInteractor_OtherOwner(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewListRemindersInteractor(motorcycles, reminders, bob)
+	listRequest, _ := request.NewListRemindersRequest(motorcycleID)
+
+	// ACT
+	listResponse, _ := interactor.Handle(listRequest)
+
+	// ASSERT
+	assert.True(t, listResponse.Status == operationstatus.NotFound)
+	assert.NotNil(t, listResponse.Error)
+}
@@ -0,0 +1,130 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+List the service records of a motorcycle.
+
+DESCRIPTION
+User accesses the system to view the service and maintenance history of a motorcycle.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to list service records.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has viewed the motorcycle's service records, in the order that the services were performed.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Service History..." from the menu.
+2. System displays a view in which the user selects a motorcycle.
+3. User click the "Submit" button.
+4. System displays the motorcycle's service records.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to list service records.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to list service records.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that a motorcycle with the
+	   ID does not exist.  The User clicks the "OK" button, and
+	   returns to the primary view.
+*/
+
+// ListServiceRecordsInteractor is a use case for listing the service records of a motorcycle.
+type ListServiceRecordsInteractor struct {
+	MotorcycleRepository    contract.MotorcycleRepository
+	ServiceRecordRepository contract.ServiceRecordRepository
+	AuthService             contract.AuthService
+}
+
+// NewListServiceRecordsInteractor creates a new instance of a ListServiceRecordsInteractor.
+// Returns (nil, error) when there is an error, otherwise (ListServiceRecordsInteractor, nil).
+func NewListServiceRecordsInteractor(motorcycleRepository contract.MotorcycleRepository, serviceRecordRepository contract.ServiceRecordRepository, authService contract.AuthService) (*ListServiceRecordsInteractor, error) {
+
+	interactor := &ListServiceRecordsInteractor{
+		MotorcycleRepository:    motorcycleRepository,
+		ServiceRecordRepository: serviceRecordRepository,
+		AuthService:             authService,
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a ListServiceRecordsInteractor's fields contain valid data.
+// Returns nil if the ListServiceRecordsInteractor contains valid data, otherwise an error.
+func (interactor ListServiceRecordsInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ServiceRecordRepository is required and cannot be null.
+		validation.Field(&interactor.ServiceRecordRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *ListServiceRecordsInteractor) Handle(requestMessage *request.ListServiceRecordsRequest) (*response.ListServiceRecordsResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewListServiceRecordsResponse(requestMessage.MotorcycleID, nil, operationstatus.NotAuthenticated, errors.New("list operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.ListServiceRecordsPermission) {
+		return response.NewListServiceRecordsResponse(requestMessage.MotorcycleID, nil, operationstatus.NotAuthorized, errors.New("list operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the motorcycle exists and belongs to the user.
+	_, status, err := findAccessibleMotorcycle(interactor.MotorcycleRepository, interactor.AuthService, requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewListServiceRecordsResponse(requestMessage.MotorcycleID, nil, status, err)
+	}
+
+	// Get the motorcycle's service records from the repository.
+	records, status, err := interactor.ServiceRecordRepository.ListByMotorcycle(requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewListServiceRecordsResponse(requestMessage.MotorcycleID, nil, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewListServiceRecordsResponse(requestMessage.MotorcycleID, records, operationstatus.Ok, nil)
+}
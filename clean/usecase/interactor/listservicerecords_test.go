@@ -0,0 +1,58 @@
+// Package interactor implements unit tests for the ListServiceRecordsInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestListServiceRecordsInteractor_Handle verifies that the service history is listed in the order that the service was performed.
+func TestListServiceRecordsInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	insertInteractor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+	now := time.Now()
+	_, laterID := insertServiceRecord(insertInteractor, motorcycleID, now.AddDate(0, 0, -1), 5000)
+	_, earlierID := insertServiceRecord(insertInteractor, motorcycleID, now.AddDate(0, -1, 0), 4000)
+	interactor, _ := NewListServiceRecordsInteractor(motorcycles, records, alice)
+	listRequest, _ := request.NewListServiceRecordsRequest(motorcycleID)
+
+	// ACT
+	listResponse, _ := interactor.Handle(listRequest)
+
+	// ASSERT
+	assert.True(t, listResponse.Status == operationstatus.Ok)
+	assert.True(t, len(listResponse.ServiceRecords) == 2)
+	assert.True(t, listResponse.ServiceRecords[0].ID == earlierID)
+	assert.True(t, listResponse.ServiceRecords[1].ID == laterID)
+}
+
+// TestListServiceRecordsInteractor_OtherOwner verifies that a user cannot list the service history of another user's motorcycle.
+func TestListServiceRecordsInteractor_OtherOwner(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewListServiceRecordsInteractor(motorcycles, records, bob)
+	listRequest, _ := request.NewListServiceRecordsRequest(motorcycleID)
+
+	// ACT
+	listResponse, _ := interactor.Handle(listRequest)
+
+	// ASSERT
+	assert.True(t, listResponse.Status == operationstatus.NotFound)
+	assert.NotNil(t, listResponse.Error)
+}
@@ -0,0 +1,27 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+package interactor
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+)
+
+// nopLogger discards every entry.  It is the logger of an interactor whose creator did not provide one.
+type nopLogger struct {
+}
+
+// WithField implements contract.Logger.WithField().
+func (logger nopLogger) WithField(key string, value interface{}) contract.Logger {
+	return logger
+}
+
+// Info implements contract.Logger.Info().
+func (logger nopLogger) Info(message string) {
+}
+
+// Warn implements contract.Logger.Warn().
+func (logger nopLogger) Warn(err error, message string) {
+}
+
+// Error implements contract.Logger.Error().
+func (logger nopLogger) Error(err error, message string) {
+}
@@ -0,0 +1,88 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+package interactor
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/pkg/errors"
+)
+
+// canAccessAllMotorcycles determines whether the user may access every user's motorcycles, rather than only their own.
+// Returns true if the user may access all motorcycles, otherwise false.
+func canAccessAllMotorcycles(authService contract.AuthService) bool {
+	return authService.IsPermitted(permission.AccessAllMotorcyclesPermission)
+}
+
+// canAccessMotorcycle determines whether the user may access the motorcycle, which is when they own it,
+// or when they may access all motorcycles.  A user who cannot be identified does not own any motorcycles.
+// Returns true if the user may access the motorcycle, otherwise false.
+func canAccessMotorcycle(authService contract.AuthService, motorcycle *entity.Motorcycle) bool {
+	if canAccessAllMotorcycles(authService) {
+		return true
+	}
+
+	return authService.UserID() != "" && motorcycle.OwnerID == authService.UserID()
+}
+
+// findAccessibleMotorcycle finds the motorcycle with the ID, which the user must be able to access.  A motorcycle that
+// belongs to another user is reported as not found, so its existence is not disclosed.
+// Returns (motorcycle, Ok, nil) on success, otherwise (nil, operationStatus, error).
+func findAccessibleMotorcycle(motorcycleRepository contract.MotorcycleRepository, authService contract.AuthService, id typedef.ID) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	motorcycle, status, err := motorcycleRepository.FindByID(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if motorcycle == nil || !canAccessMotorcycle(authService, motorcycle) {
+		return nil, operationstatus.NotFound, errors.Errorf("the motorcycle with ID %d doesn't exist in the repository", id)
+	}
+
+	return motorcycle, operationstatus.Ok, nil
+}
+
+// findAccessibleServiceRecord finds the service record with the ID, which must belong to the motorcycle, and the user must be able
+// to access the motorcycle.  A service record that belongs to another motorcycle is reported as not found.
+// Returns (service record, Ok, nil) on success, otherwise (nil, operationStatus, error).
+func findAccessibleServiceRecord(motorcycleRepository contract.MotorcycleRepository, serviceRecordRepository contract.ServiceRecordRepository,
+	authService contract.AuthService, motorcycleID typedef.ID, id typedef.ID) (*entity.ServiceRecord, operationstatus.OperationStatus, error) {
+	_, status, err := findAccessibleMotorcycle(motorcycleRepository, authService, motorcycleID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	record, status, err := serviceRecordRepository.FindByID(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if record == nil || record.MotorcycleID != motorcycleID {
+		return nil, operationstatus.NotFound, errors.Errorf("the service record with ID %d doesn't exist in the repository", id)
+	}
+
+	return record, operationstatus.Ok, nil
+}
+
+// findAccessibleReminder finds the reminder with the ID, which must belong to the motorcycle, and the user must be able
+// to access the motorcycle.  A reminder that belongs to another motorcycle is reported as not found.
+// Returns (reminder, Ok, nil) on success, otherwise (nil, operationStatus, error).
+func findAccessibleReminder(motorcycleRepository contract.MotorcycleRepository, reminderRepository contract.ReminderRepository,
+	authService contract.AuthService, motorcycleID typedef.ID, id typedef.ID) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	_, status, err := findAccessibleMotorcycle(motorcycleRepository, authService, motorcycleID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	reminder, status, err := reminderRepository.FindByID(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if reminder == nil || reminder.MotorcycleID != motorcycleID {
+		return nil, operationstatus.NotFound, errors.Errorf("the reminder with ID %d doesn't exist in the repository", id)
+	}
+
+	return reminder, operationstatus.Ok, nil
+}
@@ -0,0 +1,211 @@
+// Package interactor implements unit tests for the ownership of motorcycles.
+package interactor
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRiderAuthService creates an auth service for an authenticated user with the role.
+func newRiderAuthService(subject string, role authorizationrole.AuthorizationRole) *security.AuthService {
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{role: true})
+	authService.Subject = subject
+	return authService
+}
+
+// insertOwnedMotorcycle inserts a motorcycle on behalf of the user.
+// Returns the ID of the new motorcycle.
+func insertOwnedMotorcycle(repo contract.MotorcycleRepository, authService contract.AuthService, vin string) typedef.ID {
+	interactor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, vin, vincheck.UndefinedVinCheck)
+	insertResponse, _ := interactor.Handle(insertRequest)
+	return insertResponse.ID
+}
+
+// TestOwnership_Insert verifies that the user who inserts a motorcycle owns it.
+func TestOwnership_Insert(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := repository.NewMotorcycleRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+
+	// ACT
+	id := insertOwnedMotorcycle(repo, alice, "01234567890123456")
+	motorcycle, _, _ := repo.FindByID(id)
+
+	// ASSERT
+	assert.True(t, motorcycle.OwnerID == "alice")
+}
+
+// TestOwnership_Insert_UnidentifiedUser verifies that a user who cannot be identified cannot own a motorcycle.
+func TestOwnership_Insert_UnidentifiedUser(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := repository.NewMotorcycleRepository()
+	anonymous := newRiderAuthService("", authorizationrole.GeneralAuthorizationRole)
+	interactor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), anonymous)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+
+	// ACT
+	response, _ := interactor.Handle(insertRequest)
+	motorcycles, _, _ := repo.List()
+
+	// ASSERT
+	assert.True(t, response.Status == operationstatus.NotAuthorized)
+	assert.True(t, len(motorcycles) == 0)
+}
+
+// TestOwnership_List verifies that a General user only lists their own motorcycles, while an Admin lists all of them.
+func TestOwnership_List(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := repository.NewMotorcycleRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	admin := newRiderAuthService("admin", authorizationrole.AdminAuthorizationRole)
+	insertOwnedMotorcycle(repo, alice, "01234567890123456")
+	insertOwnedMotorcycle(repo, bob, "11234567590123456")
+	insertOwnedMotorcycle(repo, bob, "21234567290123456")
+	listRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{})
+
+	// ACT
+	aliceInteractor, _ := NewListMotorcyclesInteractor(repo, alice)
+	aliceResponse, _ := aliceInteractor.Handle(listRequest)
+	adminInteractor, _ := NewListMotorcyclesInteractor(repo, admin)
+	adminResponse, _ := adminInteractor.Handle(listRequest)
+
+	// ASSERT
+	assert.True(t, len(aliceResponse.Motorcycles) == 1)
+	assert.True(t, aliceResponse.Motorcycles[0].OwnerID == "alice")
+	assert.True(t, len(adminResponse.Motorcycles) == 3)
+}
+
+// TestOwnership_List_QueryOwner verifies that a General user cannot list another user's motorcycles by choosing the owner in the query.
+func TestOwnership_List_QueryOwner(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := repository.NewMotorcycleRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	insertOwnedMotorcycle(repo, alice, "01234567890123456")
+	insertOwnedMotorcycle(repo, bob, "11234567590123456")
+	listRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{OwnerID: "bob"})
+	interactor, _ := NewListMotorcyclesInteractor(repo, alice)
+
+	// ACT
+	response, _ := interactor.Handle(listRequest)
+
+	// ASSERT
+	assert.True(t, len(response.Motorcycles) == 1)
+	assert.True(t, response.Motorcycles[0].OwnerID == "alice")
+}
+
+// TestOwnership_List_UnownedMotorcycle verifies that a motorcycle without an owner is only listed for an Admin.
+func TestOwnership_List_UnownedMotorcycle(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := repository.NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	repo.Insert(motorcycle)
+	anonymous := newRiderAuthService("", authorizationrole.GeneralAuthorizationRole)
+	listRequest, _ := request.NewListMotorcyclesRequest(entity.MotorcycleQuery{})
+	interactor, _ := NewListMotorcyclesInteractor(repo, anonymous)
+
+	// ACT
+	response, _ := interactor.Handle(listRequest)
+
+	// ASSERT
+	assert.Nil(t, response.Error)
+	assert.True(t, len(response.Motorcycles) == 0)
+}
+
+// TestOwnership_Get verifies that another user's motorcycle is not found.
+func TestOwnership_Get(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := repository.NewMotorcycleRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	admin := newRiderAuthService("admin", authorizationrole.AdminAuthorizationRole)
+	id := insertOwnedMotorcycle(repo, alice, "01234567890123456")
+	getRequest, _ := request.NewGetMotorcycleRequest(id)
+
+	// ACT
+	bobInteractor, _ := NewGetMotorcycleInteractor(repo, bob)
+	bobResponse, _ := bobInteractor.Handle(getRequest)
+	adminInteractor, _ := NewGetMotorcycleInteractor(repo, admin)
+	adminResponse, _ := adminInteractor.Handle(getRequest)
+
+	// ASSERT
+	assert.True(t, bobResponse.Status == operationstatus.NotFound)
+	assert.Nil(t, bobResponse.Motorcycle)
+	assert.True(t, adminResponse.Status == operationstatus.Ok)
+	assert.True(t, adminResponse.Motorcycle.OwnerID == "alice")
+}
+
+// TestOwnership_Update verifies that another user's motorcycle cannot be updated, and that an update does not change the owner.
+func TestOwnership_Update(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := repository.NewMotorcycleRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	id := insertOwnedMotorcycle(repo, alice, "01234567890123456")
+	motorcycle, _ := entity.NewMotorcycle("Harley Davidson", "Softail", 2006, "01234567890123456")
+	motorcycle.OwnerID = "bob"
+	updateRequest, _ := request.NewUpdateMotorcycleRequest(id, constant.AnyRowVersion, motorcycle)
+
+	// ACT
+	bobInteractor, _ := NewUpdateMotorcycleInteractor(repo, bob)
+	bobResponse, _ := bobInteractor.Handle(updateRequest)
+	aliceInteractor, _ := NewUpdateMotorcycleInteractor(repo, alice)
+	aliceResponse, _ := aliceInteractor.Handle(updateRequest)
+	updated, _, _ := repo.FindByID(id)
+
+	// ASSERT
+	assert.True(t, bobResponse.Status == operationstatus.NotFound)
+	assert.True(t, aliceResponse.Status == operationstatus.Ok)
+	assert.True(t, updated.Make == "Harley Davidson")
+	assert.True(t, updated.OwnerID == "alice")
+}
+
+// TestOwnership_Delete verifies that another user's motorcycle cannot be deleted, except by an Admin.
+func TestOwnership_Delete(t *testing.T) {
+
+	// ARRANGE
+	repo, _ := repository.NewMotorcycleRepository()
+	admin := newRiderAuthService("admin", authorizationrole.AdminAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	id := insertOwnedMotorcycle(repo, bob, "01234567890123456")
+	policy := security.DefaultRolePolicy()
+	policy.Grants[authorizationrole.GeneralAuthorizationRole][permission.DeleteMotorcyclePermission] = true
+	carol, _ := security.NewAuthServiceWithPolicy(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.GeneralAuthorizationRole: true}, policy)
+	carol.Subject = "carol"
+	deleteRequest, _ := request.NewDeleteMotorcycleRequest(id, constant.AnyRowVersion)
+
+	// ACT
+	carolInteractor, _ := NewDeleteMotorcycleInteractor(repo, carol)
+	carolResponse, _ := carolInteractor.Handle(deleteRequest)
+	existsAfterCarol, _, _ := repo.ExistsByID(id)
+	adminInteractor, _ := NewDeleteMotorcycleInteractor(repo, admin)
+	adminResponse, _ := adminInteractor.Handle(deleteRequest)
+	existsAfterAdmin, _, _ := repo.ExistsByID(id)
+
+	// ASSERT
+	assert.True(t, carolResponse.Status == operationstatus.NotFound)
+	assert.True(t, existsAfterCarol)
+	assert.True(t, adminResponse.Status == operationstatus.Ok)
+	assert.False(t, existsAfterAdmin)
+}
@@ -0,0 +1,216 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Partially update an existing motorcycle in the motorcycle repository.
+
+DESCRIPTION
+User accesses the system to change some of the fields of a motorcycle, without providing the others.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to update a motorcycle.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has changed the fields of a motorcycle in the system, unless it didn't exist.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Edit Motorcycle..." in the menu.
+2. System displays a view in which the user selects a motorcycle to edit.
+3. User changes some of the fields of the motorcycle.
+4. User click the "Submit" button.
+5. System applies the changes to the motorcycle in the motorcycle repository, and displays the changed motorcycle.
+6. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to update a motorcycle.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to update a motorcycle.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that a motorcycle with the
+	   ID does not exist.  The User clicks the "OK" button, and
+	   returns to the primary view.
+
+(3d) The motorcycle has been changed by someone else since the User selected it.
+       System displays an error message indicating that the motorcycle has been
+	   changed.  The User clicks the "OK" button, and returns to the view to
+	   select the motorcycle again.
+
+(3e) The changes cannot be made to the motorcycle, they change a field that is managed by the System,
+     or the changed motorcycle is not valid.
+       System displays an error message describing the problem with the changes.  The User
+	   clicks the "OK" button, and returns to the view to correct the changes.
+*/
+
+// PatchMotorcycleInteractor is a use case for partially updating a motorcycle in the motorcycle repository.
+type PatchMotorcycleInteractor struct {
+	MotorcycleRepository contract.MotorcycleRepository
+	AuthService          contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
+}
+
+// NewPatchMotorcycleInteractor creates a new instance of a PatchMotorcycleInteractor.
+// Returns (nil, error) when there is an error, otherwise (PatchMotorcycleInteractor, nil).
+func NewPatchMotorcycleInteractor(motorcycleRepository contract.MotorcycleRepository, authService contract.AuthService) (*PatchMotorcycleInteractor, error) {
+
+	interactor := &PatchMotorcycleInteractor{
+		MotorcycleRepository: motorcycleRepository,
+		AuthService:          authService,
+		Logger:               nopLogger{},
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a PatchMotorcycleInteractor's fields contain valid data.
+// Returns nil if the PatchMotorcycleInteractor contains valid data, otherwise an error.
+func (interactor PatchMotorcycleInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *PatchMotorcycleInteractor) Handle(requestMessage *request.PatchMotorcycleRequest) (*response.PatchMotorcycleResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewPatchMotorcycleResponse(nil, operationstatus.NotAuthenticated, errors.New("patch operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.UpdateMotorcyclePermission) {
+		return response.NewPatchMotorcycleResponse(nil, operationstatus.NotAuthorized, errors.New("patch operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the motorcycle belongs to the user.  One that belongs to another user is reported as not found,
+	// so its existence is not disclosed.
+	existing, status, err := interactor.MotorcycleRepository.FindByID(requestMessage.ID)
+	if err != nil {
+		return response.NewPatchMotorcycleResponse(nil, status, err)
+	}
+
+	if existing == nil || !canAccessMotorcycle(interactor.AuthService, existing) {
+		return response.NewPatchMotorcycleResponse(nil, operationstatus.NotFound, errors.Errorf("cannot patch the motorcycle with ID %d because it doesn't exist in the repository", requestMessage.ID))
+	}
+
+	// Apply the changes to the motorcycle, as it is represented to the user.
+	motorcycle, status, err := patchMotorcycle(existing, requestMessage)
+	if err != nil {
+		return response.NewPatchMotorcycleResponse(nil, status, err)
+	}
+
+	// When the user did not say which version they changed, it is the one that was patched, so a change that
+	// someone else makes in the meantime is not lost.
+	motorcycle.RowVersion = requestMessage.RowVersion
+	if motorcycle.RowVersion == constant.AnyRowVersion {
+		motorcycle.RowVersion = existing.RowVersion
+	}
+
+	// Update the motorcycle in the repository, as long as nobody else has changed it since it was read.
+	updated, status, err := interactor.MotorcycleRepository.Update(requestMessage.ID, motorcycle)
+	if err != nil {
+		return response.NewPatchMotorcycleResponse(nil, status, err)
+	}
+
+	// Save the changes.
+	status, err = interactor.MotorcycleRepository.Save()
+	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("motorcycleId", requestMessage.ID).Error(err, "Failed to save the patched motorcycle.")
+		return response.NewPatchMotorcycleResponse(nil, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewPatchMotorcycleResponse(updated, operationstatus.Ok, nil)
+}
+
+// patchMotorcycle applies the changes in the request to a copy of the motorcycle.  The fields that are managed by the
+// repository, such as its ID and row version, cannot be changed, although a JSON patch may test them.
+// Returns (patched motorcycle, Ok, nil) on success, otherwise (nil, operationStatus, error).
+func patchMotorcycle(existing *entity.Motorcycle, requestMessage *request.PatchMotorcycleRequest) (*entity.Motorcycle, operationstatus.OperationStatus, error) {
+	document, err := json.Marshal(existing)
+	if err != nil {
+		return nil, operationstatus.InternalError, err
+	}
+
+	document, err = requestMessage.Patch.Apply(document)
+	if err != nil {
+		return nil, operationstatus.UnprocessableEntity, errors.Wrapf(err, "cannot patch the motorcycle with ID %d", requestMessage.ID)
+	}
+
+	// A field that a motorcycle does not have is a mistake, rather than something to ignore.
+	motorcycle := &entity.Motorcycle{}
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(motorcycle)
+	if err != nil {
+		return nil, operationstatus.BadRequest, errors.Wrapf(err, "cannot patch the motorcycle with ID %d", requestMessage.ID)
+	}
+
+	switch {
+	case motorcycle.ID != existing.ID:
+		return nil, operationstatus.BadRequest, errors.Errorf("cannot patch the motorcycle with ID %d because its id is managed by the server", requestMessage.ID)
+	case !motorcycle.CreatedUtc.Equal(existing.CreatedUtc):
+		return nil, operationstatus.BadRequest, errors.Errorf("cannot patch the motorcycle with ID %d because its createdUtc is managed by the server", requestMessage.ID)
+	case !motorcycle.ModifiedUtc.Equal(existing.ModifiedUtc):
+		return nil, operationstatus.BadRequest, errors.Errorf("cannot patch the motorcycle with ID %d because its modifiedUtc is managed by the server", requestMessage.ID)
+	case motorcycle.RowVersion != existing.RowVersion:
+		return nil, operationstatus.BadRequest, errors.Errorf("cannot patch the motorcycle with ID %d because its rowVersion is managed by the server, so use an If-Match header instead", requestMessage.ID)
+	case motorcycle.OwnerID != existing.OwnerID:
+		return nil, operationstatus.BadRequest, errors.Errorf("cannot patch the motorcycle with ID %d because its ownerId is managed by the server", requestMessage.ID)
+	}
+
+	// Verify that the patched motorcycle is still valid.
+	err = motorcycle.Validate()
+	if err != nil {
+		return nil, operationstatus.BadRequest, errors.Wrapf(err, "cannot patch the motorcycle with ID %d", requestMessage.ID)
+	}
+
+	return motorcycle, operationstatus.Ok, nil
+}
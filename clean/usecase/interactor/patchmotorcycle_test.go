@@ -0,0 +1,130 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/patchformat"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPatchMotorcycleInteractor_MotorcycleRepositoryIsNil verifies that a nil motorcycle repository fails properly.
+func TestPatchMotorcycleInteractor_MotorcycleRepositoryIsNil(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{})
+
+	// ACT
+	_, err := NewPatchMotorcycleInteractor(nil, authService)
+
+	// ASSERT
+	assert.NotNil(t, err)
+}
+
+// TestPatchMotorcycleInteractor_MergePatch verifies that only the fields in a merge patch are changed.
+func TestPatchMotorcycleInteractor_MergePatch(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.AdminAuthorizationRole: true})
+	repo, _ := repository.NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	patchRequest, _ := request.NewPatchMotorcycleRequest(moto.ID, constant.AnyRowVersion, patchformat.MergePatchFormat, []byte(`{"model": "Gold Wing"}`))
+	interactor, _ := NewPatchMotorcycleInteractor(repo, authService)
+
+	// ACT
+	response, _ := interactor.Handle(patchRequest)
+
+	// ASSERT
+	assert.Nil(t, response.Error)
+	assert.True(t, response.Motorcycle.Model == "Gold Wing")
+	assert.True(t, response.Motorcycle.Make == "Honda")
+	assert.True(t, response.Motorcycle.CreatedUtc.Equal(moto.CreatedUtc))
+	assert.True(t, response.Motorcycle.RowVersion == moto.RowVersion+1)
+}
+
+// TestPatchMotorcycleInteractor_ServerManagedField verifies that a field managed by the server cannot be changed,
+// although a JSON patch may test it.
+func TestPatchMotorcycleInteractor_ServerManagedField(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.AdminAuthorizationRole: true})
+	repo, _ := repository.NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	changeID, _ := request.NewPatchMotorcycleRequest(moto.ID, constant.AnyRowVersion, patchformat.MergePatchFormat, []byte(`{"id": 99}`))
+	changeCreated, _ := request.NewPatchMotorcycleRequest(moto.ID, constant.AnyRowVersion, patchformat.JsonPatchFormat,
+		[]byte(`[{"op": "replace", "path": "/createdUtc", "value": "2001-01-01T00:00:00Z"}]`))
+	testRowVersion, _ := request.NewPatchMotorcycleRequest(moto.ID, constant.AnyRowVersion, patchformat.JsonPatchFormat,
+		[]byte(`[{"op": "test", "path": "/rowVersion", "value": 1}, {"op": "replace", "path": "/year", "value": 2007}]`))
+	interactor, _ := NewPatchMotorcycleInteractor(repo, authService)
+
+	// ACT
+	idResponse, _ := interactor.Handle(changeID)
+	createdResponse, _ := interactor.Handle(changeCreated)
+	testResponse, _ := interactor.Handle(testRowVersion)
+
+	// ASSERT
+	assert.True(t, idResponse.Status == operationstatus.BadRequest)
+	assert.True(t, createdResponse.Status == operationstatus.BadRequest)
+	assert.Nil(t, testResponse.Error)
+	assert.True(t, testResponse.Motorcycle.Year == 2007)
+}
+
+// TestPatchMotorcycleInteractor_Unprocessable verifies that a patch that cannot be applied, or that makes the motorcycle invalid, is rejected.
+func TestPatchMotorcycleInteractor_Unprocessable(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.AdminAuthorizationRole: true})
+	repo, _ := repository.NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	failedTest, _ := request.NewPatchMotorcycleRequest(moto.ID, constant.AnyRowVersion, patchformat.JsonPatchFormat, []byte(`[{"op": "test", "path": "/make", "value": "Yamaha"}]`))
+	invalid, _ := request.NewPatchMotorcycleRequest(moto.ID, constant.AnyRowVersion, patchformat.MergePatchFormat, []byte(`{"make": "Ford"}`))
+	unknownField, _ := request.NewPatchMotorcycleRequest(moto.ID, constant.AnyRowVersion, patchformat.MergePatchFormat, []byte(`{"color": "red"}`))
+	interactor, _ := NewPatchMotorcycleInteractor(repo, authService)
+
+	// ACT
+	failedTestResponse, _ := interactor.Handle(failedTest)
+	invalidResponse, _ := interactor.Handle(invalid)
+	unknownFieldResponse, _ := interactor.Handle(unknownField)
+	unchanged, _, _ := repo.FindByID(moto.ID)
+
+	// ASSERT
+	assert.True(t, failedTestResponse.Status == operationstatus.UnprocessableEntity)
+	assert.True(t, invalidResponse.Status == operationstatus.BadRequest)
+	assert.True(t, unknownFieldResponse.Status == operationstatus.BadRequest)
+	assert.True(t, unchanged.Make == "Honda")
+	assert.True(t, unchanged.RowVersion == moto.RowVersion)
+}
+
+// TestPatchMotorcycleInteractor_StaleRowVersion verifies that a patch of a version that has since been changed is a conflict.
+func TestPatchMotorcycleInteractor_StaleRowVersion(t *testing.T) {
+
+	// ARRANGE
+	authService, _ := security.NewAuthService(true, map[authorizationrole.AuthorizationRole]bool{authorizationrole.AdminAuthorizationRole: true})
+	repo, _ := repository.NewMotorcycleRepository()
+	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
+	moto, _, _ := repo.Insert(motorcycle)
+	patchRequest, _ := request.NewPatchMotorcycleRequest(moto.ID, moto.RowVersion, patchformat.MergePatchFormat, []byte(`{"year": 2007}`))
+	interactor, _ := NewPatchMotorcycleInteractor(repo, authService)
+	interactor.Handle(patchRequest)
+
+	// ACT
+	response, _ := interactor.Handle(patchRequest)
+
+	// ASSERT
+	assert.True(t, response.Status == operationstatus.Conflict)
+}
@@ -10,8 +10,9 @@ import (
 	"github.com/abitofhelp/motominderapi/clean/domain/constant"
 	"github.com/abitofhelp/motominderapi/clean/domain/contract"
 	"github.com/abitofhelp/motominderapi/clean/domain/entity"
-	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/abitofhelp/motominderapi/clean/usecase/response"
 	"github.com/go-ozzo/ozzo-validation"
@@ -61,21 +62,31 @@ EXTENSIONS
        System displays an error message indicating that a motorcycle with the same
 	   make, model, and year already exists.  The User clicks the "OK" button, and
 	   returns to the primary view.
+
+(3d) The User asked for the make and year to be filled in or verified from the VIN, and they do not match it.
+       System displays an error message describing how the make or year differs from the one
+	   decoded from the VIN.  The User clicks the "OK" button, and returns to the view to
+	   correct the motorcycle.
 */
 
 // InsertMotorcycleInteractor is a use case for adding a motorcycle to the motorcycle repository.
 type InsertMotorcycleInteractor struct {
-	MotorcycleRepository contract.MotorcycleRepository
-	AuthService          contract.AuthService
+	MotorcycleRepository   contract.MotorcycleRepository
+	ManufacturerRepository contract.ManufacturerRepository
+	AuthService            contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
 }
 
 // NewInsertMotorcycleInteractor creates a new instance of a InsertMotorcycleInteractor.
 // Returns (nil, error) when there is an error, otherwise (InsertMotorcycleInteractor, nil).
-func NewInsertMotorcycleInteractor(motorcycleRepository contract.MotorcycleRepository, authService contract.AuthService) (*InsertMotorcycleInteractor, error) {
+func NewInsertMotorcycleInteractor(motorcycleRepository contract.MotorcycleRepository, manufacturerRepository contract.ManufacturerRepository, authService contract.AuthService) (*InsertMotorcycleInteractor, error) {
 
 	interactor := &InsertMotorcycleInteractor{
-		MotorcycleRepository: motorcycleRepository,
-		AuthService:          authService,
+		MotorcycleRepository:   motorcycleRepository,
+		ManufacturerRepository: manufacturerRepository,
+		AuthService:            authService,
+		Logger:                 nopLogger{},
 	}
 
 	// Validate the interactor
@@ -94,6 +105,8 @@ func (interactor InsertMotorcycleInteractor) Validate() error {
 	return validation.ValidateStruct(&interactor,
 		// MotorcycleRepository is required and cannot be null.
 		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ManufacturerRepository is required and cannot be null.
+		validation.Field(&interactor.ManufacturerRepository, validation.Required),
 		// AuthService is required and cannot be null.
 		validation.Field(&interactor.AuthService, validation.Required))
 }
@@ -107,19 +120,32 @@ func (interactor *InsertMotorcycleInteractor) Handle(requestMessage *request.Ins
 		return response.NewInsertMotorcycleResponse(constant.InvalidEntityID, operationstatus.NotAuthenticated, errors.New("insert operation failed due to not being authenticated"))
 	}
 
-	// Verify that the user has the necessary authorizations.
-	if !interactor.AuthService.IsAuthorized(authorizationrole.AdminAuthorizationRole) {
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.InsertMotorcyclePermission) {
 		return response.NewInsertMotorcycleResponse(constant.InvalidEntityID, operationstatus.NotAuthorized, errors.New("insert operation failed due to not being authorized, so please contact your system administrator"))
 	}
 
+	// Fill in or verify the make and year from the VIN, when the user asked for it.
+	make, year, status, err := interactor.checkVin(requestMessage)
+	if err != nil {
+		return response.NewInsertMotorcycleResponse(constant.InvalidEntityID, status, err)
+	}
+
 	// Create a new Motorcycle entity.
-	motorcycle, err := entity.NewMotorcycle(requestMessage.Make, requestMessage.Model, requestMessage.Year, requestMessage.Vin)
+	motorcycle, err := entity.NewMotorcycle(make, requestMessage.Model, year, requestMessage.Vin)
 	if err != nil {
-		return response.NewInsertMotorcycleResponse(constant.InvalidEntityID, operationstatus.InternalError, err)
+		return response.NewInsertMotorcycleResponse(constant.InvalidEntityID, operationstatus.BadRequest, err)
 	}
 
+	// The user owns the motorcycle that they insert.  A user who cannot be identified can only insert a motorcycle
+	// when they may access all motorcycles, since nobody else could access it.
+	if interactor.AuthService.UserID() == "" && !canAccessAllMotorcycles(interactor.AuthService) {
+		return response.NewInsertMotorcycleResponse(constant.InvalidEntityID, operationstatus.NotAuthorized, errors.New("insert operation failed because the owner of the motorcycle cannot be identified"))
+	}
+	motorcycle.OwnerID = interactor.AuthService.UserID()
+
 	// Insert the new motorcycle entity into the repository.
-	motorcycle, status, err := interactor.MotorcycleRepository.Insert(motorcycle)
+	motorcycle, status, err = interactor.MotorcycleRepository.Insert(motorcycle)
 	if err != nil {
 		return response.NewInsertMotorcycleResponse(constant.InvalidEntityID, status, err)
 	}
@@ -127,9 +153,50 @@ func (interactor *InsertMotorcycleInteractor) Handle(requestMessage *request.Ins
 	// Save the changes.
 	status, err = interactor.MotorcycleRepository.Save()
 	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("motorcycleId", motorcycle.ID).Error(err, "Failed to save the inserted motorcycle.")
 		return response.NewInsertMotorcycleResponse(constant.InvalidEntityID, status, err)
 	}
 
 	// Return the successful response message.
 	return response.NewInsertMotorcycleResponse(motorcycle.ID, operationstatus.Ok, nil)
 }
+
+// checkVin compares the make and year in the request with those decoded from the VIN, as the request's VinCheck asks.
+// A mismatch is reported before the motorcycle reaches the repository.  When the make or year is missing, it is filled
+// in from the VIN.
+// Returns (make, year, Ok, nil) on success, otherwise ("", 0, operationStatus, error).
+func (interactor *InsertMotorcycleInteractor) checkVin(requestMessage *request.InsertMotorcycleRequest) (string, int, operationstatus.OperationStatus, error) {
+	if requestMessage.VinCheck == vincheck.UndefinedVinCheck {
+		return requestMessage.Make, requestMessage.Year, operationstatus.Ok, nil
+	}
+
+	decoded, status, err := decodeVin(interactor.ManufacturerRepository, requestMessage.Vin)
+	if err != nil {
+		return "", 0, status, err
+	}
+
+	make, year := requestMessage.Make, requestMessage.Year
+	if requestMessage.VinCheck == vincheck.FillVinCheck {
+		if make == "" {
+			make = decoded.Manufacturer
+		}
+		if year == 0 {
+			year = decoded.ModelYear
+		}
+	}
+
+	switch {
+	case make == "":
+		return "", 0, operationstatus.BadRequest, errors.Errorf("insert operation failed because the manufacturer of the VIN %s is unknown, so the make must be provided", decoded.Vin)
+	case year == 0:
+		return "", 0, operationstatus.BadRequest, errors.Errorf("insert operation failed because the VIN %s does not contain a model year, so the year must be provided", decoded.Vin)
+	}
+
+	err = decoded.Verify(make, year)
+	if err != nil {
+		return "", 0, operationstatus.BadRequest, errors.Wrap(err, "insert operation failed because the motorcycle does not match its VIN")
+	}
+
+	return make, year, operationstatus.Ok, nil
+}
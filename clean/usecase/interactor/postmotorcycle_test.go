@@ -10,6 +10,7 @@ import (
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -23,7 +24,7 @@ func TestInsertMotorcycleInteractor_MotorcycleRepositoryIsNil(t *testing.T) {
 	authService, _ := security.NewAuthService(true, roles)
 
 	// ACT
-	_, err := NewInsertMotorcycleInteractor(nil, authService)
+	_, err := NewInsertMotorcycleInteractor(nil, newManufacturerRepository(), authService)
 
 	// ASSERT
 	assert.NotNil(t, err)
@@ -36,7 +37,7 @@ func TestInsertMotorcycleInteractor_AuthServiceIsNil(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 
 	// ACT
-	_, err := NewInsertMotorcycleInteractor(repo, nil)
+	_, err := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), nil)
 
 	// ASSERT
 	assert.NotNil(t, err)
@@ -51,8 +52,8 @@ func TestInsertMotorcycleInteractor_NotAuthenticated(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(false, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	interactor, _ := NewInsertMotorcycleInteractor(repo, authService)
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	interactor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
 
 	// ACT
 	response, _ := interactor.Handle(motorcycleRequest)
@@ -71,8 +72,8 @@ func TestInsertMotorcycleInteractor_NotAuthorized(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	interactor, _ := NewInsertMotorcycleInteractor(repo, authService)
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	interactor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
 
 	// ACT
 	response, _ := interactor.Handle(motorcycleRequest)
@@ -91,8 +92,8 @@ func TestInsertMotorcycleInteractor_Insert(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	interactor, _ := NewInsertMotorcycleInteractor(repo, authService)
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	interactor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
 
 	// ACT
 	response, _ := interactor.Handle(motorcycleRequest)
@@ -111,8 +112,8 @@ func TestInsertMotorcycleInteractor_Insert_VinAlreadyExists(t *testing.T) {
 	}
 	authService, _ := security.NewAuthService(true, roles)
 	repo, _ := repository.NewMotorcycleRepository()
-	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
-	interactor, _ := NewInsertMotorcycleInteractor(repo, authService)
+	motorcycleRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	interactor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
 	interactor.Handle(motorcycleRequest)
 
 	// ACT
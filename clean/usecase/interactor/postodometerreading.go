@@ -0,0 +1,173 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Record an odometer reading for a motorcycle.
+
+DESCRIPTION
+User accesses the system to record the distance displayed by a motorcycle's odometer.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to record an odometer reading.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has recorded an odometer reading, and its total distance has been calculated.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Record Odometer Reading..." from the menu.
+2. System displays a view in which the user enters the reading, its unit, and whether the odometer rolled over.
+3. User click the "Submit" button.
+4. System adds the reading to the odometer reading repository, and displays a confirmation message.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to record an odometer reading.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to record an odometer reading.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that a motorcycle with the
+	   ID does not exist.  The User clicks the "OK" button, and
+	   returns to the primary view.
+
+(3d) The reading is in the future, earlier than the previous reading, or less than the previous
+     reading without the odometer having rolled over.
+       System displays an error message explaining why the reading is invalid.  The User clicks
+	   the "OK" button, and returns to the view to correct the reading.
+*/
+
+// InsertOdometerReadingInteractor is a use case for recording an odometer reading for a motorcycle.
+type InsertOdometerReadingInteractor struct {
+	MotorcycleRepository      contract.MotorcycleRepository
+	OdometerReadingRepository contract.OdometerReadingRepository
+	AuthService               contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
+}
+
+// NewInsertOdometerReadingInteractor creates a new instance of a InsertOdometerReadingInteractor.
+// Returns (nil, error) when there is an error, otherwise (InsertOdometerReadingInteractor, nil).
+func NewInsertOdometerReadingInteractor(motorcycleRepository contract.MotorcycleRepository, odometerReadingRepository contract.OdometerReadingRepository, authService contract.AuthService) (*InsertOdometerReadingInteractor, error) {
+
+	interactor := &InsertOdometerReadingInteractor{
+		MotorcycleRepository:      motorcycleRepository,
+		OdometerReadingRepository: odometerReadingRepository,
+		AuthService:               authService,
+		Logger:                    nopLogger{},
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a InsertOdometerReadingInteractor's fields contain valid data.
+// Returns nil if the InsertOdometerReadingInteractor contains valid data, otherwise an error.
+func (interactor InsertOdometerReadingInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// OdometerReadingRepository is required and cannot be null.
+		validation.Field(&interactor.OdometerReadingRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *InsertOdometerReadingInteractor) Handle(requestMessage *request.InsertOdometerReadingRequest) (*response.InsertOdometerReadingResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewInsertOdometerReadingResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, operationstatus.NotAuthenticated, errors.New("insert operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.InsertOdometerReadingPermission) {
+		return response.NewInsertOdometerReadingResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, operationstatus.NotAuthorized, errors.New("insert operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the motorcycle exists and belongs to the user.
+	_, status, err := findAccessibleMotorcycle(interactor.MotorcycleRepository, interactor.AuthService, requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewInsertOdometerReadingResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, status, err)
+	}
+
+	// A reading without a time was taken now, and one without a source was entered manually.
+	now := time.Now().UTC()
+	readingUtc := requestMessage.ReadingUtc.UTC()
+	if requestMessage.ReadingUtc.IsZero() {
+		readingUtc = now
+	}
+
+	source := requestMessage.Source
+	if source == readingsource.UndefinedReadingSource {
+		source = readingsource.ManualReadingSource
+	}
+
+	// A reading cannot be taken in the future, although the client's clock may be slightly ahead of ours.
+	if readingUtc.After(now.Add(constant.MaxReadingClockSkew)) {
+		return response.NewInsertOdometerReadingResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, operationstatus.BadRequest, errors.New("insert operation failed because the odometer reading is in the future"))
+	}
+
+	reading, err := entity.NewOdometerReading(requestMessage.MotorcycleID, requestMessage.Value, requestMessage.Unit, readingUtc, source, requestMessage.Rollover)
+	if err != nil {
+		return response.NewInsertOdometerReadingResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, operationstatus.BadRequest, err)
+	}
+
+	// Insert the reading, which must follow the motorcycle's previous reading.
+	reading, status, err = interactor.OdometerReadingRepository.Insert(reading)
+	if err != nil {
+		return response.NewInsertOdometerReadingResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, status, err)
+	}
+
+	// Save the changes.
+	status, err = interactor.OdometerReadingRepository.Save()
+	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("readingId", reading.ID).Error(err, "Failed to save the inserted odometer reading.")
+		return response.NewInsertOdometerReadingResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewInsertOdometerReadingResponse(requestMessage.MotorcycleID, reading.ID, operationstatus.Ok, nil)
+}
@@ -0,0 +1,122 @@
+// Package interactor implements unit tests for the InsertOdometerReadingInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// insertOdometerReading records an odometer reading in miles on behalf of the user.
+// Returns the (response status, ID of the new reading).
+func insertOdometerReading(interactor *InsertOdometerReadingInteractor, motorcycleID typedef.ID, value float64, readingUtc time.Time, rollover bool) (operationstatus.OperationStatus, typedef.ID) {
+	insertRequest, _ := request.NewInsertOdometerReadingRequest(motorcycleID, value, distanceunit.MilesDistanceUnit, readingUtc, readingsource.UndefinedReadingSource, rollover)
+	insertResponse, _ := interactor.Handle(insertRequest)
+	return insertResponse.Status, insertResponse.ID
+}
+
+// TestInsertOdometerReadingInteractor_Defaults verifies that a reading without a time or source was entered manually now.
+func TestInsertOdometerReadingInteractor_Defaults(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, alice)
+	before := time.Now().UTC()
+
+	// ACT
+	status, id := insertOdometerReading(interactor, motorcycleID, 1200, time.Time{}, false)
+	reading, _, _ := readings.FindByID(id)
+
+	// ASSERT
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, reading.Source == readingsource.ManualReadingSource)
+	assert.False(t, reading.ReadingUtc.Before(before))
+	assert.True(t, reading.Distance == 1200)
+}
+
+// TestInsertOdometerReadingInteractor_Future verifies that a reading cannot be in the future.
+func TestInsertOdometerReadingInteractor_Future(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, alice)
+
+	// ACT
+	skewedStatus, _ := insertOdometerReading(interactor, motorcycleID, 1200, time.Now().Add(time.Minute), false)
+	futureStatus, _ := insertOdometerReading(interactor, motorcycleID, 1300, time.Now().Add(time.Hour), false)
+
+	// ASSERT
+	assert.True(t, skewedStatus == operationstatus.Ok)
+	assert.True(t, futureStatus == operationstatus.BadRequest)
+}
+
+// TestInsertOdometerReadingInteractor_Decrease verifies that a reading cannot decrease unless the odometer rolled over.
+func TestInsertOdometerReadingInteractor_Decrease(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, alice)
+	now := time.Now()
+	insertOdometerReading(interactor, motorcycleID, 99900, now.Add(-2*time.Hour), false)
+
+	// ACT
+	decreasedStatus, _ := insertOdometerReading(interactor, motorcycleID, 150, now.Add(-time.Hour), false)
+	rolloverStatus, _ := insertOdometerReading(interactor, motorcycleID, 150, now.Add(-time.Hour), true)
+
+	// ASSERT
+	assert.True(t, decreasedStatus == operationstatus.BadRequest)
+	assert.True(t, rolloverStatus == operationstatus.Ok)
+}
+
+// TestInsertOdometerReadingInteractor_OtherOwner verifies that a user cannot record a reading for another user's motorcycle.
+func TestInsertOdometerReadingInteractor_OtherOwner(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, bob)
+
+	// ACT
+	status, _ := insertOdometerReading(interactor, motorcycleID, 1200, time.Time{}, false)
+
+	// ASSERT
+	assert.True(t, status == operationstatus.NotFound)
+}
+
+// TestInsertOdometerReadingInteractor_NotAuthorized verifies that the Accounting role cannot record a reading.
+func TestInsertOdometerReadingInteractor_NotAuthorized(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	readings, _ := repository.NewOdometerReadingRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	accountant := newRiderAuthService("alice", authorizationrole.AccountingAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewInsertOdometerReadingInteractor(motorcycles, readings, accountant)
+
+	// ACT
+	status, _ := insertOdometerReading(interactor, motorcycleID, 1200, time.Time{}, false)
+
+	// ASSERT
+	assert.True(t, status == operationstatus.NotAuthorized)
+}
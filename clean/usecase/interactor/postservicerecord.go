@@ -0,0 +1,156 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Record service or maintenance that was performed on a motorcycle.
+
+DESCRIPTION
+User accesses the system to record an oil change, chain adjustment, valve check, tire replacement, or other service.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to record a service.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has recorded the service in the motorcycle's service history.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Record Service..." from the menu.
+2. System displays a view in which the user enters the type of service, its date, odometer, parts, labor and cost.
+3. User click the "Submit" button.
+4. System adds the service record to the service record repository, and displays a confirmation message.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to record a service.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to record a service.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that a motorcycle with the
+	   ID does not exist.  The User clicks the "OK" button, and
+	   returns to the primary view.
+
+(3d) The service was performed in the future.
+       System displays an error message explaining that the date is invalid.  The User clicks
+	   the "OK" button, and returns to the view to correct the service record.
+*/
+
+// InsertServiceRecordInteractor is a use case for recording service or maintenance that was performed on a motorcycle.
+type InsertServiceRecordInteractor struct {
+	MotorcycleRepository    contract.MotorcycleRepository
+	ServiceRecordRepository contract.ServiceRecordRepository
+	AuthService             contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
+}
+
+// NewInsertServiceRecordInteractor creates a new instance of a InsertServiceRecordInteractor.
+// Returns (nil, error) when there is an error, otherwise (InsertServiceRecordInteractor, nil).
+func NewInsertServiceRecordInteractor(motorcycleRepository contract.MotorcycleRepository, serviceRecordRepository contract.ServiceRecordRepository, authService contract.AuthService) (*InsertServiceRecordInteractor, error) {
+
+	interactor := &InsertServiceRecordInteractor{
+		MotorcycleRepository:    motorcycleRepository,
+		ServiceRecordRepository: serviceRecordRepository,
+		AuthService:             authService,
+		Logger:                  nopLogger{},
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a InsertServiceRecordInteractor's fields contain valid data.
+// Returns nil if the InsertServiceRecordInteractor contains valid data, otherwise an error.
+func (interactor InsertServiceRecordInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ServiceRecordRepository is required and cannot be null.
+		validation.Field(&interactor.ServiceRecordRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *InsertServiceRecordInteractor) Handle(requestMessage *request.InsertServiceRecordRequest) (*response.InsertServiceRecordResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewInsertServiceRecordResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, constant.AnyRowVersion, operationstatus.NotAuthenticated, errors.New("insert operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.InsertServiceRecordPermission) {
+		return response.NewInsertServiceRecordResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, constant.AnyRowVersion, operationstatus.NotAuthorized, errors.New("insert operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the motorcycle exists and belongs to the user.
+	_, status, err := findAccessibleMotorcycle(interactor.MotorcycleRepository, interactor.AuthService, requestMessage.MotorcycleID)
+	if err != nil {
+		return response.NewInsertServiceRecordResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, constant.AnyRowVersion, status, err)
+	}
+
+	// A service cannot be performed in the future, although it may be dated in a time zone that is ahead of ours.
+	if requestMessage.ServiceRecord.PerformedUtc.After(time.Now().Add(constant.MaxServiceClockSkew)) {
+		return response.NewInsertServiceRecordResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, constant.AnyRowVersion, operationstatus.BadRequest,
+			errors.New("insert operation failed because the service was performed in the future"))
+	}
+
+	// Insert the service record for the motorcycle.
+	record := *requestMessage.ServiceRecord
+	record.MotorcycleID = requestMessage.MotorcycleID
+	inserted, status, err := interactor.ServiceRecordRepository.Insert(&record)
+	if err != nil {
+		return response.NewInsertServiceRecordResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, constant.AnyRowVersion, status, err)
+	}
+
+	// Save the changes.
+	status, err = interactor.ServiceRecordRepository.Save()
+	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("serviceRecordId", inserted.ID).Error(err, "Failed to save the inserted service record.")
+		return response.NewInsertServiceRecordResponse(requestMessage.MotorcycleID, constant.InvalidEntityID, constant.AnyRowVersion, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewInsertServiceRecordResponse(requestMessage.MotorcycleID, inserted.ID, inserted.RowVersion, operationstatus.Ok, nil)
+}
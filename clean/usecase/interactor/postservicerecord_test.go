@@ -0,0 +1,108 @@
+// Package interactor implements unit tests for the InsertServiceRecordInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/servicetype"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// newServiceRecord creates a record of an oil change that was performed at the time, with the odometer in miles.
+func newServiceRecord(performedUtc time.Time, odometer float64) *entity.ServiceRecord {
+	record, _ := entity.NewServiceRecord(1, servicetype.OilChangeServiceType, performedUtc, odometer, distanceunit.MilesDistanceUnit,
+		"", []string{"Oil filter"}, 0.5, 45.50, "Owner")
+	return record
+}
+
+// insertServiceRecord records service that was performed on the motorcycle on behalf of the user.
+// Returns the (response status, ID of the new service record).
+func insertServiceRecord(interactor *InsertServiceRecordInteractor, motorcycleID typedef.ID, performedUtc time.Time, odometer float64) (operationstatus.OperationStatus, typedef.ID) {
+	insertRequest, _ := request.NewInsertServiceRecordRequest(motorcycleID, newServiceRecord(performedUtc, odometer))
+	insertResponse, _ := interactor.Handle(insertRequest)
+	return insertResponse.Status, insertResponse.ID
+}
+
+// TestInsertServiceRecordInteractor_Handle verifies that the service record belongs to the requested motorcycle.
+func TestInsertServiceRecordInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "11234567590123456")
+	interactor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+
+	// ACT
+	status, id := insertServiceRecord(interactor, motorcycleID, time.Now().AddDate(0, 0, -1), 4000)
+	record, _, _ := records.FindByID(id)
+
+	// ASSERT
+	assert.True(t, status == operationstatus.Ok)
+	assert.True(t, record.MotorcycleID == motorcycleID)
+	assert.True(t, record.Parts[0] == "Oil filter")
+}
+
+// TestInsertServiceRecordInteractor_Future verifies that service cannot be performed in the future.
+func TestInsertServiceRecordInteractor_Future(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+
+	// ACT
+	skewedStatus, _ := insertServiceRecord(interactor, motorcycleID, time.Now().Add(time.Hour), 4000)
+	futureStatus, _ := insertServiceRecord(interactor, motorcycleID, time.Now().AddDate(0, 0, 2), 4000)
+
+	// ASSERT
+	assert.True(t, skewedStatus == operationstatus.Ok)
+	assert.True(t, futureStatus == operationstatus.BadRequest)
+}
+
+// TestInsertServiceRecordInteractor_OtherOwner verifies that a user cannot record service for another user's motorcycle.
+func TestInsertServiceRecordInteractor_OtherOwner(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewInsertServiceRecordInteractor(motorcycles, records, bob)
+
+	// ACT
+	status, _ := insertServiceRecord(interactor, motorcycleID, time.Now(), 4000)
+
+	// ASSERT
+	assert.True(t, status == operationstatus.NotFound)
+}
+
+// TestInsertServiceRecordInteractor_NotAuthorized verifies that the Accounting role cannot record service.
+func TestInsertServiceRecordInteractor_NotAuthorized(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	accountant := newRiderAuthService("alice", authorizationrole.AccountingAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	interactor, _ := NewInsertServiceRecordInteractor(motorcycles, records, accountant)
+
+	// ACT
+	status, _ := insertServiceRecord(interactor, motorcycleID, time.Now(), 4000)
+
+	// ASSERT
+	assert.True(t, status == operationstatus.NotAuthorized)
+}
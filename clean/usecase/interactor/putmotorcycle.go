@@ -10,8 +10,8 @@ import (
 	"github.com/abitofhelp/motominderapi/clean/domain/contract"
 	"github.com/go-ozzo/ozzo-validation"
 
-	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/abitofhelp/motominderapi/clean/usecase/response"
 	"github.com/pkg/errors"
@@ -56,16 +56,23 @@ EXTENSIONS
 	   System Administrator.  The User clicks the "OK" button, and returns to the
 	   primary view.
 
-(3c) A motorcycle with the ID does not exist in the repository.
+(3c) A motorcycle with the ID does not exist in the repository, or it belongs to another user.
        System displays an error message indicating that a motorcycle with the
 	   ID does not exist.  The User clicks the "OK" button, and
 	   returns to the primary view.
+
+(3d) The motorcycle has been changed by someone else since the User selected it.
+       System displays an error message indicating that the motorcycle has been
+	   changed.  The User clicks the "OK" button, and returns to the view to
+	   select the motorcycle again.
 */
 
 // UpdateMotorcycleInteractor is a use case for updating a motorcycle in the motorcycle repository.
 type UpdateMotorcycleInteractor struct {
 	MotorcycleRepository contract.MotorcycleRepository
 	AuthService          contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
 }
 
 // NewUpdateMotorcycleInteractor creates a new instance of a UpdateMotorcycleInteractor.
@@ -75,6 +82,7 @@ func NewUpdateMotorcycleInteractor(motorcycleRepository contract.MotorcycleRepos
 	interactor := &UpdateMotorcycleInteractor{
 		MotorcycleRepository: motorcycleRepository,
 		AuthService:          authService,
+		Logger:               nopLogger{},
 	}
 
 	// Validate the interactor
@@ -106,13 +114,26 @@ func (interactor *UpdateMotorcycleInteractor) Handle(requestMessage *request.Upd
 		return response.NewUpdateMotorcycleResponse(requestMessage.ID, operationstatus.NotAuthenticated, errors.New("update operation failed due to not being authenticated"))
 	}
 
-	// Verify that the user has the necessary authorizations.
-	if !interactor.AuthService.IsAuthorized(authorizationrole.AdminAuthorizationRole) {
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.UpdateMotorcyclePermission) {
 		return response.NewUpdateMotorcycleResponse(requestMessage.ID, operationstatus.NotAuthorized, errors.New("update operation failed due to not being authorized, so please contact your system administrator"))
 	}
 
-	// Update the motorcycle in the repository.
-	_, status, err := interactor.MotorcycleRepository.Update(requestMessage.ID, requestMessage.Motorcycle)
+	// Verify that the motorcycle belongs to the user.  One that belongs to another user is reported as not found,
+	// so its existence is not disclosed.
+	existing, status, err := interactor.MotorcycleRepository.FindByID(requestMessage.ID)
+	if err != nil {
+		return response.NewUpdateMotorcycleResponse(requestMessage.ID, status, err)
+	}
+
+	if existing == nil || !canAccessMotorcycle(interactor.AuthService, existing) {
+		return response.NewUpdateMotorcycleResponse(requestMessage.ID, operationstatus.NotFound, errors.Errorf("cannot update the motorcycle with ID %d because it doesn't exist in the repository", requestMessage.ID))
+	}
+
+	// Update the motorcycle in the repository, as long as nobody else has changed it since it was read.
+	motorcycle := *requestMessage.Motorcycle
+	motorcycle.RowVersion = requestMessage.RowVersion
+	_, status, err = interactor.MotorcycleRepository.Update(requestMessage.ID, &motorcycle)
 	if err != nil {
 		return response.NewUpdateMotorcycleResponse(requestMessage.ID, status, err)
 	}
@@ -120,6 +141,8 @@ func (interactor *UpdateMotorcycleInteractor) Handle(requestMessage *request.Upd
 	// Save the changes.
 	status, err = interactor.MotorcycleRepository.Save()
 	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("motorcycleId", requestMessage.ID).Error(err, "Failed to save the updated motorcycle.")
 		return response.NewUpdateMotorcycleResponse(requestMessage.ID, status, err)
 	}
 
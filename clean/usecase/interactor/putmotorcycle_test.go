@@ -9,8 +9,11 @@ package interactor
 import (
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
 	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/security"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
 	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/abitofhelp/motominderapi/clean/usecase/request"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -54,7 +57,7 @@ func TestUpdateMotorcycleInteractor_NotAuthenticated(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
 	motorcycle.ID = 123
-	motorcycleRequest, _ := request.NewUpdateMotorcycleRequest(123, motorcycle)
+	motorcycleRequest, _ := request.NewUpdateMotorcycleRequest(123, constant.AnyRowVersion, motorcycle)
 	interactor, _ := NewUpdateMotorcycleInteractor(repo, authService)
 
 	// ACT
@@ -75,7 +78,7 @@ func TestUpdateMotorcycleInteractor_NotAuthorized(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 	motorcycle, _ := entity.NewMotorcycle("Honda", "Shadow", 2006, "01234567890123456")
 	motorcycle.ID = 123
-	motorcycleRequest, _ := request.NewUpdateMotorcycleRequest(123, motorcycle)
+	motorcycleRequest, _ := request.NewUpdateMotorcycleRequest(123, constant.AnyRowVersion, motorcycle)
 	interactor, _ := NewUpdateMotorcycleInteractor(repo, authService)
 
 	// ACT
@@ -96,15 +99,15 @@ func TestUpdateMotorcycleInteractor_Update(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 
 	// Add a motorcycle so we can update it.
-	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, authService)
-	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
+	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
 	insertResponse, _ := insertInteractor.Handle(insertRequest)
 
 	// Get the new motorcycle and change its vin.
 	motorcycle, _, _ := repo.FindByID(insertResponse.ID)
-	vin := "65432109876543210"
+	vin := "65432109176543210"
 	motorcycle.Vin = vin
-	updateRequest, _ := request.NewUpdateMotorcycleRequest(insertResponse.ID, motorcycle)
+	updateRequest, _ := request.NewUpdateMotorcycleRequest(insertResponse.ID, constant.AnyRowVersion, motorcycle)
 	updateInteractor, _ := NewUpdateMotorcycleInteractor(repo, authService)
 
 	// ACT
@@ -127,12 +130,12 @@ func TestUpdateMotorcycleInteractor_NotExist(t *testing.T) {
 	repo, _ := repository.NewMotorcycleRepository()
 
 	// Add a motorcycle so we can seek it.
-	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, authService)
-	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456")
+	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
 	insertResponse, _ := insertInteractor.Handle(insertRequest)
 
 	motorcycle, _, _ := repo.FindByID(insertResponse.ID)
-	updateRequest, _ := request.NewUpdateMotorcycleRequest(123, motorcycle)
+	updateRequest, _ := request.NewUpdateMotorcycleRequest(123, constant.AnyRowVersion, motorcycle)
 	updateInteractor, _ := NewUpdateMotorcycleInteractor(repo, authService)
 
 	// ACT
@@ -141,3 +144,33 @@ func TestUpdateMotorcycleInteractor_NotExist(t *testing.T) {
 	// ASSERT
 	assert.NotNil(t, updateResponse.Error)
 }
+
+// TestUpdateMotorcycleInteractor_StaleRowVersion verifies that an update based on an out of date motorcycle fails with a conflict.
+func TestUpdateMotorcycleInteractor_StaleRowVersion(t *testing.T) {
+
+	// ARRANGE
+	roles := map[authorizationrole.AuthorizationRole]bool{
+		authorizationrole.AdminAuthorizationRole: true,
+	}
+	authService, _ := security.NewAuthService(true, roles)
+	repo, _ := repository.NewMotorcycleRepository()
+
+	// Add a motorcycle, and have someone else update it.
+	insertInteractor, _ := NewInsertMotorcycleInteractor(repo, newManufacturerRepository(), authService)
+	insertRequest, _ := request.NewInsertMotorcycleRequest("Honda", "Shadow", 2006, "01234567890123456", vincheck.UndefinedVinCheck)
+	insertResponse, _ := insertInteractor.Handle(insertRequest)
+	motorcycle, _, _ := repo.FindByID(insertResponse.ID)
+	staleRowVersion := motorcycle.RowVersion
+	repo.Update(motorcycle.ID, motorcycle)
+
+	motorcycle.Vin = "65432109176543210"
+	updateRequest, _ := request.NewUpdateMotorcycleRequest(insertResponse.ID, staleRowVersion, motorcycle)
+	updateInteractor, _ := NewUpdateMotorcycleInteractor(repo, authService)
+
+	// ACT
+	updateResponse, _ := updateInteractor.Handle(updateRequest)
+
+	// ASSERT
+	assert.NotNil(t, updateResponse.Error)
+	assert.True(t, updateResponse.Status == operationstatus.Conflict)
+}
@@ -0,0 +1,162 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/permission"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+/*
+TITLE
+Update a service record of a motorcycle.
+
+DESCRIPTION
+User accesses the system to correct a service record.
+
+PRIMARY ACTOR
+User
+
+PRECONDITIONS
+User is logged into system.
+User possesses the necessary security authorizations to update a service record.
+A Motorcycle with the ID exists in the repository, and it belongs to the User.
+A Service record with the ID exists for the motorcycle.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+User has updated the service record.
+
+MAIN SUCCESS SCENARIO
+1. User selects "Service History..." from the menu.
+2. System displays a view in which the user selects a service record, and changes it.
+3. User click the "Submit" button.
+4. System updates the service record in the service record repository, and displays a confirmation message.
+5. User clicks the "OK" button, and returns to the primary view.
+
+EXTENSIONS
+(3a) The user cannot log into the system.
+       System displays an error message saying that authentication has failed,
+	   and provides suggestions for resolving the issue.  The User clicks the
+	   "OK" button, and returns to the login view.
+
+(3b) The user does not possess the required authorization to update a service record.
+       System displays an error message saying that the user does possess the required
+	   security authorizations to update a service record.  It recommends contacting the
+	   System Administrator.  The User clicks the "OK" button, and returns to the
+	   primary view.
+
+(3c) A motorcycle or service record with the ID does not exist in the repository, or it belongs to another user.
+       System displays an error message indicating that the service record does not exist.
+	   The User clicks the "OK" button, and returns to the primary view.
+
+(3d) The service record has been changed by someone else since the User selected it.
+       System displays an error message indicating that the service record has been
+	   changed.  The User clicks the "OK" button, and returns to the view to
+	   select the service record again.
+
+(3e) The service was performed in the future.
+       System displays an error message explaining that the date is invalid.  The User clicks
+	   the "OK" button, and returns to the view to correct the service record.
+*/
+
+// UpdateServiceRecordInteractor is a use case for updating a service record of a motorcycle.
+type UpdateServiceRecordInteractor struct {
+	MotorcycleRepository    contract.MotorcycleRepository
+	ServiceRecordRepository contract.ServiceRecordRepository
+	AuthService             contract.AuthService
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
+}
+
+// NewUpdateServiceRecordInteractor creates a new instance of a UpdateServiceRecordInteractor.
+// Returns (nil, error) when there is an error, otherwise (UpdateServiceRecordInteractor, nil).
+func NewUpdateServiceRecordInteractor(motorcycleRepository contract.MotorcycleRepository, serviceRecordRepository contract.ServiceRecordRepository, authService contract.AuthService) (*UpdateServiceRecordInteractor, error) {
+
+	interactor := &UpdateServiceRecordInteractor{
+		MotorcycleRepository:    motorcycleRepository,
+		ServiceRecordRepository: serviceRecordRepository,
+		AuthService:             authService,
+		Logger:                  nopLogger{},
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a UpdateServiceRecordInteractor's fields contain valid data.
+// Returns nil if the UpdateServiceRecordInteractor contains valid data, otherwise an error.
+func (interactor UpdateServiceRecordInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ServiceRecordRepository is required and cannot be null.
+		validation.Field(&interactor.ServiceRecordRepository, validation.Required),
+		// AuthService is required and cannot be null.
+		validation.Field(&interactor.AuthService, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *UpdateServiceRecordInteractor) Handle(requestMessage *request.UpdateServiceRecordRequest) (*response.UpdateServiceRecordResponse, error) {
+	// Verify that the user has been properly authenticated.
+	if !interactor.AuthService.IsAuthenticated() {
+		return response.NewUpdateServiceRecordResponse(requestMessage.ID, constant.AnyRowVersion, operationstatus.NotAuthenticated, errors.New("update operation failed due to not being authenticated"))
+	}
+
+	// Verify that one of the user's roles grants the permission required by this use case.
+	if !interactor.AuthService.IsPermitted(permission.UpdateServiceRecordPermission) {
+		return response.NewUpdateServiceRecordResponse(requestMessage.ID, constant.AnyRowVersion, operationstatus.NotAuthorized, errors.New("update operation failed due to not being authorized, so please contact your system administrator"))
+	}
+
+	// Verify that the service record belongs to a motorcycle that the user can access.
+	_, status, err := findAccessibleServiceRecord(interactor.MotorcycleRepository, interactor.ServiceRecordRepository, interactor.AuthService,
+		requestMessage.MotorcycleID, requestMessage.ID)
+	if err != nil {
+		return response.NewUpdateServiceRecordResponse(requestMessage.ID, constant.AnyRowVersion, status, err)
+	}
+
+	// A service cannot be performed in the future, although it may be dated in a time zone that is ahead of ours.
+	if requestMessage.ServiceRecord.PerformedUtc.After(time.Now().Add(constant.MaxServiceClockSkew)) {
+		return response.NewUpdateServiceRecordResponse(requestMessage.ID, constant.AnyRowVersion, operationstatus.BadRequest,
+			errors.New("update operation failed because the service was performed in the future"))
+	}
+
+	// Update the service record in the repository, as long as nobody else has changed it since it was read.
+	record := *requestMessage.ServiceRecord
+	record.RowVersion = requestMessage.RowVersion
+	updated, status, err := interactor.ServiceRecordRepository.Update(requestMessage.ID, &record)
+	if err != nil {
+		return response.NewUpdateServiceRecordResponse(requestMessage.ID, constant.AnyRowVersion, status, err)
+	}
+
+	// Save the changes.
+	status, err = interactor.ServiceRecordRepository.Save()
+	if err != nil {
+		// The change might not have been kept, so the operator is told what was changed.
+		interactor.Logger.WithField("serviceRecordId", requestMessage.ID).Error(err, "Failed to save the updated service record.")
+		return response.NewUpdateServiceRecordResponse(requestMessage.ID, constant.AnyRowVersion, status, err)
+	}
+
+	// Return the successful response message.
+	return response.NewUpdateServiceRecordResponse(requestMessage.ID, updated.RowVersion, operationstatus.Ok, nil)
+}
@@ -0,0 +1,61 @@
+// Package interactor implements unit tests for the UpdateServiceRecordInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUpdateServiceRecordInteractor_Handle verifies that a current service record is updated, and a stale one is a conflict.
+func TestUpdateServiceRecordInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	insertInteractor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+	now := time.Now()
+	_, id := insertServiceRecord(insertInteractor, motorcycleID, now, 4000)
+	interactor, _ := NewUpdateServiceRecordInteractor(motorcycles, records, alice)
+	updateRequest, _ := request.NewUpdateServiceRecordRequest(motorcycleID, id, constant.InitialRowVersion, newServiceRecord(now, 4100))
+	staleRequest, _ := request.NewUpdateServiceRecordRequest(motorcycleID, id, constant.InitialRowVersion, newServiceRecord(now, 4200))
+
+	// ACT
+	updateResponse, _ := interactor.Handle(updateRequest)
+	staleResponse, _ := interactor.Handle(staleRequest)
+	record, _, _ := records.FindByID(id)
+
+	// ASSERT
+	assert.True(t, updateResponse.Status == operationstatus.Ok)
+	assert.True(t, updateResponse.RowVersion == constant.InitialRowVersion+1)
+	assert.True(t, staleResponse.Status == operationstatus.Conflict)
+	assert.True(t, record.Odometer == 4100)
+}
+
+// TestUpdateServiceRecordInteractor_Future verifies that a service record cannot be changed to the future.
+func TestUpdateServiceRecordInteractor_Future(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	records, _ := repository.NewServiceRecordRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	insertInteractor, _ := NewInsertServiceRecordInteractor(motorcycles, records, alice)
+	_, id := insertServiceRecord(insertInteractor, motorcycleID, time.Now(), 4000)
+	interactor, _ := NewUpdateServiceRecordInteractor(motorcycles, records, alice)
+	updateRequest, _ := request.NewUpdateServiceRecordRequest(motorcycleID, id, constant.AnyRowVersion, newServiceRecord(time.Now().AddDate(0, 1, 0), 4100))
+
+	// ACT
+	updateResponse, _ := interactor.Handle(updateRequest)
+
+	// ASSERT
+	assert.True(t, updateResponse.Status == operationstatus.BadRequest)
+}
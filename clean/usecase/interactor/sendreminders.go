@@ -0,0 +1,226 @@
+// Package interactor contains use cases, which contain the application specific business rules.
+// Interactors encapsulate and implement all of the use cases of the system.  They orchestrate the
+// flow of data to and from the entity, and can rely on their business rules to achieve the goals
+// of the use case.  They do not have any dependencies, and are totally isolated from things like
+// a database, UI or special frameworks, which exist in the outer rings.  They Will almost certainly
+// require refactoring if details of the use case requirements change.
+package interactor
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/abitofhelp/motominderapi/clean/usecase/response"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+/*
+TITLE
+Send the reminders that are due.
+
+DESCRIPTION
+System periodically reminds the owners of motorcycles about calendar dates, such as renewing a registration.
+
+PRIMARY ACTOR
+System
+
+PRECONDITIONS
+The reminder rules have been configured.
+The network and configuration is working properly.
+
+POSTCONDITIONS
+A reminder exists for each date whose reminder rule has come into effect.
+The owner of each pending reminder, and of each snoozed reminder whose snooze has ended, has been notified.
+
+MAIN SUCCESS SCENARIO
+1. System walks every motorcycle in the repository.
+2. System evaluates the reminder rules that apply to the motorcycle, and creates a reminder for each date that is
+   within the rule's lead time, unless the motorcycle already has one.
+3. System notifies the owner of each reminder that needs to be delivered, and records that they were notified.
+
+EXTENSIONS
+(3a) The owner cannot be notified.
+       System leaves the reminder as it was, so the owner is notified on a later pass.
+
+(3b) The owner has snoozed or acknowledged the reminder while it was being delivered.
+       System keeps the owner's change.
+*/
+
+// SendRemindersInteractor is a use case for creating the reminders that are due, and notifying their owners.
+// It is performed by the system rather than a user, so it is not authenticated.
+type SendRemindersInteractor struct {
+	MotorcycleRepository   contract.MotorcycleRepository
+	ReminderRuleRepository contract.ReminderRuleRepository
+	ReminderRepository     contract.ReminderRepository
+	Notifier               contract.Notifier
+	// Logger records what happened while the use case was performed.  It discards everything unless it is replaced.
+	Logger contract.Logger
+}
+
+// NewSendRemindersInteractor creates a new instance of a SendRemindersInteractor.
+// Returns (nil, error) when there is an error, otherwise (SendRemindersInteractor, nil).
+func NewSendRemindersInteractor(motorcycleRepository contract.MotorcycleRepository, reminderRuleRepository contract.ReminderRuleRepository,
+	reminderRepository contract.ReminderRepository, notifier contract.Notifier) (*SendRemindersInteractor, error) {
+
+	interactor := &SendRemindersInteractor{
+		MotorcycleRepository:   motorcycleRepository,
+		ReminderRuleRepository: reminderRuleRepository,
+		ReminderRepository:     reminderRepository,
+		Notifier:               notifier,
+		Logger:                 nopLogger{},
+	}
+
+	// Validate the interactor
+	err := interactor.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return interactor, nil
+}
+
+// Validate verifies that a SendRemindersInteractor's fields contain valid data.
+// Returns nil if the SendRemindersInteractor contains valid data, otherwise an error.
+func (interactor SendRemindersInteractor) Validate() error {
+	return validation.ValidateStruct(&interactor,
+		// MotorcycleRepository is required and cannot be null.
+		validation.Field(&interactor.MotorcycleRepository, validation.Required),
+		// ReminderRuleRepository is required and cannot be null.
+		validation.Field(&interactor.ReminderRuleRepository, validation.Required),
+		// ReminderRepository is required and cannot be null.
+		validation.Field(&interactor.ReminderRepository, validation.Required),
+		// Notifier is required and cannot be null.
+		validation.Field(&interactor.Notifier, validation.Required))
+}
+
+// Handle processes the request message and generates the response message.  It is performing the use case.
+// The request message is a dto containing the required data for completing the use case.
+// On success, the method returns the (response message, nil), otherwise (nil, error).
+func (interactor *SendRemindersInteractor) Handle(requestMessage *request.SendRemindersRequest) (*response.SendRemindersResponse, error) {
+	asOfUtc := requestMessage.AsOfUtc
+	if asOfUtc.IsZero() {
+		asOfUtc = time.Now()
+	}
+	asOfUtc = asOfUtc.UTC()
+
+	motorcycles, status, err := interactor.MotorcycleRepository.List()
+	if err != nil {
+		return response.NewSendRemindersResponse(asOfUtc, 0, 0, 0, status, err)
+	}
+
+	created, notified, failed := 0, 0, 0
+	for _, motorcycle := range motorcycles {
+		count, status, err := interactor.createReminders(motorcycle, asOfUtc)
+		created += count
+		if err != nil {
+			return response.NewSendRemindersResponse(asOfUtc, created, notified, failed, status, err)
+		}
+
+		sent, unsent, status, err := interactor.dispatchReminders(motorcycle, asOfUtc)
+		notified += sent
+		failed += unsent
+		if err != nil {
+			return response.NewSendRemindersResponse(asOfUtc, created, notified, failed, status, err)
+		}
+
+		// Save the motorcycle's changes before the next motorcycle's are made, so they are kept when a later change fails.
+		status, err = interactor.ReminderRepository.Save()
+		if err != nil {
+			return response.NewSendRemindersResponse(asOfUtc, created, notified, failed, status, err)
+		}
+	}
+
+	// Return the successful response message.
+	return response.NewSendRemindersResponse(asOfUtc, created, notified, failed, operationstatus.Ok, nil)
+}
+
+// createReminders creates a reminder for each date of the motorcycle's reminder rules that is within the rule's lead time,
+// unless the motorcycle already has a reminder for it.
+// Returns (number of reminders created, Ok, nil) on success, otherwise (number of reminders created, operationStatus, error).
+func (interactor *SendRemindersInteractor) createReminders(motorcycle entity.Motorcycle, asOfUtc time.Time) (int, operationstatus.OperationStatus, error) {
+	rules, status, err := interactor.ReminderRuleRepository.ListByMotorcycle(motorcycle)
+	if err != nil {
+		return 0, status, err
+	}
+
+	created := 0
+	for _, rule := range rules {
+		dueUtc, ok := rule.NextDueUtc(motorcycle.CreatedUtc, asOfUtc)
+		if !ok || asOfUtc.Before(rule.RemindUtc(dueUtc)) {
+			continue
+		}
+
+		existing, status, err := interactor.ReminderRepository.FindByDue(motorcycle.ID, rule.Name, dueUtc)
+		if err != nil {
+			return created, status, err
+		}
+
+		if existing != nil {
+			// The owner has already been reminded of this date.
+			continue
+		}
+
+		reminder, err := entity.NewReminder(motorcycle.ID, rule.Name, dueUtc)
+		if err != nil {
+			return created, operationstatus.InternalError, err
+		}
+
+		_, status, err = interactor.ReminderRepository.Insert(reminder)
+		if err != nil {
+			return created, status, err
+		}
+
+		created++
+	}
+
+	return created, operationstatus.Ok, nil
+}
+
+// dispatchReminders notifies the owner of each of the motorcycle's reminders that needs to be delivered.  A reminder whose
+// owner cannot be notified is left as it was, so it is retried on a later pass.
+// Returns (number notified, number failed, Ok, nil) on success, otherwise (number notified, number failed, operationStatus, error).
+func (interactor *SendRemindersInteractor) dispatchReminders(motorcycle entity.Motorcycle, asOfUtc time.Time) (int, int, operationstatus.OperationStatus, error) {
+	reminders, status, err := interactor.ReminderRepository.ListByMotorcycle(motorcycle.ID)
+	if err != nil {
+		return 0, 0, status, err
+	}
+
+	notified, failed := 0, 0
+	for _, reminder := range reminders {
+		if !reminder.IsDispatchable(asOfUtc) {
+			continue
+		}
+
+		err = interactor.Notifier.Notify(reminder, motorcycle)
+		if err != nil {
+			// The pass continues without the reminder, so the reason that it was not delivered is only in the log.
+			interactor.Logger.WithField("reminderId", reminder.ID).WithField("motorcycleId", motorcycle.ID).Warn(err, "Failed to notify the owner of the reminder.")
+			failed++
+			continue
+		}
+
+		// The row version ensures that a snooze or acknowledgement made while the owner was being notified is kept.
+		reminder.Notified(asOfUtc)
+		_, status, err = interactor.ReminderRepository.Update(reminder.ID, &reminder)
+		if status == operationstatus.Conflict {
+			continue
+		}
+		if err != nil {
+			return notified, failed, status, err
+		}
+
+		// The owner cannot be notified again, so the reminder is saved as notified before anything else can fail.
+		status, err = interactor.ReminderRepository.Save()
+		if err != nil {
+			return notified, failed, status, err
+		}
+
+		notified++
+	}
+
+	return notified, failed, operationstatus.Ok, nil
+}
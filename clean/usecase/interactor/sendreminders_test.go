@@ -0,0 +1,200 @@
+// Package interactor implements unit tests for the SendRemindersInteractor.
+package interactor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/contract"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNotifier records the reminders that it is asked to deliver, and fails to deliver them while it is broken.
+type fakeNotifier struct {
+	Broken    bool
+	Reminders []entity.Reminder
+}
+
+// Notify records the reminder, unless the notifier is broken.
+// Returns nil on success, otherwise an error.
+func (notifier *fakeNotifier) Notify(reminder entity.Reminder, motorcycle entity.Motorcycle) error {
+	if notifier.Broken {
+		return errors.New("the notifier is broken")
+	}
+
+	notifier.Reminders = append(notifier.Reminders, reminder)
+	return nil
+}
+
+// loggedEntry is an entry that was recorded by a recordingLogger.
+type loggedEntry struct {
+	Level   string
+	Message string
+	Err     error
+	Fields  map[string]interface{}
+}
+
+// recordingLogger records its entries, with its fields, so a test can verify what a use case logged.
+type recordingLogger struct {
+	fields  map[string]interface{}
+	entries *[]loggedEntry
+}
+
+// newRecordingLogger creates a logger without any fields or entries.
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{fields: map[string]interface{}{}, entries: &[]loggedEntry{}}
+}
+
+// WithField creates a logger with the field, which records its entries with this logger's.
+func (logger *recordingLogger) WithField(key string, value interface{}) contract.Logger {
+	fields := map[string]interface{}{key: value}
+	for k, v := range logger.fields {
+		fields[k] = v
+	}
+	return &recordingLogger{fields: fields, entries: logger.entries}
+}
+
+// Info records the message.
+func (logger *recordingLogger) Info(message string) {
+	*logger.entries = append(*logger.entries, loggedEntry{Level: "info", Message: message, Fields: logger.fields})
+}
+
+// Warn records the error and message.
+func (logger *recordingLogger) Warn(err error, message string) {
+	*logger.entries = append(*logger.entries, loggedEntry{Level: "warning", Message: message, Err: err, Fields: logger.fields})
+}
+
+// Error records the error and message.
+func (logger *recordingLogger) Error(err error, message string) {
+	*logger.entries = append(*logger.entries, loggedEntry{Level: "error", Message: message, Err: err, Fields: logger.fields})
+}
+
+// failingReminderRepository is a reminder repository that fails to update the reminders of a motorcycle, and records
+// the reminders that it had when it was last saved.
+type failingReminderRepository struct {
+	*repository.ReminderRepository
+	FailingMotorcycleID typedef.ID
+	Saved               []entity.Reminder
+}
+
+// Update replaces the reminder, unless it belongs to the failing motorcycle.
+// Returns (updated reminder, Ok, nil) on success, otherwise an (nil, operationStatus, error).
+func (repo *failingReminderRepository) Update(id typedef.ID, reminder *entity.Reminder) (*entity.Reminder, operationstatus.OperationStatus, error) {
+	if reminder.MotorcycleID == repo.FailingMotorcycleID {
+		return nil, operationstatus.InternalError, errors.New("the repository is broken")
+	}
+
+	return repo.ReminderRepository.Update(id, reminder)
+}
+
+// Save records the reminders in the repository.
+// Returns (Ok, nil).
+func (repo *failingReminderRepository) Save() (operationstatus.OperationStatus, error) {
+	repo.Saved = append([]entity.Reminder{}, repo.ReminderRepository.Reminders...)
+	return operationstatus.Ok, nil
+}
+
+// newTestReminderRules creates a rule for a service each year after the motorcycle was added, with two weeks' notice.
+func newTestReminderRules() *repository.ReminderRuleRepository {
+	rules, _ := repository.NewReminderRuleRepository([]entity.ReminderRule{
+		{Name: "Annual service", Months: 12, LeadDays: 14},
+	})
+	return rules
+}
+
+// TestSendRemindersInteractor_Handle verifies that a reminder is created and delivered once within its lead time, and not again.
+func TestSendRemindersInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	notifier := &fakeNotifier{}
+	interactor, _ := NewSendRemindersInteractor(motorcycles, newTestReminderRules(), reminders, notifier)
+	earlyRequest, _ := request.NewSendRemindersRequest(time.Now().AddDate(0, 11, 0))
+	sendRequest, _ := request.NewSendRemindersRequest(time.Now().AddDate(1, 0, -7))
+
+	// ACT
+	earlyResponse, _ := interactor.Handle(earlyRequest)
+	sendResponse, _ := interactor.Handle(sendRequest)
+	repeatResponse, _ := interactor.Handle(sendRequest)
+	list, _, _ := reminders.ListByMotorcycle(motorcycleID)
+
+	// ASSERT
+	assert.True(t, earlyResponse.Status == operationstatus.Ok)
+	assert.True(t, earlyResponse.Created == 0)
+	assert.True(t, sendResponse.Status == operationstatus.Ok)
+	assert.True(t, sendResponse.Created == 1)
+	assert.True(t, sendResponse.Notified == 1)
+	assert.True(t, repeatResponse.Created == 0)
+	assert.True(t, repeatResponse.Notified == 0)
+	assert.True(t, len(notifier.Reminders) == 1)
+	assert.True(t, len(list) == 1)
+	assert.True(t, list[0].Status == reminderstatus.NotifiedReminderStatus)
+}
+
+// TestSendRemindersInteractor_NotifierFails verifies that a reminder that cannot be delivered is retried on the next pass.
+func TestSendRemindersInteractor_NotifierFails(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	notifier := &fakeNotifier{Broken: true}
+	interactor, _ := NewSendRemindersInteractor(motorcycles, newTestReminderRules(), reminders, notifier)
+	logger := newRecordingLogger()
+	interactor.Logger = logger
+	sendRequest, _ := request.NewSendRemindersRequest(time.Now().AddDate(1, 0, -7))
+
+	// ACT
+	failedResponse, _ := interactor.Handle(sendRequest)
+	notifier.Broken = false
+	retryResponse, _ := interactor.Handle(sendRequest)
+
+	// ASSERT
+	assert.True(t, failedResponse.Status == operationstatus.Ok)
+	assert.True(t, failedResponse.Created == 1)
+	assert.True(t, failedResponse.Failed == 1)
+	assert.True(t, retryResponse.Created == 0)
+	assert.True(t, retryResponse.Notified == 1)
+	assert.True(t, len(*logger.entries) == 1)
+	assert.Equal(t, "warning", (*logger.entries)[0].Level)
+	assert.EqualError(t, (*logger.entries)[0].Err, "the notifier is broken")
+	assert.Contains(t, (*logger.entries)[0].Fields, "reminderId")
+}
+
+// TestSendRemindersInteractor_RepositoryFails verifies that the reminders which have been delivered are saved as
+// notified, even when the pass fails afterward, so their owners are not notified again.
+func TestSendRemindersInteractor_RepositoryFails(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	inner, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	firstID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	secondID := insertOwnedMotorcycle(motorcycles, alice, "11234567590123456")
+	reminders := &failingReminderRepository{ReminderRepository: inner, FailingMotorcycleID: secondID}
+	notifier := &fakeNotifier{}
+	interactor, _ := NewSendRemindersInteractor(motorcycles, newTestReminderRules(), reminders, notifier)
+	sendRequest, _ := request.NewSendRemindersRequest(time.Now().AddDate(1, 0, -7))
+
+	// ACT
+	sendResponse, _ := interactor.Handle(sendRequest)
+
+	// ASSERT
+	assert.NotNil(t, sendResponse.Error)
+	assert.True(t, sendResponse.Notified == 1)
+	assert.True(t, len(reminders.Saved) == 1)
+	assert.True(t, reminders.Saved[0].MotorcycleID == firstID)
+	assert.True(t, reminders.Saved[0].Status == reminderstatus.NotifiedReminderStatus)
+}
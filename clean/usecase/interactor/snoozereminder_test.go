@@ -0,0 +1,65 @@
+// Package interactor implements unit tests for the SnoozeReminderInteractor.
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/adapter/gateway/repository"
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/authorizationrole"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/reminderstatus"
+	"github.com/abitofhelp/motominderapi/clean/usecase/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSnoozeReminderInteractor_Handle verifies that a reminder is snoozed until a time in the near future.
+func TestSnoozeReminderInteractor_Handle(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	id := insertReminder(reminders, motorcycleID, "Annual service")
+	interactor, _ := NewSnoozeReminderInteractor(motorcycles, reminders, alice)
+	untilUtc := time.Now().UTC().AddDate(0, 0, 3)
+	snoozeRequest, _ := request.NewSnoozeReminderRequest(motorcycleID, id, untilUtc)
+	pastRequest, _ := request.NewSnoozeReminderRequest(motorcycleID, id, time.Now().UTC().AddDate(0, 0, -1))
+	farRequest, _ := request.NewSnoozeReminderRequest(motorcycleID, id, time.Now().UTC().Add(constant.MaxReminderSnooze+time.Hour))
+
+	// ACT
+	snoozeResponse, _ := interactor.Handle(snoozeRequest)
+	pastResponse, _ := interactor.Handle(pastRequest)
+	farResponse, _ := interactor.Handle(farRequest)
+	reminder, _, _ := reminders.FindByID(id)
+
+	// ASSERT
+	assert.True(t, snoozeResponse.Status == operationstatus.Ok)
+	assert.True(t, snoozeResponse.RowVersion == constant.InitialRowVersion+1)
+	assert.True(t, pastResponse.Status == operationstatus.BadRequest)
+	assert.True(t, farResponse.Status == operationstatus.BadRequest)
+	assert.True(t, reminder.Status == reminderstatus.SnoozedReminderStatus)
+	assert.True(t, reminder.SnoozedUntilUtc.Equal(untilUtc))
+}
+
+// TestSnoozeReminderInteractor_OtherOwner verifies that a user cannot snooze a reminder of another user's motorcycle.
+func TestSnoozeReminderInteractor_OtherOwner(t *testing.T) {
+
+	// ARRANGE
+	motorcycles, _ := repository.NewMotorcycleRepository()
+	reminders, _ := repository.NewReminderRepository()
+	alice := newRiderAuthService("alice", authorizationrole.GeneralAuthorizationRole)
+	bob := newRiderAuthService("bob", authorizationrole.GeneralAuthorizationRole)
+	motorcycleID := insertOwnedMotorcycle(motorcycles, alice, "01234567890123456")
+	id := insertReminder(reminders, motorcycleID, "Annual service")
+	interactor, _ := NewSnoozeReminderInteractor(motorcycles, reminders, bob)
+	snoozeRequest, _ := request.NewSnoozeReminderRequest(motorcycleID, id, time.Now().UTC().AddDate(0, 0, 3))
+
+	// ACT
+	snoozeResponse, _ := interactor.Handle(snoozeRequest)
+
+	// ASSERT
+	assert.True(t, snoozeResponse.Status == operationstatus.NotFound)
+}
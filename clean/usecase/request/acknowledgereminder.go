@@ -0,0 +1,41 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// AcknowledgeReminderRequest is a simple dto containing the required data for the AcknowledgeReminderInteractor.
+type AcknowledgeReminderRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	ID           typedef.ID `json:"id"`
+}
+
+// NewAcknowledgeReminderRequest creates a new instance of a AcknowledgeReminderRequest.
+// Returns (nil, error) when there is an error, otherwise (AcknowledgeReminderRequest, nil).
+func NewAcknowledgeReminderRequest(motorcycleID typedef.ID, id typedef.ID) (*AcknowledgeReminderRequest, error) {
+
+	acknowledgeRequest := &AcknowledgeReminderRequest{
+		MotorcycleID: motorcycleID,
+		ID:           id,
+	}
+
+	err := acknowledgeRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return acknowledgeRequest, nil
+}
+
+// Validate verifies that a AcknowledgeReminderRequest's fields contain valid data.
+// Returns (an instance of AcknowledgeReminderRequest, nil) on success, otherwise (nil, error)
+func (request AcknowledgeReminderRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)),
+		// ID is required and it must be greater than 0.
+		validation.Field(&request.ID, validation.Required, validation.Min(1)))
+}
@@ -0,0 +1,37 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// DecodeVinRequest is a simple dto containing the required data for the DecodeVinInteractor.
+type DecodeVinRequest struct {
+	Vin string `json:"vin"`
+}
+
+// NewDecodeVinRequest creates a new instance of a DecodeVinRequest.
+// Returns (nil, error) when there is an error, otherwise (DecodeVinRequest, nil).
+func NewDecodeVinRequest(vin string) (*DecodeVinRequest, error) {
+
+	vinRequest := &DecodeVinRequest{
+		Vin: vin,
+	}
+
+	err := vinRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return vinRequest, nil
+}
+
+// Validate verifies that a DecodeVinRequest's fields contain valid data.
+// Returns (an instance of DecodeVinRequest, nil) on success, otherwise (nil, error)
+func (request DecodeVinRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// Vin cannot be nil, cannot be empty, and must be a valid VIN
+		validation.Field(&request.Vin, validation.Required, validation.By(entity.IsVin)))
+}
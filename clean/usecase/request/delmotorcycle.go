@@ -9,14 +9,17 @@ import (
 // DeleteMotorcycleRequest is a simple dto containing the required data for the DeleteMotorcycleInteractor.
 type DeleteMotorcycleRequest struct {
 	ID typedef.ID `json:"id"`
+	// RowVersion is the version of the motorcycle that was deleted, or AnyRowVersion to skip the check.
+	RowVersion typedef.RowVersion `json:"rowVersion"`
 }
 
 // NewDeleteMotorcycleRequest creates a new instance of a DeleteMotorcycleRequest.
 // Returns (nil, error) when there is an error, otherwise (DeleteMotorcycleRequest, nil).
-func NewDeleteMotorcycleRequest(id typedef.ID) (*DeleteMotorcycleRequest, error) {
+func NewDeleteMotorcycleRequest(id typedef.ID, rowVersion typedef.RowVersion) (*DeleteMotorcycleRequest, error) {
 
 	motorcycleRequest := &DeleteMotorcycleRequest{
-		ID: id,
+		ID:         id,
+		RowVersion: rowVersion,
 	}
 
 	err := motorcycleRequest.Validate()
@@ -33,5 +36,7 @@ func NewDeleteMotorcycleRequest(id typedef.ID) (*DeleteMotorcycleRequest, error)
 func (request DeleteMotorcycleRequest) Validate() error {
 	return validation.ValidateStruct(&request,
 		// ID is required and it must be greater than 0.
-		validation.Field(&request.ID, validation.Required, validation.Min(1)))
+		validation.Field(&request.ID, validation.Required, validation.Min(1)),
+		// RowVersion cannot be negative.
+		validation.Field(&request.RowVersion, validation.Min(0)))
 }
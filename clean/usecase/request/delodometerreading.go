@@ -0,0 +1,41 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// DeleteOdometerReadingRequest is a simple dto containing the required data for the DeleteOdometerReadingInteractor.
+type DeleteOdometerReadingRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	ID           typedef.ID `json:"id"`
+}
+
+// NewDeleteOdometerReadingRequest creates a new instance of a DeleteOdometerReadingRequest.
+// Returns (nil, error) when there is an error, otherwise (DeleteOdometerReadingRequest, nil).
+func NewDeleteOdometerReadingRequest(motorcycleID typedef.ID, id typedef.ID) (*DeleteOdometerReadingRequest, error) {
+
+	readingRequest := &DeleteOdometerReadingRequest{
+		MotorcycleID: motorcycleID,
+		ID:           id,
+	}
+
+	err := readingRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return readingRequest, nil
+}
+
+// Validate verifies that a DeleteOdometerReadingRequest's fields contain valid data.
+// Returns (an instance of DeleteOdometerReadingRequest, nil) on success, otherwise (nil, error)
+func (request DeleteOdometerReadingRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)),
+		// ID is required and it must be greater than 0.
+		validation.Field(&request.ID, validation.Required, validation.Min(1)))
+}
@@ -0,0 +1,46 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// DeleteServiceRecordRequest is a simple dto containing the required data for the DeleteServiceRecordInteractor.
+type DeleteServiceRecordRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	ID           typedef.ID `json:"id"`
+	// RowVersion is the version of the service record that was changed, or AnyRowVersion to skip the check.
+	RowVersion typedef.RowVersion `json:"rowVersion"`
+}
+
+// NewDeleteServiceRecordRequest creates a new instance of a DeleteServiceRecordRequest.
+// Returns (nil, error) when there is an error, otherwise (DeleteServiceRecordRequest, nil).
+func NewDeleteServiceRecordRequest(motorcycleID typedef.ID, id typedef.ID, rowVersion typedef.RowVersion) (*DeleteServiceRecordRequest, error) {
+
+	recordRequest := &DeleteServiceRecordRequest{
+		MotorcycleID: motorcycleID,
+		ID:           id,
+		RowVersion:   rowVersion,
+	}
+
+	err := recordRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return recordRequest, nil
+}
+
+// Validate verifies that a DeleteServiceRecordRequest's fields contain valid data.
+// Returns (an instance of DeleteServiceRecordRequest, nil) on success, otherwise (nil, error)
+func (request DeleteServiceRecordRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)),
+		// ID is required and it must be greater than 0.
+		validation.Field(&request.ID, validation.Required, validation.Min(1)),
+		// RowVersion cannot be negative.
+		validation.Field(&request.RowVersion, validation.Min(0)))
+}
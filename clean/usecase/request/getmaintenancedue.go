@@ -0,0 +1,42 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// GetMaintenanceDueRequest is a simple dto containing the required data for the GetMaintenanceDueInteractor.
+type GetMaintenanceDueRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	// AsOfUtc is the time at which the maintenance is evaluated.  It is zero for the current time.
+	AsOfUtc time.Time `json:"asOfUtc"`
+}
+
+// NewGetMaintenanceDueRequest creates a new instance of a GetMaintenanceDueRequest.
+// Returns (nil, error) when there is an error, otherwise (GetMaintenanceDueRequest, nil).
+func NewGetMaintenanceDueRequest(motorcycleID typedef.ID, asOfUtc time.Time) (*GetMaintenanceDueRequest, error) {
+
+	dueRequest := &GetMaintenanceDueRequest{
+		MotorcycleID: motorcycleID,
+		AsOfUtc:      asOfUtc,
+	}
+
+	err := dueRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return dueRequest, nil
+}
+
+// Validate verifies that a GetMaintenanceDueRequest's fields contain valid data.
+// Returns (an instance of GetMaintenanceDueRequest, nil) on success, otherwise (nil, error)
+func (request GetMaintenanceDueRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)))
+}
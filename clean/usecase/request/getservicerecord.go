@@ -0,0 +1,41 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// GetServiceRecordRequest is a simple dto containing the required data for the GetServiceRecordInteractor.
+type GetServiceRecordRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	ID           typedef.ID `json:"id"`
+}
+
+// NewGetServiceRecordRequest creates a new instance of a GetServiceRecordRequest.
+// Returns (nil, error) when there is an error, otherwise (GetServiceRecordRequest, nil).
+func NewGetServiceRecordRequest(motorcycleID typedef.ID, id typedef.ID) (*GetServiceRecordRequest, error) {
+
+	recordRequest := &GetServiceRecordRequest{
+		MotorcycleID: motorcycleID,
+		ID:           id,
+	}
+
+	err := recordRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return recordRequest, nil
+}
+
+// Validate verifies that a GetServiceRecordRequest's fields contain valid data.
+// Returns (an instance of GetServiceRecordRequest, nil) on success, otherwise (nil, error)
+func (request GetServiceRecordRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)),
+		// ID is required and it must be greater than 0.
+		validation.Field(&request.ID, validation.Required, validation.Min(1)))
+}
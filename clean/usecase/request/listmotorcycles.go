@@ -2,18 +2,22 @@
 package request
 
 import (
-	"github.com/go-ozzo/ozzo-validation"
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
 )
 
 // ListMotorcyclesRequest is a simple dto containing the required data for the ListMotorcyclesInteractor.
+// The query selects a page of the motorcycles, with filters that are empty or zero when they are not applied.
 type ListMotorcyclesRequest struct {
+	Query entity.MotorcycleQuery
 }
 
 // NewListMotorcyclesRequest creates a new instance of a ListMotorcyclesRequest.
 // Returns (nil, error) when there is an error, otherwise (ListMotorcyclesRequest, nil).
-func NewListMotorcyclesRequest() (*ListMotorcyclesRequest, error) {
+func NewListMotorcyclesRequest(query entity.MotorcycleQuery) (*ListMotorcyclesRequest, error) {
 
-	listRequest := &ListMotorcyclesRequest{}
+	listRequest := &ListMotorcyclesRequest{
+		Query: query,
+	}
 
 	err := listRequest.Validate()
 	if err != nil {
@@ -27,5 +31,5 @@ func NewListMotorcyclesRequest() (*ListMotorcyclesRequest, error) {
 // Validate verifies that a ListMotorcyclesRequest's fields contain valid data.
 // Returns (an instance of ListMotorcyclesRequest, nil) on success, otherwise (nil, error)
 func (request ListMotorcyclesRequest) Validate() error {
-	return validation.ValidateStruct(&request)
+	return request.Query.Validate()
 }
@@ -0,0 +1,37 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ListOdometerReadingsRequest is a simple dto containing the required data for the ListOdometerReadingsInteractor.
+type ListOdometerReadingsRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+}
+
+// NewListOdometerReadingsRequest creates a new instance of a ListOdometerReadingsRequest.
+// Returns (nil, error) when there is an error, otherwise (ListOdometerReadingsRequest, nil).
+func NewListOdometerReadingsRequest(motorcycleID typedef.ID) (*ListOdometerReadingsRequest, error) {
+
+	listRequest := &ListOdometerReadingsRequest{
+		MotorcycleID: motorcycleID,
+	}
+
+	err := listRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return listRequest, nil
+}
+
+// Validate verifies that a ListOdometerReadingsRequest's fields contain valid data.
+// Returns (an instance of ListOdometerReadingsRequest, nil) on success, otherwise (nil, error)
+func (request ListOdometerReadingsRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)))
+}
@@ -0,0 +1,37 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ListRemindersRequest is a simple dto containing the required data for the ListRemindersInteractor.
+type ListRemindersRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+}
+
+// NewListRemindersRequest creates a new instance of a ListRemindersRequest.
+// Returns (nil, error) when there is an error, otherwise (ListRemindersRequest, nil).
+func NewListRemindersRequest(motorcycleID typedef.ID) (*ListRemindersRequest, error) {
+
+	listRequest := &ListRemindersRequest{
+		MotorcycleID: motorcycleID,
+	}
+
+	err := listRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return listRequest, nil
+}
+
+// Validate verifies that a ListRemindersRequest's fields contain valid data.
+// Returns (an instance of ListRemindersRequest, nil) on success, otherwise (nil, error)
+func (request ListRemindersRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)))
+}
@@ -0,0 +1,37 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// ListServiceRecordsRequest is a simple dto containing the required data for the ListServiceRecordsInteractor.
+type ListServiceRecordsRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+}
+
+// NewListServiceRecordsRequest creates a new instance of a ListServiceRecordsRequest.
+// Returns (nil, error) when there is an error, otherwise (ListServiceRecordsRequest, nil).
+func NewListServiceRecordsRequest(motorcycleID typedef.ID) (*ListServiceRecordsRequest, error) {
+
+	recordRequest := &ListServiceRecordsRequest{
+		MotorcycleID: motorcycleID,
+	}
+
+	err := recordRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return recordRequest, nil
+}
+
+// Validate verifies that a ListServiceRecordsRequest's fields contain valid data.
+// Returns (an instance of ListServiceRecordsRequest, nil) on success, otherwise (nil, error)
+func (request ListServiceRecordsRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)))
+}
@@ -0,0 +1,53 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/patchformat"
+	"github.com/abitofhelp/motominderapi/clean/domain/patch"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// PatchMotorcycleRequest is a simple dto containing the required data for the PatchMotorcycleInteractor.
+type PatchMotorcycleRequest struct {
+	ID typedef.ID `json:"id"`
+	// RowVersion is the version of the motorcycle that was patched, or AnyRowVersion to skip the check.
+	RowVersion typedef.RowVersion `json:"rowVersion"`
+	Patch      patch.Patch        `json:"patch"`
+}
+
+// NewPatchMotorcycleRequest creates a new instance of a PatchMotorcycleRequest from a patch in the format.
+// Returns (nil, error) when there is an error, such as a patch that is not well formed, otherwise (PatchMotorcycleRequest, nil).
+func NewPatchMotorcycleRequest(id typedef.ID, rowVersion typedef.RowVersion, format patchformat.PatchFormat, contents []byte) (*PatchMotorcycleRequest, error) {
+
+	changes, err := patch.Parse(format, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	motorcycleRequest := &PatchMotorcycleRequest{
+		ID:         id,
+		RowVersion: rowVersion,
+		Patch:      changes,
+	}
+
+	err = motorcycleRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return motorcycleRequest, nil
+}
+
+// Validate verifies that a PatchMotorcycleRequest's fields contain valid data.
+// Returns (an instance of PatchMotorcycleRequest, nil) on success, otherwise (nil, error)
+func (request PatchMotorcycleRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// ID is required and it must be greater than 0.
+		validation.Field(&request.ID, validation.Required, validation.Min(1)),
+		// RowVersion cannot be negative.
+		validation.Field(&request.RowVersion, validation.Min(0)),
+		// Patch is required.
+		validation.Field(&request.Patch, validation.Required))
+}
@@ -2,7 +2,10 @@
 package request
 
 import (
+	"time"
+
 	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/vincheck"
 	"github.com/go-ozzo/ozzo-validation"
 )
 
@@ -12,17 +15,20 @@ type InsertMotorcycleRequest struct {
 	Model string `json:"model"`
 	Year  int    `json:"year"`
 	Vin   string `json:"vin"`
+	// VinCheck is how the make and year are compared with those decoded from the VIN.  The VIN is not decoded when it is undefined.
+	VinCheck vincheck.VinCheck `json:"vinCheck"`
 }
 
 // NewInsertMotorcycleRequest creates a new instance of a InsertMotorcycleRequest.
 // Returns (nil, error) when there is an error, otherwise (InsertMotorcycleRequest, nil).
-func NewInsertMotorcycleRequest(make string, model string, year int, vin string) (*InsertMotorcycleRequest, error) {
+func NewInsertMotorcycleRequest(make string, model string, year int, vin string, vinCheck vincheck.VinCheck) (*InsertMotorcycleRequest, error) {
 
 	motorcycleRequest := &InsertMotorcycleRequest{
-		Make:  make,
-		Model: model,
-		Year:  year,
-		Vin:   vin,
+		Make:     make,
+		Model:    model,
+		Year:     year,
+		Vin:      vin,
+		VinCheck: vinCheck,
 	}
 
 	err := motorcycleRequest.Validate()
@@ -37,14 +43,27 @@ func NewInsertMotorcycleRequest(make string, model string, year int, vin string)
 // Validate verifies that a InsertMotorcycleRequest's fields contain valid data.
 // Returns (an instance of InsertMotorcycleRequest, nil) on success, otherwise (nil, error)
 func (request InsertMotorcycleRequest) Validate() error {
+	policy := entity.CurrentValidationPolicy()
+	minYear, maxYear := policy.YearRange(time.Now())
+
+	// Make and Year can only be missing when they are filled in from the VIN.
+	makeRules := []validation.Rule{validation.Length(policy.MinMakeLength, policy.MaxMakeLength), validation.By(policy.IsValidMake)}
+	yearRules := []validation.Rule{validation.Min(minYear), validation.Max(maxYear)}
+	if request.VinCheck != vincheck.FillVinCheck {
+		makeRules = append(makeRules, validation.Required)
+		yearRules = append(yearRules, validation.Required)
+	}
+
 	return validation.ValidateStruct(&request,
-		// Make cannot be nil, cannot be empty, max length of 20, and not Ford (case insensitive)
-		validation.Field(&request.Make, validation.Required, validation.Length(1, 20)),
-		// Model cannot be nil, cannot be empty, and max length of 20
-		validation.Field(&request.Model, validation.Required, validation.Length(1, 20)),
-		// Year must be between 1999 and 2020, inclusive.
-		validation.Field(&request.Year, validation.Required, validation.Min(1999), validation.Max(2020)),
-		// Vin cannot be nil, cannot be empty, and has a length of 17
-		validation.Field(&request.Vin, validation.Required, validation.By(entity.Is17Characters)),
+		// Make cannot be nil, cannot be empty, is limited in length, and must be permitted by the validation policy (case insensitive)
+		validation.Field(&request.Make, makeRules...),
+		// Model cannot be nil, cannot be empty, and is limited in length
+		validation.Field(&request.Model, validation.Required, validation.Length(policy.MinModelLength, policy.MaxModelLength)),
+		// Year must be within the range of the validation policy, inclusive.
+		validation.Field(&request.Year, yearRules...),
+		// Vin cannot be nil, cannot be empty, and must be a valid VIN
+		validation.Field(&request.Vin, validation.Required, validation.By(entity.IsVin)),
+		// VinCheck is optional, but must be known when it is present.
+		validation.Field(&request.VinCheck, validation.In(vincheck.All...)),
 	)
 }
@@ -0,0 +1,59 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/constant"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/readingsource"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// InsertOdometerReadingRequest is a simple dto containing the required data for the InsertOdometerReadingInteractor.
+type InsertOdometerReadingRequest struct {
+	MotorcycleID typedef.ID                `json:"motorcycleId"`
+	Value        float64                   `json:"value"`
+	Unit         distanceunit.DistanceUnit `json:"unit"`
+	// ReadingUtc is when the odometer was read.  A zero time is now.
+	ReadingUtc time.Time `json:"readingUtc"`
+	// Source is where the reading came from.  An undefined source was entered manually.
+	Source   readingsource.ReadingSource `json:"source"`
+	Rollover bool                        `json:"rollover"`
+}
+
+// NewInsertOdometerReadingRequest creates a new instance of an InsertOdometerReadingRequest.
+// Returns (nil, error) when there is an error, otherwise (InsertOdometerReadingRequest, nil).
+func NewInsertOdometerReadingRequest(motorcycleID typedef.ID, value float64, unit distanceunit.DistanceUnit, readingUtc time.Time, source readingsource.ReadingSource, rollover bool) (*InsertOdometerReadingRequest, error) {
+
+	readingRequest := &InsertOdometerReadingRequest{
+		MotorcycleID: motorcycleID,
+		Value:        value,
+		Unit:         unit,
+		ReadingUtc:   readingUtc,
+		Source:       source,
+		Rollover:     rollover,
+	}
+
+	err := readingRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return readingRequest, nil
+}
+
+// Validate verifies that an InsertOdometerReadingRequest's fields contain valid data.
+// Returns (an instance of InsertOdometerReadingRequest, nil) on success, otherwise (nil, error)
+func (request InsertOdometerReadingRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)),
+		// Value cannot be negative, or greater than an odometer can display.
+		validation.Field(&request.Value, validation.Min(0.0), validation.Max(float64(constant.MaxOdometerValue))),
+		// Unit is required, and must be kilometers or miles.
+		validation.Field(&request.Unit, validation.Required, validation.In(distanceunit.KilometersDistanceUnit, distanceunit.MilesDistanceUnit)),
+	)
+}
@@ -0,0 +1,42 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// InsertServiceRecordRequest is a simple dto containing the required data for the InsertServiceRecordInteractor.
+type InsertServiceRecordRequest struct {
+	MotorcycleID  typedef.ID            `json:"motorcycleId"`
+	ServiceRecord *entity.ServiceRecord `json:"serviceRecord"`
+}
+
+// NewInsertServiceRecordRequest creates a new instance of a InsertServiceRecordRequest.
+// Returns (nil, error) when there is an error, otherwise (InsertServiceRecordRequest, nil).
+func NewInsertServiceRecordRequest(motorcycleID typedef.ID, serviceRecord *entity.ServiceRecord) (*InsertServiceRecordRequest, error) {
+
+	recordRequest := &InsertServiceRecordRequest{
+		MotorcycleID:  motorcycleID,
+		ServiceRecord: serviceRecord,
+	}
+
+	err := recordRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return recordRequest, nil
+}
+
+// Validate verifies that a InsertServiceRecordRequest's fields contain valid data.
+// Returns (an instance of InsertServiceRecordRequest, nil) on success, otherwise (nil, error)
+func (request InsertServiceRecordRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)),
+		// ServiceRecord is required, and it must be valid.
+		validation.Field(&request.ServiceRecord, validation.Required))
+}
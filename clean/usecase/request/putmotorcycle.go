@@ -10,16 +10,19 @@ import (
 
 // UpdateMotorcycleRequest is a simple dto containing the required data for the UpdateMotorcycleInteractor.
 type UpdateMotorcycleRequest struct {
-	ID         typedef.ID         `json:"id"`
+	ID typedef.ID `json:"id"`
+	// RowVersion is the version of the motorcycle that was changed, or AnyRowVersion to skip the check.
+	RowVersion typedef.RowVersion `json:"rowVersion"`
 	Motorcycle *entity.Motorcycle `json:"motorcycle"`
 }
 
 // NewUpdateMotorcycleRequest creates a new instance of a UpdateMotorcycleRequest.
 // Returns (nil, error) when there is an error, otherwise (UpdateMotorcycleRequest, nil).
-func NewUpdateMotorcycleRequest(id typedef.ID, motorcycle *entity.Motorcycle) (*UpdateMotorcycleRequest, error) {
+func NewUpdateMotorcycleRequest(id typedef.ID, rowVersion typedef.RowVersion, motorcycle *entity.Motorcycle) (*UpdateMotorcycleRequest, error) {
 
 	motorcycleRequest := &UpdateMotorcycleRequest{
 		ID:         id,
+		RowVersion: rowVersion,
 		Motorcycle: motorcycle,
 	}
 
@@ -38,6 +41,8 @@ func (request UpdateMotorcycleRequest) Validate() error {
 	return validation.ValidateStruct(&request,
 		// ID is required and it must be greater than 0.
 		validation.Field(&request.ID, validation.Required, validation.Min(1)),
+		// RowVersion cannot be negative.
+		validation.Field(&request.RowVersion, validation.Min(0)),
 		// Make cannot be nil, cannot be empty, max length of 20, and not Ford (case insensitive)
 		validation.Field(&request.Motorcycle, validation.Required))
 
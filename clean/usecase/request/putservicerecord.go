@@ -0,0 +1,51 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// UpdateServiceRecordRequest is a simple dto containing the required data for the UpdateServiceRecordInteractor.
+type UpdateServiceRecordRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	ID           typedef.ID `json:"id"`
+	// RowVersion is the version of the service record that was changed, or AnyRowVersion to skip the check.
+	RowVersion    typedef.RowVersion    `json:"rowVersion"`
+	ServiceRecord *entity.ServiceRecord `json:"serviceRecord"`
+}
+
+// NewUpdateServiceRecordRequest creates a new instance of a UpdateServiceRecordRequest.
+// Returns (nil, error) when there is an error, otherwise (UpdateServiceRecordRequest, nil).
+func NewUpdateServiceRecordRequest(motorcycleID typedef.ID, id typedef.ID, rowVersion typedef.RowVersion, serviceRecord *entity.ServiceRecord) (*UpdateServiceRecordRequest, error) {
+
+	recordRequest := &UpdateServiceRecordRequest{
+		MotorcycleID:  motorcycleID,
+		ID:            id,
+		RowVersion:    rowVersion,
+		ServiceRecord: serviceRecord,
+	}
+
+	err := recordRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return recordRequest, nil
+}
+
+// Validate verifies that a UpdateServiceRecordRequest's fields contain valid data.
+// Returns (an instance of UpdateServiceRecordRequest, nil) on success, otherwise (nil, error)
+func (request UpdateServiceRecordRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)),
+		// ID is required and it must be greater than 0.
+		validation.Field(&request.ID, validation.Required, validation.Min(1)),
+		// RowVersion cannot be negative.
+		validation.Field(&request.RowVersion, validation.Min(0)),
+		// ServiceRecord is required, and it must be valid.
+		validation.Field(&request.ServiceRecord, validation.Required))
+}
@@ -0,0 +1,37 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"time"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// SendRemindersRequest is a simple dto containing the required data for the SendRemindersInteractor.
+type SendRemindersRequest struct {
+	// AsOfUtc is the time at which the reminder rules are evaluated.  It is zero for the current time.
+	AsOfUtc time.Time `json:"asOfUtc"`
+}
+
+// NewSendRemindersRequest creates a new instance of a SendRemindersRequest.
+// Returns (nil, error) when there is an error, otherwise (SendRemindersRequest, nil).
+func NewSendRemindersRequest(asOfUtc time.Time) (*SendRemindersRequest, error) {
+
+	sendRequest := &SendRemindersRequest{
+		AsOfUtc: asOfUtc,
+	}
+
+	err := sendRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return sendRequest, nil
+}
+
+// Validate verifies that a SendRemindersRequest's fields contain valid data.
+// Returns (an instance of SendRemindersRequest, nil) on success, otherwise (nil, error)
+func (request SendRemindersRequest) Validate() error {
+	return validation.ValidateStruct(&request)
+}
@@ -0,0 +1,48 @@
+// Package request contains the request messages for the use cases.
+package request
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// SnoozeReminderRequest is a simple dto containing the required data for the SnoozeReminderInteractor.
+type SnoozeReminderRequest struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	ID           typedef.ID `json:"id"`
+	// UntilUtc is when the owner is notified of the reminder again.
+	UntilUtc time.Time `json:"untilUtc"`
+}
+
+// NewSnoozeReminderRequest creates a new instance of a SnoozeReminderRequest.
+// Returns (nil, error) when there is an error, otherwise (SnoozeReminderRequest, nil).
+func NewSnoozeReminderRequest(motorcycleID typedef.ID, id typedef.ID, untilUtc time.Time) (*SnoozeReminderRequest, error) {
+
+	snoozeRequest := &SnoozeReminderRequest{
+		MotorcycleID: motorcycleID,
+		ID:           id,
+		UntilUtc:     untilUtc,
+	}
+
+	err := snoozeRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// All okay
+	return snoozeRequest, nil
+}
+
+// Validate verifies that a SnoozeReminderRequest's fields contain valid data.
+// Returns (an instance of SnoozeReminderRequest, nil) on success, otherwise (nil, error)
+func (request SnoozeReminderRequest) Validate() error {
+	return validation.ValidateStruct(&request,
+		// MotorcycleID is required and it must be greater than 0.
+		validation.Field(&request.MotorcycleID, validation.Required, validation.Min(1)),
+		// ID is required and it must be greater than 0.
+		validation.Field(&request.ID, validation.Required, validation.Min(1)),
+		// UntilUtc is required.
+		validation.Field(&request.UntilUtc, validation.Required))
+}
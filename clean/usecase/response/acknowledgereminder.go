@@ -0,0 +1,60 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// AcknowledgeReminderResponse is a simple dto containing the response data from the AcknowledgeReminderInteractor.
+type AcknowledgeReminderResponse struct {
+	// ID will be set to the value that was requested to be acknowledged.
+	ID typedef.ID `json:"id"`
+	// RowVersion is the new version of the reminder, once it has been acknowledged.
+	RowVersion typedef.RowVersion              `json:"rowVersion"`
+	Status     operationstatus.OperationStatus `json:"operationStatus"`
+	Error      error                           `json:"error"`
+}
+
+// NewAcknowledgeReminderResponse creates a new instance of a AcknowledgeReminderResponse.
+// Returns (nil, error) when there is an error, otherwise (AcknowledgeReminderResponse, nil).
+func NewAcknowledgeReminderResponse(id typedef.ID, rowVersion typedef.RowVersion, status operationstatus.OperationStatus, err error) (*AcknowledgeReminderResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	acknowledgeResponse := &AcknowledgeReminderResponse{
+		ID:         id,
+		RowVersion: rowVersion,
+		Status:     status,
+		Error:      err,
+	}
+
+	msgErr := acknowledgeResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if acknowledgeResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(acknowledgeResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if acknowledgeResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if acknowledgeResponse.Error != nil && msgErr == nil {
+		return acknowledgeResponse, nil
+	}
+
+	// Otherwise, all okay
+	return acknowledgeResponse, nil
+}
+
+// Validate verifies that a AcknowledgeReminderResponse's fields contain valid data.
+// Returns nil if the AcknowledgeReminderResponse contains valid data, otherwise an error.
+func (response AcknowledgeReminderResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// ID is required and it must be non-zero
+		validation.Field(&response.ID, validation.Required))
+}
@@ -0,0 +1,56 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// DecodeVinResponse is a simple dto containing the response data from the DecodeVinInteractor.
+type DecodeVinResponse struct {
+	DecodedVin *entity.DecodedVin              `json:"decodedVin"`
+	Status     operationstatus.OperationStatus `json:"operationStatus"`
+	Error      error                           `json:"error"`
+}
+
+// NewDecodeVinResponse creates a new instance of a DecodeVinResponse.
+// Returns (nil, error) when there is an error, otherwise (DecodeVinResponse, nil).
+func NewDecodeVinResponse(decodedVin *entity.DecodedVin, status operationstatus.OperationStatus, err error) (*DecodeVinResponse, error) {
+
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+
+	vinResponse := &DecodeVinResponse{
+		DecodedVin: decodedVin,
+		Status:     status,
+		Error:      err,
+	}
+
+	msgErr := vinResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if vinResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(vinResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if vinResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if vinResponse.Error != nil && msgErr == nil {
+		return vinResponse, nil
+	}
+
+	// Otherwise, all okay
+	return vinResponse, nil
+}
+
+// Validate verifies that a DecodeVinResponse's fields contain valid data.
+// Returns nil if the DecodeVinResponse contains valid data, otherwise an error.
+func (response DecodeVinResponse) Validate() error {
+	return validation.ValidateStruct(&response)
+}
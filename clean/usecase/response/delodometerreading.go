@@ -0,0 +1,57 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// DeleteOdometerReadingResponse is a simple dto containing the response data from the DeleteOdometerReadingInteractor.
+type DeleteOdometerReadingResponse struct {
+	// ID will be set to the value that was requested to be deleted.
+	ID     typedef.ID                      `json:"id"`
+	Status operationstatus.OperationStatus `json:"operationStatus"`
+	Error  error                           `json:"error"`
+}
+
+// NewDeleteOdometerReadingResponse creates a new instance of a DeleteOdometerReadingResponse.
+// Returns (nil, error) when there is an error, otherwise (DeleteOdometerReadingResponse, nil).
+func NewDeleteOdometerReadingResponse(id typedef.ID, status operationstatus.OperationStatus, err error) (*DeleteOdometerReadingResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	readingResponse := &DeleteOdometerReadingResponse{
+		ID:     id,
+		Status: status,
+		Error:  err,
+	}
+
+	msgErr := readingResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if readingResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(readingResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if readingResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if readingResponse.Error != nil && msgErr == nil {
+		return readingResponse, nil
+	}
+
+	// Otherwise, all okay
+	return readingResponse, nil
+}
+
+// Validate verifies that a DeleteOdometerReadingResponse's fields contain valid data.
+// Returns nil if the DeleteOdometerReadingResponse contains valid data, otherwise an error.
+func (response DeleteOdometerReadingResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// ID is required and it must be non-zero
+		validation.Field(&response.ID, validation.Required, validation.Min(1)))
+}
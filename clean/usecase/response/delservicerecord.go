@@ -0,0 +1,57 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// DeleteServiceRecordResponse is a simple dto containing the response data from the DeleteServiceRecordInteractor.
+type DeleteServiceRecordResponse struct {
+	// ID will be set to the value that was requested to be deleted.
+	ID     typedef.ID                      `json:"id"`
+	Status operationstatus.OperationStatus `json:"operationStatus"`
+	Error  error                           `json:"error"`
+}
+
+// NewDeleteServiceRecordResponse creates a new instance of a DeleteServiceRecordResponse.
+// Returns (nil, error) when there is an error, otherwise (DeleteServiceRecordResponse, nil).
+func NewDeleteServiceRecordResponse(id typedef.ID, status operationstatus.OperationStatus, err error) (*DeleteServiceRecordResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	recordResponse := &DeleteServiceRecordResponse{
+		ID:     id,
+		Status: status,
+		Error:  err,
+	}
+
+	msgErr := recordResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if recordResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(recordResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if recordResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if recordResponse.Error != nil && msgErr == nil {
+		return recordResponse, nil
+	}
+
+	// Otherwise, all okay
+	return recordResponse, nil
+}
+
+// Validate verifies that a DeleteServiceRecordResponse's fields contain valid data.
+// Returns nil if the DeleteServiceRecordResponse contains valid data, otherwise an error.
+func (response DeleteServiceRecordResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// ID is required and it must be non-zero
+		validation.Field(&response.ID, validation.Required))
+}
@@ -0,0 +1,71 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/distanceunit"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// GetMaintenanceDueResponse is a simple dto containing the response data from the GetMaintenanceDueInteractor.
+type GetMaintenanceDueResponse struct {
+	MotorcycleID typedef.ID `json:"motorcycleId"`
+	// Odometer is the distance travelled by the motorcycle when the maintenance was evaluated, in the unit.
+	Odometer float64                   `json:"odometer"`
+	Unit     distanceunit.DistanceUnit `json:"unit"`
+	AsOfUtc  time.Time                 `json:"asOfUtc"`
+	// Items are the motorcycle's maintenance tasks, with the most urgent first.
+	Items  []entity.MaintenanceDue         `json:"items"`
+	Status operationstatus.OperationStatus `json:"operationStatus"`
+	Error  error                           `json:"error"`
+}
+
+// NewGetMaintenanceDueResponse creates a new instance of a GetMaintenanceDueResponse.
+// Returns (nil, error) when there is an error, otherwise (GetMaintenanceDueResponse, nil).
+func NewGetMaintenanceDueResponse(motorcycleID typedef.ID, odometer float64, unit distanceunit.DistanceUnit, asOfUtc time.Time,
+	items []entity.MaintenanceDue, status operationstatus.OperationStatus, err error) (*GetMaintenanceDueResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	dueResponse := &GetMaintenanceDueResponse{
+		MotorcycleID: motorcycleID,
+		Odometer:     odometer,
+		Unit:         unit,
+		AsOfUtc:      asOfUtc,
+		Items:        items,
+		Status:       status,
+		Error:        err,
+	}
+
+	msgErr := dueResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if dueResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(dueResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if dueResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if dueResponse.Error != nil && msgErr == nil {
+		return dueResponse, nil
+	}
+
+	// Otherwise, all okay
+	return dueResponse, nil
+}
+
+// Validate verifies that a GetMaintenanceDueResponse's fields contain valid data.
+// Returns nil if the GetMaintenanceDueResponse contains valid data, otherwise an error.
+func (response GetMaintenanceDueResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// MotorcycleID is required and it must be non-zero
+		validation.Field(&response.MotorcycleID, validation.Required))
+}
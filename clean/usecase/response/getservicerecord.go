@@ -0,0 +1,54 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// GetServiceRecordResponse is a simple dto containing the response data from the GetServiceRecordInteractor.
+type GetServiceRecordResponse struct {
+	ServiceRecord *entity.ServiceRecord           `json:"serviceRecord"`
+	Status        operationstatus.OperationStatus `json:"operationStatus"`
+	Error         error                           `json:"error"`
+}
+
+// NewGetServiceRecordResponse creates a new instance of a GetServiceRecordResponse.
+// Returns (nil, error) when there is an error, otherwise (GetServiceRecordResponse, nil).
+func NewGetServiceRecordResponse(serviceRecord *entity.ServiceRecord, status operationstatus.OperationStatus, err error) (*GetServiceRecordResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	recordResponse := &GetServiceRecordResponse{
+		ServiceRecord: serviceRecord,
+		Status:        status,
+		Error:         err,
+	}
+
+	msgErr := recordResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if recordResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(recordResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if recordResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if recordResponse.Error != nil && msgErr == nil {
+		return recordResponse, nil
+	}
+
+	// Otherwise, all okay
+	return recordResponse, nil
+}
+
+// Validate verifies that a GetServiceRecordResponse's fields contain valid data.
+// Returns nil if the GetServiceRecordResponse contains valid data, otherwise an error.
+func (response GetServiceRecordResponse) Validate() error {
+	return validation.ValidateStruct(&response)
+}
@@ -10,20 +10,23 @@ import (
 
 // ListMotorcyclesResponse is a simple dto containing the response data from the ListMotorcyclesInteractor.
 type ListMotorcyclesResponse struct {
-	Motorcycles []entity.Motorcycle             `json:"motorcycles"`
-	Status      operationstatus.OperationStatus `json:"operationStatus"`
-	Error       error                           `json:"error"`
+	Motorcycles []entity.Motorcycle `json:"motorcycles"`
+	// Next is the position of the last motorcycle in the page, or nil when there are no more motorcycles.
+	Next   *entity.MotorcycleCursor        `json:"next"`
+	Status operationstatus.OperationStatus `json:"operationStatus"`
+	Error  error                           `json:"error"`
 }
 
 // NewListMotorcyclesResponse creates a new instance of a ListMotorcyclesResponse.
 // Returns (nil, error) when there is an error, otherwise (ListMotorcyclesResponse, nil).
-func NewListMotorcyclesResponse(motorcycles []entity.Motorcycle, status operationstatus.OperationStatus, err error) (*ListMotorcyclesResponse, error) {
+func NewListMotorcyclesResponse(motorcycles []entity.Motorcycle, next *entity.MotorcycleCursor, status operationstatus.OperationStatus, err error) (*ListMotorcyclesResponse, error) {
 
 	// We return a (nil, error) only when validation of the response message fails, not for whether the
 	// response message indicates failure.
 
 	motorcycleResponse := &ListMotorcyclesResponse{
 		Motorcycles: motorcycles,
+		Next:        next,
 		Status:      status,
 		Error:       err,
 	}
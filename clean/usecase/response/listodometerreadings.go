@@ -0,0 +1,59 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// ListOdometerReadingsResponse is a simple dto containing the response data from the ListOdometerReadingsInteractor.
+type ListOdometerReadingsResponse struct {
+	MotorcycleID typedef.ID                      `json:"motorcycleId"`
+	Readings     []entity.OdometerReading        `json:"readings"`
+	Status       operationstatus.OperationStatus `json:"operationStatus"`
+	Error        error                           `json:"error"`
+}
+
+// NewListOdometerReadingsResponse creates a new instance of a ListOdometerReadingsResponse.
+// Returns (nil, error) when there is an error, otherwise (ListOdometerReadingsResponse, nil).
+func NewListOdometerReadingsResponse(motorcycleID typedef.ID, readings []entity.OdometerReading, status operationstatus.OperationStatus, err error) (*ListOdometerReadingsResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	readingResponse := &ListOdometerReadingsResponse{
+		MotorcycleID: motorcycleID,
+		Readings:     readings,
+		Status:       status,
+		Error:        err,
+	}
+
+	msgErr := readingResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if readingResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(readingResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if readingResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if readingResponse.Error != nil && msgErr == nil {
+		return readingResponse, nil
+	}
+
+	// Otherwise, all okay
+	return readingResponse, nil
+}
+
+// Validate verifies that a ListOdometerReadingsResponse's fields contain valid data.
+// Returns nil if the ListOdometerReadingsResponse contains valid data, otherwise an error.
+func (response ListOdometerReadingsResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// MotorcycleID is required and it must be non-zero
+		validation.Field(&response.MotorcycleID, validation.Required))
+}
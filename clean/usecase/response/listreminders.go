@@ -0,0 +1,59 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// ListRemindersResponse is a simple dto containing the response data from the ListRemindersInteractor.
+type ListRemindersResponse struct {
+	MotorcycleID typedef.ID                      `json:"motorcycleId"`
+	Reminders    []entity.Reminder               `json:"reminders"`
+	Status       operationstatus.OperationStatus `json:"operationStatus"`
+	Error        error                           `json:"error"`
+}
+
+// NewListRemindersResponse creates a new instance of a ListRemindersResponse.
+// Returns (nil, error) when there is an error, otherwise (ListRemindersResponse, nil).
+func NewListRemindersResponse(motorcycleID typedef.ID, reminders []entity.Reminder, status operationstatus.OperationStatus, err error) (*ListRemindersResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	listResponse := &ListRemindersResponse{
+		MotorcycleID: motorcycleID,
+		Reminders:    reminders,
+		Status:       status,
+		Error:        err,
+	}
+
+	msgErr := listResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if listResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(listResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if listResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if listResponse.Error != nil && msgErr == nil {
+		return listResponse, nil
+	}
+
+	// Otherwise, all okay
+	return listResponse, nil
+}
+
+// Validate verifies that a ListRemindersResponse's fields contain valid data.
+// Returns nil if the ListRemindersResponse contains valid data, otherwise an error.
+func (response ListRemindersResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// MotorcycleID is required and it must be non-zero
+		validation.Field(&response.MotorcycleID, validation.Required))
+}
@@ -0,0 +1,59 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/entity"
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// ListServiceRecordsResponse is a simple dto containing the response data from the ListServiceRecordsInteractor.
+type ListServiceRecordsResponse struct {
+	MotorcycleID   typedef.ID                      `json:"motorcycleId"`
+	ServiceRecords []entity.ServiceRecord          `json:"serviceRecords"`
+	Status         operationstatus.OperationStatus `json:"operationStatus"`
+	Error          error                           `json:"error"`
+}
+
+// NewListServiceRecordsResponse creates a new instance of a ListServiceRecordsResponse.
+// Returns (nil, error) when there is an error, otherwise (ListServiceRecordsResponse, nil).
+func NewListServiceRecordsResponse(motorcycleID typedef.ID, serviceRecords []entity.ServiceRecord, status operationstatus.OperationStatus, err error) (*ListServiceRecordsResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	recordResponse := &ListServiceRecordsResponse{
+		MotorcycleID:   motorcycleID,
+		ServiceRecords: serviceRecords,
+		Status:         status,
+		Error:          err,
+	}
+
+	msgErr := recordResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if recordResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(recordResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if recordResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if recordResponse.Error != nil && msgErr == nil {
+		return recordResponse, nil
+	}
+
+	// Otherwise, all okay
+	return recordResponse, nil
+}
+
+// Validate verifies that a ListServiceRecordsResponse's fields contain valid data.
+// Returns nil if the ListServiceRecordsResponse contains valid data, otherwise an error.
+func (response ListServiceRecordsResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// MotorcycleID is required and it must be non-zero
+		validation.Field(&response.MotorcycleID, validation.Required))
+}
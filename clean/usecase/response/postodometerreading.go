@@ -0,0 +1,60 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// InsertOdometerReadingResponse is a simple dto containing the response data from the InsertOdometerReadingInteractor.
+type InsertOdometerReadingResponse struct {
+	MotorcycleID typedef.ID                      `json:"motorcycleId"`
+	ID           typedef.ID                      `json:"id"`
+	Status       operationstatus.OperationStatus `json:"operationStatus"`
+	Error        error                           `json:"error"`
+}
+
+// NewInsertOdometerReadingResponse creates a new instance of an InsertOdometerReadingResponse.
+// Returns (nil, error) when there is an error, otherwise (InsertOdometerReadingResponse, nil).
+func NewInsertOdometerReadingResponse(motorcycleID typedef.ID, id typedef.ID, status operationstatus.OperationStatus, err error) (*InsertOdometerReadingResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	readingResponse := &InsertOdometerReadingResponse{
+		MotorcycleID: motorcycleID,
+		ID:           id,
+		Status:       status,
+		Error:        err,
+	}
+
+	msgErr := readingResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if readingResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(readingResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if readingResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if readingResponse.Error != nil && msgErr == nil {
+		return readingResponse, nil
+	}
+
+	// Otherwise, all okay
+	return readingResponse, nil
+}
+
+// Validate verifies that an InsertOdometerReadingResponse's fields contain valid data.
+// Returns nil if the InsertOdometerReadingResponse contains valid data, otherwise an error.
+func (response InsertOdometerReadingResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// MotorcycleID is required and it must be non-zero
+		validation.Field(&response.MotorcycleID, validation.Required),
+		// ID is required and it must be non-zero
+		validation.Field(&response.ID, validation.Required))
+}
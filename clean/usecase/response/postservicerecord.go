@@ -0,0 +1,62 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// InsertServiceRecordResponse is a simple dto containing the response data from the InsertServiceRecordInteractor.
+type InsertServiceRecordResponse struct {
+	MotorcycleID typedef.ID                      `json:"motorcycleId"`
+	ID           typedef.ID                      `json:"id"`
+	RowVersion   typedef.RowVersion              `json:"rowVersion"`
+	Status       operationstatus.OperationStatus `json:"operationStatus"`
+	Error        error                           `json:"error"`
+}
+
+// NewInsertServiceRecordResponse creates a new instance of a InsertServiceRecordResponse.
+// Returns (nil, error) when there is an error, otherwise (InsertServiceRecordResponse, nil).
+func NewInsertServiceRecordResponse(motorcycleID typedef.ID, id typedef.ID, rowVersion typedef.RowVersion, status operationstatus.OperationStatus, err error) (*InsertServiceRecordResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	recordResponse := &InsertServiceRecordResponse{
+		MotorcycleID: motorcycleID,
+		ID:           id,
+		RowVersion:   rowVersion,
+		Status:       status,
+		Error:        err,
+	}
+
+	msgErr := recordResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if recordResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(recordResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if recordResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if recordResponse.Error != nil && msgErr == nil {
+		return recordResponse, nil
+	}
+
+	// Otherwise, all okay
+	return recordResponse, nil
+}
+
+// Validate verifies that a InsertServiceRecordResponse's fields contain valid data.
+// Returns nil if the InsertServiceRecordResponse contains valid data, otherwise an error.
+func (response InsertServiceRecordResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// MotorcycleID is required and it must be non-zero
+		validation.Field(&response.MotorcycleID, validation.Required),
+		// ID is required and it must be non-zero
+		validation.Field(&response.ID, validation.Required))
+}
@@ -0,0 +1,70 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"time"
+
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// SendRemindersResponse is a simple dto containing the response data from the SendRemindersInteractor.
+type SendRemindersResponse struct {
+	AsOfUtc time.Time `json:"asOfUtc"`
+	// Created is the number of reminders that were created from the reminder rules.
+	Created int `json:"created"`
+	// Notified is the number of reminders whose owners were notified.
+	Notified int `json:"notified"`
+	// Failed is the number of reminders whose owners could not be notified, which will be retried.
+	Failed int                             `json:"failed"`
+	Status operationstatus.OperationStatus `json:"operationStatus"`
+	Error  error                           `json:"error"`
+}
+
+// NewSendRemindersResponse creates a new instance of a SendRemindersResponse.
+// Returns (nil, error) when there is an error, otherwise (SendRemindersResponse, nil).
+func NewSendRemindersResponse(asOfUtc time.Time, created int, notified int, failed int, status operationstatus.OperationStatus, err error) (*SendRemindersResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	sendResponse := &SendRemindersResponse{
+		AsOfUtc:  asOfUtc,
+		Created:  created,
+		Notified: notified,
+		Failed:   failed,
+		Status:   status,
+		Error:    err,
+	}
+
+	msgErr := sendResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if sendResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(sendResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if sendResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if sendResponse.Error != nil && msgErr == nil {
+		return sendResponse, nil
+	}
+
+	// Otherwise, all okay
+	return sendResponse, nil
+}
+
+// Validate verifies that a SendRemindersResponse's fields contain valid data.
+// Returns nil if the SendRemindersResponse contains valid data, otherwise an error.
+func (response SendRemindersResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// Created cannot be negative.
+		validation.Field(&response.Created, validation.Min(0)),
+		// Notified cannot be negative.
+		validation.Field(&response.Notified, validation.Min(0)),
+		// Failed cannot be negative.
+		validation.Field(&response.Failed, validation.Min(0)))
+}
@@ -0,0 +1,60 @@
+// Package response contains the response messages for the use cases.
+package response
+
+import (
+	"github.com/abitofhelp/motominderapi/clean/domain/enumeration/operationstatus"
+	"github.com/abitofhelp/motominderapi/clean/domain/typedef"
+	"github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// SnoozeReminderResponse is a simple dto containing the response data from the SnoozeReminderInteractor.
+type SnoozeReminderResponse struct {
+	// ID will be set to the value that was requested to be snoozed.
+	ID typedef.ID `json:"id"`
+	// RowVersion is the new version of the reminder, once it has been snoozed.
+	RowVersion typedef.RowVersion              `json:"rowVersion"`
+	Status     operationstatus.OperationStatus `json:"operationStatus"`
+	Error      error                           `json:"error"`
+}
+
+// NewSnoozeReminderResponse creates a new instance of a SnoozeReminderResponse.
+// Returns (nil, error) when there is an error, otherwise (SnoozeReminderResponse, nil).
+func NewSnoozeReminderResponse(id typedef.ID, rowVersion typedef.RowVersion, status operationstatus.OperationStatus, err error) (*SnoozeReminderResponse, error) {
+	// We return a (nil, error) only when validation of the response message fails, not for whether the
+	// response message indicates failure.
+	snoozeResponse := &SnoozeReminderResponse{
+		ID:         id,
+		RowVersion: rowVersion,
+		Status:     status,
+		Error:      err,
+	}
+
+	msgErr := snoozeResponse.Validate()
+
+	// If we have a response message with a failure and validation failed, we will wrap the original error with the validation error.
+	if snoozeResponse.Error != nil && msgErr != nil {
+		return nil, errors.Wrap(snoozeResponse.Error, msgErr.Error())
+	}
+
+	// If we have a response message that indicates success, but validation failed, we will return the validation error.
+	if snoozeResponse.Error == nil && msgErr != nil {
+		return nil, msgErr
+	}
+
+	// If we have a response message that failed, but validation was successful, we will return response.
+	if snoozeResponse.Error != nil && msgErr == nil {
+		return snoozeResponse, nil
+	}
+
+	// Otherwise, all okay
+	return snoozeResponse, nil
+}
+
+// Validate verifies that a SnoozeReminderResponse's fields contain valid data.
+// Returns nil if the SnoozeReminderResponse contains valid data, otherwise an error.
+func (response SnoozeReminderResponse) Validate() error {
+	return validation.ValidateStruct(&response,
+		// ID is required and it must be non-zero
+		validation.Field(&response.ID, validation.Required))
+}